@@ -24,28 +24,40 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/auth/login": {
-            "post": {
-                "description": "Authenticate user and return access/refresh tokens",
-                "consumes": [
-                    "application/json"
-                ],
+        "/admin/stats/cars/availability": {
+            "get": {
+                "description": "Live cars split by whether an active reservation covers the current time, with the share of available ones (admin only). from and to bound the cars' creation time.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "stats"
                 ],
-                "summary": "Login user",
+                "summary": "Fleet availability",
                 "parameters": [
                     {
-                        "description": "Login credentials",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.LoginRequest"
-                        }
+                        "enum": [
+                            "no-cache",
+                            "no-store",
+                            "max-age=60",
+                            "only-if-cached"
+                        ],
+                        "type": "string",
+                        "description": "Request cache control",
+                        "name": "Cache-Control",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Earliest creation time (RFC 3339, inclusive)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Latest creation time (RFC 3339, inclusive)",
+                        "name": "to",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -60,11 +72,21 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/dto.TokenResponse"
+                                            "$ref": "#/definitions/entity.FleetAvailability"
                                         }
                                     }
                                 }
                             ]
+                        },
+                        "headers": {
+                            "Age": {
+                                "type": "integer",
+                                "description": "Seconds since the cached copy was stored"
+                            },
+                            "X-Cache": {
+                                "type": "string",
+                                "description": "HIT, MISS or BYPASS"
+                            }
                         }
                     },
                     "400": {
@@ -79,6 +101,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.HTTPErrorResp"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -88,28 +116,40 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/refresh": {
-            "post": {
-                "description": "Generate a new access token using a valid refresh token",
-                "consumes": [
-                    "application/json"
-                ],
+        "/admin/stats/cars/brands": {
+            "get": {
+                "description": "Number of live cars per brand, most common first (admin only). from and to bound the cars' creation time.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "stats"
                 ],
-                "summary": "Refresh access token",
+                "summary": "Count cars by brand",
                 "parameters": [
                     {
-                        "description": "Refresh token",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.RefreshTokenRequest"
-                        }
+                        "enum": [
+                            "no-cache",
+                            "no-store",
+                            "max-age=60",
+                            "only-if-cached"
+                        ],
+                        "type": "string",
+                        "description": "Request cache control",
+                        "name": "Cache-Control",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Earliest creation time (RFC 3339, inclusive)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Latest creation time (RFC 3339, inclusive)",
+                        "name": "to",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -124,11 +164,24 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/dto.TokenResponse"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/entity.BrandCount"
+                                            }
                                         }
                                     }
                                 }
                             ]
+                        },
+                        "headers": {
+                            "Age": {
+                                "type": "integer",
+                                "description": "Seconds since the cached copy was stored"
+                            },
+                            "X-Cache": {
+                                "type": "string",
+                                "description": "HIT, MISS or BYPASS"
+                            }
                         }
                     },
                     "400": {
@@ -143,6 +196,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.HTTPErrorResp"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -152,33 +211,45 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/register": {
-            "post": {
-                "description": "Create a new user account",
-                "consumes": [
-                    "application/json"
-                ],
+        "/admin/stats/cars/per-user": {
+            "get": {
+                "description": "How many active users own 0, 1, 2, ... live cars (admin only). from and to bound the cars' creation time.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "stats"
                 ],
-                "summary": "Register a new user",
+                "summary": "Cars per user distribution",
                 "parameters": [
                     {
-                        "description": "Registration data",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.RegisterRequest"
-                        }
+                        "enum": [
+                            "no-cache",
+                            "no-store",
+                            "max-age=60",
+                            "only-if-cached"
+                        ],
+                        "type": "string",
+                        "description": "Request cache control",
+                        "name": "Cache-Control",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Earliest creation time (RFC 3339, inclusive)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Latest creation time (RFC 3339, inclusive)",
+                        "name": "to",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "allOf": [
                                 {
@@ -188,11 +259,24 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/entity.User"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/entity.CarsPerUser"
+                                            }
                                         }
                                     }
                                 }
                             ]
+                        },
+                        "headers": {
+                            "Age": {
+                                "type": "integer",
+                                "description": "Seconds since the cached copy was stored"
+                            },
+                            "X-Cache": {
+                                "type": "string",
+                                "description": "HIT, MISS or BYPASS"
+                            }
                         }
                     },
                     "400": {
@@ -201,8 +285,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.HTTPErrorResp"
                         }
                     },
-                    "409": {
-                        "description": "Conflict",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/dto.HTTPErrorResp"
                         }
@@ -216,33 +306,45 @@ const docTemplate = `{
                 }
             }
         },
-        "/cars": {
-            "post": {
-                "description": "Create a new car with the provided information",
-                "consumes": [
-                    "application/json"
-                ],
+        "/admin/stats/cars/years": {
+            "get": {
+                "description": "Number of live cars per model year, oldest first (admin only). from and to bound the cars' creation time.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "cars"
+                    "stats"
                 ],
-                "summary": "Create a new car",
+                "summary": "Model year histogram",
                 "parameters": [
                     {
-                        "description": "Car data",
-                        "name": "car",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.CreateCarRequest"
-                        }
+                        "enum": [
+                            "no-cache",
+                            "no-store",
+                            "max-age=60",
+                            "only-if-cached"
+                        ],
+                        "type": "string",
+                        "description": "Request cache control",
+                        "name": "Cache-Control",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Earliest creation time (RFC 3339, inclusive)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Latest creation time (RFC 3339, inclusive)",
+                        "name": "to",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "allOf": [
                                 {
@@ -252,11 +354,24 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/entity.Car"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/entity.YearCount"
+                                            }
                                         }
                                     }
                                 }
                             ]
+                        },
+                        "headers": {
+                            "Age": {
+                                "type": "integer",
+                                "description": "Seconds since the cached copy was stored"
+                            },
+                            "X-Cache": {
+                                "type": "string",
+                                "description": "HIT, MISS or BYPASS"
+                            }
                         }
                     },
                     "400": {
@@ -265,48 +380,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.HTTPErrorResp"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/dto.HTTPErrorResp"
                         }
-                    }
-                }
-            }
-        },
-        "/cars/availability": {
-            "put": {
-                "description": "Update availability status for multiple cars",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "cars"
-                ],
-                "summary": "Bulk update car availability",
-                "parameters": [
-                    {
-                        "description": "Car IDs and availability status",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.BulkUpdateAvailabilityRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/dto.HttpSuccessResp"
-                        }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/dto.HTTPErrorResp"
                         }
@@ -320,33 +401,57 @@ const docTemplate = `{
                 }
             }
         },
-        "/cars/bulk": {
-            "post": {
-                "description": "Create multiple cars for a user in a single request",
-                "consumes": [
-                    "application/json"
-                ],
+        "/admin/stats/users/logins": {
+            "get": {
+                "description": "Successful logins and distinct users logging in per UTC day, week or month, empty periods included (admin only). Without from the last 30 periods are returned; a trend spans at most 366 periods.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "cars"
+                    "stats"
                 ],
-                "summary": "Create multiple cars",
+                "summary": "User login trend",
                 "parameters": [
                     {
-                        "description": "Cars data",
-                        "name": "cars",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.BulkCreateCarsRequest"
-                        }
+                        "enum": [
+                            "no-cache",
+                            "no-store",
+                            "max-age=60",
+                            "only-if-cached"
+                        ],
+                        "type": "string",
+                        "description": "Request cache control",
+                        "name": "Cache-Control",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start of the trend (RFC 3339, inclusive)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End of the trend (RFC 3339, inclusive), defaults to now",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "day",
+                            "week",
+                            "month"
+                        ],
+                        "type": "string",
+                        "default": "day",
+                        "description": "Trend period",
+                        "name": "interval",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "allOf": [
                                 {
@@ -358,12 +463,22 @@ const docTemplate = `{
                                         "data": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/entity.Car"
+                                                "$ref": "#/definitions/entity.LoginTrendPoint"
                                             }
                                         }
                                     }
                                 }
                             ]
+                        },
+                        "headers": {
+                            "Age": {
+                                "type": "integer",
+                                "description": "Seconds since the cached copy was stored"
+                            },
+                            "X-Cache": {
+                                "type": "string",
+                                "description": "HIT, MISS or BYPASS"
+                            }
                         }
                     },
                     "400": {
@@ -372,6 +487,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.HTTPErrorResp"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -381,23 +508,52 @@ const docTemplate = `{
                 }
             }
         },
-        "/cars/{id}": {
+        "/admin/stats/users/registrations": {
             "get": {
-                "description": "Get a car by its ID",
+                "description": "New users per UTC day, week or month, empty periods included (admin only). Without from the last 30 periods are returned; a trend spans at most 366 periods.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "cars"
+                    "stats"
                 ],
-                "summary": "Get car by ID",
+                "summary": "User registration trend",
                 "parameters": [
                     {
+                        "enum": [
+                            "no-cache",
+                            "no-store",
+                            "max-age=60",
+                            "only-if-cached"
+                        ],
                         "type": "string",
-                        "description": "Car ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Request cache control",
+                        "name": "Cache-Control",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start of the trend (RFC 3339, inclusive)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End of the trend (RFC 3339, inclusive), defaults to now",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "day",
+                            "week",
+                            "month"
+                        ],
+                        "type": "string",
+                        "default": "day",
+                        "description": "Trend period",
+                        "name": "interval",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -412,15 +568,40 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/entity.Car"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/entity.TrendPoint"
+                                            }
                                         }
                                     }
                                 }
                             ]
+                        },
+                        "headers": {
+                            "Age": {
+                                "type": "integer",
+                                "description": "Seconds since the cached copy was stored"
+                            },
+                            "X-Cache": {
+                                "type": "string",
+                                "description": "HIT, MISS or BYPASS"
+                            }
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/dto.HTTPErrorResp"
                         }
@@ -432,54 +613,46 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "Update an existing car",
-                "consumes": [
-                    "application/json"
-                ],
+            }
+        },
+        "/attachments/{id}/download": {
+            "get": {
+                "description": "Stream an attachment's file. The link is the signed, expiring download_url returned with the attachment and needs no bearer token.",
                 "produces": [
-                    "application/json"
+                    "application/octet-stream"
                 ],
                 "tags": [
-                    "cars"
+                    "attachments"
                 ],
-                "summary": "Update car",
+                "summary": "Download car attachment",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Car ID",
+                        "description": "Attachment ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Car data",
-                        "name": "car",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.UpdateCarRequest"
-                        }
+                        "type": "integer",
+                        "description": "Expiry of the link (Unix seconds)",
+                        "name": "expires",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Signature of the link",
+                        "name": "signature",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/dto.HttpSuccessResp"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/entity.Car"
-                                        }
-                                    }
-                                }
-                            ]
+                            "type": "file"
                         }
                     },
                     "400": {
@@ -488,47 +661,78 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.HTTPErrorResp"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/dto.HTTPErrorResp"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "503": {
+                        "description": "Service Unavailable",
                         "schema": {
                             "$ref": "#/definitions/dto.HTTPErrorResp"
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete a car by ID",
+            }
+        },
+        "/auth/login": {
+            "post": {
+                "description": "Authenticate user and return access/refresh tokens",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "cars"
+                    "auth"
                 ],
-                "summary": "Delete car",
+                "summary": "Login user",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Car ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Login credentials",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.LoginRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.HttpSuccessResp"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.HttpSuccessResp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.TokenResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/dto.HTTPErrorResp"
                         }
@@ -542,23 +746,28 @@ const docTemplate = `{
                 }
             }
         },
-        "/cars/{id}/owner": {
-            "get": {
-                "description": "Get a car by its ID with owner information",
+        "/auth/refresh": {
+            "post": {
+                "description": "Generate a new access token using a valid refresh token",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "cars"
+                    "auth"
                 ],
-                "summary": "Get car with owner details",
+                "summary": "Refresh access token",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Car ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Refresh token",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.RefreshTokenRequest"
+                        }
                     }
                 ],
                 "responses": {
@@ -573,15 +782,21 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/entity.CarWithOwner"
+                                            "$ref": "#/definitions/dto.TokenResponse"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/dto.HTTPErrorResp"
                         }
@@ -595,9 +810,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/cars/{id}/transfer": {
+        "/auth/register": {
             "post": {
-                "description": "Transfer a car to a new owner",
+                "description": "Create a new user account",
                 "consumes": [
                     "application/json"
                 ],
@@ -605,32 +820,37 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "cars"
+                    "auth"
                 ],
-                "summary": "Transfer car ownership",
+                "summary": "Register a new user",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Car ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "New owner ID",
+                        "description": "Registration data",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.TransferCarRequest"
+                            "$ref": "#/definitions/dto.RegisterRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/dto.HttpSuccessResp"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.HttpSuccessResp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/entity.User"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -639,8 +859,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.HTTPErrorResp"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "$ref": "#/definitions/dto.HTTPErrorResp"
                         }
@@ -654,19 +874,33 @@ const docTemplate = `{
                 }
             }
         },
-        "/health": {
-            "get": {
-                "description": "Returns the health status of the service",
+        "/cars": {
+            "post": {
+                "description": "Create a new car with the provided information",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "health"
+                    "cars"
+                ],
+                "summary": "Create a new car",
+                "parameters": [
+                    {
+                        "description": "Car data",
+                        "name": "car",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateCarRequest"
+                        }
+                    }
                 ],
-                "summary": "Health check endpoint",
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
                             "allOf": [
                                 {
@@ -676,47 +910,21 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/dto.HealthStatus"
+                                            "$ref": "#/definitions/entity.Car"
                                         }
                                     }
                                 }
                             ]
                         }
-                    }
-                }
-            }
-        },
-        "/ready": {
-            "get": {
-                "description": "Returns the readiness status of the service (checks dependencies)",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "health"
-                ],
-                "summary": "Readiness check endpoint",
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    },
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/dto.HttpSuccessResp"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/dto.ReadinessStatus"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
                         }
                     },
-                    "503": {
-                        "description": "Service Unavailable",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/dto.HTTPErrorResp"
                         }
@@ -724,49 +932,35 @@ const docTemplate = `{
                 }
             }
         },
-        "/users": {
+        "/cars/availability": {
             "get": {
-                "description": "Get a paginated list of users with optional filters",
+                "description": "List cars with no active reservation overlapping [from, to)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "reservations"
                 ],
-                "summary": "List users",
+                "summary": "Search available cars",
                 "parameters": [
                     {
-                        "enum": [
-                            "must-revalidate",
-                            "must-db-revalidate"
-                        ],
                         "type": "string",
-                        "description": "Request cache control",
-                        "name": "Cache-Control",
-                        "in": "header"
+                        "description": "Start of the window (RFC 3339)",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Filter by name",
-                        "name": "name",
-                        "in": "query"
+                        "description": "End of the window (RFC 3339), after from",
+                        "name": "to",
+                        "in": "query",
+                        "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Filter by email",
-                        "name": "email",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Minimum age",
-                        "name": "min_age",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Maximum age",
-                        "name": "max_age",
+                        "description": "Brand contains",
+                        "name": "brand",
                         "in": "query"
                     },
                     {
@@ -782,19 +976,6 @@ const docTemplate = `{
                         "description": "Page size",
                         "name": "page_size",
                         "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Sort by field",
-                        "name": "sort_by",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "default": "asc",
-                        "description": "Sort direction (asc/desc)",
-                        "name": "sort_dir",
-                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -811,7 +992,7 @@ const docTemplate = `{
                                         "data": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/entity.User"
+                                                "$ref": "#/definitions/entity.Car"
                                             }
                                         }
                                     }
@@ -825,6 +1006,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.HTTPErrorResp"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -833,8 +1020,28 @@ const docTemplate = `{
                     }
                 }
             },
+            "put": {
+                "description": "Availability is derived from reservations and can no longer be set. Reserve the car or cancel its reservation instead.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cars"
+                ],
+                "summary": "Bulk update car availability (removed)",
+                "responses": {
+                    "410": {
+                        "description": "Gone",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/cars/bulk": {
             "post": {
-                "description": "Create a new user with the provided information",
+                "description": "Create multiple cars for a user in a single request",
                 "consumes": [
                     "application/json"
                 ],
@@ -842,17 +1049,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "cars"
                 ],
-                "summary": "Create a new user",
+                "summary": "Create multiple cars",
                 "parameters": [
                     {
-                        "description": "User data",
-                        "name": "user",
+                        "description": "Cars data",
+                        "name": "cars",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.CreateUserRequest"
+                            "$ref": "#/definitions/dto.BulkCreateCarsRequest"
                         }
                     }
                 ],
@@ -868,7 +1075,10 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/entity.User"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/entity.Car"
+                                            }
                                         }
                                     }
                                 }
@@ -890,60 +1100,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/{id}": {
-            "get": {
-                "description": "Get a user by their ID",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "users"
-                ],
-                "summary": "Get user by ID",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "User ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/dto.HttpSuccessResp"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/entity.User"
-                                        }
-                                    }
-                                }
-                            ]
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/dto.HTTPErrorResp"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/dto.HTTPErrorResp"
-                        }
-                    }
-                }
-            },
-            "put": {
-                "description": "Update an existing user",
+        "/cars/decode-vin": {
+            "post": {
+                "description": "Look a VIN up in the NHTSA vPIC API to pre-fill the brand, model and year of a new car. Decodings are cached; a VIN vPIC cannot decode is answered with 422.",
                 "consumes": [
                     "application/json"
                 ],
@@ -951,24 +1110,29 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "cars"
                 ],
-                "summary": "Update user",
+                "summary": "Decode a VIN",
                 "parameters": [
                     {
+                        "enum": [
+                            "no-cache",
+                            "no-store",
+                            "max-age=60",
+                            "only-if-cached"
+                        ],
                         "type": "string",
-                        "description": "User ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Request cache control",
+                        "name": "Cache-Control",
+                        "in": "header"
                     },
                     {
-                        "description": "User data",
-                        "name": "user",
+                        "description": "VIN to decode",
+                        "name": "vin",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.UpdateUserRequest"
+                            "$ref": "#/definitions/dto.DecodeVINRequest"
                         }
                     }
                 ],
@@ -984,11 +1148,21 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/entity.User"
+                                            "$ref": "#/definitions/entity.DecodedVIN"
                                         }
                                     }
                                 }
                             ]
+                        },
+                        "headers": {
+                            "Age": {
+                                "type": "integer",
+                                "description": "Seconds since the cached copy was stored"
+                            },
+                            "X-Cache": {
+                                "type": "string",
+                                "description": "HIT, MISS or BYPASS"
+                            }
                         }
                     },
                     "400": {
@@ -997,53 +1171,112 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.HTTPErrorResp"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "422": {
+                        "description": "Unprocessable Entity",
                         "schema": {
                             "$ref": "#/definitions/dto.HTTPErrorResp"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "503": {
+                        "description": "Service Unavailable",
                         "schema": {
                             "$ref": "#/definitions/dto.HTTPErrorResp"
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete a user by ID",
+            }
+        },
+        "/cars/export": {
+            "get": {
+                "description": "Stream every car matching the V2 filters as CSV or NDJSON, chosen by the Accept header (admin only)",
                 "produces": [
-                    "application/json"
+                    "text/csv",
+                    "application/x-ndjson"
                 ],
                 "tags": [
-                    "users"
+                    "cars"
                 ],
-                "summary": "Delete user",
+                "summary": "Export cars",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
+                        "description": "Filter by ID",
                         "name": "id",
-                        "in": "path",
-                        "required": true
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by brand (use % for LIKE)",
+                        "name": "brand",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by model (use % for LIKE)",
+                        "name": "model",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by color",
+                        "name": "color",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by license plate",
+                        "name": "license_plate",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by VIN",
+                        "name": "vin",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Minimum year",
+                        "name": "min_year__gte",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum year",
+                        "name": "max_year__lte",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter by availability",
+                        "name": "is_available",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.HttpSuccessResp"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.Car"
+                            }
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/dto.HTTPErrorResp"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
                         "schema": {
                             "$ref": "#/definitions/dto.HTTPErrorResp"
                         }
@@ -1051,28 +1284,37 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/{user_id}/cars": {
-            "get": {
-                "description": "Get all cars owned by a specific user",
+        "/cars/import": {
+            "post": {
+                "description": "Import cars from a CSV file (columns: brand, model, year, color, license_plate, plate_region, vin). Each row is validated like POST /cars; valid rows are inserted in chunks and assigned to the caller, invalid rows are reported with their CSV line number. Set dry_run=true to validate without inserting.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "cars"
                 ],
-                "summary": "List cars by user",
+                "summary": "Import cars from CSV",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "User ID",
-                        "name": "user_id",
-                        "in": "path",
+                        "type": "file",
+                        "description": "CSV file with a header row",
+                        "name": "file",
+                        "in": "formData",
                         "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Validate only, do not insert",
+                        "name": "dry_run",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Dry run",
                         "schema": {
                             "allOf": [
                                 {
@@ -1082,24 +1324,33 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/entity.Car"
-                                            }
+                                            "$ref": "#/definitions/dto.CarImportResult"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.HttpSuccessResp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.CarImportResult"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/dto.HTTPErrorResp"
                         }
@@ -1113,23 +1364,68 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/{user_id}/cars/count": {
+        "/cars/{id}": {
             "get": {
-                "description": "Get the total number of cars owned by a specific user",
+                "description": "Get a car by its ID",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "cars"
                 ],
-                "summary": "Count cars by user",
+                "summary": "Get car by ID",
                 "parameters": [
                     {
+                        "enum": [
+                            "no-cache",
+                            "no-store",
+                            "max-age=60",
+                            "only-if-cached"
+                        ],
                         "type": "string",
-                        "description": "User ID",
-                        "name": "user_id",
+                        "description": "Request cache control",
+                        "name": "Cache-Control",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Car ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated columns to return (e.g. id,brand,model)",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "owner"
+                        ],
+                        "type": "string",
+                        "description": "Embed related records",
+                        "name": "include",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Also find a soft-deleted car, bypassing the cache (admin only, not with include)",
+                        "name": "include_deleted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of a cached copy",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last-Modified of a cached copy",
+                        "name": "If-Modified-Since",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -1144,13 +1440,34 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "integer"
+                                            "$ref": "#/definitions/entity.CarWithOwner"
                                         }
                                     }
                                 }
                             ]
+                        },
+                        "headers": {
+                            "Age": {
+                                "type": "integer",
+                                "description": "Seconds since the cached copy was stored"
+                            },
+                            "ETag": {
+                                "type": "string",
+                                "description": "Strong entity tag of the response data"
+                            },
+                            "Last-Modified": {
+                                "type": "string",
+                                "description": "updated_at of the record, without include"
+                            },
+                            "X-Cache": {
+                                "type": "string",
+                                "description": "HIT, MISS or BYPASS"
+                            }
                         }
                     },
+                    "304": {
+                        "description": "Not Modified"
+                    },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
@@ -1163,94 +1480,4183 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.HTTPErrorResp"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/dto.HTTPErrorResp"
                         }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
                     }
                 }
-            }
-        }
-    },
-    "definitions": {
-        "dto.BulkCreateCarsRequest": {
-            "type": "object",
-            "required": [
-                "cars"
-            ],
-            "properties": {
-                "cars": {
-                    "type": "array",
-                    "maxItems": 50,
-                    "minItems": 1,
-                    "items": {
-                        "$ref": "#/definitions/dto.CreateCarRequest"
+            },
+            "put": {
+                "description": "Update an existing car",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cars"
+                ],
+                "summary": "Update car",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Car ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Car data",
+                        "name": "car",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateCarRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag the change is based on",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last-Modified the change is based on",
+                        "name": "If-Unmodified-Since",
+                        "in": "header"
                     }
-                }
-            }
-        },
-        "dto.BulkUpdateAvailabilityRequest": {
-            "type": "object",
-            "required": [
-                "car_ids",
-                "is_available"
-            ],
-            "properties": {
-                "car_ids": {
-                    "type": "array",
-                    "minItems": 1,
-                    "items": {
-                        "type": "string"
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.HttpSuccessResp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/entity.Car"
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the updated car"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.HTTPErrorResp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/entity.Car"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
                     }
-                },
-                "is_available": {
-                    "type": "boolean"
                 }
-            }
-        },
-        "dto.CreateCarRequest": {
-            "type": "object",
-            "required": [
-                "brand",
-                "license_plate",
-                "model",
-                "year"
-            ],
-            "properties": {
-                "brand": {
-                    "type": "string",
-                    "maxLength": 100,
-                    "minLength": 2
-                },
-                "color": {
-                    "type": "string",
-                    "maxLength": 50
-                },
-                "license_plate": {
-                    "type": "string",
-                    "maxLength": 20,
-                    "minLength": 3
+            },
+            "delete": {
+                "description": "Soft-delete a car by ID. The car is hidden from every read and can be restored by an admin until the purge job removes it.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cars"
+                ],
+                "summary": "Delete car",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Car ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Why the car is deleted, kept in its ownership history",
+                        "name": "reason",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag the change is based on",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last-Modified the change is based on",
+                        "name": "If-Unmodified-Since",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HttpSuccessResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Partially update a car with a JSON Merge Patch (RFC 7396) or a JSON Patch (RFC 6902). The patch applies to the fields of dto.CarPatch and the result is validated as a whole, so a null color clears it.",
+                "consumes": [
+                    "application/merge-patch+json",
+                    "application/json-patch+json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cars"
+                ],
+                "summary": "Patch car",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Car ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Merge patch object, or an array of JSON Patch operations",
+                        "name": "patch",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CarPatch"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag the change is based on",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last-Modified the change is based on",
+                        "name": "If-Unmodified-Since",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.HttpSuccessResp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/entity.Car"
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the updated car"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.HTTPErrorResp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/entity.Car"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/cars/{id}/attachments": {
+            "get": {
+                "description": "List a car's photos and documents, newest first, each with a fresh signed download URL (any user the car is shared with, or an admin)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "attachments"
+                ],
+                "summary": "List car attachments",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Car ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.HttpSuccessResp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/entity.CarAttachment"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Attach a photo (JPEG, PNG or WebP) or a document (PDF, JPEG or PNG) to a car (owner or co-owner only). The type is sniffed from the file content, not taken from the client. The response carries a signed download URL.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "attachments"
+                ],
+                "summary": "Upload car attachment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Car ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "photo",
+                            "document"
+                        ],
+                        "type": "string",
+                        "description": "Attachment kind",
+                        "name": "kind",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Photo or document",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.HttpSuccessResp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/entity.CarAttachment"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/cars/{id}/attachments/{attachment_id}": {
+            "get": {
+                "description": "Get one attachment's metadata with a fresh signed download URL (any user the car is shared with, or an admin)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "attachments"
+                ],
+                "summary": "Get car attachment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Car ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Attachment ID",
+                        "name": "attachment_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.HttpSuccessResp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/entity.CarAttachment"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Remove an attachment and its stored file (owner or co-owner only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "attachments"
+                ],
+                "summary": "Delete car attachment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Car ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Attachment ID",
+                        "name": "attachment_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HttpSuccessResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/cars/{id}/history": {
+            "get": {
+                "description": "List every creation, transfer, deletion and restore of a car, oldest first, with the acting user and reason (owner or admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cars"
+                ],
+                "summary": "Get car ownership history",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Car ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.HttpSuccessResp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/entity.CarOwnershipRecord"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/cars/{id}/owner": {
+            "get": {
+                "description": "Get a car by its ID with owner information",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cars"
+                ],
+                "summary": "Get car with owner details",
+                "parameters": [
+                    {
+                        "enum": [
+                            "no-cache",
+                            "no-store",
+                            "max-age=60",
+                            "only-if-cached"
+                        ],
+                        "type": "string",
+                        "description": "Request cache control",
+                        "name": "Cache-Control",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Car ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.HttpSuccessResp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/entity.CarWithOwner"
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "Age": {
+                                "type": "integer",
+                                "description": "Seconds since the cached copy was stored"
+                            },
+                            "X-Cache": {
+                                "type": "string",
+                                "description": "HIT, MISS or BYPASS"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/cars/{id}/reservations": {
+            "get": {
+                "description": "List the reservations of a car, ordered by start. The owner and admins see all of them; other users only their own.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reservations"
+                ],
+                "summary": "List car reservations",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Car ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only reservations overlapping [from, to) (RFC 3339, with to)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End of the window (RFC 3339, with from)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Also list cancelled reservations",
+                        "name": "include_cancelled",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.HttpSuccessResp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/entity.Reservation"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Book a car for the half-open period [starts_at, ends_at). Only the car's owner and admins may reserve it. Overlapping an active reservation of the same car returns 409.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reservations"
+                ],
+                "summary": "Reserve car",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Car ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reservation period",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateReservationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.HttpSuccessResp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/entity.Reservation"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/cars/{id}/restore": {
+            "post": {
+                "description": "Undo the soft delete of a car (admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cars"
+                ],
+                "summary": "Restore car",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Car ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.HttpSuccessResp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/entity.Car"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/cars/{id}/services": {
+            "get": {
+                "description": "List a car's maintenance log, newest first (owner or co-owner only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "services"
+                ],
+                "summary": "List car services",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Car ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "oil_change",
+                            "tire_rotation",
+                            "brakes",
+                            "battery",
+                            "inspection",
+                            "repair",
+                            "other"
+                        ],
+                        "type": "string",
+                        "description": "Service type",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Earliest service date (RFC 3339, inclusive)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Latest service date (RFC 3339, inclusive)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.HttpSuccessResp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/entity.ServiceRecord"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Add an entry to a car's maintenance log (owner or co-owner only). Only the date part of service_date is kept; future dates are rejected.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "services"
+                ],
+                "summary": "Log car service",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Car ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Service details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ServiceRecordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.HttpSuccessResp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/entity.ServiceRecord"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/cars/{id}/services/{service_id}": {
+            "get": {
+                "description": "Get one entry of a car's maintenance log (owner or co-owner only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "services"
+                ],
+                "summary": "Get car service",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Car ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Service record ID",
+                        "name": "service_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.HttpSuccessResp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/entity.ServiceRecord"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Replace an entry of a car's maintenance log (owner or co-owner only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "services"
+                ],
+                "summary": "Update car service",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Car ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Service record ID",
+                        "name": "service_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Service details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ServiceRecordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.HttpSuccessResp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/entity.ServiceRecord"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Remove an entry from a car's maintenance log (owner or co-owner only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "services"
+                ],
+                "summary": "Delete car service",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Car ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Service record ID",
+                        "name": "service_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HttpSuccessResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/cars/{id}/shares": {
+            "get": {
+                "description": "List every user with access to a car and their role, owner first (owner or admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cars"
+                ],
+                "summary": "List car shares",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Car ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.HttpSuccessResp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/entity.CarShare"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/cars/{id}/shares/{user_id}": {
+            "put": {
+                "description": "Give a user a co-owner, driver or viewer role on a car, or change the role they have (owner only). Co-owners may edit the car; drivers and viewers only see it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cars"
+                ],
+                "summary": "Share car",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Car ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Role",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ShareCarRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.HttpSuccessResp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/entity.CarShare"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Revoke a user's access to a car. The owner may revoke any share; other users may only give up their own.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cars"
+                ],
+                "summary": "Unshare car",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Car ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HttpSuccessResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/cars/{id}/transfer": {
+            "post": {
+                "description": "Offer a car to another user. The car changes hands only when the recipient accepts; the request expires after the configured TTL. A car has at most one pending request.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transfers"
+                ],
+                "summary": "Request car transfer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Car ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Recipient ID and optional reason",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.TransferCarRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.HttpSuccessResp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/entity.CarTransferRequest"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/health": {
+            "get": {
+                "description": "Returns the health status of the service",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Health check endpoint",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.HttpSuccessResp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.HealthStatus"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/plates/{plate}": {
+            "get": {
+                "description": "Find a car by its license plate, ignoring case, spaces and punctuation: \"b 1234-xy\" finds B1234XY.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cars"
+                ],
+                "summary": "Get car by license plate",
+                "parameters": [
+                    {
+                        "enum": [
+                            "no-cache",
+                            "no-store",
+                            "max-age=60",
+                            "only-if-cached"
+                        ],
+                        "type": "string",
+                        "description": "Request cache control",
+                        "name": "Cache-Control",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "License plate",
+                        "name": "plate",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated columns to return (e.g. id,brand,model)",
+                        "name": "fields",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.HttpSuccessResp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/entity.Car"
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "Age": {
+                                "type": "integer",
+                                "description": "Seconds since the cached copy was stored"
+                            },
+                            "Last-Modified": {
+                                "type": "string",
+                                "description": "updated_at of the record"
+                            },
+                            "X-Cache": {
+                                "type": "string",
+                                "description": "HIT, MISS or BYPASS"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/ready": {
+            "get": {
+                "description": "Returns the readiness status of the service (checks dependencies)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Readiness check endpoint",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.HttpSuccessResp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.ReadinessStatus"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/reservations": {
+            "get": {
+                "description": "List the authenticated user's reservations, ordered by start",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reservations"
+                ],
+                "summary": "List my reservations",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Only reservations overlapping [from, to) (RFC 3339, with to)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End of the window (RFC 3339, with from)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Also list cancelled reservations",
+                        "name": "include_cancelled",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.HttpSuccessResp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/entity.Reservation"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/reservations/{id}": {
+            "get": {
+                "description": "Get a reservation by ID. Visible to the reserving user, the car's owner and admins.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reservations"
+                ],
+                "summary": "Get reservation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Reservation ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.HttpSuccessResp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/entity.Reservation"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/reservations/{id}/cancel": {
+            "post": {
+                "description": "Cancel an active reservation, freeing its period. Allowed for the reserving user, the car's owner and admins.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reservations"
+                ],
+                "summary": "Cancel reservation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Reservation ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.HttpSuccessResp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/entity.Reservation"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/search": {
+            "get": {
+                "description": "Full-text search across users and cars, ranked by relevance with highlighted snippets. Admins search all records; other users only their own cars.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "search"
+                ],
+                "summary": "Search users and cars",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search terms (web search syntax)",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.HttpSuccessResp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/entity.SearchResult"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/transfer-requests/incoming": {
+            "get": {
+                "description": "List the transfer requests offered to the authenticated user, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transfers"
+                ],
+                "summary": "List incoming transfer requests",
+                "parameters": [
+                    {
+                        "enum": [
+                            "pending",
+                            "accepted",
+                            "rejected",
+                            "cancelled",
+                            "expired"
+                        ],
+                        "type": "string",
+                        "description": "Only requests with this status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.HttpSuccessResp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/entity.CarTransferRequest"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/transfer-requests/outgoing": {
+            "get": {
+                "description": "List the transfer requests sent by the authenticated user, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transfers"
+                ],
+                "summary": "List outgoing transfer requests",
+                "parameters": [
+                    {
+                        "enum": [
+                            "pending",
+                            "accepted",
+                            "rejected",
+                            "cancelled",
+                            "expired"
+                        ],
+                        "type": "string",
+                        "description": "Only requests with this status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.HttpSuccessResp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/entity.CarTransferRequest"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/transfer-requests/{id}": {
+            "get": {
+                "description": "Get a transfer request by ID. Visible to its sender, its recipient and admins.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transfers"
+                ],
+                "summary": "Get transfer request",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Transfer request ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.HttpSuccessResp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/entity.CarTransferRequest"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/transfer-requests/{id}/accept": {
+            "post": {
+                "description": "Accept a pending transfer request as its recipient; the car becomes yours. Returns 409 if the request was already answered, has expired or the sender no longer owns the car.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transfers"
+                ],
+                "summary": "Accept transfer request",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Transfer request ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.HttpSuccessResp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/entity.CarTransferRequest"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/transfer-requests/{id}/cancel": {
+            "post": {
+                "description": "Withdraw a pending transfer request as its sender, before the recipient accepts",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transfers"
+                ],
+                "summary": "Cancel transfer request",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Transfer request ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.HttpSuccessResp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/entity.CarTransferRequest"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/transfer-requests/{id}/reject": {
+            "post": {
+                "description": "Reject a pending transfer request as its recipient",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transfers"
+                ],
+                "summary": "Reject transfer request",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Transfer request ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.HttpSuccessResp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/entity.CarTransferRequest"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/users": {
+            "get": {
+                "description": "Get a paginated list of users with optional filters",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "List users",
+                "parameters": [
+                    {
+                        "enum": [
+                            "no-cache",
+                            "no-store",
+                            "max-age=60",
+                            "only-if-cached"
+                        ],
+                        "type": "string",
+                        "description": "Request cache control",
+                        "name": "Cache-Control",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by name",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by email",
+                        "name": "email",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Minimum age",
+                        "name": "min_age",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum age",
+                        "name": "max_age",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort by field",
+                        "name": "sort_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "asc",
+                        "description": "Sort direction (asc/desc)",
+                        "name": "sort_dir",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated columns to return (e.g. id,name,email)",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "cars"
+                        ],
+                        "type": "string",
+                        "description": "Embed related records",
+                        "name": "include",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Also list soft-deleted users (admin only)",
+                        "name": "include_deleted",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.HttpSuccessResp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/entity.UserWithCars"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "Age": {
+                                "type": "integer",
+                                "description": "Seconds since the cached copy was stored"
+                            },
+                            "X-Cache": {
+                                "type": "string",
+                                "description": "HIT, MISS or BYPASS"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a new user with the provided information",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Create a new user",
+                "parameters": [
+                    {
+                        "description": "User data",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.HttpSuccessResp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/entity.User"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/export": {
+            "get": {
+                "description": "Stream every user matching the V2 filters as CSV or NDJSON, chosen by the Accept header (admin only)",
+                "produces": [
+                    "text/csv",
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Export users",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter by ID",
+                        "name": "id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by name (use % for LIKE)",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by email (use % for LIKE)",
+                        "name": "email",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Minimum age",
+                        "name": "min_age__gte",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum age",
+                        "name": "max_age__lte",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.User"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}": {
+            "get": {
+                "description": "Get a user by their ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Get user by ID",
+                "parameters": [
+                    {
+                        "enum": [
+                            "no-cache",
+                            "no-store",
+                            "max-age=60",
+                            "only-if-cached"
+                        ],
+                        "type": "string",
+                        "description": "Request cache control",
+                        "name": "Cache-Control",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated columns to return (e.g. id,name,email)",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "cars"
+                        ],
+                        "type": "string",
+                        "description": "Embed related records",
+                        "name": "include",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Also find a soft-deleted user, bypassing the cache (admin only, not with include)",
+                        "name": "include_deleted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of a cached copy",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last-Modified of a cached copy",
+                        "name": "If-Modified-Since",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.HttpSuccessResp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/entity.UserWithCars"
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "Age": {
+                                "type": "integer",
+                                "description": "Seconds since the cached copy was stored"
+                            },
+                            "ETag": {
+                                "type": "string",
+                                "description": "Strong entity tag of the response data"
+                            },
+                            "Last-Modified": {
+                                "type": "string",
+                                "description": "updated_at of the record, without include"
+                            },
+                            "X-Cache": {
+                                "type": "string",
+                                "description": "HIT, MISS or BYPASS"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Not Modified"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Update an existing user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Update user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "User data",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateUserRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag the change is based on",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last-Modified the change is based on",
+                        "name": "If-Unmodified-Since",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.HttpSuccessResp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/entity.User"
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the updated user"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.HTTPErrorResp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/entity.User"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Soft-delete a user by ID. The user is hidden from every read and can be restored by an admin until the purge job removes it.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Delete user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag the change is based on",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last-Modified the change is based on",
+                        "name": "If-Unmodified-Since",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HttpSuccessResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Partially update a user with a JSON Merge Patch (RFC 7396) or a JSON Patch (RFC 6902). The patch applies to the fields of dto.UserPatch and the result is validated as a whole, so a null age clears it.",
+                "consumes": [
+                    "application/merge-patch+json",
+                    "application/json-patch+json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Patch user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Merge patch object, or an array of JSON Patch operations",
+                        "name": "patch",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UserPatch"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag the change is based on",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last-Modified the change is based on",
+                        "name": "If-Unmodified-Since",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.HttpSuccessResp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/entity.User"
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the updated user"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.HTTPErrorResp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/entity.User"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/restore": {
+            "post": {
+                "description": "Undo the soft delete of a user (admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Restore user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.HttpSuccessResp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/entity.User"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{user_id}/cars": {
+            "get": {
+                "description": "Get all cars owned by a specific user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cars"
+                ],
+                "summary": "List cars by user",
+                "parameters": [
+                    {
+                        "enum": [
+                            "no-cache",
+                            "no-store",
+                            "max-age=60",
+                            "only-if-cached"
+                        ],
+                        "type": "string",
+                        "description": "Request cache control",
+                        "name": "Cache-Control",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated columns to return (e.g. id,brand,model)",
+                        "name": "fields",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.HttpSuccessResp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/entity.Car"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "Age": {
+                                "type": "integer",
+                                "description": "Seconds since the cached copy was stored"
+                            },
+                            "X-Cache": {
+                                "type": "string",
+                                "description": "HIT, MISS or BYPASS"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{user_id}/cars/count": {
+            "get": {
+                "description": "Get the total number of cars owned by a specific user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cars"
+                ],
+                "summary": "Count cars by user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.HttpSuccessResp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "integer"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HTTPErrorResp"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "dto.BulkCreateCarsRequest": {
+            "type": "object",
+            "required": [
+                "cars"
+            ],
+            "properties": {
+                "cars": {
+                    "type": "array",
+                    "maxItems": 50,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/dto.CreateCarRequest"
+                    }
+                }
+            }
+        },
+        "dto.CarImportResult": {
+            "type": "object",
+            "properties": {
+                "dry_run": {
+                    "type": "boolean"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CarImportRowError"
+                    }
+                },
+                "failed": {
+                    "type": "integer"
+                },
+                "imported": {
+                    "type": "integer"
+                },
+                "total_rows": {
+                    "type": "integer"
+                },
+                "valid": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.CarImportRowError": {
+            "type": "object",
+            "properties": {
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "license_plate": {
+                    "type": "string"
+                },
+                "row": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.CarPatch": {
+            "type": "object",
+            "required": [
+                "brand",
+                "license_plate",
+                "model",
+                "year"
+            ],
+            "properties": {
+                "brand": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 2
+                },
+                "color": {
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "license_plate": {
+                    "type": "string",
+                    "maxLength": 20
+                },
+                "model": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 2
+                },
+                "plate_region": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "vin": {
+                    "type": "string"
+                },
+                "year": {
+                    "type": "integer",
+                    "maximum": 2100,
+                    "minimum": 1900
+                }
+            }
+        },
+        "dto.CreateCarRequest": {
+            "type": "object",
+            "required": [
+                "brand",
+                "license_plate",
+                "model",
+                "year"
+            ],
+            "properties": {
+                "brand": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 2
+                },
+                "color": {
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "license_plate": {
+                    "type": "string",
+                    "maxLength": 20
+                },
+                "model": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 2
+                },
+                "plate_region": {
+                    "type": "string"
+                },
+                "vin": {
+                    "type": "string"
+                },
+                "year": {
+                    "type": "integer",
+                    "maximum": 2100,
+                    "minimum": 1900
+                }
+            }
+        },
+        "dto.CreateReservationRequest": {
+            "type": "object",
+            "required": [
+                "ends_at",
+                "starts_at"
+            ],
+            "properties": {
+                "ends_at": {
+                    "type": "string"
+                },
+                "starts_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CreateUserRequest": {
+            "type": "object",
+            "required": [
+                "age",
+                "email",
+                "name",
+                "password",
+                "role"
+            ],
+            "properties": {
+                "age": {
+                    "type": "integer",
+                    "maximum": 150,
+                    "minimum": 1
+                },
+                "email": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 2
+                },
+                "password": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 8
+                },
+                "role": {
+                    "$ref": "#/definitions/entity.Role"
+                }
+            }
+        },
+        "dto.DecodeVINRequest": {
+            "type": "object",
+            "required": [
+                "vin"
+            ],
+            "properties": {
+                "vin": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.HTTPErrorResp": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Data is the current server state on a 409 version conflict."
+                },
+                "metadata": {
+                    "$ref": "#/definitions/dto.Meta"
+                }
+            }
+        },
+        "dto.HealthStatus": {
+            "type": "object",
+            "properties": {
+                "service": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "timestamp": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.HttpSuccessResp": {
+            "type": "object",
+            "properties": {
+                "metadata": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.Meta"
+                        }
+                    ],
+                    "x-order": "0"
+                },
+                "data": {
+                    "x-order": "1"
+                },
+                "pagination": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.Pagination"
+                        }
+                    ],
+                    "x-order": "2"
+                }
+            }
+        },
+        "dto.LoginRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.Meta": {
+            "type": "object",
+            "properties": {
+                "path": {
+                    "type": "string",
+                    "x-order": "0"
+                },
+                "status_code": {
+                    "type": "integer",
+                    "x-order": "1"
+                },
+                "status": {
+                    "type": "string",
+                    "x-order": "2"
+                },
+                "message": {
+                    "type": "string",
+                    "x-order": "3"
+                },
+                "error": {
+                    "type": "object",
+                    "x-order": "4"
+                },
+                "timestamp": {
+                    "type": "string",
+                    "x-order": "5"
+                }
+            }
+        },
+        "dto.Pagination": {
+            "type": "object",
+            "properties": {
+                "current_page": {
+                    "type": "integer",
+                    "x-order": "0"
+                },
+                "current_elements": {
+                    "type": "integer",
+                    "x-order": "1"
+                },
+                "total_pages": {
+                    "type": "integer",
+                    "x-order": "2"
+                },
+                "total_elements": {
+                    "type": "integer",
+                    "x-order": "3"
+                },
+                "sort_by": {
+                    "type": "string",
+                    "x-order": "4"
+                },
+                "sort_dir": {
+                    "type": "string",
+                    "x-order": "5"
+                },
+                "cursor_start": {
+                    "type": "string",
+                    "x-order": "6"
+                },
+                "cursor_end": {
+                    "type": "string",
+                    "x-order": "7"
+                }
+            }
+        },
+        "dto.ReadinessStatus": {
+            "type": "object",
+            "properties": {
+                "dependencies": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "status": {
+                    "type": "string"
+                },
+                "timestamp": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.RefreshTokenRequest": {
+            "type": "object",
+            "required": [
+                "refreshToken"
+            ],
+            "properties": {
+                "refreshToken": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.RegisterRequest": {
+            "type": "object",
+            "required": [
+                "age",
+                "email",
+                "name",
+                "password",
+                "role"
+            ],
+            "properties": {
+                "age": {
+                    "type": "integer",
+                    "maximum": 150,
+                    "minimum": 1
+                },
+                "email": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 2
+                },
+                "password": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 8
+                },
+                "role": {
+                    "$ref": "#/definitions/entity.Role"
+                }
+            }
+        },
+        "dto.ServiceRecordRequest": {
+            "type": "object",
+            "required": [
+                "service_date",
+                "service_type"
+            ],
+            "properties": {
+                "cost": {
+                    "type": "number",
+                    "maximum": 9999999999,
+                    "minimum": 0
+                },
+                "notes": {
+                    "type": "string",
+                    "maxLength": 1000
+                },
+                "odometer": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "service_date": {
+                    "type": "string"
+                },
+                "service_type": {
+                    "type": "string",
+                    "enum": [
+                        "oil_change",
+                        "tire_rotation",
+                        "brakes",
+                        "battery",
+                        "inspection",
+                        "repair",
+                        "other"
+                    ]
+                }
+            }
+        },
+        "dto.ShareCarRequest": {
+            "type": "object",
+            "required": [
+                "role"
+            ],
+            "properties": {
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "co-owner",
+                        "driver",
+                        "viewer"
+                    ]
+                }
+            }
+        },
+        "dto.TokenResponse": {
+            "type": "object",
+            "properties": {
+                "accessToken": {
+                    "type": "string"
+                },
+                "expiresAt": {
+                    "type": "integer"
+                },
+                "refreshToken": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.TransferCarRequest": {
+            "type": "object",
+            "required": [
+                "new_user_id"
+            ],
+            "properties": {
+                "new_user_id": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string",
+                    "maxLength": 500
+                }
+            }
+        },
+        "dto.UpdateCarRequest": {
+            "type": "object",
+            "properties": {
+                "brand": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 2
+                },
+                "color": {
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "license_plate": {
+                    "type": "string",
+                    "maxLength": 20
+                },
+                "model": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 2
+                },
+                "plate_region": {
+                    "type": "string"
+                },
+                "version": {
+                    "description": "Version, when set, is the version the change is based on. The update\nfails with 409 if the car changed since.",
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "vin": {
+                    "type": "string"
+                },
+                "year": {
+                    "type": "integer",
+                    "maximum": 2100,
+                    "minimum": 1900
+                }
+            }
+        },
+        "dto.UpdateUserRequest": {
+            "type": "object",
+            "properties": {
+                "age": {
+                    "type": "integer",
+                    "maximum": 150,
+                    "minimum": 1
+                },
+                "email": {
+                    "type": "string"
+                },
+                "is_active": {
+                    "type": "boolean"
                 },
-                "model": {
+                "name": {
                     "type": "string",
                     "maxLength": 100,
                     "minLength": 2
                 },
-                "year": {
+                "role": {
+                    "$ref": "#/definitions/entity.Role"
+                },
+                "version": {
+                    "description": "Version, when set, is the version the change is based on. The update\nfails with 409 if the user changed since.",
                     "type": "integer",
-                    "maximum": 2100,
-                    "minimum": 1900
+                    "minimum": 1
                 }
             }
         },
-        "dto.CreateUserRequest": {
+        "dto.UserPatch": {
             "type": "object",
             "required": [
-                "age",
                 "email",
+                "is_active",
                 "name",
-                "password",
                 "role"
             ],
             "properties": {
@@ -1262,385 +5668,622 @@ const docTemplate = `{
                 "email": {
                     "type": "string"
                 },
+                "is_active": {
+                    "type": "boolean"
+                },
                 "name": {
                     "type": "string",
                     "maxLength": 100,
                     "minLength": 2
                 },
-                "password": {
-                    "type": "string",
-                    "maxLength": 100,
-                    "minLength": 8
-                },
                 "role": {
                     "$ref": "#/definitions/entity.Role"
+                },
+                "version": {
+                    "type": "integer",
+                    "minimum": 1
                 }
             }
         },
-        "dto.HTTPErrorResp": {
+        "entity.AttachmentKind": {
+            "type": "string",
+            "enum": [
+                "photo",
+                "document"
+            ],
+            "x-enum-varnames": [
+                "AttachmentPhoto",
+                "AttachmentDocument"
+            ]
+        },
+        "entity.BrandCount": {
             "type": "object",
             "properties": {
-                "metadata": {
-                    "$ref": "#/definitions/dto.Meta"
+                "brand": {
+                    "type": "string"
+                },
+                "count": {
+                    "type": "integer"
                 }
             }
         },
-        "dto.HealthStatus": {
+        "entity.Car": {
             "type": "object",
             "properties": {
-                "service": {
+                "brand": {
                     "type": "string"
                 },
-                "status": {
+                "color": {
                     "type": "string"
                 },
-                "timestamp": {
+                "created_at": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_available": {
+                    "type": "boolean"
+                },
+                "last_service_date": {
+                    "type": "string"
+                },
+                "last_service_odometer": {
+                    "type": "integer"
+                },
+                "license_plate": {
+                    "type": "string"
+                },
+                "model": {
+                    "type": "string"
+                },
+                "plate_region": {
+                    "type": "string"
+                },
+                "service_count": {
+                    "type": "integer"
+                },
+                "service_overdue_at": {
+                    "type": "string"
+                },
+                "total_service_cost": {
+                    "type": "number"
+                },
+                "updated_at": {
                     "type": "string"
                 },
                 "version": {
+                    "type": "integer"
+                },
+                "vin": {
                     "type": "string"
+                },
+                "year": {
+                    "type": "integer"
                 }
             }
         },
-        "dto.HttpSuccessResp": {
+        "entity.CarAttachment": {
             "type": "object",
             "properties": {
-                "metadata": {
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/dto.Meta"
-                        }
-                    ],
-                    "x-order": "0"
+                "car_id": {
+                    "type": "string"
                 },
-                "data": {
-                    "x-order": "1"
+                "checksum": {
+                    "type": "string"
                 },
-                "pagination": {
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/dto.Pagination"
-                        }
-                    ],
-                    "x-order": "2"
+                "content_type": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "download_url": {
+                    "type": "string"
+                },
+                "download_url_expires_at": {
+                    "type": "string"
+                },
+                "file_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "kind": {
+                    "$ref": "#/definitions/entity.AttachmentKind"
+                },
+                "size_bytes": {
+                    "type": "integer"
+                },
+                "uploaded_by": {
+                    "type": "string"
                 }
             }
         },
-        "dto.LoginRequest": {
-            "type": "object",
-            "required": [
-                "email",
-                "password"
+        "entity.CarOwnershipEvent": {
+            "type": "string",
+            "enum": [
+                "created",
+                "transferred",
+                "deleted",
+                "restored"
             ],
+            "x-enum-varnames": [
+                "CarOwnershipCreated",
+                "CarOwnershipTransferred",
+                "CarOwnershipDeleted",
+                "CarOwnershipRestored"
+            ]
+        },
+        "entity.CarOwnershipRecord": {
+            "type": "object",
             "properties": {
-                "email": {
+                "actor_id": {
                     "type": "string"
                 },
-                "password": {
+                "car_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "event": {
+                    "$ref": "#/definitions/entity.CarOwnershipEvent"
+                },
+                "from_user_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "to_user_id": {
                     "type": "string"
                 }
             }
         },
-        "dto.Meta": {
+        "entity.CarRole": {
+            "type": "string",
+            "enum": [
+                "owner",
+                "co-owner",
+                "driver",
+                "viewer"
+            ],
+            "x-enum-varnames": [
+                "CarRoleOwner",
+                "CarRoleCoOwner",
+                "CarRoleDriver",
+                "CarRoleViewer"
+            ]
+        },
+        "entity.CarShare": {
             "type": "object",
             "properties": {
-                "path": {
-                    "type": "string",
-                    "x-order": "0"
-                },
-                "status_code": {
-                    "type": "integer",
-                    "x-order": "1"
-                },
-                "status": {
-                    "type": "string",
-                    "x-order": "2"
+                "car_id": {
+                    "type": "string"
                 },
-                "message": {
-                    "type": "string",
-                    "x-order": "3"
+                "created_at": {
+                    "type": "string"
                 },
-                "error": {
-                    "type": "object",
-                    "x-order": "4"
+                "role": {
+                    "$ref": "#/definitions/entity.CarRole"
                 },
-                "timestamp": {
-                    "type": "string",
-                    "x-order": "5"
+                "user_id": {
+                    "type": "string"
                 }
             }
         },
-        "dto.Pagination": {
+        "entity.CarTransferRequest": {
             "type": "object",
             "properties": {
-                "current_page": {
-                    "type": "integer",
-                    "x-order": "0"
+                "car_id": {
+                    "type": "string"
                 },
-                "current_elements": {
-                    "type": "integer",
-                    "x-order": "1"
+                "created_at": {
+                    "type": "string"
                 },
-                "total_pages": {
-                    "type": "integer",
-                    "x-order": "2"
+                "expires_at": {
+                    "type": "string"
                 },
-                "total_elements": {
-                    "type": "integer",
-                    "x-order": "3"
+                "from_user_id": {
+                    "type": "string"
                 },
-                "sort_by": {
-                    "type": "string",
-                    "x-order": "4"
+                "id": {
+                    "type": "string"
                 },
-                "sort_dir": {
-                    "type": "string",
-                    "x-order": "5"
+                "reason": {
+                    "type": "string"
                 },
-                "cursor_start": {
-                    "type": "string",
-                    "x-order": "6"
+                "responded_at": {
+                    "type": "string"
                 },
-                "cursor_end": {
-                    "type": "string",
-                    "x-order": "7"
+                "status": {
+                    "$ref": "#/definitions/entity.TransferRequestStatus"
+                },
+                "to_user_id": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
                 }
             }
         },
-        "dto.ReadinessStatus": {
+        "entity.CarWithOwner": {
             "type": "object",
             "properties": {
-                "dependencies": {
-                    "type": "object",
-                    "additionalProperties": {
-                        "type": "string"
-                    }
+                "brand": {
+                    "type": "string"
+                },
+                "color": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_available": {
+                    "type": "boolean"
+                },
+                "last_service_date": {
+                    "type": "string"
+                },
+                "last_service_odometer": {
+                    "type": "integer"
+                },
+                "license_plate": {
+                    "type": "string"
+                },
+                "model": {
+                    "type": "string"
+                },
+                "owner_email": {
+                    "type": "string"
+                },
+                "owner_name": {
+                    "type": "string"
+                },
+                "plate_region": {
+                    "type": "string"
+                },
+                "service_count": {
+                    "type": "integer"
                 },
-                "status": {
+                "service_overdue_at": {
                     "type": "string"
                 },
-                "timestamp": {
+                "total_service_cost": {
+                    "type": "number"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                },
+                "vin": {
                     "type": "string"
+                },
+                "year": {
+                    "type": "integer"
                 }
             }
         },
-        "dto.RefreshTokenRequest": {
+        "entity.CarsPerUser": {
             "type": "object",
-            "required": [
-                "refreshToken"
-            ],
             "properties": {
-                "refreshToken": {
-                    "type": "string"
+                "car_count": {
+                    "type": "integer"
+                },
+                "user_count": {
+                    "type": "integer"
                 }
             }
         },
-        "dto.RegisterRequest": {
+        "entity.DecodedVIN": {
             "type": "object",
-            "required": [
-                "age",
-                "email",
-                "name",
-                "password",
-                "role"
-            ],
             "properties": {
-                "age": {
-                    "type": "integer",
-                    "maximum": 150,
-                    "minimum": 1
+                "body_class": {
+                    "type": "string"
                 },
-                "email": {
+                "brand": {
                     "type": "string"
                 },
-                "name": {
-                    "type": "string",
-                    "maxLength": 100,
-                    "minLength": 2
+                "fuel_type": {
+                    "type": "string"
                 },
-                "password": {
-                    "type": "string",
-                    "maxLength": 100,
-                    "minLength": 8
+                "manufacturer": {
+                    "type": "string"
                 },
-                "role": {
-                    "$ref": "#/definitions/entity.Role"
+                "model": {
+                    "type": "string"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "trim": {
+                    "type": "string"
+                },
+                "vehicle_type": {
+                    "type": "string"
+                },
+                "vin": {
+                    "type": "string"
+                },
+                "year": {
+                    "type": "integer"
                 }
             }
         },
-        "dto.TokenResponse": {
+        "entity.FleetAvailability": {
             "type": "object",
             "properties": {
-                "accessToken": {
-                    "type": "string"
+                "available": {
+                    "type": "integer"
                 },
-                "expiresAt": {
+                "ratio": {
+                    "type": "number"
+                },
+                "total": {
                     "type": "integer"
                 },
-                "refreshToken": {
-                    "type": "string"
+                "unavailable": {
+                    "type": "integer"
                 }
             }
         },
-        "dto.TransferCarRequest": {
+        "entity.LoginTrendPoint": {
             "type": "object",
-            "required": [
-                "new_user_id"
-            ],
             "properties": {
-                "new_user_id": {
+                "active_users": {
+                    "type": "integer"
+                },
+                "logins": {
+                    "type": "integer"
+                },
+                "period_start": {
                     "type": "string"
                 }
             }
         },
-        "dto.UpdateCarRequest": {
+        "entity.Reservation": {
             "type": "object",
             "properties": {
-                "brand": {
-                    "type": "string",
-                    "maxLength": 100,
-                    "minLength": 2
+                "cancelled_at": {
+                    "type": "string"
                 },
-                "color": {
-                    "type": "string",
-                    "maxLength": 50
+                "car_id": {
+                    "type": "string"
                 },
-                "is_available": {
-                    "type": "boolean"
+                "created_at": {
+                    "type": "string"
                 },
-                "license_plate": {
-                    "type": "string",
-                    "maxLength": 20,
-                    "minLength": 3
+                "ends_at": {
+                    "type": "string"
                 },
-                "model": {
-                    "type": "string",
-                    "maxLength": 100,
-                    "minLength": 2
+                "id": {
+                    "type": "string"
                 },
-                "year": {
-                    "type": "integer",
-                    "maximum": 2100,
-                    "minimum": 1900
+                "starts_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/entity.ReservationStatus"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
                 }
             }
         },
-        "dto.UpdateUserRequest": {
+        "entity.ReservationStatus": {
+            "type": "string",
+            "enum": [
+                "active",
+                "cancelled"
+            ],
+            "x-enum-varnames": [
+                "ReservationActive",
+                "ReservationCancelled"
+            ]
+        },
+        "entity.Role": {
+            "type": "string",
+            "enum": [
+                "admin",
+                "user",
+                "guest"
+            ],
+            "x-enum-varnames": [
+                "RoleAdmin",
+                "RoleUser",
+                "RoleGuest"
+            ]
+        },
+        "entity.SearchResult": {
             "type": "object",
             "properties": {
-                "age": {
-                    "type": "integer",
-                    "maximum": 150,
-                    "minimum": 1
-                },
-                "email": {
+                "id": {
                     "type": "string"
                 },
-                "is_active": {
-                    "type": "boolean"
+                "rank": {
+                    "type": "number"
                 },
-                "name": {
-                    "type": "string",
-                    "maxLength": 100,
-                    "minLength": 2
+                "snippet": {
+                    "type": "string"
                 },
-                "role": {
-                    "$ref": "#/definitions/entity.Role"
+                "title": {
+                    "type": "string"
+                },
+                "type": {
+                    "$ref": "#/definitions/entity.SearchType"
                 }
             }
         },
-        "entity.Car": {
+        "entity.SearchType": {
+            "type": "string",
+            "enum": [
+                "user",
+                "car"
+            ],
+            "x-enum-varnames": [
+                "SearchTypeUser",
+                "SearchTypeCar"
+            ]
+        },
+        "entity.ServiceRecord": {
             "type": "object",
             "properties": {
-                "brand": {
+                "car_id": {
                     "type": "string"
                 },
-                "color": {
-                    "type": "string"
+                "cost": {
+                    "type": "number"
                 },
                 "created_at": {
                     "type": "string"
                 },
-                "id": {
+                "created_by": {
                     "type": "string"
                 },
-                "is_available": {
-                    "type": "boolean"
+                "id": {
+                    "type": "string"
                 },
-                "license_plate": {
+                "notes": {
                     "type": "string"
                 },
-                "model": {
+                "odometer": {
+                    "type": "integer"
+                },
+                "service_date": {
                     "type": "string"
                 },
+                "service_type": {
+                    "$ref": "#/definitions/entity.ServiceType"
+                },
                 "updated_at": {
                     "type": "string"
-                },
-                "year": {
-                    "type": "integer"
                 }
             }
         },
-        "entity.CarWithOwner": {
+        "entity.ServiceType": {
+            "type": "string",
+            "enum": [
+                "oil_change",
+                "tire_rotation",
+                "brakes",
+                "battery",
+                "inspection",
+                "repair",
+                "other"
+            ],
+            "x-enum-varnames": [
+                "ServiceOilChange",
+                "ServiceTireRotation",
+                "ServiceBrakes",
+                "ServiceBattery",
+                "ServiceInspection",
+                "ServiceRepair",
+                "ServiceOther"
+            ]
+        },
+        "entity.TransferRequestStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "accepted",
+                "rejected",
+                "cancelled",
+                "expired"
+            ],
+            "x-enum-varnames": [
+                "TransferRequestPending",
+                "TransferRequestAccepted",
+                "TransferRequestRejected",
+                "TransferRequestCancelled",
+                "TransferRequestExpired"
+            ]
+        },
+        "entity.TrendPoint": {
             "type": "object",
             "properties": {
-                "brand": {
-                    "type": "string"
+                "count": {
+                    "type": "integer"
                 },
-                "color": {
+                "period_start": {
                     "type": "string"
+                }
+            }
+        },
+        "entity.User": {
+            "type": "object",
+            "properties": {
+                "age": {
+                    "type": "integer"
                 },
                 "created_at": {
                     "type": "string"
                 },
-                "id": {
+                "deleted_at": {
                     "type": "string"
                 },
-                "is_available": {
-                    "type": "boolean"
-                },
-                "license_plate": {
+                "email": {
                     "type": "string"
                 },
-                "model": {
+                "id": {
                     "type": "string"
                 },
-                "owner_email": {
-                    "type": "string"
+                "is_active": {
+                    "type": "boolean"
                 },
-                "owner_name": {
+                "name": {
                     "type": "string"
                 },
+                "role": {
+                    "$ref": "#/definitions/entity.Role"
+                },
                 "updated_at": {
                     "type": "string"
                 },
-                "year": {
+                "version": {
                     "type": "integer"
                 }
             }
         },
-        "entity.Role": {
-            "type": "string",
-            "enum": [
-                "admin",
-                "user",
-                "guest"
-            ],
-            "x-enum-varnames": [
-                "RoleAdmin",
-                "RoleUser",
-                "RoleGuest"
-            ]
-        },
-        "entity.User": {
+        "entity.UserWithCars": {
             "type": "object",
             "properties": {
                 "age": {
                     "type": "integer"
                 },
+                "car_count": {
+                    "type": "integer"
+                },
+                "cars": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.Car"
+                    }
+                },
                 "created_at": {
                     "type": "string"
                 },
+                "deleted_at": {
+                    "type": "string"
+                },
                 "email": {
                     "type": "string"
                 },
@@ -1658,6 +6301,20 @@ const docTemplate = `{
                 },
                 "updated_at": {
                     "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entity.YearCount": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "year": {
+                    "type": "integer"
                 }
             }
         }
@@ -0,0 +1,11 @@
+package main
+
+import (
+	"go-far/internal/app"
+)
+
+// check-queries verifies every named SQL query in configs/queries against
+// the configured Postgres schema and exits non-zero if any of them fail.
+func main() {
+	app.CheckQueries()
+}
@@ -0,0 +1,27 @@
+package main
+
+import (
+	"flag"
+	"os"
+
+	"go-far/internal/infra/query"
+	"go-far/internal/infra/query/querygen"
+
+	"github.com/rs/zerolog"
+)
+
+// querygen reads the annotated `-- name:` blocks under -in and writes typed
+// repository wrappers into -out. It is run through `go generate`.
+func main() {
+	in := flag.String("in", "./configs/queries/", "directory containing the SQL query files")
+	out := flag.String("out", "./internal/repository/queries/", "output directory for generated code")
+	pkg := flag.String("pkg", "queries", "package name of the generated code")
+	flag.Parse()
+
+	log := zerolog.New(zerolog.ConsoleWriter{Out: os.Stderr}).Level(zerolog.InfoLevel).With().Timestamp().Logger()
+
+	loader := query.InitQueryLoader(&log, &query.QueriesOptions{Path: *in})
+	if err := querygen.Generate(loader.Definitions(), querygen.Options{Package: *pkg, OutDir: *out}); err != nil {
+		log.Fatal().Err(err).Msg("Failed to generate queries")
+	}
+}
@@ -0,0 +1,48 @@
+-- name: CreateAttachment
+-- params: CarID uuid.UUID, UploadedBy string, Kind entity.AttachmentKind, FileName string, ContentType string, SizeBytes int64, Checksum string, StorageKey string
+-- returns: one entity.CarAttachment
+-- No row is returned when the car does not exist or is deleted.
+INSERT INTO car_attachments (car_id, kind, file_name, content_type, size_bytes, checksum, storage_key, uploaded_by)
+SELECT id, {{ arg .Kind }}, {{ arg .FileName }}, {{ arg .ContentType }}, {{ arg .SizeBytes }}, {{ arg .Checksum }}, {{ arg .StorageKey }}, {{ arg .UploadedBy }}
+FROM cars
+WHERE id = {{ arg .CarID }} AND deleted_at IS NULL
+RETURNING id, car_id, kind, file_name, content_type, size_bytes, checksum, storage_key, uploaded_by, created_at;
+
+-- name: FindAttachmentByID
+-- params: CarID uuid.UUID, ID uuid.UUID
+-- returns: one entity.CarAttachment
+SELECT id, car_id, kind, file_name, content_type, size_bytes, checksum, storage_key, uploaded_by, created_at
+FROM car_attachments
+WHERE id = {{ arg .ID }} AND car_id = {{ arg .CarID }};
+
+-- name: FindAttachmentForDownload
+-- params: ID uuid.UUID
+-- returns: one entity.CarAttachment
+-- Attachments of deleted cars cannot be downloaded until the car is restored.
+SELECT a.id, a.car_id, a.kind, a.file_name, a.content_type, a.size_bytes, a.checksum, a.storage_key, a.uploaded_by, a.created_at
+FROM car_attachments a
+INNER JOIN cars c ON c.id = a.car_id AND c.deleted_at IS NULL
+WHERE a.id = {{ arg .ID }};
+
+-- name: FindAttachmentsByCarID
+-- params: CarID uuid.UUID
+-- returns: many entity.CarAttachment
+SELECT id, car_id, kind, file_name, content_type, size_bytes, checksum, storage_key, uploaded_by, created_at
+FROM car_attachments
+WHERE car_id = {{ arg .CarID }}
+ORDER BY created_at DESC, id DESC;
+
+-- name: CountAttachmentsByCarID
+-- params: CarID uuid.UUID
+-- returns: one int
+SELECT COUNT(*)
+FROM car_attachments
+WHERE car_id = {{ arg .CarID }};
+
+-- name: DeleteAttachment
+-- params: CarID uuid.UUID, ID uuid.UUID
+-- returns: one string
+-- Returns the storage key, so the file can be removed once the row is gone.
+DELETE FROM car_attachments
+WHERE id = {{ arg .ID }} AND car_id = {{ arg .CarID }}
+RETURNING storage_key;
@@ -1,20 +1,65 @@
 -- name: CreateCar
-INSERT INTO cars (brand, model, year, color, license_plate, is_available)
-VALUES ({{ arg .Brand }}, {{ arg .Model }}, {{ arg .Year }}, {{ arg .Color }}, {{ arg .LicensePlate }}, {{ arg .IsAvailable }})
-RETURNING id, brand, model, year, color, license_plate, is_available, created_at, updated_at;
+-- params: *entity.Car
+-- returns: one entity.Car
+INSERT INTO cars (brand, model, year, color, license_plate, plate_region, vin)
+VALUES ({{ arg .Brand }}, {{ arg .Model }}, {{ arg .Year }}, {{ arg .Color }}, {{ arg .LicensePlate }}, {{ arg .PlateRegion }}, {{ arg .VIN }})
+RETURNING id, brand, model, year, color, license_plate, plate_region, vin, car_is_available(id) AS is_available, created_at, updated_at, version;
 
 -- name: CreateCarBulk
-INSERT INTO cars (brand, model, year, color, license_plate, is_available, created_at, updated_at)
+-- params: []*entity.Car
+-- returns: many {ID string, LicensePlate string}
+INSERT INTO cars (brand, model, year, color, license_plate, plate_region, vin, created_at, updated_at)
 VALUES
 {{ range $i, $car := . }}
-  {{ if $i }},{{ end }} ({{ arg $car.Brand }}, {{ arg $car.Model }}, {{ arg $car.Year }}, {{ arg $car.Color }}, {{ arg $car.LicensePlate }}, {{ arg $car.IsAvailable }}, {{ arg $car.CreatedAt }}, {{ arg $car.UpdatedAt }})
-{{ end }};
+  {{ if $i }},{{ end }} ({{ arg $car.Brand }}, {{ arg $car.Model }}, {{ arg $car.Year }}, {{ arg $car.Color }}, {{ arg $car.LicensePlate }}, {{ arg $car.PlateRegion }}, {{ arg $car.VIN }}, {{ arg $car.CreatedAt }}, {{ arg $car.UpdatedAt }})
+{{ end }}
+RETURNING id, license_plate;
+
+-- name: ImportCarBulk
+-- params: []*entity.Car
+-- returns: many {ID string, LicensePlate string}
+INSERT INTO cars (brand, model, year, color, license_plate, plate_region, vin, created_at, updated_at)
+VALUES
+{{ range $i, $car := . }}
+  {{ if $i }},{{ end }} ({{ arg $car.Brand }}, {{ arg $car.Model }}, {{ arg $car.Year }}, {{ arg $car.Color }}, {{ arg $car.LicensePlate }}, {{ arg $car.PlateRegion }}, {{ arg $car.VIN }}, {{ arg $car.CreatedAt }}, {{ arg $car.UpdatedAt }})
+{{ end }}
+ON CONFLICT DO NOTHING
+RETURNING id, license_plate;
+
+-- name: FindExistingLicensePlates
+-- params: LicensePlates []string
+-- returns: many string
+-- Plates are compared in their normalized form. Deleted cars are included, as
+-- their plates stay taken until they are purged.
+SELECT license_plate_normalized
+FROM cars
+WHERE license_plate_normalized = ANY({{ arg .LicensePlates }}::text[]);
+
+-- name: FindCarIDByLicensePlate
+-- params: LicensePlate string
+-- returns: one string
+-- The plate is passed in its normalized form.
+SELECT id
+FROM cars
+WHERE license_plate_normalized = {{ arg .LicensePlate }} AND deleted_at IS NULL;
+
+-- name: FindExistingVINs
+-- params: VINs []string
+-- returns: many string
+-- Deleted cars are included, as their VINs stay taken until they are purged.
+SELECT vin
+FROM cars
+WHERE vin = ANY({{ arg .VINs }}::text[]);
 
 -- name: AssignCarToUser
+-- params: UserID uuid.UUID, CarID uuid.UUID
+-- returns: exec
 INSERT INTO users_cars (user_id, car_id)
 VALUES ({{ arg .UserID }}, {{ arg .CarID }});
 
 -- name: AssignCarToUserBulk
+-- params: []entity.UserCar
+-- returns: exec
 INSERT INTO users_cars (user_id, car_id)
 VALUES
 {{ range $i, $uc := . }}
@@ -22,11 +67,38 @@ VALUES
 {{ end }};
 
 -- name: FindCarByID
-SELECT id, brand, model, year, color, license_plate, is_available, created_at, updated_at
-FROM cars
-WHERE id = {{ arg .ID }};
+-- params: ID uuid.UUID
+-- returns: one entity.Car
+SELECT c.id, c.brand, c.model, c.year, c.color, c.license_plate, c.plate_region, c.vin, car_is_available(c.id) AS is_available, c.created_at, c.updated_at, c.version, c.deleted_at,
+    c.service_overdue_at, s.service_count, s.total_service_cost, s.last_service_date, s.last_service_odometer
+FROM cars c
+CROSS JOIN LATERAL car_service_summary(c.id) s
+WHERE c.id = {{ arg .ID }} AND c.deleted_at IS NULL;
+
+-- name: FindCarByIDWithDeleted
+-- params: ID uuid.UUID
+-- returns: one entity.Car
+SELECT c.id, c.brand, c.model, c.year, c.color, c.license_plate, c.plate_region, c.vin, car_is_available(c.id) AS is_available, c.created_at, c.updated_at, c.version, c.deleted_at,
+    c.service_overdue_at, s.service_count, s.total_service_cost, s.last_service_date, s.last_service_odometer
+FROM cars c
+CROSS JOIN LATERAL car_service_summary(c.id) s
+WHERE c.id = {{ arg .ID }};
+
+-- name: FindCarColumnsByID
+-- params: Columns []string, ID uuid.UUID
+-- returns: one entity.Car
+-- sample: {"Columns": ["id", "brand"]}
+SELECT {{ range $i, $c := .Columns }}{{ if $i }}, {{ end }}{{ raw $c }}{{ end }}
+FROM (
+    SELECT c.*, car_is_available(c.id) AS is_available, s.*
+    FROM cars c
+    CROSS JOIN LATERAL car_service_summary(c.id) s
+    WHERE c.id = {{ arg .ID }} AND c.deleted_at IS NULL
+) car;
 
 -- name: FindCarByIDWithOwner
+-- params: ID uuid.UUID
+-- returns: one entity.CarWithOwner
 SELECT
     c.id,
     c.brand,
@@ -34,54 +106,208 @@ SELECT
     c.year,
     c.color,
     c.license_plate,
-    c.is_available,
+    c.plate_region,
+    c.vin,
+    car_is_available(c.id) AS is_available,
     c.created_at,
     c.updated_at,
+    c.version,
+    c.deleted_at,
+    c.service_overdue_at,
+    s.service_count,
+    s.total_service_cost,
+    s.last_service_date,
+    s.last_service_odometer,
     u.name AS owner_name,
     u.email AS owner_email
 FROM cars c
-INNER JOIN users_cars uc ON c.id = uc.car_id
-INNER JOIN users u ON uc.user_id = u.id
-WHERE c.id = {{ arg .ID }};
+INNER JOIN users_cars uc ON c.id = uc.car_id AND uc.role = 'owner'
+INNER JOIN users u ON uc.user_id = u.id AND u.deleted_at IS NULL
+CROSS JOIN LATERAL car_service_summary(c.id) s
+WHERE c.id = {{ arg .ID }} AND c.deleted_at IS NULL;
+
+-- name: FindCarColumnsByIDWithOwner
+-- params: Columns []string, ID uuid.UUID
+-- returns: one entity.CarWithOwner
+-- sample: {"Columns": ["id", "owner_name"]}
+SELECT {{ range $i, $c := .Columns }}{{ if $i }}, {{ end }}{{ raw $c }}{{ end }}
+FROM (
+    SELECT c.*, car_is_available(c.id) AS is_available, s.*, u.name AS owner_name, u.email AS owner_email
+    FROM cars c
+    INNER JOIN users_cars uc ON c.id = uc.car_id AND uc.role = 'owner'
+    INNER JOIN users u ON uc.user_id = u.id AND u.deleted_at IS NULL
+    CROSS JOIN LATERAL car_service_summary(c.id) s
+    WHERE c.id = {{ arg .ID }} AND c.deleted_at IS NULL
+) car;
 
 -- name: FindCarsByUserID
-SELECT c.id, c.brand, c.model, c.year, c.color, c.license_plate, c.is_available, c.created_at, c.updated_at
+-- params: UserID uuid.UUID
+-- returns: many *entity.Car
+SELECT c.id, c.brand, c.model, c.year, c.color, c.license_plate, c.plate_region, c.vin, car_is_available(c.id) AS is_available, c.created_at, c.updated_at, c.version, c.deleted_at
 FROM cars c
 INNER JOIN users_cars uc ON c.id = uc.car_id
-WHERE uc.user_id = {{ arg .UserID }}
+WHERE uc.user_id = {{ arg .UserID }} AND c.deleted_at IS NULL
 ORDER BY c.created_at DESC;
 
+-- name: FindCarColumnsByUserID
+-- params: Columns []string, UserID uuid.UUID
+-- returns: many *entity.Car
+-- sample: {"Columns": ["id", "brand"]}
+SELECT {{ range $i, $c := .Columns }}{{ if $i }}, {{ end }}{{ raw $c }}{{ end }}
+FROM (
+    SELECT c.*, car_is_available(c.id) AS is_available, s.*
+    FROM cars c
+    INNER JOIN users_cars uc ON c.id = uc.car_id
+    CROSS JOIN LATERAL car_service_summary(c.id) s
+    WHERE uc.user_id = {{ arg .UserID }} AND c.deleted_at IS NULL
+) car
+ORDER BY car.created_at DESC;
+
+-- name: FindCarsByUserIDs
+-- params: UserIDs []uuid.UUID
+-- returns: many entity.OwnedCar
+SELECT uc.user_id, c.id, c.brand, c.model, c.year, c.color, c.license_plate, c.plate_region, c.vin, car_is_available(c.id) AS is_available, c.created_at, c.updated_at, c.version, c.deleted_at
+FROM cars c
+INNER JOIN users_cars uc ON c.id = uc.car_id
+WHERE uc.user_id = ANY({{ arg .UserIDs }}::uuid[]) AND c.deleted_at IS NULL
+ORDER BY uc.user_id, c.created_at DESC;
+
 -- name: CountCarsByUserID
+-- params: UserID uuid.UUID
+-- returns: one int
 SELECT COUNT(*)
 FROM cars c
 INNER JOIN users_cars uc ON c.id = uc.car_id
-WHERE uc.user_id = {{ arg .UserID }};
+WHERE uc.user_id = {{ arg .UserID }} AND c.deleted_at IS NULL;
 
 -- name: UpdateCar
+-- params: ID uuid.UUID, Brand string, Model string, Year int, Color string, LicensePlate string, PlateRegion *string, VIN *string, UpdatedAt time.Time, Version int64
+-- returns: one {UpdatedAt time.Time, Version int64}
 UPDATE cars
-SET brand = {{ arg .Brand }}, model = {{ arg .Model }}, year = {{ arg .Year }}, color = {{ arg .Color }}, license_plate = {{ arg .LicensePlate }}, is_available = {{ arg .IsAvailable }}, updated_at = {{ arg .UpdatedAt }}
-WHERE id = {{ arg .ID }}
-RETURNING updated_at;
+SET brand = {{ arg .Brand }}, model = {{ arg .Model }}, year = {{ arg .Year }}, color = {{ arg .Color }}, license_plate = {{ arg .LicensePlate }}, plate_region = {{ arg .PlateRegion }}, vin = {{ arg .VIN }}, updated_at = {{ arg .UpdatedAt }}
+WHERE id = {{ arg .ID }} AND version = {{ arg .Version }} AND deleted_at IS NULL
+RETURNING updated_at, version;
 
 -- name: DeleteCar
-DELETE FROM cars WHERE id = {{ arg .ID }};
+-- params: ID uuid.UUID
+-- returns: execrows
+UPDATE cars SET deleted_at = CURRENT_TIMESTAMP WHERE id = {{ arg .ID }} AND deleted_at IS NULL;
+
+-- name: RestoreCar
+-- params: ID uuid.UUID
+-- returns: one entity.Car
+UPDATE cars SET deleted_at = NULL
+WHERE id = {{ arg .ID }} AND deleted_at IS NOT NULL
+RETURNING id, brand, model, year, color, license_plate, plate_region, vin, car_is_available(id) AS is_available, created_at, updated_at, version, deleted_at;
+
+-- name: PurgeCars
+-- params: DeletedBefore time.Time, Limit int
+-- returns: many {ID uuid.UUID, StorageKeys []string}
+-- The car_attachments rows cascade only once the statement ends, so
+-- RETURNING still sees them and hands back the files left to delete.
+DELETE FROM cars c
+WHERE c.id IN (
+    SELECT id FROM cars
+    WHERE deleted_at < {{ arg .DeletedBefore }}
+    LIMIT {{ arg .Limit }}
+)
+RETURNING c.id, ARRAY(SELECT a.storage_key FROM car_attachments a WHERE a.car_id = c.id) AS storage_keys;
 
 -- name: TransferCarOwnership
+-- params: CarID uuid.UUID, NewUserID uuid.UUID
+-- returns: execrows
+-- Only the owner link moves; other users keep their shares.
 UPDATE users_cars
 SET user_id = {{ arg .NewUserID }}
-WHERE car_id = {{ arg .CarID }};
-
--- name: BulkUpdateCarAvailability
-UPDATE cars
-SET is_available = {{ arg .IsAvailable }}, updated_at = {{ arg .UpdatedAt }}
-WHERE id = ANY(ARRAY[{{ range $i, $id := .CarIDs }}{{ if $i }},{{ end }}{{ $id }}{{ end }}]);
+WHERE car_id = {{ arg .CarID }} AND role = 'owner'
+  AND EXISTS (SELECT 1 FROM cars WHERE id = {{ arg .CarID }} AND deleted_at IS NULL)
+  AND EXISTS (SELECT 1 FROM users WHERE id = {{ arg .NewUserID }} AND deleted_at IS NULL);
 
 -- name: CheckCarOwnership
+-- params: CarID uuid.UUID, UserID string
+-- returns: one int
 SELECT COUNT(*)
 FROM users_cars
-WHERE car_id = {{ arg .CarID }} AND user_id = {{ arg .UserID }};
+WHERE car_id = {{ arg .CarID }} AND user_id = {{ arg .UserID }} AND role = 'owner';
 
 -- name: CheckCarsOwnership
+-- params: CarIDs []uuid.UUID, UserID string
+-- returns: many uuid.UUID
 SELECT car_id
 FROM users_cars
-WHERE car_id = ANY(ARRAY[{{ range $i, $id := .CarIDs }}{{ if $i }},{{ end }}{{ arg $id }}{{ end }}]) AND user_id = {{ arg .UserID }};
\ No newline at end of file
+WHERE car_id = ANY({{ arg .CarIDs }}::uuid[]) AND user_id = {{ arg .UserID }} AND role = 'owner';
+
+-- name: ExportCars
+-- params: Where query.Clause
+-- returns: each *entity.Car
+-- sample: {"Where": [{"SQL": " WHERE 1=1 AND brand=", "HasArg": true, "Arg": "VW"}]}
+-- The derived is_available is computed in a subquery so the appended
+-- filters can refer to it like a column.
+SELECT id, brand, model, year, color, license_plate, plate_region, vin, is_available, created_at, updated_at, version, deleted_at
+FROM (
+    SELECT id, brand, model, year, color, license_plate, plate_region, vin, car_is_available(id) AS is_available, created_at, updated_at, version, deleted_at
+    FROM cars
+) cars{{ range .Where }}{{ raw .SQL }}{{ if .HasArg }}{{ arg .Arg }}{{ end }}{{ end }}
+
+-- name: FindCarOwnerForUpdate
+-- params: CarID uuid.UUID
+-- returns: one uuid.UUID
+-- Locks the ownership row so concurrent transfers of a car record their
+-- previous owner one after the other.
+SELECT user_id
+FROM users_cars
+WHERE car_id = {{ arg .CarID }} AND role = 'owner'
+FOR UPDATE;
+
+-- name: AppendCarOwnershipHistory
+-- params: []entity.CarOwnershipRecord
+-- returns: exec
+INSERT INTO car_ownership_history (car_id, event, from_user_id, to_user_id, actor_id, reason)
+VALUES
+{{ range $i, $r := . }}
+  {{ if $i }},{{ end }} ({{ arg $r.CarID }}, {{ arg $r.Event }}, {{ arg $r.FromUserID }}, {{ arg $r.ToUserID }}, {{ arg $r.ActorID }}, {{ arg $r.Reason }})
+{{ end }};
+
+-- name: FindCarOwnershipHistory
+-- params: CarID uuid.UUID
+-- returns: many entity.CarOwnershipRecord
+SELECT id, car_id, event, from_user_id, to_user_id, actor_id, reason, created_at
+FROM car_ownership_history
+WHERE car_id = {{ arg .CarID }}
+ORDER BY id;
+
+-- name: FindCarRole
+-- params: CarID uuid.UUID, UserID string
+-- returns: one string
+SELECT role
+FROM users_cars
+WHERE car_id = {{ arg .CarID }} AND user_id = {{ arg .UserID }};
+
+-- name: FindCarShares
+-- params: CarID uuid.UUID
+-- returns: many entity.CarShare
+SELECT user_id, car_id, role, created_at
+FROM users_cars
+WHERE car_id = {{ arg .CarID }}
+ORDER BY role = 'owner' DESC, created_at;
+
+-- name: UpsertCarShare
+-- params: CarID uuid.UUID, UserID uuid.UUID, Role entity.CarRole
+-- returns: one entity.CarShare
+-- No row is returned when the car is deleted, the user does not exist or
+-- the user is the car's owner.
+INSERT INTO users_cars (user_id, car_id, role)
+SELECT u.id, c.id, {{ arg .Role }}
+FROM cars c, users u
+WHERE c.id = {{ arg .CarID }} AND c.deleted_at IS NULL
+  AND u.id = {{ arg .UserID }} AND u.deleted_at IS NULL
+ON CONFLICT (user_id, car_id) DO UPDATE SET role = EXCLUDED.role
+WHERE users_cars.role <> 'owner'
+RETURNING user_id, car_id, role, created_at;
+
+-- name: DeleteCarShare
+-- params: CarID uuid.UUID, UserID uuid.UUID
+-- returns: execrows
+-- The owner link is never removed here; it only moves with a transfer.
+DELETE FROM users_cars
+WHERE car_id = {{ arg .CarID }} AND user_id = {{ arg .UserID }} AND role <> 'owner';
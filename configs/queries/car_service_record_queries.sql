@@ -0,0 +1,101 @@
+-- name: CreateServiceRecord
+-- params: CarID uuid.UUID, CreatedBy string, ServiceDate time.Time, Odometer int, ServiceType string, Cost float64, Notes *string
+-- returns: one entity.ServiceRecord
+-- No row is returned when the car does not exist or is deleted.
+INSERT INTO car_service_records (car_id, service_date, odometer, service_type, cost, notes, created_by)
+SELECT id, {{ arg .ServiceDate }}, {{ arg .Odometer }}, {{ arg .ServiceType }}, {{ arg .Cost }}, {{ arg .Notes }}, {{ arg .CreatedBy }}
+FROM cars
+WHERE id = {{ arg .CarID }} AND deleted_at IS NULL
+RETURNING id, car_id, service_date, odometer, service_type, cost, notes, created_by, created_at, updated_at;
+
+-- name: FindServiceRecordByID
+-- params: CarID uuid.UUID, ID uuid.UUID
+-- returns: one entity.ServiceRecord
+SELECT id, car_id, service_date, odometer, service_type, cost, notes, created_by, created_at, updated_at
+FROM car_service_records
+WHERE id = {{ arg .ID }} AND car_id = {{ arg .CarID }};
+
+-- name: UpdateServiceRecord
+-- params: CarID uuid.UUID, ID uuid.UUID, ServiceDate time.Time, Odometer int, ServiceType string, Cost float64, Notes *string
+-- returns: one entity.ServiceRecord
+UPDATE car_service_records
+SET service_date = {{ arg .ServiceDate }},
+    odometer = {{ arg .Odometer }},
+    service_type = {{ arg .ServiceType }},
+    cost = {{ arg .Cost }},
+    notes = {{ arg .Notes }}
+WHERE id = {{ arg .ID }} AND car_id = {{ arg .CarID }}
+RETURNING id, car_id, service_date, odometer, service_type, cost, notes, created_by, created_at, updated_at;
+
+-- name: DeleteServiceRecord
+-- params: CarID uuid.UUID, ID uuid.UUID
+-- returns: execrows
+DELETE FROM car_service_records
+WHERE id = {{ arg .ID }} AND car_id = {{ arg .CarID }};
+
+-- name: FindServiceRecords
+-- params: *dto.ServiceRecordFilter
+-- returns: many entity.ServiceRecord
+SELECT id, car_id, service_date, odometer, service_type, cost, notes, created_by, created_at, updated_at
+FROM car_service_records
+WHERE car_id = {{ arg .CarID }}
+{{ if .Type }}
+    AND service_type = {{ arg .Type }}
+{{ end }}
+{{ if .HasFrom }}
+    AND service_date >= {{ arg .From }}
+{{ end }}
+{{ if .HasTo }}
+    AND service_date <= {{ arg .To }}
+{{ end }}
+ORDER BY service_date DESC, id DESC
+LIMIT {{ arg .Limit }} OFFSET {{ arg .Offset }};
+
+-- name: CountServiceRecords
+-- params: *dto.ServiceRecordFilter
+-- returns: one int64
+SELECT COUNT(*)
+FROM car_service_records
+WHERE car_id = {{ arg .CarID }}
+{{ if .Type }}
+    AND service_type = {{ arg .Type }}
+{{ end }}
+{{ if .HasFrom }}
+    AND service_date >= {{ arg .From }}
+{{ end }}
+{{ if .HasTo }}
+    AND service_date <= {{ arg .To }}
+{{ end }};
+
+-- name: FlagServiceOverdueCars
+-- params: Cutoff time.Time, Limit int
+-- returns: many uuid.UUID
+-- Flags cars whose last service, or creation when never serviced, is
+-- before Cutoff.
+UPDATE cars
+SET service_overdue_at = CURRENT_TIMESTAMP
+WHERE id IN (
+    SELECT c.id FROM cars c
+    WHERE c.deleted_at IS NULL AND c.service_overdue_at IS NULL
+      AND COALESCE(
+          (SELECT MAX(s.service_date) FROM car_service_records s WHERE s.car_id = c.id),
+          c.created_at::date
+      ) < {{ arg .Cutoff }}::date
+    ORDER BY c.id
+    LIMIT {{ arg .Limit }}
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING id;
+
+-- name: UnflagServicedCars
+-- params: Cutoff time.Time
+-- returns: many uuid.UUID
+-- Clears the flag of cars that have been serviced on or after Cutoff.
+UPDATE cars
+SET service_overdue_at = NULL
+WHERE service_overdue_at IS NOT NULL
+  AND EXISTS (
+      SELECT 1 FROM car_service_records s
+      WHERE s.car_id = cars.id AND s.service_date >= {{ arg .Cutoff }}::date
+  )
+RETURNING id;
@@ -0,0 +1,97 @@
+-- name: CreateReservation
+-- params: CarID uuid.UUID, UserID string, StartsAt time.Time, EndsAt time.Time
+-- returns: one entity.Reservation
+-- No row is returned when the car does not exist or is deleted.
+INSERT INTO reservations (car_id, user_id, period)
+SELECT id, {{ arg .UserID }}, tstzrange({{ arg .StartsAt }}, {{ arg .EndsAt }}, '[)')
+FROM cars
+WHERE id = {{ arg .CarID }} AND deleted_at IS NULL
+RETURNING id, car_id, user_id, lower(period) AS starts_at, upper(period) AS ends_at, status, created_at, updated_at, cancelled_at;
+
+-- name: FindReservationByID
+-- params: ID uuid.UUID
+-- returns: one entity.Reservation
+SELECT id, car_id, user_id, lower(period) AS starts_at, upper(period) AS ends_at, status, created_at, updated_at, cancelled_at
+FROM reservations
+WHERE id = {{ arg .ID }};
+
+-- name: CancelReservation
+-- params: ID uuid.UUID
+-- returns: one entity.Reservation
+UPDATE reservations
+SET status = 'cancelled', cancelled_at = CURRENT_TIMESTAMP
+WHERE id = {{ arg .ID }} AND status = 'active'
+RETURNING id, car_id, user_id, lower(period) AS starts_at, upper(period) AS ends_at, status, created_at, updated_at, cancelled_at;
+
+-- name: FindReservations
+-- params: *dto.ReservationFilter
+-- returns: many entity.Reservation
+SELECT id, car_id, user_id, lower(period) AS starts_at, upper(period) AS ends_at, status, created_at, updated_at, cancelled_at
+FROM reservations
+WHERE 1=1
+{{ if .CarID }}
+    AND car_id = {{ arg .CarID }}
+{{ end }}
+{{ if .UserID }}
+    AND user_id = {{ arg .UserID }}
+{{ end }}
+{{ if not .IncludeCancelled }}
+    AND status = 'active'
+{{ end }}
+{{ if .HasWindow }}
+    AND period && tstzrange({{ arg .From }}, {{ arg .To }}, '[)')
+{{ end }}
+ORDER BY lower(period), id
+LIMIT {{ arg .Limit }} OFFSET {{ arg .Offset }};
+
+-- name: CountReservations
+-- params: *dto.ReservationFilter
+-- returns: one int64
+SELECT COUNT(*)
+FROM reservations
+WHERE 1=1
+{{ if .CarID }}
+    AND car_id = {{ arg .CarID }}
+{{ end }}
+{{ if .UserID }}
+    AND user_id = {{ arg .UserID }}
+{{ end }}
+{{ if not .IncludeCancelled }}
+    AND status = 'active'
+{{ end }}
+{{ if .HasWindow }}
+    AND period && tstzrange({{ arg .From }}, {{ arg .To }}, '[)')
+{{ end }};
+
+-- name: FindAvailableCars
+-- params: *dto.AvailabilityFilter
+-- returns: many *entity.Car
+-- Cars with no active reservation overlapping [From, To).
+SELECT c.id, c.brand, c.model, c.year, c.color, c.license_plate, c.plate_region, c.vin, car_is_available(c.id) AS is_available, c.created_at, c.updated_at, c.version, c.deleted_at
+FROM cars c
+WHERE c.deleted_at IS NULL
+  AND NOT EXISTS (
+      SELECT 1 FROM reservations r
+      WHERE r.car_id = c.id AND r.status = 'active'
+        AND r.period && tstzrange({{ arg .From }}, {{ arg .To }}, '[)')
+  )
+{{ if .Brand }}
+  AND c.brand ILIKE {{ arg .BrandPattern }}
+{{ end }}
+ORDER BY c.created_at DESC, c.id
+LIMIT {{ arg .Limit }} OFFSET {{ arg .Offset }};
+
+-- name: CountAvailableCars
+-- params: *dto.AvailabilityFilter
+-- returns: one int64
+SELECT COUNT(*)
+FROM cars c
+WHERE c.deleted_at IS NULL
+  AND NOT EXISTS (
+      SELECT 1 FROM reservations r
+      WHERE r.car_id = c.id AND r.status = 'active'
+        AND r.period && tstzrange({{ arg .From }}, {{ arg .To }}, '[)')
+  )
+{{ if .Brand }}
+  AND c.brand ILIKE {{ arg .BrandPattern }}
+{{ end }};
@@ -0,0 +1,40 @@
+-- name: SearchAll
+-- params: Query string, UserID string, IsAdmin bool, Limit int64, Offset int64
+-- returns: many entity.SearchResult
+SELECT type, id, title, snippet, rank
+FROM (
+{{ if .IsAdmin }}
+    SELECT 'user' AS type, u.id, u.name AS title,
+        ts_headline('simple', u.name || ' ' || u.email, q.query, 'StartSel=<mark>, StopSel=</mark>, MaxFragments=1') AS snippet,
+        ts_rank(u.search_vector, q.query) AS rank
+    FROM users u
+    CROSS JOIN websearch_to_tsquery('simple', {{ arg .Query }}) AS q(query)
+    WHERE u.search_vector @@ q.query AND u.deleted_at IS NULL
+    UNION ALL
+{{ end }}
+    SELECT 'car' AS type, c.id, c.brand || ' ' || c.model AS title,
+        ts_headline('simple', c.brand || ' ' || c.model || ' ' || c.license_plate || ' ' || coalesce(c.color, ''), q.query, 'StartSel=<mark>, StopSel=</mark>, MaxFragments=1') AS snippet,
+        ts_rank(c.search_vector, q.query) AS rank
+    FROM cars c
+{{ if not .IsAdmin }}
+    INNER JOIN users_cars uc ON uc.car_id = c.id AND uc.user_id = {{ arg .UserID }}
+{{ end }}
+    CROSS JOIN websearch_to_tsquery('simple', {{ arg .Query }}) AS q(query)
+    WHERE c.search_vector @@ q.query AND c.deleted_at IS NULL
+) results
+ORDER BY rank DESC, title
+LIMIT {{ arg .Limit }} OFFSET {{ arg .Offset }};
+
+-- name: CountSearchAll
+-- params: Query string, UserID string, IsAdmin bool
+-- returns: one int64
+SELECT
+{{ if .IsAdmin }}
+    (SELECT COUNT(*) FROM users WHERE search_vector @@ websearch_to_tsquery('simple', {{ arg .Query }}) AND deleted_at IS NULL) +
+{{ end }}
+    (SELECT COUNT(*)
+     FROM cars c
+{{ if not .IsAdmin }}
+     INNER JOIN users_cars uc ON uc.car_id = c.id AND uc.user_id = {{ arg .UserID }}
+{{ end }}
+     WHERE c.search_vector @@ websearch_to_tsquery('simple', {{ arg .Query }}) AND c.deleted_at IS NULL) AS total;
@@ -0,0 +1,103 @@
+-- name: StatsCarsByBrand
+-- params: *dto.StatsFilter
+-- returns: many entity.BrandCount
+SELECT brand, COUNT(*) AS count
+FROM cars
+WHERE deleted_at IS NULL
+{{ if .HasFrom }}
+    AND created_at >= {{ arg .From }}
+{{ end }}
+{{ if .HasTo }}
+    AND created_at <= {{ arg .To }}
+{{ end }}
+GROUP BY brand
+ORDER BY count DESC, brand;
+
+-- name: StatsCarsByYear
+-- params: *dto.StatsFilter
+-- returns: many entity.YearCount
+SELECT year, COUNT(*) AS count
+FROM cars
+WHERE deleted_at IS NULL
+{{ if .HasFrom }}
+    AND created_at >= {{ arg .From }}
+{{ end }}
+{{ if .HasTo }}
+    AND created_at <= {{ arg .To }}
+{{ end }}
+GROUP BY year
+ORDER BY year;
+
+-- name: StatsCarAvailability
+-- params: *dto.StatsFilter
+-- returns: one entity.FleetAvailability
+SELECT COUNT(*) AS total, COUNT(*) FILTER (WHERE car_is_available(id)) AS available
+FROM cars
+WHERE deleted_at IS NULL
+{{ if .HasFrom }}
+    AND created_at >= {{ arg .From }}
+{{ end }}
+{{ if .HasTo }}
+    AND created_at <= {{ arg .To }}
+{{ end }};
+
+-- name: StatsCarsPerUser
+-- params: *dto.StatsFilter
+-- returns: many entity.CarsPerUser
+-- Counts the cars each active user owns, users without one included, then
+-- how many users own each count. The window bounds the cars' creation.
+SELECT car_count, COUNT(*) AS user_count
+FROM (
+    SELECT u.id, COUNT(c.id) AS car_count
+    FROM users u
+    LEFT JOIN users_cars uc ON uc.user_id = u.id AND uc.role = 'owner'
+    LEFT JOIN cars c ON c.id = uc.car_id AND c.deleted_at IS NULL
+    {{ if .HasFrom }}
+        AND c.created_at >= {{ arg .From }}
+    {{ end }}
+    {{ if .HasTo }}
+        AND c.created_at <= {{ arg .To }}
+    {{ end }}
+    WHERE u.deleted_at IS NULL
+    GROUP BY u.id
+) per_user
+GROUP BY car_count
+ORDER BY car_count;
+
+-- name: StatsRegistrationTrend
+-- params: *dto.StatsFilter
+-- returns: many entity.TrendPoint
+-- One row per UTC period between From and To, empty periods included.
+-- Soft-deleted users still count towards the period they registered in.
+SELECT s.period_start AS period_start, COALESCE(r.count, 0) AS count
+FROM generate_series(
+    date_trunc({{ arg .Interval }}::text, {{ arg .From }}::timestamptz AT TIME ZONE 'UTC'),
+    {{ arg .To }}::timestamptz AT TIME ZONE 'UTC',
+    ('1 ' || {{ arg .Interval }}::text)::interval
+) AS s(period_start)
+LEFT JOIN (
+    SELECT date_trunc({{ arg .Interval }}::text, created_at AT TIME ZONE 'UTC') AS period_start, COUNT(*) AS count
+    FROM users
+    WHERE created_at >= {{ arg .From }} AND created_at <= {{ arg .To }}
+    GROUP BY 1
+) r USING (period_start)
+ORDER BY s.period_start;
+
+-- name: StatsLoginTrend
+-- params: *dto.StatsFilter
+-- returns: many entity.LoginTrendPoint
+-- One row per UTC period between From and To, empty periods included.
+SELECT s.period_start AS period_start, COALESCE(l.logins, 0) AS logins, COALESCE(l.active_users, 0) AS active_users
+FROM generate_series(
+    date_trunc({{ arg .Interval }}::text, {{ arg .From }}::timestamptz AT TIME ZONE 'UTC'),
+    {{ arg .To }}::timestamptz AT TIME ZONE 'UTC',
+    ('1 ' || {{ arg .Interval }}::text)::interval
+) AS s(period_start)
+LEFT JOIN (
+    SELECT date_trunc({{ arg .Interval }}::text, logged_in_at AT TIME ZONE 'UTC') AS period_start,
+        COUNT(*) AS logins, COUNT(DISTINCT user_id) AS active_users
+    FROM user_logins
+    WHERE logged_in_at >= {{ arg .From }} AND logged_in_at <= {{ arg .To }}
+    GROUP BY 1
+) l USING (period_start)
+ORDER BY s.period_start;
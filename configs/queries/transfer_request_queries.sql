@@ -0,0 +1,81 @@
+-- name: CreateTransferRequest
+-- params: CarID uuid.UUID, FromUserID string, ToUserID uuid.UUID, Reason *string, ExpiresAt time.Time
+-- returns: one entity.CarTransferRequest
+-- No row is returned when the car is deleted or the recipient does not exist.
+INSERT INTO car_transfer_requests (car_id, from_user_id, to_user_id, reason, expires_at)
+SELECT c.id, {{ arg .FromUserID }}, u.id, {{ arg .Reason }}, {{ arg .ExpiresAt }}
+FROM cars c, users u
+WHERE c.id = {{ arg .CarID }} AND c.deleted_at IS NULL
+  AND u.id = {{ arg .ToUserID }} AND u.deleted_at IS NULL
+RETURNING id, car_id, from_user_id, to_user_id, status, reason, expires_at, created_at, updated_at, responded_at;
+
+-- name: FindTransferRequestByID
+-- params: ID uuid.UUID
+-- returns: one entity.CarTransferRequest
+SELECT id, car_id, from_user_id, to_user_id, status, reason, expires_at, created_at, updated_at, responded_at
+FROM car_transfer_requests
+WHERE id = {{ arg .ID }};
+
+-- name: RespondTransferRequest
+-- params: ID uuid.UUID, Status entity.TransferRequestStatus
+-- returns: one entity.CarTransferRequest
+-- Only a pending request that has not expired can change status.
+UPDATE car_transfer_requests
+SET status = {{ arg .Status }}, responded_at = CURRENT_TIMESTAMP
+WHERE id = {{ arg .ID }} AND status = 'pending' AND expires_at > CURRENT_TIMESTAMP
+RETURNING id, car_id, from_user_id, to_user_id, status, reason, expires_at, created_at, updated_at, responded_at;
+
+-- name: ExpireCarTransferRequests
+-- params: CarID uuid.UUID
+-- returns: execrows
+-- Frees the car's pending slot when its request expired before the job ran.
+UPDATE car_transfer_requests
+SET status = 'expired'
+WHERE car_id = {{ arg .CarID }} AND status = 'pending' AND expires_at <= CURRENT_TIMESTAMP;
+
+-- name: ExpireTransferRequests
+-- params: Limit int
+-- returns: execrows
+UPDATE car_transfer_requests
+SET status = 'expired'
+WHERE id IN (
+    SELECT id FROM car_transfer_requests
+    WHERE status = 'pending' AND expires_at <= CURRENT_TIMESTAMP
+    ORDER BY expires_at
+    LIMIT {{ arg .Limit }}
+    FOR UPDATE SKIP LOCKED
+);
+
+-- name: FindTransferRequests
+-- params: *dto.TransferRequestFilter
+-- returns: many entity.CarTransferRequest
+SELECT id, car_id, from_user_id, to_user_id, status, reason, expires_at, created_at, updated_at, responded_at
+FROM car_transfer_requests
+WHERE 1=1
+{{ if .FromUserID }}
+    AND from_user_id = {{ arg .FromUserID }}
+{{ end }}
+{{ if .ToUserID }}
+    AND to_user_id = {{ arg .ToUserID }}
+{{ end }}
+{{ if .Status }}
+    AND status = {{ arg .Status }}
+{{ end }}
+ORDER BY created_at DESC, id DESC
+LIMIT {{ arg .Limit }} OFFSET {{ arg .Offset }};
+
+-- name: CountTransferRequests
+-- params: *dto.TransferRequestFilter
+-- returns: one int64
+SELECT COUNT(*)
+FROM car_transfer_requests
+WHERE 1=1
+{{ if .FromUserID }}
+    AND from_user_id = {{ arg .FromUserID }}
+{{ end }}
+{{ if .ToUserID }}
+    AND to_user_id = {{ arg .ToUserID }}
+{{ end }}
+{{ if .Status }}
+    AND status = {{ arg .Status }}
+{{ end }};
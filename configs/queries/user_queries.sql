@@ -1,22 +1,55 @@
 -- name: CreateUser
+-- params: *entity.User
+-- returns: one {ID string, CreatedAt time.Time, UpdatedAt time.Time, Version int64}
 INSERT INTO users (email, password, name, age, role, is_active)
 VALUES ({{ arg .Email }}, {{ arg .Password }}, {{ arg .Name }}, {{ arg .Age }}, {{ arg .Role }}, true)
-RETURNING id, created_at, updated_at;
+RETURNING id, created_at, updated_at, version;
 
 -- name: FindUserByID
-SELECT id, email, name, password, age, role, is_active, created_at, updated_at
+-- params: ID string
+-- returns: one entity.User
+SELECT id, email, name, password, age, role, is_active, created_at, updated_at, version, deleted_at
+FROM users
+WHERE id = {{ arg .ID }} AND deleted_at IS NULL;
+
+-- name: FindUserByIDWithDeleted
+-- params: ID string
+-- returns: one entity.User
+SELECT id, email, name, password, age, role, is_active, created_at, updated_at, version, deleted_at
 FROM users
 WHERE id = {{ arg .ID }};
 
+-- name: FindUserColumnsByID
+-- params: Columns []string, ID string
+-- returns: one entity.User
+-- sample: {"Columns": ["id", "email"]}
+SELECT {{ range $i, $c := .Columns }}{{ if $i }}, {{ end }}{{ raw $c }}{{ end }}
+FROM users
+WHERE id = {{ arg .ID }} AND deleted_at IS NULL;
+
 -- name: FindUserByEmail
-SELECT id, email, name, password, age, role, is_active, created_at, updated_at
+-- params: Email string
+-- returns: one entity.User
+SELECT id, email, name, password, age, role, is_active, created_at, updated_at, version, deleted_at
 FROM users
-WHERE email = {{ arg .Email }};
+WHERE email = {{ arg .Email }} AND deleted_at IS NULL;
+
+-- name: CreateUserLogin
+-- params: UserID string
+-- returns: exec
+INSERT INTO user_logins (user_id)
+VALUES ({{ arg .UserID }});
 
 -- name: FindAllUsersBase
-SELECT id, email, name, age, role, is_active, created_at, updated_at
+-- params: *dto.UserFilter
+-- returns: many entity.User
+-- sample: {"SortBy": "name", "SortDir": "ASC", "Columns": ["id", "name"]}
+SELECT {{ if .Columns }}{{ range $i, $c := .Columns }}{{ if $i }}, {{ end }}{{ raw $c }}{{ end }}{{ else }}id, email, name, age, role, is_active, created_at, updated_at, version, deleted_at{{ end }}
 FROM users
 WHERE 1=1
+{{ if not .IncludeDeleted }}
+    AND deleted_at IS NULL
+{{ end }}
 {{ if .ID }}
     AND id = {{ arg .ID }}
 {{ end }}
@@ -38,9 +71,14 @@ WHERE 1=1
 LIMIT {{ arg .Limit }} OFFSET {{ arg .Offset }};
 
 -- name: CountUsersBase
+-- params: *dto.UserFilter
+-- returns: one int64
 SELECT COUNT(*)
 FROM users
 WHERE 1=1
+{{ if not .IncludeDeleted }}
+    AND deleted_at IS NULL
+{{ end }}
 {{ if .ID }}
     AND id = {{ arg .ID }}
 {{ end }}
@@ -58,17 +96,44 @@ WHERE 1=1
 {{ end }};
 
 -- name: UpdateUser
+-- params: *entity.User
+-- returns: one {UpdatedAt time.Time, Version int64}
 UPDATE users
 SET email = {{ arg .Email }}, name = {{ arg .Name }}, age = {{ arg .Age }}, role = {{ arg .Role }}, is_active = {{ arg .IsActive }}, updated_at = {{ arg .UpdatedAt }}
-WHERE id = {{ arg .ID }};
+WHERE id = {{ arg .ID }} AND version = {{ arg .Version }} AND deleted_at IS NULL
+RETURNING updated_at, version;
 
 -- name: DeleteUser
-DELETE FROM users WHERE id = {{ arg .ID }};
+-- params: ID string
+-- returns: execrows
+UPDATE users SET deleted_at = CURRENT_TIMESTAMP WHERE id = {{ arg .ID }} AND deleted_at IS NULL;
+
+-- name: RestoreUser
+-- params: ID string
+-- returns: one entity.User
+UPDATE users SET deleted_at = NULL
+WHERE id = {{ arg .ID }} AND deleted_at IS NOT NULL
+RETURNING id, email, name, age, role, is_active, created_at, updated_at, version, deleted_at;
+
+-- name: PurgeUsers
+-- params: DeletedBefore time.Time, Limit int
+-- returns: execrows
+DELETE FROM users
+WHERE id IN (
+    SELECT id FROM users
+    WHERE deleted_at < {{ arg .DeletedBefore }}
+    LIMIT {{ arg .Limit }}
+);
 
 -- name: CheckEmailExists
+-- params: Email string, ID string
+-- returns: one int
+-- Deleted users are counted, as their email stays taken until they are purged.
 SELECT COUNT(*) FROM users WHERE email = {{ arg .Email }} AND id != {{ arg .ID }};
 
 -- name: BulkInsertUsers
+-- params: Users []entity.User
+-- returns: exec
 INSERT INTO users (email, name, age, role)
 VALUES
 {{ range $i, $user := .Users }}
@@ -77,5 +142,15 @@ VALUES
 {{ end }}
 
 -- name: FindUsersBaseV2
-SELECT id, email, name, age, role, is_active, created_at, updated_at
-FROM users;
+-- params: Where query.Clause
+-- returns: many entity.User
+-- sample: {"Where": [{"SQL": " WHERE 1=1 AND age>=", "HasArg": true, "Arg": 1}, {"SQL": " ORDER BY created_at asc;"}]}
+SELECT id, email, name, age, role, is_active, created_at, updated_at, version, deleted_at
+FROM users{{ range .Where }}{{ raw .SQL }}{{ if .HasArg }}{{ arg .Arg }}{{ end }}{{ end }}
+
+-- name: ExportUsers
+-- params: Where query.Clause
+-- returns: each *entity.User
+-- sample: {"Where": [{"SQL": " WHERE 1=1 AND age>=", "HasArg": true, "Arg": 1}]}
+SELECT id, email, name, age, role, is_active, created_at, updated_at, version, deleted_at
+FROM users{{ range .Where }}{{ raw .SQL }}{{ if .HasArg }}{{ arg .Arg }}{{ end }}{{ end }}
@@ -0,0 +1,33 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Full-text search vectors, kept in sync by Postgres as generated columns.
+-- The 'simple' configuration is used because names, emails and plates are
+-- not natural-language text and must not be stemmed.
+ALTER TABLE public.users
+    ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+        setweight(to_tsvector('simple', coalesce(name, '')), 'A') ||
+        setweight(to_tsvector('simple', coalesce(email, '')), 'B')
+    ) STORED;
+
+ALTER TABLE public.cars
+    ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+        setweight(to_tsvector('simple', coalesce(brand, '') || ' ' || coalesce(model, '')), 'A') ||
+        setweight(to_tsvector('simple', coalesce(license_plate, '')), 'B') ||
+        setweight(to_tsvector('simple', coalesce(color, '')), 'C')
+    ) STORED;
+
+CREATE INDEX idx_users_search_vector ON public.users USING gin (search_vector);
+CREATE INDEX idx_cars_search_vector ON public.cars USING gin (search_vector);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_cars_search_vector;
+DROP INDEX IF EXISTS idx_users_search_vector;
+ALTER TABLE public.cars DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE public.users DROP COLUMN IF EXISTS search_vector;
+
+-- +goose StatementEnd
@@ -0,0 +1,37 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Row versions for optimistic concurrency. Every UPDATE bumps the version,
+-- including bulk writes, so a read-modify-write conditioned on the version it
+-- read fails instead of overwriting a concurrent change.
+ALTER TABLE public.users ADD COLUMN version int8 NOT NULL DEFAULT 1;
+ALTER TABLE public.cars ADD COLUMN version int8 NOT NULL DEFAULT 1;
+
+CREATE OR REPLACE FUNCTION trigger_bump_version()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW.version = OLD.version + 1;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER bump_users_version
+    BEFORE UPDATE ON public.users
+    FOR EACH ROW EXECUTE FUNCTION trigger_bump_version();
+
+CREATE TRIGGER bump_cars_version
+    BEFORE UPDATE ON public.cars
+    FOR EACH ROW EXECUTE FUNCTION trigger_bump_version();
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TRIGGER IF EXISTS bump_cars_version ON public.cars;
+DROP TRIGGER IF EXISTS bump_users_version ON public.users;
+DROP FUNCTION IF EXISTS trigger_bump_version();
+ALTER TABLE public.cars DROP COLUMN IF EXISTS version;
+ALTER TABLE public.users DROP COLUMN IF EXISTS version;
+
+-- +goose StatementEnd
@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Soft delete. A deleted row keeps its id, email or license plate and its
+-- users_cars links until the purge job removes it, so a restore brings it
+-- back exactly as it was.
+ALTER TABLE public.users ADD COLUMN deleted_at timestamptz NULL;
+ALTER TABLE public.cars ADD COLUMN deleted_at timestamptz NULL;
+
+-- Only deleted rows are indexed: live reads filter on IS NULL, which the
+-- existing indexes serve, and the purge job scans by deletion time.
+CREATE INDEX idx_users_deleted_at ON public.users USING btree (deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX idx_cars_deleted_at ON public.cars USING btree (deleted_at) WHERE deleted_at IS NOT NULL;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_cars_deleted_at;
+DROP INDEX IF EXISTS idx_users_deleted_at;
+DELETE FROM public.cars WHERE deleted_at IS NOT NULL;
+DELETE FROM public.users WHERE deleted_at IS NOT NULL;
+ALTER TABLE public.cars DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE public.users DROP COLUMN IF EXISTS deleted_at;
+
+-- +goose StatementEnd
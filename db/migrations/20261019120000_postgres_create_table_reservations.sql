@@ -0,0 +1,66 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- btree_gist lets the exclusion constraint compare car_id with = in a GiST index
+CREATE EXTENSION IF NOT EXISTS btree_gist;
+
+-- Create reservations table. period is half-open, [starts_at, ends_at), so
+-- back-to-back bookings do not overlap.
+CREATE TABLE public.reservations (
+    id           uuid         DEFAULT uuidv7() NOT NULL,
+    car_id       uuid         NOT NULL,
+    user_id      uuid         NOT NULL,
+    "period"     tstzrange    NOT NULL,
+    status       varchar(20)  NOT NULL DEFAULT 'active',
+    created_at   timestamptz  NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at   timestamptz  NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    cancelled_at timestamptz  NULL,
+    CONSTRAINT reservations_pkey PRIMARY KEY (id),
+    CONSTRAINT fk_reservations_car FOREIGN KEY (car_id)
+        REFERENCES public.cars(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT fk_reservations_user FOREIGN KEY (user_id)
+        REFERENCES public.users(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT reservations_status_check CHECK (status IN ('active', 'cancelled')),
+    CONSTRAINT reservations_period_check CHECK (
+        NOT isempty("period") AND NOT lower_inf("period") AND NOT upper_inf("period")
+        AND lower_inc("period") AND NOT upper_inc("period")
+    ),
+    -- A car cannot have two active reservations whose periods overlap.
+    CONSTRAINT reservations_no_overlap EXCLUDE USING gist (car_id WITH =, "period" WITH &&)
+        WHERE (status = 'active')
+);
+
+CREATE INDEX idx_reservations_user ON public.reservations USING btree (user_id);
+
+CREATE TRIGGER set_reservations_updated_at
+    BEFORE UPDATE ON public.reservations
+    FOR EACH ROW EXECUTE FUNCTION trigger_set_updated_at();
+
+-- Availability is derived from reservations: a car is available unless an
+-- active reservation covers the current time.
+CREATE FUNCTION car_is_available(p_car_id uuid) RETURNS boolean
+    LANGUAGE sql STABLE AS $$
+    SELECT NOT EXISTS (
+        SELECT 1 FROM public.reservations
+        WHERE car_id = p_car_id AND status = 'active' AND "period" @> CURRENT_TIMESTAMP
+    )
+$$;
+
+DROP INDEX IF EXISTS idx_cars_is_available;
+ALTER TABLE public.cars DROP COLUMN is_available;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE public.cars ADD COLUMN is_available bool NOT NULL DEFAULT true;
+UPDATE public.cars SET is_available = car_is_available(id);
+CREATE INDEX idx_cars_is_available ON public.cars USING btree (is_available);
+
+DROP FUNCTION IF EXISTS car_is_available(uuid);
+DROP TRIGGER IF EXISTS set_reservations_updated_at ON public.reservations;
+DROP INDEX IF EXISTS idx_reservations_user;
+DROP TABLE IF EXISTS public.reservations;
+
+-- +goose StatementEnd
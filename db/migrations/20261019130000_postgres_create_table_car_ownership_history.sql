@@ -0,0 +1,48 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Create car_ownership_history table. It records every change of hands and
+-- has no foreign keys, so the provenance of a car outlives the purge of the
+-- car or of its former owners.
+CREATE TABLE public.car_ownership_history (
+    id           uuid         DEFAULT uuidv7() NOT NULL,
+    car_id       uuid         NOT NULL,
+    event        varchar(20)  NOT NULL,
+    from_user_id uuid         NULL,
+    to_user_id   uuid         NULL,
+    actor_id     uuid         NULL,
+    reason       text         NULL,
+    created_at   timestamptz  NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT car_ownership_history_pkey PRIMARY KEY (id),
+    CONSTRAINT car_ownership_history_event_check CHECK (event IN ('created', 'transferred', 'deleted', 'restored'))
+);
+
+CREATE INDEX idx_car_ownership_history_car ON public.car_ownership_history USING btree (car_id, id);
+
+-- The history is append-only: rows can be inserted but never changed or removed.
+CREATE OR REPLACE FUNCTION trigger_forbid_car_ownership_history_change()
+RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'car_ownership_history is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER forbid_car_ownership_history_change
+    BEFORE UPDATE OR DELETE ON public.car_ownership_history
+    FOR EACH ROW EXECUTE FUNCTION trigger_forbid_car_ownership_history_change();
+
+-- Seed the current owner of every existing car; who created it is unknown.
+INSERT INTO public.car_ownership_history (car_id, event, to_user_id, created_at)
+SELECT car_id, 'created', user_id, created_at FROM public.users_cars;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TRIGGER IF EXISTS forbid_car_ownership_history_change ON public.car_ownership_history;
+DROP FUNCTION IF EXISTS trigger_forbid_car_ownership_history_change();
+DROP INDEX IF EXISTS idx_car_ownership_history_car;
+DROP TABLE IF EXISTS public.car_ownership_history;
+
+-- +goose StatementEnd
@@ -0,0 +1,52 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Create car_transfer_requests table. A request stays pending until the
+-- recipient accepts or rejects it, the sender cancels it or it expires.
+CREATE TABLE public.car_transfer_requests (
+    id           uuid         DEFAULT uuidv7() NOT NULL,
+    car_id       uuid         NOT NULL,
+    from_user_id uuid         NOT NULL,
+    to_user_id   uuid         NOT NULL,
+    status       varchar(20)  NOT NULL DEFAULT 'pending',
+    reason       text         NULL,
+    expires_at   timestamptz  NOT NULL,
+    created_at   timestamptz  NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at   timestamptz  NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    responded_at timestamptz  NULL,
+    CONSTRAINT car_transfer_requests_pkey PRIMARY KEY (id),
+    CONSTRAINT fk_car_transfer_requests_car FOREIGN KEY (car_id)
+        REFERENCES public.cars(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT fk_car_transfer_requests_from_user FOREIGN KEY (from_user_id)
+        REFERENCES public.users(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT fk_car_transfer_requests_to_user FOREIGN KEY (to_user_id)
+        REFERENCES public.users(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT car_transfer_requests_status_check CHECK (status IN ('pending', 'accepted', 'rejected', 'cancelled', 'expired')),
+    CONSTRAINT car_transfer_requests_recipient_check CHECK (from_user_id <> to_user_id)
+);
+
+-- A car has at most one pending transfer request.
+CREATE UNIQUE INDEX idx_car_transfer_requests_pending_car ON public.car_transfer_requests USING btree (car_id)
+    WHERE (status = 'pending');
+CREATE INDEX idx_car_transfer_requests_from_user ON public.car_transfer_requests USING btree (from_user_id);
+CREATE INDEX idx_car_transfer_requests_to_user ON public.car_transfer_requests USING btree (to_user_id);
+CREATE INDEX idx_car_transfer_requests_pending_expiry ON public.car_transfer_requests USING btree (expires_at)
+    WHERE (status = 'pending');
+
+CREATE TRIGGER set_car_transfer_requests_updated_at
+    BEFORE UPDATE ON public.car_transfer_requests
+    FOR EACH ROW EXECUTE FUNCTION trigger_set_updated_at();
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TRIGGER IF EXISTS set_car_transfer_requests_updated_at ON public.car_transfer_requests;
+DROP INDEX IF EXISTS idx_car_transfer_requests_pending_expiry;
+DROP INDEX IF EXISTS idx_car_transfer_requests_to_user;
+DROP INDEX IF EXISTS idx_car_transfer_requests_from_user;
+DROP INDEX IF EXISTS idx_car_transfer_requests_pending_car;
+DROP TABLE IF EXISTS public.car_transfer_requests;
+
+-- +goose StatementEnd
@@ -0,0 +1,35 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Each link between a user and a car carries the user's role on it. The
+-- owner has full control; co-owners may edit the car, drivers and viewers
+-- only see it.
+ALTER TABLE public.users_cars ADD COLUMN role varchar(20) NOT NULL DEFAULT 'owner';
+ALTER TABLE public.users_cars ADD CONSTRAINT users_cars_role_check
+    CHECK (role IN ('owner', 'co-owner', 'driver', 'viewer'));
+
+-- Existing cars linked to several users keep their earliest link as owner;
+-- the others become co-owners.
+UPDATE public.users_cars uc
+SET role = 'co-owner'
+WHERE EXISTS (
+    SELECT 1 FROM public.users_cars earlier
+    WHERE earlier.car_id = uc.car_id
+      AND (earlier.created_at, earlier.user_id) < (uc.created_at, uc.user_id)
+);
+
+-- A car has exactly one owner.
+CREATE UNIQUE INDEX idx_users_cars_owner ON public.users_cars USING btree (car_id)
+    WHERE (role = 'owner');
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_users_cars_owner;
+DELETE FROM public.users_cars WHERE role <> 'owner';
+ALTER TABLE public.users_cars DROP CONSTRAINT IF EXISTS users_cars_role_check;
+ALTER TABLE public.users_cars DROP COLUMN role;
+
+-- +goose StatementEnd
@@ -0,0 +1,67 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Create car_service_records table, the maintenance log of a car.
+CREATE TABLE public.car_service_records (
+    id           uuid           DEFAULT uuidv7() NOT NULL,
+    car_id       uuid           NOT NULL,
+    service_date date           NOT NULL,
+    odometer     int4           NOT NULL,
+    service_type varchar(30)    NOT NULL,
+    cost         numeric(12, 2) NOT NULL DEFAULT 0,
+    notes        text           NULL,
+    created_by   uuid           NULL,
+    created_at   timestamptz    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at   timestamptz    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT car_service_records_pkey PRIMARY KEY (id),
+    CONSTRAINT fk_car_service_records_car FOREIGN KEY (car_id)
+        REFERENCES public.cars(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT fk_car_service_records_created_by FOREIGN KEY (created_by)
+        REFERENCES public.users(id) ON DELETE SET NULL ON UPDATE CASCADE,
+    CONSTRAINT car_service_records_odometer_check CHECK (odometer >= 0),
+    CONSTRAINT car_service_records_cost_check CHECK (cost >= 0),
+    CONSTRAINT car_service_records_type_check CHECK (service_type IN (
+        'oil_change', 'tire_rotation', 'brakes', 'battery', 'inspection', 'repair', 'other'
+    ))
+);
+
+CREATE INDEX idx_car_service_records_car ON public.car_service_records USING btree (car_id, service_date DESC, id DESC);
+
+CREATE TRIGGER set_car_service_records_updated_at
+    BEFORE UPDATE ON public.car_service_records
+    FOR EACH ROW EXECUTE FUNCTION trigger_set_updated_at();
+
+-- Aggregated service log of a car, joined into the single-car reads.
+CREATE FUNCTION car_service_summary(p_car_id uuid)
+    RETURNS TABLE (
+        service_count         bigint,
+        total_service_cost    numeric,
+        last_service_date     date,
+        last_service_odometer int4
+    )
+    LANGUAGE sql STABLE AS $$
+    SELECT
+        COUNT(*),
+        COALESCE(SUM(cost), 0),
+        MAX(service_date),
+        (array_agg(odometer ORDER BY service_date DESC, id DESC))[1]
+    FROM public.car_service_records
+    WHERE car_id = p_car_id
+$$;
+
+-- Set by the overdue-service job when a car's last service (or, without
+-- one, its creation) is older than the service interval.
+ALTER TABLE public.cars ADD COLUMN service_overdue_at timestamptz NULL;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE public.cars DROP COLUMN IF EXISTS service_overdue_at;
+DROP FUNCTION IF EXISTS car_service_summary(uuid);
+DROP TRIGGER IF EXISTS set_car_service_records_updated_at ON public.car_service_records;
+DROP INDEX IF EXISTS idx_car_service_records_car;
+DROP TABLE IF EXISTS public.car_service_records;
+
+-- +goose StatementEnd
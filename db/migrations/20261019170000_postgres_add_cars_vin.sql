@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Vehicle identification number. Optional, as existing cars have none; the
+-- check digit is validated by the API, the column only enforces the format.
+ALTER TABLE public.cars ADD COLUMN vin varchar(17) NULL;
+ALTER TABLE public.cars ADD CONSTRAINT cars_vin_key UNIQUE (vin);
+ALTER TABLE public.cars ADD CONSTRAINT cars_vin_check CHECK (vin ~ '^[A-HJ-NPR-Z0-9]{17}$');
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE public.cars DROP CONSTRAINT IF EXISTS cars_vin_check;
+ALTER TABLE public.cars DROP CONSTRAINT IF EXISTS cars_vin_key;
+ALTER TABLE public.cars DROP COLUMN IF EXISTS vin;
+
+-- +goose StatementEnd
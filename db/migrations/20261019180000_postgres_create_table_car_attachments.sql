@@ -0,0 +1,37 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Create car_attachments table, the metadata of photos and documents whose
+-- contents live in the blob store under storage_key.
+CREATE TABLE public.car_attachments (
+    id           uuid         DEFAULT uuidv7() NOT NULL,
+    car_id       uuid         NOT NULL,
+    kind         varchar(20)  NOT NULL,
+    file_name    varchar(255) NOT NULL,
+    content_type varchar(100) NOT NULL,
+    size_bytes   int8         NOT NULL,
+    checksum     char(64)     NOT NULL,
+    storage_key  text         NOT NULL,
+    uploaded_by  uuid         NULL,
+    created_at   timestamptz  NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT car_attachments_pkey PRIMARY KEY (id),
+    CONSTRAINT car_attachments_storage_key_key UNIQUE (storage_key),
+    CONSTRAINT fk_car_attachments_car FOREIGN KEY (car_id)
+        REFERENCES public.cars(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT fk_car_attachments_uploaded_by FOREIGN KEY (uploaded_by)
+        REFERENCES public.users(id) ON DELETE SET NULL ON UPDATE CASCADE,
+    CONSTRAINT car_attachments_kind_check CHECK (kind IN ('photo', 'document')),
+    CONSTRAINT car_attachments_size_check CHECK (size_bytes > 0)
+);
+
+CREATE INDEX idx_car_attachments_car ON public.car_attachments USING btree (car_id, created_at DESC, id DESC);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_car_attachments_car;
+DROP TABLE IF EXISTS public.car_attachments;
+
+-- +goose StatementEnd
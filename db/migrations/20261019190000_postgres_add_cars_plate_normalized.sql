@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Region whose plate format the license plate follows, e.g. DE or ID. NULL for
+-- cars registered without one, which are held to the generic format.
+ALTER TABLE public.cars ADD COLUMN plate_region varchar(8) NULL;
+
+-- Canonical plate: upper case, with everything but letters and digits removed,
+-- so "B 1234 XY" and "b1234xy" are the same plate. Mirrors util.NormalizePlate.
+ALTER TABLE public.cars ADD COLUMN license_plate_normalized varchar(20)
+    GENERATED ALWAYS AS (upper(regexp_replace(license_plate, '[^A-Za-z0-9]', '', 'g'))) STORED;
+
+-- Uniqueness moves from the plate as typed to its canonical form. This fails
+-- if existing plates only differ in case, spacing or punctuation; rename or
+-- purge those cars first.
+CREATE UNIQUE INDEX cars_license_plate_normalized_key ON public.cars USING btree (license_plate_normalized);
+ALTER TABLE public.cars DROP CONSTRAINT IF EXISTS cars_license_plate_key;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE public.cars ADD CONSTRAINT cars_license_plate_key UNIQUE (license_plate);
+DROP INDEX IF EXISTS cars_license_plate_normalized_key;
+ALTER TABLE public.cars DROP COLUMN IF EXISTS license_plate_normalized;
+ALTER TABLE public.cars DROP COLUMN IF EXISTS plate_region;
+
+-- +goose StatementEnd
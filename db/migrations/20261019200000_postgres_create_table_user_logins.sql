@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Create user_logins table, one row per successful login, feeding the login
+-- trend of the admin statistics.
+CREATE TABLE public.user_logins (
+    id           uuid        DEFAULT uuidv7() NOT NULL,
+    user_id      uuid        NOT NULL,
+    logged_in_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT user_logins_pkey PRIMARY KEY (id),
+    CONSTRAINT fk_user_logins_user FOREIGN KEY (user_id)
+        REFERENCES public.users(id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE INDEX idx_user_logins_logged_in_at ON public.user_logins USING btree (logged_in_at);
+
+-- Registration trends filter and bucket users by creation time.
+CREATE INDEX idx_users_created_at ON public.users USING btree (created_at);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_users_created_at;
+DROP INDEX IF EXISTS idx_user_logins_logged_in_at;
+DROP TABLE IF EXISTS public.user_logins;
+
+-- +goose StatementEnd
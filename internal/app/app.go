@@ -1,6 +1,8 @@
 package app
 
 import (
+	"context"
+	"crypto/rand"
 	"flag"
 	"log"
 	"net/http"
@@ -9,6 +11,8 @@ import (
 	"go-far/internal/config"
 	httpHandler "go-far/internal/handler/http"
 	schedHandler "go-far/internal/handler/scheduler"
+	"go-far/internal/infra/blob"
+	"go-far/internal/infra/cache"
 	"go-far/internal/infra/database"
 	"go-far/internal/infra/grace"
 	httpclient "go-far/internal/infra/http/client"
@@ -24,11 +28,14 @@ import (
 	"go-far/internal/infra/token"
 	"go-far/internal/infra/tracer"
 	"go-far/internal/infra/validator"
+	"go-far/internal/infra/vpic"
 	"go-far/internal/preference"
 	"go-far/internal/repository"
 	"go-far/internal/service"
+	"go-far/internal/service/attachment"
 	"go-far/internal/util"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/rs/zerolog"
 )
 
@@ -53,8 +60,16 @@ func Run() {
 	// Logger Initialization
 	log := logger.InitLogger(conf.Logger)
 
+	// Query Loader Initialization
+	queryLoader := query.InitQueryLoader(log, conf.Queries)
+
 	// SQL Initialization
-	sql0 := database.InitDB(log, conf.Database.Postgres)
+	var afterConnect func(context.Context, *pgx.Conn) error
+	if conf.Queries.PrepareStatements {
+		afterConnect = queryLoader.PrepareStatements
+	}
+
+	sql0 := database.InitDB(log, conf.Database.Postgres, afterConnect)
 	defer sql0.Close()
 
 	// Redis Initialization - Apps
@@ -83,13 +98,25 @@ func Run() {
 
 	// HTTP Client Initialization
 	httpClient := httpclient.InitHttpClient(log, conf.HTTP.Client)
+	vpicClient := vpic.InitVPIC(log, conf.VPIC, httpClient)
 
-	// Query Loader Initialization
-	queryLoader := query.InitQueryLoader(log, conf.Queries)
+	// Query Verification
+	if conf.Queries.VerifyOnStartup && sql0 != nil {
+		if err := queryLoader.Verify(context.Background(), sql0); err != nil {
+			log.Panic().Err(err).Msg("Failed to verify queries")
+		}
+	}
+
+	// Cache Initialization (L1 invalidations arrive over Redis pub/sub)
+	cacheStore := cache.InitStore(redis0, conf.Redis.LocalCache)
+	cacheStore.Start(log)
+	defer cacheStore.Stop()
 
 	// Business Layers Initialization
-	repo := repository.InitRepository(sql0, redis0, queryLoader, conf.Redis.CacheTTL)
-	svc := service.InitService(repo)
+	repo := repository.InitRepository(sql0, cacheStore, queryLoader, conf.Redis.CacheTTL, vpicClient, conf.VPIC.CacheTTL, conf.Stats.CacheTTL)
+	txManager := database.InitTxManager(sql0, conf.Database.Postgres)
+	blobStore := blob.InitBlobStore(log, conf.Attachment.Storage)
+	svc := service.InitService(repo, txManager, conf.Transfer.RequestTTL, blobStore, attachmentOptions(log, conf.Attachment))
 
 	// Tracer Initialization
 	var tracerInst tracer.Tracer
@@ -101,7 +128,7 @@ func Run() {
 	// Metrics Initialization
 	var metricsInst metrics.Metrics
 	if conf.Metric.Enabled {
-		metricsInst = metrics.InitMetrics(log, sql0, redis0, redis1, redis2)
+		metricsInst = metrics.InitMetrics(log, sql0, queryLoader, cacheStore.Stats(), redis0, redis1, redis2)
 	}
 
 	// Auth & MiddlewareInitialization
@@ -110,6 +137,7 @@ func Run() {
 
 	// HTTP router & validator
 	httpMux := httpmux.InitHttpMux(log, metricsInst)
+	registerPlateFormats(log, conf.Plate)
 	validator.InitValidator(log)
 
 	// REST Handler Initialization (registers routes on mux)
@@ -161,6 +189,40 @@ func initScheduler(log *zerolog.Logger, conf *config.Config, svc *service.Servic
 	return scheduler
 }
 
+// attachmentOptions maps the attachment config for the service. Without a
+// configured signing key a random one is used, so download links then only
+// work on this instance and until it restarts.
+func attachmentOptions(log *zerolog.Logger, conf config.AttachmentConfig) attachment.AttachmentOptions {
+	signingKey := []byte(conf.SigningKey)
+	if len(signingKey) == 0 {
+		log.Warn().Msg("ATTACHMENT_SIGNING_KEY_GO_FAR is not set, download links will not survive a restart")
+		signingKey = make([]byte, 32)
+		if _, err := rand.Read(signingKey); err != nil {
+			log.Panic().Err(err).Msg("Failed to generate attachment signing key")
+		}
+	}
+
+	return attachment.AttachmentOptions{
+		SigningKey:   signingKey,
+		URLTTL:       conf.URLTTL,
+		MaxFileBytes: conf.MaxFileBytes,
+		MaxPerCar:    conf.MaxPerCar,
+	}
+}
+
+// registerPlateFormats adds the configured license plate formats to the
+// registry used by the plate validation.
+func registerPlateFormats(log *zerolog.Logger, conf config.PlateConfig) {
+	for region, expr := range conf.Formats {
+		format, err := util.NewPlatePattern(expr)
+		if err != nil {
+			log.Panic().Err(err).Str("region", region).Msg("Invalid license plate format")
+		}
+
+		util.RegisterPlateFormat(region, format)
+	}
+}
+
 func parseFlags() (minJitter, maxJitter int) {
 	flag.IntVar(&minJitter, "minSleep", DefaultMinJitter, "min. sleep duration during app initialization")
 	flag.IntVar(&maxJitter, "maxSleep", DefaultMaxJitter, "max. sleep duration during app initialization")
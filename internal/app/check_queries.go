@@ -0,0 +1,41 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"go-far/internal/config"
+	"go-far/internal/infra/database"
+	"go-far/internal/infra/logger"
+	"go-far/internal/infra/query"
+	appErr "go-far/internal/model/errors"
+)
+
+// CheckQueries loads every named SQL query and verifies it against the
+// configured database, printing a report and exiting non-zero on failure.
+// It is meant for CI, so it runs regardless of queries.verify_on_startup.
+func CheckQueries() {
+	conf, err := config.InitConfig()
+	if err != nil {
+		panic(err)
+	}
+
+	log := logger.InitLogger(conf.Logger)
+
+	sql0 := database.InitDB(log, conf.Database.Postgres, nil)
+	if sql0 == nil {
+		fmt.Fprintln(os.Stderr, "database is disabled, nothing to verify against")
+		os.Exit(1)
+	}
+	defer sql0.Close()
+
+	queryLoader := query.InitQueryLoader(log, conf.Queries)
+	if err := queryLoader.Verify(context.Background(), sql0); err != nil {
+		fmt.Fprintln(os.Stderr, appErr.RootCause(err))
+		sql0.Close()
+		os.Exit(1)
+	}
+
+	fmt.Println("all queries verified")
+}
@@ -1,6 +1,9 @@
 package config
 
 import (
+	"time"
+
+	"go-far/internal/infra/blob"
 	"go-far/internal/infra/database"
 	app "go-far/internal/infra/grace"
 	httpclient "go-far/internal/infra/http/client"
@@ -14,6 +17,7 @@ import (
 	cfgscheduler "go-far/internal/infra/scheduler"
 	"go-far/internal/infra/token"
 	"go-far/internal/infra/tracer"
+	"go-far/internal/infra/vpic"
 
 	"github.com/yuseferi/envyaml"
 )
@@ -31,6 +35,11 @@ type Config struct {
 	Tracer     *tracer.TracerOptions          `yaml:"tracer"`
 	Metric     *metrics.MetricsOptions        `yaml:"metric"`
 	Pyroscope  *pyroscope.PyroscopeOptions    `yaml:"pyroscope"`
+	Transfer   TransferConfig                 `yaml:"transfer"`
+	VPIC       *vpic.VPICOptions              `yaml:"vpic"`
+	Attachment AttachmentConfig               `yaml:"attachment"`
+	Plate      PlateConfig                    `yaml:"plate"`
+	Stats      StatsConfig                    `yaml:"stats"`
 }
 
 type HTTPConfig struct {
@@ -43,6 +52,34 @@ type DatabaseConfig struct {
 	Postgres *database.DatabaseOptions `yaml:"postgres"`
 }
 
+// TransferConfig holds the car transfer workflow settings.
+type TransferConfig struct {
+	RequestTTL time.Duration `yaml:"request_ttl"`
+}
+
+// AttachmentConfig holds the car attachment settings. MaxFileBytes limits
+// uploads independently of http.server.max_body_bytes.
+type AttachmentConfig struct {
+	Storage      *blob.BlobOptions `yaml:"storage"`
+	SigningKey   string            `yaml:"signing_key" env:"ATTACHMENT_SIGNING_KEY_GO_FAR"`
+	URLTTL       time.Duration     `yaml:"url_ttl"`
+	MaxFileBytes int64             `yaml:"max_file_bytes"`
+	MaxPerCar    int               `yaml:"max_per_car"`
+}
+
+// PlateConfig maps region codes to regular expressions for their license
+// plates, matched against the normalized plate. They add to the built-in
+// formats, replacing a built-in one of the same region.
+type PlateConfig struct {
+	Formats map[string]string `yaml:"formats"`
+}
+
+// StatsConfig holds the admin statistics settings. CacheTTL is how long an
+// aggregate is served from cache, and so how stale it can get.
+type StatsConfig struct {
+	CacheTTL time.Duration `yaml:"cache_ttl"`
+}
+
 func InitConfig() (*Config, error) {
 	var cfg Config
 	if err := envyaml.LoadConfig("./configs/config.yaml", &cfg); err != nil {
@@ -0,0 +1,281 @@
+package rest
+
+import (
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go-far/internal/infra/middleware"
+	"go-far/internal/infra/validator"
+	"go-far/internal/model/dto"
+	"go-far/internal/model/entity"
+	appErr "go-far/internal/model/errors"
+	"go-far/internal/preference"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+// attachmentFormOverhead is the room left on top of the file size limit for
+// the multipart boundaries and the other form fields.
+const attachmentFormOverhead = 64 << 10
+
+// UploadAttachment godoc
+//
+//	@Summary		Upload car attachment
+//	@Description	Attach a photo (JPEG, PNG or WebP) or a document (PDF, JPEG or PNG) to a car (owner or co-owner only). The type is sniffed from the file content, not taken from the client. The response carries a signed download URL.
+//	@Tags			attachments
+//	@Accept			multipart/form-data
+//	@Produce		json
+//	@Param			id		path		string	true	"Car ID"
+//	@Param			kind	formData	string	true	"Attachment kind"	Enums(photo, document)
+//	@Param			file	formData	file	true	"Photo or document"
+//	@Success		201		{object}	dto.HttpSuccessResp{data=entity.CarAttachment}
+//	@Failure		400		{object}	dto.HTTPErrorResp
+//	@Failure		401		{object}	dto.HTTPErrorResp
+//	@Failure		403		{object}	dto.HTTPErrorResp
+//	@Failure		404		{object}	dto.HTTPErrorResp
+//	@Failure		409		{object}	dto.HTTPErrorResp
+//	@Failure		415		{object}	dto.HTTPErrorResp
+//	@Failure		500		{object}	dto.HTTPErrorResp
+//	@Router			/cars/{id}/attachments [post]
+func (e *rest) UploadAttachment(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	authUser, ok := middleware.GetAuthUser(ctx)
+	if !ok {
+		e.httpRespError(w, r, appErr.NewWithCode(appErr.CodeHTTPUnauthorized, "unauthenticated"))
+		return
+	}
+
+	carID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("invalid_car_id")
+		e.httpRespError(w, r, appErr.WrapWithCode(err, appErr.CodeHTTPBadRequest, "invalid_car_id"))
+		return
+	}
+
+	middleware.SetBodyLimit(w, r, e.svc.Attachment.MaxFileBytes()+attachmentFormOverhead)
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		zerolog.Ctx(ctx).Warn().Err(err).Msg("invalid_attachment_file")
+		e.httpRespError(w, r, appErr.WrapWithCode(err, appErr.CodeHTTPBadRequest, "invalid_attachment_file"))
+		return
+	}
+	defer file.Close()
+
+	form := dto.AttachmentUploadForm{Kind: r.FormValue("kind")}
+	if err := validator.ValidateRequest(&form); err != nil {
+		zerolog.Ctx(ctx).Warn().Err(err).Msg("validation_failed_upload_attachment")
+		e.httpRespError(w, r, err)
+		return
+	}
+
+	att, err := e.svc.Attachment.UploadAttachment(ctx, carID, entity.AttachmentKind(form.Kind), header.Filename, file, header.Size, authUser.UserID)
+	if err != nil {
+		e.httpRespError(w, r, err)
+		return
+	}
+
+	e.httpRespSuccess(w, r, http.StatusCreated, att, nil)
+}
+
+// ListAttachments godoc
+//
+//	@Summary		List car attachments
+//	@Description	List a car's photos and documents, newest first, each with a fresh signed download URL (any user the car is shared with, or an admin)
+//	@Tags			attachments
+//	@Produce		json
+//	@Param			id	path		string	true	"Car ID"
+//	@Success		200	{object}	dto.HttpSuccessResp{data=[]entity.CarAttachment}
+//	@Failure		400	{object}	dto.HTTPErrorResp
+//	@Failure		401	{object}	dto.HTTPErrorResp
+//	@Failure		403	{object}	dto.HTTPErrorResp
+//	@Failure		500	{object}	dto.HTTPErrorResp
+//	@Router			/cars/{id}/attachments [get]
+func (e *rest) ListAttachments(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	authUser, ok := middleware.GetAuthUser(ctx)
+	if !ok {
+		e.httpRespError(w, r, appErr.NewWithCode(appErr.CodeHTTPUnauthorized, "unauthenticated"))
+		return
+	}
+
+	carID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("invalid_car_id")
+		e.httpRespError(w, r, appErr.WrapWithCode(err, appErr.CodeHTTPBadRequest, "invalid_car_id"))
+		return
+	}
+
+	isAdmin := authUser.Role == string(entity.RoleAdmin)
+
+	atts, err := e.svc.Attachment.ListAttachments(ctx, carID, authUser.UserID, isAdmin)
+	if err != nil {
+		e.httpRespError(w, r, err)
+		return
+	}
+
+	e.httpRespSuccess(w, r, http.StatusOK, atts, nil)
+}
+
+// GetAttachment godoc
+//
+//	@Summary		Get car attachment
+//	@Description	Get one attachment's metadata with a fresh signed download URL (any user the car is shared with, or an admin)
+//	@Tags			attachments
+//	@Produce		json
+//	@Param			id				path		string	true	"Car ID"
+//	@Param			attachment_id	path		string	true	"Attachment ID"
+//	@Success		200				{object}	dto.HttpSuccessResp{data=entity.CarAttachment}
+//	@Failure		400				{object}	dto.HTTPErrorResp
+//	@Failure		401				{object}	dto.HTTPErrorResp
+//	@Failure		403				{object}	dto.HTTPErrorResp
+//	@Failure		404				{object}	dto.HTTPErrorResp
+//	@Failure		500				{object}	dto.HTTPErrorResp
+//	@Router			/cars/{id}/attachments/{attachment_id} [get]
+func (e *rest) GetAttachment(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	authUser, ok := middleware.GetAuthUser(ctx)
+	if !ok {
+		e.httpRespError(w, r, appErr.NewWithCode(appErr.CodeHTTPUnauthorized, "unauthenticated"))
+		return
+	}
+
+	carID, id, ok := e.parseAttachmentPath(w, r)
+	if !ok {
+		return
+	}
+
+	isAdmin := authUser.Role == string(entity.RoleAdmin)
+
+	att, err := e.svc.Attachment.GetAttachment(ctx, carID, id, authUser.UserID, isAdmin)
+	if err != nil {
+		e.httpRespError(w, r, err)
+		return
+	}
+
+	e.httpRespSuccess(w, r, http.StatusOK, att, nil)
+}
+
+// DeleteAttachment godoc
+//
+//	@Summary		Delete car attachment
+//	@Description	Remove an attachment and its stored file (owner or co-owner only)
+//	@Tags			attachments
+//	@Produce		json
+//	@Param			id				path		string	true	"Car ID"
+//	@Param			attachment_id	path		string	true	"Attachment ID"
+//	@Success		200				{object}	dto.HttpSuccessResp
+//	@Failure		400				{object}	dto.HTTPErrorResp
+//	@Failure		401				{object}	dto.HTTPErrorResp
+//	@Failure		403				{object}	dto.HTTPErrorResp
+//	@Failure		404				{object}	dto.HTTPErrorResp
+//	@Failure		500				{object}	dto.HTTPErrorResp
+//	@Router			/cars/{id}/attachments/{attachment_id} [delete]
+func (e *rest) DeleteAttachment(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	authUser, ok := middleware.GetAuthUser(ctx)
+	if !ok {
+		e.httpRespError(w, r, appErr.NewWithCode(appErr.CodeHTTPUnauthorized, "unauthenticated"))
+		return
+	}
+
+	carID, id, ok := e.parseAttachmentPath(w, r)
+	if !ok {
+		return
+	}
+
+	if err := e.svc.Attachment.DeleteAttachment(ctx, carID, id, authUser.UserID); err != nil {
+		e.httpRespError(w, r, err)
+		return
+	}
+
+	e.httpRespSuccess(w, r, http.StatusOK, nil, nil)
+}
+
+// DownloadAttachment godoc
+//
+//	@Summary		Download car attachment
+//	@Description	Stream an attachment's file. The link is the signed, expiring download_url returned with the attachment and needs no bearer token.
+//	@Tags			attachments
+//	@Produce		application/octet-stream
+//	@Param			id			path		string	true	"Attachment ID"
+//	@Param			expires		query		int		true	"Expiry of the link (Unix seconds)"
+//	@Param			signature	query		string	true	"Signature of the link"
+//	@Success		200			{file}		file
+//	@Failure		400			{object}	dto.HTTPErrorResp
+//	@Failure		403			{object}	dto.HTTPErrorResp
+//	@Failure		404			{object}	dto.HTTPErrorResp
+//	@Failure		503			{object}	dto.HTTPErrorResp
+//	@Router			/attachments/{id}/download [get]
+func (e *rest) DownloadAttachment(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("invalid_attachment_id")
+		e.httpRespError(w, r, appErr.WrapWithCode(err, appErr.CodeHTTPBadRequest, "invalid_attachment_id"))
+		return
+	}
+
+	query := r.URL.Query()
+	expires, err := strconv.ParseInt(query.Get("expires"), 10, 64)
+	if err != nil {
+		e.httpRespError(w, r, appErr.WrapWithCode(err, appErr.CodeHTTPBadRequest, "invalid_expires"))
+		return
+	}
+
+	att, body, err := e.svc.Attachment.OpenDownload(ctx, id, expires, query.Get("signature"))
+	if err != nil {
+		e.httpRespError(w, r, err)
+		return
+	}
+	defer body.Close()
+
+	disposition := "attachment"
+	if att.Kind == entity.AttachmentPhoto {
+		disposition = "inline"
+	}
+
+	maxAge := max(int64(time.Until(*att.DownloadURLExpiresAt).Seconds()), 0)
+
+	w.Header().Set(preference.HeaderContentType, att.ContentType)
+	w.Header().Set("Content-Length", strconv.FormatInt(att.SizeBytes, 10))
+	w.Header().Set(preference.HeaderContentDisposition, mime.FormatMediaType(disposition, map[string]string{"filename": att.FileName}))
+	w.Header().Set(preference.HeaderXContentTypeOptions, "nosniff")
+	w.Header().Set(preference.CacheControl, "private, max-age="+strconv.FormatInt(maxAge, 10))
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := io.Copy(w, body); err != nil {
+		zerolog.Ctx(ctx).Warn().Err(err).Str("attachment_id", att.ID).Msg("download_attachment_err")
+	}
+}
+
+// parseAttachmentPath reads the car and attachment IDs of the path, writing a
+// 400 response when either is malformed.
+func (e *rest) parseAttachmentPath(w http.ResponseWriter, r *http.Request) (uuid.UUID, uuid.UUID, bool) {
+	ctx := r.Context()
+
+	carID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("invalid_car_id")
+		e.httpRespError(w, r, appErr.WrapWithCode(err, appErr.CodeHTTPBadRequest, "invalid_car_id"))
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	id, err := uuid.Parse(r.PathValue("attachment_id"))
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("invalid_attachment_id")
+		e.httpRespError(w, r, appErr.WrapWithCode(err, appErr.CodeHTTPBadRequest, "invalid_attachment_id"))
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return carID, id, true
+}
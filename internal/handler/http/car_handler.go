@@ -3,17 +3,30 @@ package rest
 import (
 	"encoding/json"
 	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
 
 	"go-far/internal/infra/middleware"
 	"go-far/internal/infra/validator"
 	"go-far/internal/model/dto"
 	"go-far/internal/model/entity"
 	appErr "go-far/internal/model/errors"
+	"go-far/internal/preference"
+	"go-far/internal/util"
 
 	"github.com/google/uuid"
 	"github.com/rs/zerolog"
 )
 
+const (
+	// carImportMaxBytes caps the multipart body of a CSV import.
+	carImportMaxBytes = 10 << 20
+	// maxOwnershipReasonLength matches the limit on TransferCarRequest.Reason.
+	maxOwnershipReasonLength = 500
+)
+
 // CreateCar godoc
 //
 //	@Summary		Create a new car
@@ -100,16 +113,123 @@ func (e *rest) CreateBulkCars(w http.ResponseWriter, r *http.Request) {
 	e.httpRespSuccess(w, r, http.StatusCreated, cars, nil)
 }
 
+// ImportCars godoc
+//
+//	@Summary		Import cars from CSV
+//	@Description	Import cars from a CSV file (columns: brand, model, year, color, license_plate, plate_region, vin). Each row is validated like POST /cars; valid rows are inserted in chunks and assigned to the caller, invalid rows are reported with their CSV line number. Set dry_run=true to validate without inserting.
+//	@Tags			cars
+//	@Accept			multipart/form-data
+//	@Produce		json
+//	@Param			file	formData	file											true	"CSV file with a header row"
+//	@Param			dry_run	query		bool											false	"Validate only, do not insert"
+//	@Success		200		{object}	dto.HttpSuccessResp{data=dto.CarImportResult}	"Dry run"
+//	@Success		201		{object}	dto.HttpSuccessResp{data=dto.CarImportResult}
+//	@Failure		400		{object}	dto.HTTPErrorResp
+//	@Failure		500		{object}	dto.HTTPErrorResp
+//	@Router			/cars/import [post]
+func (e *rest) ImportCars(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	authUser, ok := middleware.GetAuthUser(ctx)
+	if !ok {
+		e.httpRespError(w, r, appErr.NewWithCode(appErr.CodeHTTPUnauthorized, "unauthenticated"))
+		return
+	}
+
+	middleware.SetBodyLimit(w, r, carImportMaxBytes)
+
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		zerolog.Ctx(ctx).Warn().Err(err).Msg("invalid_import_file")
+		e.httpRespError(w, r, appErr.WrapWithCode(err, appErr.CodeHTTPBadRequest, "invalid_import_file"))
+		return
+	}
+	defer file.Close()
+
+	dryRun, _ := strconv.ParseBool(r.URL.Query().Get("dry_run"))
+
+	result, err := e.svc.Car.ImportCarsCSV(ctx, file, authUser.UserID, dryRun)
+	if err != nil {
+		e.httpRespError(w, r, err)
+		return
+	}
+
+	status := http.StatusCreated
+	if dryRun {
+		status = http.StatusOK
+	}
+
+	e.httpRespSuccess(w, r, status, result, nil)
+}
+
+// DecodeVIN godoc
+//
+//	@Summary		Decode a VIN
+//	@Description	Look a VIN up in the NHTSA vPIC API to pre-fill the brand, model and year of a new car. Decodings are cached; a VIN vPIC cannot decode is answered with 422.
+//	@Tags			cars
+//	@Accept			json
+//	@Produce		json
+//	@Param			Cache-Control	header		string					false	"Request cache control"	Enums(no-cache, no-store, max-age=60, only-if-cached)
+//	@Param			vin				body		dto.DecodeVINRequest	true	"VIN to decode"
+//	@Success		200				{object}	dto.HttpSuccessResp{data=entity.DecodedVIN}
+//	@Header			200				{string}	X-Cache	"HIT, MISS or BYPASS"
+//	@Header			200				{integer}	Age		"Seconds since the cached copy was stored"
+//	@Failure		400				{object}	dto.HTTPErrorResp
+//	@Failure		422				{object}	dto.HTTPErrorResp
+//	@Failure		503				{object}	dto.HTTPErrorResp
+//	@Router			/cars/decode-vin [post]
+func (e *rest) DecodeVIN(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req dto.DecodeVINRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("invalid_request_body")
+		e.httpRespError(w, r, appErr.WrapWithCode(err, appErr.CodeHTTPUnmarshal, "invalid_request_body"))
+		return
+	}
+
+	if err := validator.ValidateRequest(&req); err != nil {
+		zerolog.Ctx(ctx).Warn().Err(err).Msg("validation_failed_decode_vin")
+		e.httpRespError(w, r, err)
+		return
+	}
+
+	cacheControl := parseCacheControl(r)
+
+	decoded, err := e.svc.Car.DecodeVIN(ctx, cacheControl, req.VIN)
+	setCacheHeaders(w, cacheControl)
+	if err != nil {
+		e.httpRespError(w, r, err)
+		return
+	}
+
+	e.httpRespSuccess(w, r, http.StatusOK, decoded, nil)
+}
+
 // GetCar godoc
 //
 //	@Summary		Get car by ID
 //	@Description	Get a car by its ID
 //	@Tags			cars
 //	@Produce		json
-//	@Param			id	path		string	true	"Car ID"
-//	@Success		200	{object}	dto.HttpSuccessResp{data=entity.Car}
-//	@Failure		404	{object}	dto.HTTPErrorResp
-//	@Failure		500	{object}	dto.HTTPErrorResp
+//	@Param			Cache-Control		header		string	false	"Request cache control"	Enums(no-cache, no-store, max-age=60, only-if-cached)
+//	@Param			id					path		string	true	"Car ID"
+//	@Param			fields				query		string	false	"Comma separated columns to return (e.g. id,brand,model)"
+//	@Param			include				query		string	false	"Embed related records"	Enums(owner)
+//	@Param			include_deleted		query		bool	false	"Also find a soft-deleted car, bypassing the cache (admin only, not with include)"
+//	@Param			If-None-Match		header		string	false	"ETag of a cached copy"
+//	@Param			If-Modified-Since	header		string	false	"Last-Modified of a cached copy"
+//	@Success		200					{object}	dto.HttpSuccessResp{data=entity.CarWithOwner}
+//	@Header			200					{string}	X-Cache			"HIT, MISS or BYPASS"
+//	@Header			200					{integer}	Age				"Seconds since the cached copy was stored"
+//	@Header			200					{string}	ETag			"Strong entity tag of the response data"
+//	@Header			200					{string}	Last-Modified	"updated_at of the record, without include"
+//	@Success		304					"Not Modified"
+//	@Failure		400					{object}	dto.HTTPErrorResp
+//	@Failure		403					{object}	dto.HTTPErrorResp
+//	@Failure		404					{object}	dto.HTTPErrorResp
+//	@Failure		500					{object}	dto.HTTPErrorResp
+//	@Failure		504					{object}	dto.HTTPErrorResp
 //	@Router			/cars/{id} [get]
 func (e *rest) GetCar(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -121,13 +241,59 @@ func (e *rest) GetCar(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	car, err := e.svc.Car.GetCar(ctx, id)
+	sel := util.DecodeURL[dto.FieldSelection](r.URL.Query())
+
+	includes, err := parseIncludes(sel, preference.IncludeOwner)
 	if err != nil {
 		e.httpRespError(w, r, err)
 		return
 	}
 
-	e.httpRespSuccess(w, r, http.StatusOK, car, nil)
+	fields, err := parseFields[entity.Car](sel)
+	if err != nil {
+		e.httpRespError(w, r, err)
+		return
+	}
+
+	includeDeleted, err := parseIncludeDeleted(r)
+	if err != nil {
+		e.httpRespError(w, r, err)
+		return
+	}
+
+	if includeDeleted && len(includes) > 0 {
+		e.httpRespError(w, r, appErr.NewWithCode(appErr.CodeHTTPBadRequest, "include_deleted_with_include"))
+		return
+	}
+
+	cacheControl := parseCacheControl(r)
+
+	var car any
+	if slices.Contains(includes, preference.IncludeOwner) {
+		// Like the cars of ?include=cars, an embedded owner is returned whole.
+		if len(fields) > 0 {
+			fields = append(fields, ownerColumns...)
+		}
+		car, err = e.svc.Car.GetCarWithOwner(ctx, cacheControl, id, fields)
+	} else {
+		var plain *entity.Car
+		if includeDeleted {
+			plain, err = e.svc.Car.GetCarWithDeleted(ctx, id)
+		} else {
+			plain, err = e.svc.Car.GetCarColumns(ctx, cacheControl, id, fields)
+		}
+		if err == nil {
+			setLastModified(w, plain.UpdatedAt)
+		}
+		car = plain
+	}
+	setCacheHeaders(w, cacheControl)
+	if err != nil {
+		e.httpRespError(w, r, err)
+		return
+	}
+
+	e.httpRespSuccess(w, r, http.StatusOK, util.Project(car, fields), nil)
 }
 
 // GetCarWithOwner godoc
@@ -136,10 +302,14 @@ func (e *rest) GetCar(w http.ResponseWriter, r *http.Request) {
 //	@Description	Get a car by its ID with owner information
 //	@Tags			cars
 //	@Produce		json
-//	@Param			id	path		string	true	"Car ID"
-//	@Success		200	{object}	dto.HttpSuccessResp{data=entity.CarWithOwner}
-//	@Failure		404	{object}	dto.HTTPErrorResp
-//	@Failure		500	{object}	dto.HTTPErrorResp
+//	@Param			Cache-Control	header		string	false	"Request cache control"	Enums(no-cache, no-store, max-age=60, only-if-cached)
+//	@Param			id				path		string	true	"Car ID"
+//	@Success		200				{object}	dto.HttpSuccessResp{data=entity.CarWithOwner}
+//	@Header			200				{string}	X-Cache	"HIT, MISS or BYPASS"
+//	@Header			200				{integer}	Age		"Seconds since the cached copy was stored"
+//	@Failure		404				{object}	dto.HTTPErrorResp
+//	@Failure		500				{object}	dto.HTTPErrorResp
+//	@Failure		504				{object}	dto.HTTPErrorResp
 //	@Router			/cars/{id}/owner [get]
 func (e *rest) GetCarWithOwner(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -151,7 +321,10 @@ func (e *rest) GetCarWithOwner(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	car, err := e.svc.Car.GetCarWithOwner(ctx, id)
+	cacheControl := parseCacheControl(r)
+
+	car, err := e.svc.Car.GetCarWithOwner(ctx, cacheControl, id, nil)
+	setCacheHeaders(w, cacheControl)
 	if err != nil {
 		e.httpRespError(w, r, err)
 		return
@@ -160,17 +333,66 @@ func (e *rest) GetCarWithOwner(w http.ResponseWriter, r *http.Request) {
 	e.httpRespSuccess(w, r, http.StatusOK, car, nil)
 }
 
+// GetCarByPlate godoc
+//
+//	@Summary		Get car by license plate
+//	@Description	Find a car by its license plate, ignoring case, spaces and punctuation: "b 1234-xy" finds B1234XY.
+//	@Tags			cars
+//	@Produce		json
+//	@Param			Cache-Control	header		string	false	"Request cache control"	Enums(no-cache, no-store, max-age=60, only-if-cached)
+//	@Param			plate			path		string	true	"License plate"
+//	@Param			fields			query		string	false	"Comma separated columns to return (e.g. id,brand,model)"
+//	@Success		200				{object}	dto.HttpSuccessResp{data=entity.Car}
+//	@Header			200				{string}	X-Cache			"HIT, MISS or BYPASS"
+//	@Header			200				{integer}	Age				"Seconds since the cached copy was stored"
+//	@Header			200				{string}	Last-Modified	"updated_at of the record"
+//	@Failure		400				{object}	dto.HTTPErrorResp
+//	@Failure		404				{object}	dto.HTTPErrorResp
+//	@Failure		500				{object}	dto.HTTPErrorResp
+//	@Failure		504				{object}	dto.HTTPErrorResp
+//	@Router			/plates/{plate} [get]
+func (e *rest) GetCarByPlate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	sel := util.DecodeURL[dto.FieldSelection](r.URL.Query())
+
+	fields, err := parseFields[entity.Car](sel)
+	if err != nil {
+		e.httpRespError(w, r, err)
+		return
+	}
+
+	cacheControl := parseCacheControl(r)
+
+	car, err := e.svc.Car.GetCarByPlate(ctx, cacheControl, r.PathValue("plate"))
+	if err == nil {
+		setLastModified(w, car.UpdatedAt)
+	}
+	setCacheHeaders(w, cacheControl)
+	if err != nil {
+		e.httpRespError(w, r, err)
+		return
+	}
+
+	e.httpRespSuccess(w, r, http.StatusOK, util.Project(car, fields), nil)
+}
+
 // ListCarsByUser godoc
 //
 //	@Summary		List cars by user
 //	@Description	Get all cars owned by a specific user
 //	@Tags			cars
 //	@Produce		json
-//	@Param			user_id	path		string	true	"User ID"
-//	@Success		200		{object}	dto.HttpSuccessResp{data=[]entity.Car}
-//	@Failure		400		{object}	dto.HTTPErrorResp
-//	@Failure		403		{object}	dto.HTTPErrorResp
-//	@Failure		500		{object}	dto.HTTPErrorResp
+//	@Param			Cache-Control	header		string	false	"Request cache control"	Enums(no-cache, no-store, max-age=60, only-if-cached)
+//	@Param			user_id			path		string	true	"User ID"
+//	@Param			fields			query		string	false	"Comma separated columns to return (e.g. id,brand,model)"
+//	@Success		200				{object}	dto.HttpSuccessResp{data=[]entity.Car}
+//	@Header			200				{string}	X-Cache	"HIT, MISS or BYPASS"
+//	@Header			200				{integer}	Age		"Seconds since the cached copy was stored"
+//	@Failure		400				{object}	dto.HTTPErrorResp
+//	@Failure		403				{object}	dto.HTTPErrorResp
+//	@Failure		500				{object}	dto.HTTPErrorResp
+//	@Failure		504				{object}	dto.HTTPErrorResp
 //	@Router			/users/{user_id}/cars [get]
 func (e *rest) ListCarsByUser(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -194,13 +416,22 @@ func (e *rest) ListCarsByUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	cars, err := e.svc.Car.ListCarsByUser(ctx, userID)
+	fields, err := parseFields[entity.Car](util.DecodeURL[dto.FieldSelection](r.URL.Query()))
 	if err != nil {
 		e.httpRespError(w, r, err)
 		return
 	}
 
-	e.httpRespSuccess(w, r, http.StatusOK, cars, nil)
+	cacheControl := parseCacheControl(r)
+
+	cars, err := e.svc.Car.ListCarsByUser(ctx, cacheControl, userID, fields)
+	setCacheHeaders(w, cacheControl)
+	if err != nil {
+		e.httpRespError(w, r, err)
+		return
+	}
+
+	e.httpRespSuccess(w, r, http.StatusOK, util.Project(cars, fields), nil)
 }
 
 // CountCarsByUser godoc
@@ -253,12 +484,17 @@ func (e *rest) CountCarsByUser(w http.ResponseWriter, r *http.Request) {
 //	@Tags			cars
 //	@Accept			json
 //	@Produce		json
-//	@Param			id	path		string					true	"Car ID"
-//	@Param			car	body		dto.UpdateCarRequest	true	"Car data"
-//	@Success		200	{object}	dto.HttpSuccessResp{data=entity.Car}
-//	@Failure		400	{object}	dto.HTTPErrorResp
-//	@Failure		404	{object}	dto.HTTPErrorResp
-//	@Failure		500	{object}	dto.HTTPErrorResp
+//	@Param			id					path		string					true	"Car ID"
+//	@Param			car					body		dto.UpdateCarRequest	true	"Car data"
+//	@Param			If-Match			header		string					false	"ETag the change is based on"
+//	@Param			If-Unmodified-Since	header		string					false	"Last-Modified the change is based on"
+//	@Success		200					{object}	dto.HttpSuccessResp{data=entity.Car}
+//	@Header			200					{string}	ETag	"Entity tag of the updated car"
+//	@Failure		400					{object}	dto.HTTPErrorResp
+//	@Failure		404					{object}	dto.HTTPErrorResp
+//	@Failure		409					{object}	dto.HTTPErrorResp{data=entity.Car}
+//	@Failure		412					{object}	dto.HTTPErrorResp
+//	@Failure		500					{object}	dto.HTTPErrorResp
 //	@Router			/cars/{id} [put]
 func (e *rest) UpdateCar(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -289,27 +525,52 @@ func (e *rest) UpdateCar(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	version, err := e.checkCarPreconditions(r, id)
+	if err != nil {
+		e.httpRespError(w, r, err)
+		return
+	}
+
+	// Bind a passed precondition to the version it was checked against, so a
+	// write landing in between still fails with 409.
+	if req.Version == nil {
+		req.Version = version
+	}
+
 	car, err := e.svc.Car.UpdateCar(ctx, id, &req, authUser.UserID)
 	if err != nil {
 		e.httpRespError(w, r, err)
 		return
 	}
 
+	setLastModified(w, car.UpdatedAt)
 	e.httpRespSuccess(w, r, http.StatusOK, car, nil)
 }
 
-// DeleteCar godoc
+// PatchCar godoc
 //
-//	@Summary		Delete car
-//	@Description	Delete a car by ID
+//	@Summary		Patch car
+//	@Description	Partially update a car with a JSON Merge Patch (RFC 7396) or a JSON Patch (RFC 6902). The patch applies to the fields of dto.CarPatch and the result is validated as a whole, so a null color clears it.
 //	@Tags			cars
+//	@Accept			application/merge-patch+json
+//	@Accept			application/json-patch+json
 //	@Produce		json
-//	@Param			id	path		string	true	"Car ID"
-//	@Success		200	{object}	dto.HttpSuccessResp
-//	@Failure		404	{object}	dto.HTTPErrorResp
-//	@Failure		500	{object}	dto.HTTPErrorResp
-//	@Router			/cars/{id} [delete]
-func (e *rest) DeleteCar(w http.ResponseWriter, r *http.Request) {
+//	@Param			id					path		string			true	"Car ID"
+//	@Param			patch				body		dto.CarPatch	true	"Merge patch object, or an array of JSON Patch operations"
+//	@Param			If-Match			header		string			false	"ETag the change is based on"
+//	@Param			If-Unmodified-Since	header		string			false	"Last-Modified the change is based on"
+//	@Success		200					{object}	dto.HttpSuccessResp{data=entity.Car}
+//	@Header			200					{string}	ETag	"Entity tag of the updated car"
+//	@Failure		400					{object}	dto.HTTPErrorResp
+//	@Failure		403					{object}	dto.HTTPErrorResp
+//	@Failure		404					{object}	dto.HTTPErrorResp
+//	@Failure		409					{object}	dto.HTTPErrorResp{data=entity.Car}
+//	@Failure		412					{object}	dto.HTTPErrorResp
+//	@Failure		415					{object}	dto.HTTPErrorResp
+//	@Failure		422					{object}	dto.HTTPErrorResp
+//	@Failure		500					{object}	dto.HTTPErrorResp
+//	@Router			/cars/{id} [patch]
+func (e *rest) PatchCar(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	authUser, ok := middleware.GetAuthUser(ctx)
@@ -325,29 +586,44 @@ func (e *rest) DeleteCar(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := e.svc.Car.DeleteCar(ctx, id, authUser.UserID); err != nil {
+	req, err := readPatch(r)
+	if err != nil {
 		e.httpRespError(w, r, err)
 		return
 	}
 
-	e.httpRespSuccess(w, r, http.StatusOK, nil, nil)
+	if req.Version, err = e.checkCarPreconditions(r, id); err != nil {
+		e.httpRespError(w, r, err)
+		return
+	}
+
+	car, err := e.svc.Car.PatchCar(ctx, id, req, authUser.UserID)
+	if err != nil {
+		e.httpRespError(w, r, err)
+		return
+	}
+
+	setLastModified(w, car.UpdatedAt)
+	e.httpRespSuccess(w, r, http.StatusOK, car, nil)
 }
 
-// TransferCarOwnership godoc
+// DeleteCar godoc
 //
-//	@Summary		Transfer car ownership
-//	@Description	Transfer a car to a new owner
+//	@Summary		Delete car
+//	@Description	Soft-delete a car by ID. The car is hidden from every read and can be restored by an admin until the purge job removes it.
 //	@Tags			cars
-//	@Accept			json
 //	@Produce		json
-//	@Param			id		path		string					true	"Car ID"
-//	@Param			request	body		dto.TransferCarRequest	true	"New owner ID"
-//	@Success		200		{object}	dto.HttpSuccessResp
-//	@Failure		400		{object}	dto.HTTPErrorResp
-//	@Failure		404		{object}	dto.HTTPErrorResp
-//	@Failure		500		{object}	dto.HTTPErrorResp
-//	@Router			/cars/{id}/transfer [post]
-func (e *rest) TransferCarOwnership(w http.ResponseWriter, r *http.Request) {
+//	@Param			id					path		string	true	"Car ID"
+//	@Param			reason				query		string	false	"Why the car is deleted, kept in its ownership history"
+//	@Param			If-Match			header		string	false	"ETag the change is based on"
+//	@Param			If-Unmodified-Since	header		string	false	"Last-Modified the change is based on"
+//	@Success		200					{object}	dto.HttpSuccessResp
+//	@Failure		400					{object}	dto.HTTPErrorResp
+//	@Failure		404					{object}	dto.HTTPErrorResp
+//	@Failure		412					{object}	dto.HTTPErrorResp
+//	@Failure		500					{object}	dto.HTTPErrorResp
+//	@Router			/cars/{id} [delete]
+func (e *rest) DeleteCar(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	authUser, ok := middleware.GetAuthUser(ctx)
@@ -356,21 +632,25 @@ func (e *rest) TransferCarOwnership(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	carID, err := uuid.Parse(r.PathValue("id"))
+	id, err := uuid.Parse(r.PathValue("id"))
 	if err != nil {
 		zerolog.Ctx(ctx).Error().Err(err).Msg("invalid_car_id")
 		e.httpRespError(w, r, appErr.WrapWithCode(err, appErr.CodeHTTPBadRequest, "invalid_car_id"))
 		return
 	}
 
-	var req dto.TransferCarRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		zerolog.Ctx(ctx).Error().Err(err).Msg("invalid_request_body")
-		e.httpRespError(w, r, appErr.WrapWithCode(err, appErr.CodeHTTPUnmarshal, "invalid_request_body"))
+	reason := strings.TrimSpace(r.URL.Query().Get("reason"))
+	if utf8.RuneCountInString(reason) > maxOwnershipReasonLength {
+		e.httpRespError(w, r, appErr.NewWithCode(appErr.CodeHTTPBadRequest, "invalid_reason"))
 		return
 	}
 
-	if err := e.svc.Car.TransferCarOwnership(ctx, carID, req.NewUserID, authUser.UserID); err != nil {
+	if _, err := e.checkCarPreconditions(r, id); err != nil {
+		e.httpRespError(w, r, err)
+		return
+	}
+
+	if err := e.svc.Car.DeleteCar(ctx, id, authUser.UserID, reason); err != nil {
 		e.httpRespError(w, r, err)
 		return
 	}
@@ -378,19 +658,71 @@ func (e *rest) TransferCarOwnership(w http.ResponseWriter, r *http.Request) {
 	e.httpRespSuccess(w, r, http.StatusOK, nil, nil)
 }
 
+// RestoreCar godoc
+//
+//	@Summary		Restore car
+//	@Description	Undo the soft delete of a car (admin only)
+//	@Tags			cars
+//	@Produce		json
+//	@Param			id	path		string	true	"Car ID"
+//	@Success		200	{object}	dto.HttpSuccessResp{data=entity.Car}
+//	@Failure		400	{object}	dto.HTTPErrorResp
+//	@Failure		403	{object}	dto.HTTPErrorResp
+//	@Failure		404	{object}	dto.HTTPErrorResp
+//	@Failure		500	{object}	dto.HTTPErrorResp
+//	@Router			/cars/{id}/restore [post]
+func (e *rest) RestoreCar(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if !e.requireAdmin(w, r) {
+		return
+	}
+
+	authUser, _ := middleware.GetAuthUser(ctx)
+
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("invalid_car_id")
+		e.httpRespError(w, r, appErr.WrapWithCode(err, appErr.CodeHTTPBadRequest, "invalid_car_id"))
+		return
+	}
+
+	car, err := e.svc.Car.RestoreCar(ctx, id, authUser.UserID)
+	if err != nil {
+		e.httpRespError(w, r, err)
+		return
+	}
+
+	e.httpRespSuccess(w, r, http.StatusOK, car, nil)
+}
+
 // BulkUpdateAvailability godoc
 //
-//	@Summary		Bulk update car availability
-//	@Description	Update availability status for multiple cars
+//	@Summary		Bulk update car availability (removed)
+//	@Description	Availability is derived from reservations and can no longer be set. Reserve the car or cancel its reservation instead.
 //	@Tags			cars
-//	@Accept			json
 //	@Produce		json
-//	@Param			request	body		dto.BulkUpdateAvailabilityRequest	true	"Car IDs and availability status"
-//	@Success		200		{object}	dto.HttpSuccessResp
-//	@Failure		400		{object}	dto.HTTPErrorResp
-//	@Failure		500		{object}	dto.HTTPErrorResp
+//	@Failure		410	{object}	dto.HTTPErrorResp
 //	@Router			/cars/availability [put]
 func (e *rest) BulkUpdateAvailability(w http.ResponseWriter, r *http.Request) {
+	e.httpRespError(w, r, appErr.NewWithCode(appErr.CodeHTTPGone, "availability is derived from reservations, use POST /cars/{id}/reservations instead"))
+}
+
+// GetCarHistory godoc
+//
+//	@Summary		Get car ownership history
+//	@Description	List every creation, transfer, deletion and restore of a car, oldest first, with the acting user and reason (owner or admin only)
+//	@Tags			cars
+//	@Produce		json
+//	@Param			id	path		string	true	"Car ID"
+//	@Success		200	{object}	dto.HttpSuccessResp{data=[]entity.CarOwnershipRecord}
+//	@Failure		400	{object}	dto.HTTPErrorResp
+//	@Failure		401	{object}	dto.HTTPErrorResp
+//	@Failure		403	{object}	dto.HTTPErrorResp
+//	@Failure		404	{object}	dto.HTTPErrorResp
+//	@Failure		500	{object}	dto.HTTPErrorResp
+//	@Router			/cars/{id}/history [get]
+func (e *rest) GetCarHistory(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	authUser, ok := middleware.GetAuthUser(ctx)
@@ -399,17 +731,40 @@ func (e *rest) BulkUpdateAvailability(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var req dto.BulkUpdateAvailabilityRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		zerolog.Ctx(ctx).Error().Err(err).Msg("invalid_request_body")
-		e.httpRespError(w, r, appErr.WrapWithCode(err, appErr.CodeHTTPUnmarshal, "invalid_request_body"))
+	carID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("invalid_car_id")
+		e.httpRespError(w, r, appErr.WrapWithCode(err, appErr.CodeHTTPBadRequest, "invalid_car_id"))
 		return
 	}
 
-	if err := e.svc.Car.BulkUpdateAvailability(ctx, req, authUser.UserID); err != nil {
+	isAdmin := authUser.Role == string(entity.RoleAdmin)
+
+	history, err := e.svc.Car.GetCarHistory(ctx, carID, authUser.UserID, isAdmin)
+	if err != nil {
 		e.httpRespError(w, r, err)
 		return
 	}
 
-	e.httpRespSuccess(w, r, http.StatusOK, nil, nil)
+	e.httpRespSuccess(w, r, http.StatusOK, history, nil)
+}
+
+// checkCarPreconditions enforces If-Match and If-Unmodified-Since against the
+// car as currently stored, bypassing the cache. It returns the version the
+// preconditions held for, or nil when none were sent.
+func (e *rest) checkCarPreconditions(r *http.Request, id uuid.UUID) (*int64, error) {
+	if !hasPreconditions(r) {
+		return nil, nil
+	}
+
+	current, err := e.svc.Car.GetCar(r.Context(), dto.CacheControl{MustDbValidate: true}, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkPreconditions(r, current, current.UpdatedAt); err != nil {
+		return nil, err
+	}
+
+	return &current.Version, nil
 }
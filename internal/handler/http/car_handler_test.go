@@ -0,0 +1,144 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"go-far/internal/model/dto"
+	"go-far/internal/model/entity"
+	"go-far/internal/service"
+	"go-far/internal/service/car"
+
+	"github.com/google/uuid"
+)
+
+type fakeCarService struct {
+	car.CarServiceItf
+	columns []string
+
+	exportFilter *dto.CarFilterV2
+	exportCars   []entity.Car
+	exportErr    error
+}
+
+func (f *fakeCarService) GetCarWithOwner(_ context.Context, _ dto.CacheControl, id uuid.UUID, columns []string) (*entity.CarWithOwner, error) {
+	f.columns = columns
+
+	return testCarWithOwner(id), nil
+}
+
+func testCarWithOwner(id uuid.UUID) *entity.CarWithOwner {
+	return &entity.CarWithOwner{
+		OwnerName:  "Ann",
+		OwnerEmail: "ann@example.com",
+		Car:        entity.Car{ID: id.String(), Brand: "VW", Model: "Golf"},
+	}
+}
+
+// jsonKeys returns the sorted top-level keys v is encoded with.
+func jsonKeys(t *testing.T, v any) []string {
+	t.Helper()
+
+	raw, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var m map[string]any
+	if err := json.Unmarshal(raw, &m); err != nil {
+		t.Fatal(err)
+	}
+
+	return slices.Sorted(maps.Keys(m))
+}
+
+// TestGetCarIncludeOwnerFields checks that ?fields= selects car columns only
+// and that ?include=owner still embeds the owner, as ?include=cars does.
+func TestGetCarIncludeOwnerFields(t *testing.T) {
+	id := uuid.MustParse("0190a3b4-0000-7000-8000-000000000000")
+
+	tests := []struct {
+		name        string
+		query       string
+		status      int
+		wantColumns []string
+		wantKeys    []string
+	}{
+		{
+			name:        "car fields keep the owner",
+			query:       "include=owner&fields=id,brand",
+			status:      http.StatusOK,
+			wantColumns: []string{"id", "brand", "owner_name", "owner_email"},
+			wantKeys:    []string{"brand", "id", "owner_email", "owner_name"},
+		},
+		{
+			name:     "no fields returns everything",
+			query:    "include=owner",
+			status:   http.StatusOK,
+			wantKeys: jsonKeys(t, testCarWithOwner(id)),
+		},
+		{
+			name:   "owner columns are not car fields",
+			query:  "include=owner&fields=id,owner_name",
+			status: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &fakeCarService{}
+			e := &rest{svc: &service.Service{Car: fake}}
+
+			req := httptest.NewRequest(http.MethodGet, "/cars/"+id.String()+"?"+tt.query, nil)
+			req.SetPathValue("id", id.String())
+			rec := httptest.NewRecorder()
+
+			e.GetCar(rec, req)
+
+			if rec.Code != tt.status {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.status, rec.Body.String())
+			}
+
+			if tt.status != http.StatusOK {
+				return
+			}
+
+			if !reflect.DeepEqual(fake.columns, tt.wantColumns) {
+				t.Errorf("service columns = %v, want %v", fake.columns, tt.wantColumns)
+			}
+
+			var resp struct {
+				Data json.RawMessage `json:"data"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("decode response: %v", err)
+			}
+
+			if got := jsonKeys(t, resp.Data); !reflect.DeepEqual(got, tt.wantKeys) {
+				t.Errorf("data keys = %v, want %v", got, tt.wantKeys)
+			}
+		})
+	}
+}
+
+// TestBulkUpdateAvailabilityGone checks that the removed bulk availability
+// update answers 410 Gone.
+func TestBulkUpdateAvailabilityGone(t *testing.T) {
+	e := &rest{svc: &service.Service{Car: &fakeCarService{}}}
+
+	req := httptest.NewRequest(http.MethodPut, "/cars/availability", strings.NewReader(`{"car_ids":[],"is_available":true}`))
+	rec := httptest.NewRecorder()
+
+	e.BulkUpdateAvailability(rec, req)
+
+	if rec.Code != http.StatusGone {
+		t.Errorf("status = %d, want %d: %s", rec.Code, http.StatusGone, rec.Body.String())
+	}
+}
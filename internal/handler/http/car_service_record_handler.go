@@ -0,0 +1,269 @@
+package rest
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"go-far/internal/infra/middleware"
+	"go-far/internal/infra/validator"
+	"go-far/internal/model/dto"
+	appErr "go-far/internal/model/errors"
+	"go-far/internal/util"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+// CreateServiceRecord godoc
+//
+//	@Summary		Log car service
+//	@Description	Add an entry to a car's maintenance log (owner or co-owner only). Only the date part of service_date is kept; future dates are rejected.
+//	@Tags			services
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string						true	"Car ID"
+//	@Param			request	body		dto.ServiceRecordRequest	true	"Service details"
+//	@Success		201		{object}	dto.HttpSuccessResp{data=entity.ServiceRecord}
+//	@Failure		400		{object}	dto.HTTPErrorResp
+//	@Failure		401		{object}	dto.HTTPErrorResp
+//	@Failure		403		{object}	dto.HTTPErrorResp
+//	@Failure		404		{object}	dto.HTTPErrorResp
+//	@Failure		500		{object}	dto.HTTPErrorResp
+//	@Router			/cars/{id}/services [post]
+func (e *rest) CreateServiceRecord(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	authUser, ok := middleware.GetAuthUser(ctx)
+	if !ok {
+		e.httpRespError(w, r, appErr.NewWithCode(appErr.CodeHTTPUnauthorized, "unauthenticated"))
+		return
+	}
+
+	carID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("invalid_car_id")
+		e.httpRespError(w, r, appErr.WrapWithCode(err, appErr.CodeHTTPBadRequest, "invalid_car_id"))
+		return
+	}
+
+	var req dto.ServiceRecordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("invalid_request_body")
+		e.httpRespError(w, r, appErr.WrapWithCode(err, appErr.CodeHTTPUnmarshal, "invalid_request_body"))
+		return
+	}
+
+	if err := validator.ValidateRequest(&req); err != nil {
+		zerolog.Ctx(ctx).Warn().Err(err).Msg("validation_failed_create_service_record")
+		e.httpRespError(w, r, err)
+		return
+	}
+
+	record, err := e.svc.ServiceRecord.CreateServiceRecord(ctx, carID, req, authUser.UserID)
+	if err != nil {
+		e.httpRespError(w, r, err)
+		return
+	}
+
+	e.httpRespSuccess(w, r, http.StatusCreated, record, nil)
+}
+
+// ListServiceRecords godoc
+//
+//	@Summary		List car services
+//	@Description	List a car's maintenance log, newest first (owner or co-owner only)
+//	@Tags			services
+//	@Produce		json
+//	@Param			id			path		string	true	"Car ID"
+//	@Param			type		query		string	false	"Service type"	Enums(oil_change, tire_rotation, brakes, battery, inspection, repair, other)
+//	@Param			from		query		string	false	"Earliest service date (RFC 3339, inclusive)"
+//	@Param			to			query		string	false	"Latest service date (RFC 3339, inclusive)"
+//	@Param			page		query		int		false	"Page number"	default(1)
+//	@Param			page_size	query		int		false	"Page size"		default(10)
+//	@Success		200			{object}	dto.HttpSuccessResp{data=[]entity.ServiceRecord}
+//	@Failure		400			{object}	dto.HTTPErrorResp
+//	@Failure		401			{object}	dto.HTTPErrorResp
+//	@Failure		403			{object}	dto.HTTPErrorResp
+//	@Failure		500			{object}	dto.HTTPErrorResp
+//	@Router			/cars/{id}/services [get]
+func (e *rest) ListServiceRecords(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	authUser, ok := middleware.GetAuthUser(ctx)
+	if !ok {
+		e.httpRespError(w, r, appErr.NewWithCode(appErr.CodeHTTPUnauthorized, "unauthenticated"))
+		return
+	}
+
+	carID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("invalid_car_id")
+		e.httpRespError(w, r, appErr.WrapWithCode(err, appErr.CodeHTTPBadRequest, "invalid_car_id"))
+		return
+	}
+
+	filter := util.DecodeURL[dto.ServiceRecordFilter](r.URL.Query())
+	if err := validator.ValidateRequest(&filter); err != nil {
+		zerolog.Ctx(ctx).Warn().Err(err).Msg("validation_failed_list_service_records")
+		e.httpRespError(w, r, err)
+		return
+	}
+
+	records, pagination, err := e.svc.ServiceRecord.ListServiceRecords(ctx, carID, &filter, authUser.UserID)
+	if err != nil {
+		e.httpRespError(w, r, err)
+		return
+	}
+
+	e.httpRespSuccess(w, r, http.StatusOK, records, pagination)
+}
+
+// GetServiceRecord godoc
+//
+//	@Summary		Get car service
+//	@Description	Get one entry of a car's maintenance log (owner or co-owner only)
+//	@Tags			services
+//	@Produce		json
+//	@Param			id			path		string	true	"Car ID"
+//	@Param			service_id	path		string	true	"Service record ID"
+//	@Success		200			{object}	dto.HttpSuccessResp{data=entity.ServiceRecord}
+//	@Failure		400			{object}	dto.HTTPErrorResp
+//	@Failure		401			{object}	dto.HTTPErrorResp
+//	@Failure		403			{object}	dto.HTTPErrorResp
+//	@Failure		404			{object}	dto.HTTPErrorResp
+//	@Failure		500			{object}	dto.HTTPErrorResp
+//	@Router			/cars/{id}/services/{service_id} [get]
+func (e *rest) GetServiceRecord(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	authUser, ok := middleware.GetAuthUser(ctx)
+	if !ok {
+		e.httpRespError(w, r, appErr.NewWithCode(appErr.CodeHTTPUnauthorized, "unauthenticated"))
+		return
+	}
+
+	carID, id, ok := e.parseServiceRecordPath(w, r)
+	if !ok {
+		return
+	}
+
+	record, err := e.svc.ServiceRecord.GetServiceRecord(ctx, carID, id, authUser.UserID)
+	if err != nil {
+		e.httpRespError(w, r, err)
+		return
+	}
+
+	e.httpRespSuccess(w, r, http.StatusOK, record, nil)
+}
+
+// UpdateServiceRecord godoc
+//
+//	@Summary		Update car service
+//	@Description	Replace an entry of a car's maintenance log (owner or co-owner only)
+//	@Tags			services
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		string						true	"Car ID"
+//	@Param			service_id	path		string						true	"Service record ID"
+//	@Param			request		body		dto.ServiceRecordRequest	true	"Service details"
+//	@Success		200			{object}	dto.HttpSuccessResp{data=entity.ServiceRecord}
+//	@Failure		400			{object}	dto.HTTPErrorResp
+//	@Failure		401			{object}	dto.HTTPErrorResp
+//	@Failure		403			{object}	dto.HTTPErrorResp
+//	@Failure		404			{object}	dto.HTTPErrorResp
+//	@Failure		500			{object}	dto.HTTPErrorResp
+//	@Router			/cars/{id}/services/{service_id} [put]
+func (e *rest) UpdateServiceRecord(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	authUser, ok := middleware.GetAuthUser(ctx)
+	if !ok {
+		e.httpRespError(w, r, appErr.NewWithCode(appErr.CodeHTTPUnauthorized, "unauthenticated"))
+		return
+	}
+
+	carID, id, ok := e.parseServiceRecordPath(w, r)
+	if !ok {
+		return
+	}
+
+	var req dto.ServiceRecordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("invalid_request_body")
+		e.httpRespError(w, r, appErr.WrapWithCode(err, appErr.CodeHTTPUnmarshal, "invalid_request_body"))
+		return
+	}
+
+	if err := validator.ValidateRequest(&req); err != nil {
+		zerolog.Ctx(ctx).Warn().Err(err).Msg("validation_failed_update_service_record")
+		e.httpRespError(w, r, err)
+		return
+	}
+
+	record, err := e.svc.ServiceRecord.UpdateServiceRecord(ctx, carID, id, req, authUser.UserID)
+	if err != nil {
+		e.httpRespError(w, r, err)
+		return
+	}
+
+	e.httpRespSuccess(w, r, http.StatusOK, record, nil)
+}
+
+// DeleteServiceRecord godoc
+//
+//	@Summary		Delete car service
+//	@Description	Remove an entry from a car's maintenance log (owner or co-owner only)
+//	@Tags			services
+//	@Produce		json
+//	@Param			id			path		string	true	"Car ID"
+//	@Param			service_id	path		string	true	"Service record ID"
+//	@Success		200			{object}	dto.HttpSuccessResp
+//	@Failure		400			{object}	dto.HTTPErrorResp
+//	@Failure		401			{object}	dto.HTTPErrorResp
+//	@Failure		403			{object}	dto.HTTPErrorResp
+//	@Failure		404			{object}	dto.HTTPErrorResp
+//	@Failure		500			{object}	dto.HTTPErrorResp
+//	@Router			/cars/{id}/services/{service_id} [delete]
+func (e *rest) DeleteServiceRecord(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	authUser, ok := middleware.GetAuthUser(ctx)
+	if !ok {
+		e.httpRespError(w, r, appErr.NewWithCode(appErr.CodeHTTPUnauthorized, "unauthenticated"))
+		return
+	}
+
+	carID, id, ok := e.parseServiceRecordPath(w, r)
+	if !ok {
+		return
+	}
+
+	if err := e.svc.ServiceRecord.DeleteServiceRecord(ctx, carID, id, authUser.UserID); err != nil {
+		e.httpRespError(w, r, err)
+		return
+	}
+
+	e.httpRespSuccess(w, r, http.StatusOK, nil, nil)
+}
+
+// parseServiceRecordPath reads the car and service record IDs of the path,
+// writing a 400 response when either is malformed.
+func (e *rest) parseServiceRecordPath(w http.ResponseWriter, r *http.Request) (uuid.UUID, uuid.UUID, bool) {
+	ctx := r.Context()
+
+	carID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("invalid_car_id")
+		e.httpRespError(w, r, appErr.WrapWithCode(err, appErr.CodeHTTPBadRequest, "invalid_car_id"))
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	id, err := uuid.Parse(r.PathValue("service_id"))
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("invalid_service_record_id")
+		e.httpRespError(w, r, appErr.WrapWithCode(err, appErr.CodeHTTPBadRequest, "invalid_service_record_id"))
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return carID, id, true
+}
@@ -0,0 +1,164 @@
+package rest
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"go-far/internal/infra/middleware"
+	"go-far/internal/infra/validator"
+	"go-far/internal/model/dto"
+	"go-far/internal/model/entity"
+	appErr "go-far/internal/model/errors"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+// ListCarShares godoc
+//
+//	@Summary		List car shares
+//	@Description	List every user with access to a car and their role, owner first (owner or admin only)
+//	@Tags			cars
+//	@Produce		json
+//	@Param			id	path		string	true	"Car ID"
+//	@Success		200	{object}	dto.HttpSuccessResp{data=[]entity.CarShare}
+//	@Failure		400	{object}	dto.HTTPErrorResp
+//	@Failure		401	{object}	dto.HTTPErrorResp
+//	@Failure		403	{object}	dto.HTTPErrorResp
+//	@Failure		404	{object}	dto.HTTPErrorResp
+//	@Failure		500	{object}	dto.HTTPErrorResp
+//	@Router			/cars/{id}/shares [get]
+func (e *rest) ListCarShares(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	authUser, ok := middleware.GetAuthUser(ctx)
+	if !ok {
+		e.httpRespError(w, r, appErr.NewWithCode(appErr.CodeHTTPUnauthorized, "unauthenticated"))
+		return
+	}
+
+	carID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("invalid_car_id")
+		e.httpRespError(w, r, appErr.WrapWithCode(err, appErr.CodeHTTPBadRequest, "invalid_car_id"))
+		return
+	}
+
+	isAdmin := authUser.Role == string(entity.RoleAdmin)
+
+	shares, err := e.svc.Car.ListCarShares(ctx, carID, authUser.UserID, isAdmin)
+	if err != nil {
+		e.httpRespError(w, r, err)
+		return
+	}
+
+	e.httpRespSuccess(w, r, http.StatusOK, shares, nil)
+}
+
+// ShareCar godoc
+//
+//	@Summary		Share car
+//	@Description	Give a user a co-owner, driver or viewer role on a car, or change the role they have (owner only). Co-owners may edit the car; drivers and viewers only see it.
+//	@Tags			cars
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string				true	"Car ID"
+//	@Param			user_id	path		string				true	"User ID"
+//	@Param			request	body		dto.ShareCarRequest	true	"Role"
+//	@Success		200		{object}	dto.HttpSuccessResp{data=entity.CarShare}
+//	@Failure		400		{object}	dto.HTTPErrorResp
+//	@Failure		401		{object}	dto.HTTPErrorResp
+//	@Failure		403		{object}	dto.HTTPErrorResp
+//	@Failure		404		{object}	dto.HTTPErrorResp
+//	@Failure		500		{object}	dto.HTTPErrorResp
+//	@Router			/cars/{id}/shares/{user_id} [put]
+func (e *rest) ShareCar(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	authUser, ok := middleware.GetAuthUser(ctx)
+	if !ok {
+		e.httpRespError(w, r, appErr.NewWithCode(appErr.CodeHTTPUnauthorized, "unauthenticated"))
+		return
+	}
+
+	carID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("invalid_car_id")
+		e.httpRespError(w, r, appErr.WrapWithCode(err, appErr.CodeHTTPBadRequest, "invalid_car_id"))
+		return
+	}
+
+	targetUserID, err := uuid.Parse(r.PathValue("user_id"))
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("invalid_user_id")
+		e.httpRespError(w, r, appErr.WrapWithCode(err, appErr.CodeHTTPBadRequest, "invalid_user_id"))
+		return
+	}
+
+	var req dto.ShareCarRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("invalid_request_body")
+		e.httpRespError(w, r, appErr.WrapWithCode(err, appErr.CodeHTTPUnmarshal, "invalid_request_body"))
+		return
+	}
+
+	if err := validator.ValidateRequest(&req); err != nil {
+		zerolog.Ctx(ctx).Warn().Err(err).Msg("validation_failed_share_car")
+		e.httpRespError(w, r, err)
+		return
+	}
+
+	share, err := e.svc.Car.ShareCar(ctx, carID, targetUserID, req, authUser.UserID)
+	if err != nil {
+		e.httpRespError(w, r, err)
+		return
+	}
+
+	e.httpRespSuccess(w, r, http.StatusOK, share, nil)
+}
+
+// UnshareCar godoc
+//
+//	@Summary		Unshare car
+//	@Description	Revoke a user's access to a car. The owner may revoke any share; other users may only give up their own.
+//	@Tags			cars
+//	@Produce		json
+//	@Param			id		path		string	true	"Car ID"
+//	@Param			user_id	path		string	true	"User ID"
+//	@Success		200		{object}	dto.HttpSuccessResp
+//	@Failure		400		{object}	dto.HTTPErrorResp
+//	@Failure		401		{object}	dto.HTTPErrorResp
+//	@Failure		403		{object}	dto.HTTPErrorResp
+//	@Failure		404		{object}	dto.HTTPErrorResp
+//	@Failure		500		{object}	dto.HTTPErrorResp
+//	@Router			/cars/{id}/shares/{user_id} [delete]
+func (e *rest) UnshareCar(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	authUser, ok := middleware.GetAuthUser(ctx)
+	if !ok {
+		e.httpRespError(w, r, appErr.NewWithCode(appErr.CodeHTTPUnauthorized, "unauthenticated"))
+		return
+	}
+
+	carID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("invalid_car_id")
+		e.httpRespError(w, r, appErr.WrapWithCode(err, appErr.CodeHTTPBadRequest, "invalid_car_id"))
+		return
+	}
+
+	targetUserID, err := uuid.Parse(r.PathValue("user_id"))
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("invalid_user_id")
+		e.httpRespError(w, r, appErr.WrapWithCode(err, appErr.CodeHTTPBadRequest, "invalid_user_id"))
+		return
+	}
+
+	if err := e.svc.Car.UnshareCar(ctx, carID, targetUserID, authUser.UserID); err != nil {
+		e.httpRespError(w, r, err)
+		return
+	}
+
+	e.httpRespSuccess(w, r, http.StatusOK, nil, nil)
+}
@@ -0,0 +1,277 @@
+package rest
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+
+	"go-far/internal/infra/middleware"
+	"go-far/internal/model/dto"
+	"go-far/internal/model/entity"
+	appErr "go-far/internal/model/errors"
+	"go-far/internal/preference"
+	"go-far/internal/util"
+
+	"github.com/rs/zerolog"
+)
+
+const (
+	// exportFlushEvery is the number of rows written between flushes.
+	exportFlushEvery = 500
+	// exportWriteWindow is how far the write deadline is pushed on each flush,
+	// so long exports are not cut off by the server's write timeout.
+	exportWriteWindow = 30 * time.Second
+)
+
+// ExportUsers godoc
+//
+//	@Summary		Export users
+//	@Description	Stream every user matching the V2 filters as CSV or NDJSON, chosen by the Accept header (admin only)
+//	@Tags			users
+//	@Produce		text/csv
+//	@Produce		application/x-ndjson
+//	@Param			id				query		string	false	"Filter by ID"
+//	@Param			name			query		string	false	"Filter by name (use % for LIKE)"
+//	@Param			email			query		string	false	"Filter by email (use % for LIKE)"
+//	@Param			min_age__gte	query		int		false	"Minimum age"
+//	@Param			max_age__lte	query		int		false	"Maximum age"
+//	@Success		200				{array}		entity.User
+//	@Failure		403				{object}	dto.HTTPErrorResp
+//	@Failure		406				{object}	dto.HTTPErrorResp
+//	@Failure		429				{object}	dto.HTTPErrorResp
+//	@Router			/users/export [get]
+func (e *rest) ExportUsers(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if !e.requireAdmin(w, r) {
+		return
+	}
+
+	contentType, err := negotiateExportFormat(r)
+	if err != nil {
+		e.httpRespError(w, r, err)
+		return
+	}
+
+	filter := util.DecodeURL[dto.UserFilterV2](r.URL.Query())
+	stream := newExportStream[entity.User](w, contentType, "users")
+
+	err = e.svc.User.ExportUsers(ctx, &filter, stream.write)
+	if err == nil {
+		err = stream.close()
+	}
+
+	e.finishExport(w, r, stream.started, err)
+}
+
+// ExportCars godoc
+//
+//	@Summary		Export cars
+//	@Description	Stream every car matching the V2 filters as CSV or NDJSON, chosen by the Accept header (admin only)
+//	@Tags			cars
+//	@Produce		text/csv
+//	@Produce		application/x-ndjson
+//	@Param			id				query		string	false	"Filter by ID"
+//	@Param			brand			query		string	false	"Filter by brand (use % for LIKE)"
+//	@Param			model			query		string	false	"Filter by model (use % for LIKE)"
+//	@Param			color			query		string	false	"Filter by color"
+//	@Param			license_plate	query		string	false	"Filter by license plate"
+//	@Param			vin				query		string	false	"Filter by VIN"
+//	@Param			min_year__gte	query		int		false	"Minimum year"
+//	@Param			max_year__lte	query		int		false	"Maximum year"
+//	@Param			is_available	query		bool	false	"Filter by availability"
+//	@Success		200				{array}		entity.Car
+//	@Failure		403				{object}	dto.HTTPErrorResp
+//	@Failure		406				{object}	dto.HTTPErrorResp
+//	@Failure		429				{object}	dto.HTTPErrorResp
+//	@Router			/cars/export [get]
+func (e *rest) ExportCars(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if !e.requireAdmin(w, r) {
+		return
+	}
+
+	contentType, err := negotiateExportFormat(r)
+	if err != nil {
+		e.httpRespError(w, r, err)
+		return
+	}
+
+	filter := util.DecodeURL[dto.CarFilterV2](r.URL.Query())
+	stream := newExportStream[entity.Car](w, contentType, "cars")
+
+	err = e.svc.Car.ExportCars(ctx, &filter, stream.write)
+	if err == nil {
+		err = stream.close()
+	}
+
+	e.finishExport(w, r, stream.started, err)
+}
+
+func (e *rest) requireAdmin(w http.ResponseWriter, r *http.Request) bool {
+	authUser, ok := middleware.GetAuthUser(r.Context())
+	if !ok {
+		e.httpRespError(w, r, appErr.NewWithCode(appErr.CodeHTTPUnauthorized, "unauthenticated"))
+		return false
+	}
+
+	if authUser.Role != string(entity.RoleAdmin) {
+		e.httpRespError(w, r, appErr.NewWithCode(appErr.CodeHTTPForbidden, "admin_only"))
+		return false
+	}
+
+	return true
+}
+
+// finishExport reports err as a normal error response when nothing has been
+// streamed yet. Once rows are on the wire the status is already sent, so the
+// connection is aborted instead, letting clients detect a truncated export.
+func (e *rest) finishExport(w http.ResponseWriter, r *http.Request, started bool, err error) {
+	if err == nil {
+		return
+	}
+
+	if !started {
+		e.httpRespError(w, r, err)
+		return
+	}
+
+	zerolog.Ctx(r.Context()).Error().Err(err).Msg("export_stream_aborted")
+	panic(http.ErrAbortHandler)
+}
+
+// negotiateExportFormat picks CSV or NDJSON from the Accept header. A missing
+// header or a wildcard defaults to CSV.
+func negotiateExportFormat(r *http.Request) (string, error) {
+	accept := r.Header.Get(preference.HeaderAccept)
+	if accept == "" {
+		return preference.ContentTypeCSV, nil
+	}
+
+	for part := range strings.SplitSeq(accept, ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+
+		switch mediaType {
+		case preference.ContentTypeCSV, "text/*", "*/*":
+			return preference.ContentTypeCSV, nil
+		case preference.ContentTypeNDJSON, "application/ndjson", "application/jsonl":
+			return preference.ContentTypeNDJSON, nil
+		}
+	}
+
+	return "", appErr.NewWithCode(appErr.CodeHTTPNotAcceptable, "unsupported export format %q, use %s or %s", accept, preference.ContentTypeCSV, preference.ContentTypeNDJSON)
+}
+
+// exportStream writes rows as they arrive from the database cursor. Headers
+// are sent with the first row so earlier failures can still be reported as a
+// regular JSON error.
+type exportStream[T any] struct {
+	w           http.ResponseWriter
+	rc          *http.ResponseController
+	contentType string
+	filename    string
+	csv         *csv.Writer
+	ndjson      *json.Encoder
+	rows        int
+	started     bool
+}
+
+func newExportStream[T any](w http.ResponseWriter, contentType, name string) *exportStream[T] {
+	s := &exportStream[T]{
+		w:           w,
+		rc:          http.NewResponseController(w),
+		contentType: contentType,
+		filename:    name + "-" + time.Now().UTC().Format("20060102T150405Z"),
+	}
+
+	if contentType == preference.ContentTypeCSV {
+		s.csv = csv.NewWriter(w)
+		s.filename += ".csv"
+	} else {
+		s.ndjson = json.NewEncoder(w)
+		s.filename += ".ndjson"
+	}
+
+	return s
+}
+
+func (s *exportStream[T]) start() error {
+	s.started = true
+
+	h := s.w.Header()
+	h.Set(preference.HeaderContentType, s.contentType+"; charset=utf-8")
+	h.Set(preference.HeaderContentDisposition, `attachment; filename="`+s.filename+`"`)
+	s.w.WriteHeader(http.StatusOK)
+	s.extendDeadline()
+
+	if s.csv != nil {
+		return s.csv.Write(util.CSVHeader[T]())
+	}
+
+	return nil
+}
+
+func (s *exportStream[T]) write(row *T) error {
+	if !s.started {
+		if err := s.start(); err != nil {
+			return err
+		}
+	}
+
+	var err error
+	if s.csv != nil {
+		err = s.csv.Write(util.CSVRecord(row))
+	} else {
+		err = s.ndjson.Encode(row)
+	}
+	if err != nil {
+		return err
+	}
+
+	s.rows++
+	if s.rows%exportFlushEvery == 0 {
+		return s.flush()
+	}
+
+	return nil
+}
+
+// close sends the headers for an empty result and flushes buffered rows.
+func (s *exportStream[T]) close() error {
+	if !s.started {
+		if err := s.start(); err != nil {
+			return err
+		}
+	}
+
+	return s.flush()
+}
+
+func (s *exportStream[T]) flush() error {
+	if s.csv != nil {
+		s.csv.Flush()
+		if err := s.csv.Error(); err != nil {
+			return err
+		}
+	}
+
+	if err := s.rc.Flush(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return err
+	}
+
+	s.extendDeadline()
+
+	return nil
+}
+
+func (s *exportStream[T]) extendDeadline() {
+	_ = s.rc.SetWriteDeadline(time.Now().Add(exportWriteWindow))
+}
@@ -0,0 +1,194 @@
+package rest
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"go-far/internal/infra/middleware"
+	"go-far/internal/model/dto"
+	"go-far/internal/model/entity"
+	appErr "go-far/internal/model/errors"
+	"go-far/internal/preference"
+	"go-far/internal/service"
+	"go-far/internal/util"
+)
+
+func (f *fakeCarService) ExportCars(_ context.Context, filter *dto.CarFilterV2, fn func(*entity.Car) error) error {
+	f.exportFilter = filter
+
+	for i := range f.exportCars {
+		if err := fn(&f.exportCars[i]); err != nil {
+			return err
+		}
+	}
+
+	return f.exportErr
+}
+
+func exportRequest(query, accept, role string) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, "/cars/export?"+query, nil)
+	if accept != "" {
+		req.Header.Set(preference.HeaderAccept, accept)
+	}
+
+	ctx := middleware.WithAuthUser(req.Context(), &middleware.AuthUser{UserID: "admin-1", Role: role})
+
+	return req.WithContext(ctx)
+}
+
+// TestExportCarsStreams checks the negotiated format of a streamed export and
+// that the optional is_available filter distinguishes false from absent.
+func TestExportCarsStreams(t *testing.T) {
+	cars := []entity.Car{
+		{ID: "c1", Brand: "VW", Model: "Golf", Year: 2019, LicensePlate: "B-AB 123"},
+		{ID: "c2", Brand: "=HYPERLINK()", Model: "Polo", Year: 2021, LicensePlate: "B-CD 456", IsAvailable: true},
+	}
+	available := false
+
+	tests := []struct {
+		name          string
+		query         string
+		accept        string
+		contentType   string
+		wantAvailable *bool
+	}{
+		{name: "csv by default", query: "brand=VW", contentType: preference.ContentTypeCSV},
+		{name: "csv for wildcard", query: "is_available=false", accept: "*/*", contentType: preference.ContentTypeCSV, wantAvailable: &available},
+		{name: "ndjson", accept: preference.ContentTypeNDJSON, contentType: preference.ContentTypeNDJSON},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &fakeCarService{exportCars: cars}
+			e := &rest{svc: &service.Service{Car: fake}}
+			rec := httptest.NewRecorder()
+
+			e.ExportCars(rec, exportRequest(tt.query, tt.accept, string(entity.RoleAdmin)))
+
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status = %d, want 200: %s", rec.Code, rec.Body.String())
+			}
+
+			if got := rec.Header().Get(preference.HeaderContentType); !strings.HasPrefix(got, tt.contentType) {
+				t.Errorf("content type = %q, want %s", got, tt.contentType)
+			}
+
+			if !reflect.DeepEqual(fake.exportFilter.IsAvailable, tt.wantAvailable) {
+				t.Errorf("is_available filter = %v, want %v", fake.exportFilter.IsAvailable, tt.wantAvailable)
+			}
+
+			if tt.contentType == preference.ContentTypeCSV {
+				checkCSVExport(t, rec.Body.String(), cars)
+				return
+			}
+
+			lines := strings.Split(strings.TrimSpace(rec.Body.String()), "\n")
+			if len(lines) != len(cars) {
+				t.Fatalf("ndjson lines = %d, want %d", len(lines), len(cars))
+			}
+
+			for i, line := range lines {
+				var got entity.Car
+				if err := json.Unmarshal([]byte(line), &got); err != nil {
+					t.Fatalf("line %d: %v", i, err)
+				}
+				if got.ID != cars[i].ID {
+					t.Errorf("line %d id = %q, want %q", i, got.ID, cars[i].ID)
+				}
+			}
+		})
+	}
+}
+
+func checkCSVExport(t *testing.T, body string, cars []entity.Car) {
+	t.Helper()
+
+	records, err := csv.NewReader(strings.NewReader(body)).ReadAll()
+	if err != nil {
+		t.Fatalf("read csv: %v", err)
+	}
+
+	if len(records) != len(cars)+1 {
+		t.Fatalf("csv records = %d, want header and %d rows", len(records), len(cars))
+	}
+
+	if !reflect.DeepEqual(records[0], util.CSVHeader[entity.Car]()) {
+		t.Errorf("header = %v", records[0])
+	}
+
+	for i := range cars {
+		if want := util.CSVRecord(&cars[i]); !reflect.DeepEqual(records[i+1], want) {
+			t.Errorf("row %d = %v, want %v", i, records[i+1], want)
+		}
+	}
+}
+
+func TestExportCarsRejects(t *testing.T) {
+	tests := []struct {
+		name   string
+		accept string
+		role   string
+		status int
+	}{
+		{name: "non-admin", role: string(entity.RoleUser), status: http.StatusForbidden},
+		{name: "unsupported format", accept: "application/xml", role: string(entity.RoleAdmin), status: http.StatusNotAcceptable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &fakeCarService{}
+			e := &rest{svc: &service.Service{Car: fake}}
+			rec := httptest.NewRecorder()
+
+			e.ExportCars(rec, exportRequest("", tt.accept, tt.role))
+
+			if rec.Code != tt.status {
+				t.Errorf("status = %d, want %d", rec.Code, tt.status)
+			}
+
+			if fake.exportFilter != nil {
+				t.Error("export ran for a rejected request")
+			}
+		})
+	}
+}
+
+// TestExportCarsFailure checks that a failure before the first row is a JSON
+// error, while one mid-stream aborts the connection.
+func TestExportCarsFailure(t *testing.T) {
+	failure := appErr.NewWithCode(appErr.CodeSQLRead, "export_cars_err")
+
+	t.Run("before first row", func(t *testing.T) {
+		e := &rest{svc: &service.Service{Car: &fakeCarService{exportErr: failure}}}
+		rec := httptest.NewRecorder()
+
+		e.ExportCars(rec, exportRequest("", "", string(entity.RoleAdmin)))
+
+		if rec.Code != http.StatusInternalServerError {
+			t.Errorf("status = %d, want 500", rec.Code)
+		}
+		if got := rec.Header().Get(preference.HeaderContentType); strings.HasPrefix(got, preference.ContentTypeCSV) {
+			t.Errorf("content type = %q, want a JSON error", got)
+		}
+	})
+
+	t.Run("mid-stream", func(t *testing.T) {
+		fake := &fakeCarService{exportCars: []entity.Car{{ID: "c1"}}, exportErr: failure}
+		e := &rest{svc: &service.Service{Car: fake}}
+
+		defer func() {
+			if r := recover(); r == nil || !errors.Is(r.(error), http.ErrAbortHandler) {
+				t.Errorf("recovered %v, want http.ErrAbortHandler", r)
+			}
+		}()
+
+		e.ExportCars(httptest.NewRecorder(), exportRequest("", "", string(entity.RoleAdmin)))
+	})
+}
@@ -1,14 +1,24 @@
 package rest
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"io"
+	"mime"
 	"net/http"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
+	"go-far/internal/infra/middleware"
 	"go-far/internal/model/dto"
+	"go-far/internal/model/entity"
 	appErr "go-far/internal/model/errors"
 	"go-far/internal/preference"
+	"go-far/internal/util"
 )
 
 // Health godoc
@@ -79,6 +89,207 @@ func (e *rest) Ready(w http.ResponseWriter, r *http.Request) {
 	e.httpRespSuccess(w, r, http.StatusOK, status, nil)
 }
 
+// parseCacheControl maps the request Cache-Control directives onto the
+// server-side cache. The legacy must-revalidate and must-db-revalidate values
+// are still accepted as aliases of no-cache and no-store. Unknown directives
+// and malformed max-age values are ignored, as RFC 9111 requires.
+func parseCacheControl(r *http.Request) dto.CacheControl {
+	cacheControl := dto.CacheControl{Result: &dto.CacheResult{}}
+
+	for _, header := range r.Header.Values(preference.CacheControl) {
+		for directive := range strings.SplitSeq(header, ",") {
+			name, value, _ := strings.Cut(strings.TrimSpace(directive), "=")
+
+			switch strings.ToLower(strings.TrimSpace(name)) {
+			case preference.CacheNoCache, preference.CacheMustRevalidate:
+				cacheControl.MustRevalidate = true
+			case preference.CacheNoStore, preference.CacheMustDbRevalidate:
+				cacheControl.MustDbValidate = true
+			case preference.CacheOnlyIfCached:
+				cacheControl.OnlyIfCached = true
+			case preference.CacheMaxAge:
+				seconds, err := strconv.Atoi(strings.Trim(strings.TrimSpace(value), `"`))
+				if err != nil || seconds < 0 {
+					continue
+				}
+				if seconds == 0 {
+					cacheControl.MustRevalidate = true
+					continue
+				}
+				maxAge := time.Duration(seconds) * time.Second
+				cacheControl.MaxAge = &maxAge
+			}
+		}
+	}
+
+	return cacheControl
+}
+
+// setCacheHeaders reports how the cache served the request. Age is only sent
+// for hits, since fresh responses have no cached age.
+func setCacheHeaders(w http.ResponseWriter, cacheControl dto.CacheControl) {
+	status, age := cacheControl.Result.Outcome()
+	if status == "" {
+		return
+	}
+
+	w.Header().Set(preference.HeaderXCache, string(status))
+	if status == dto.CacheHit {
+		w.Header().Set(preference.HeaderAge, strconv.Itoa(int(age.Seconds())))
+	}
+}
+
+// parseIncludes validates ?include= against the relations an endpoint can embed.
+func parseIncludes(sel dto.FieldSelection, allowed ...string) ([]string, error) {
+	includes := util.SplitList(sel.Include)
+	for _, include := range includes {
+		if !slices.Contains(allowed, include) {
+			return nil, appErr.NewWithCode(appErr.CodeHTTPBadRequest, "unsupported include %q, allowed: %s", include, strings.Join(allowed, ", "))
+		}
+	}
+
+	return includes, nil
+}
+
+// ownerColumns are the CarWithOwner columns embedded by ?include=owner.
+var ownerColumns = []string{"owner_name", "owner_email"}
+
+// parseIncludeDeleted reads ?include_deleted=. Soft-deleted records are only
+// shown to admins, so anyone else asking for them gets 403.
+func parseIncludeDeleted(r *http.Request) (bool, error) {
+	filter := util.DecodeURL[dto.DeletedFilter](r.URL.Query())
+	if !filter.IncludeDeleted {
+		return false, nil
+	}
+
+	authUser, ok := middleware.GetAuthUser(r.Context())
+	if !ok || authUser.Role != string(entity.RoleAdmin) {
+		return false, appErr.NewWithCode(appErr.CodeHTTPForbidden, "include_deleted_admin_only")
+	}
+
+	return true, nil
+}
+
+// parseFields validates ?fields= against the db columns of the payload type T.
+func parseFields[T any](sel dto.FieldSelection) ([]string, error) {
+	fields := util.SplitList(sel.Fields)
+	if len(fields) == 0 {
+		return nil, nil
+	}
+
+	columns := util.DBColumns[T]()
+	for _, field := range fields {
+		if _, ok := columns[field]; !ok {
+			return nil, appErr.NewWithCode(appErr.CodeHTTPBadRequest, "unknown field %q", field)
+		}
+	}
+
+	return fields, nil
+}
+
+// etag returns a strong entity tag over the JSON form of a response's data
+// and pagination. Meta is left out since its timestamp changes every call.
+func etag(data any, p *dto.Pagination) (string, error) {
+	body, err := json.Marshal(struct {
+		Data       any             `json:"data"`
+		Pagination *dto.Pagination `json:"pagination"`
+	}{Data: data, Pagination: p})
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(body)
+
+	return `"` + hex.EncodeToString(sum[:16]) + `"`, nil
+}
+
+// setLastModified sets Last-Modified for single-record responses. Lists do
+// not get one: a removed row leaves the newest updated_at unchanged.
+func setLastModified(w http.ResponseWriter, modifiedAt time.Time) {
+	if modifiedAt.IsZero() {
+		return
+	}
+
+	w.Header().Set(preference.HeaderLastModified, modifiedAt.UTC().Format(http.TimeFormat))
+}
+
+// etagMatch reports whether tag is listed in an If-Match or If-None-Match
+// header. If-None-Match uses the weak comparison, which ignores the W/ prefix;
+// If-Match never matches a weak tag.
+func etagMatch(header, tag string, weak bool) bool {
+	for candidate := range strings.SplitSeq(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" {
+			return true
+		}
+
+		if strings.HasPrefix(candidate, "W/") {
+			if !weak {
+				continue
+			}
+			candidate = strings.TrimPrefix(candidate, "W/")
+		}
+
+		if candidate == tag {
+			return true
+		}
+	}
+
+	return false
+}
+
+// notModified evaluates If-None-Match, or If-Modified-Since when the former
+// is absent, against the validators already set on the response.
+func notModified(r *http.Request, h http.Header) bool {
+	if ifNoneMatch := r.Header.Get(preference.HeaderIfNoneMatch); ifNoneMatch != "" {
+		return etagMatch(ifNoneMatch, h.Get(preference.HeaderETag), true)
+	}
+
+	ifModifiedSince, err := http.ParseTime(r.Header.Get(preference.HeaderIfModifiedSince))
+	if err != nil {
+		return false
+	}
+
+	lastModified, err := http.ParseTime(h.Get(preference.HeaderLastModified))
+
+	return err == nil && !lastModified.After(ifModifiedSince)
+}
+
+// hasPreconditions reports whether a write must be checked against the
+// current state of the record first.
+func hasPreconditions(r *http.Request) bool {
+	return r.Header.Get(preference.HeaderIfMatch) != "" || r.Header.Get(preference.HeaderIfUnmodifiedSince) != ""
+}
+
+// checkPreconditions evaluates If-Match, or If-Unmodified-Since when the
+// former is absent, against current, which must be the record exactly as a
+// plain GET returns it so that its ETag matches the one the client holds.
+func checkPreconditions(r *http.Request, current any, modifiedAt time.Time) error {
+	if ifMatch := r.Header.Get(preference.HeaderIfMatch); ifMatch != "" {
+		tag, err := etag(current, nil)
+		if err != nil {
+			return appErr.WrapWithCode(err, appErr.CodeHTTPMarshal, "compute_etag")
+		}
+
+		if !etagMatch(ifMatch, tag, false) {
+			return appErr.NewWithCode(appErr.CodeHTTPPreconditionFailed, "etag_mismatch")
+		}
+
+		return nil
+	}
+
+	ifUnmodifiedSince, err := http.ParseTime(r.Header.Get(preference.HeaderIfUnmodifiedSince))
+	if err != nil {
+		return nil
+	}
+
+	if modifiedAt.Truncate(time.Second).After(ifUnmodifiedSince) {
+		return appErr.NewWithCode(appErr.CodeHTTPPreconditionFailed, "modified_since")
+	}
+
+	return nil
+}
+
 func (e *rest) httpRespSuccess(w http.ResponseWriter, r *http.Request, statusCode int, resp any, p *dto.Pagination) {
 	meta := dto.Meta{
 		Path:       r.URL.Path,
@@ -89,6 +300,20 @@ func (e *rest) httpRespSuccess(w http.ResponseWriter, r *http.Request, statusCod
 		Timestamp:  time.Now().Format(time.RFC3339),
 	}
 
+	if statusCode == http.StatusOK && resp != nil {
+		switch r.Method {
+		case http.MethodGet, http.MethodHead, http.MethodPut:
+			if tag, err := etag(resp, p); err == nil {
+				w.Header().Set(preference.HeaderETag, tag)
+			}
+		}
+
+		if (r.Method == http.MethodGet || r.Method == http.MethodHead) && notModified(r, w.Header()) {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+	}
+
 	httpResp := &dto.HttpSuccessResp{
 		Meta:       meta,
 		Data:       any(resp),
@@ -110,6 +335,7 @@ func (e *rest) httpRespError(w http.ResponseWriter, r *http.Request, err error)
 	statusStr := http.StatusText(statusCode)
 
 	jsonErrResp := &dto.HTTPErrorResp{
+		Data: conflictState(err),
 		Meta: dto.Meta{
 			Path:       r.URL.Path,
 			StatusCode: statusCode,
@@ -123,6 +349,32 @@ func (e *rest) httpRespError(w http.ResponseWriter, r *http.Request, err error)
 	writeJSON(w, statusCode, jsonErrResp)
 }
 
+// readPatch reads a PATCH body along with its media type. Whether the type
+// is a supported patch format is left to util.ApplyPatch.
+func readPatch(r *http.Request) (dto.PatchRequest, error) {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get(preference.HeaderContentType))
+	if err != nil {
+		return dto.PatchRequest{}, appErr.WrapWithCode(err, appErr.CodeHTTPUnsupportedMediaType, "invalid_content_type")
+	}
+
+	document, err := io.ReadAll(r.Body)
+	if err != nil {
+		return dto.PatchRequest{}, appErr.WrapWithCode(err, appErr.CodeHTTPErrorOnReadBody, "read_patch_body")
+	}
+
+	return dto.PatchRequest{MediaType: mediaType, Document: document}, nil
+}
+
+// conflictState returns the stored record behind a version conflict, or nil
+// for any other error.
+func conflictState(err error) any {
+	if conflict, ok := appErr.RootCause(err).(*appErr.ConflictError); ok {
+		return conflict.Current
+	}
+
+	return nil
+}
+
 func writeJSON(w http.ResponseWriter, statusCode int, v any) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(statusCode)
@@ -0,0 +1,83 @@
+package rest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestParseCacheControl(t *testing.T) {
+	seconds := func(n int) *time.Duration {
+		d := time.Duration(n) * time.Second
+		return &d
+	}
+
+	tests := []struct {
+		name           string
+		headers        []string
+		maxAge         *time.Duration
+		mustRevalidate bool
+		mustDbValidate bool
+		onlyIfCached   bool
+	}{
+		{name: "no header"},
+		{name: "no-cache", headers: []string{"no-cache"}, mustRevalidate: true},
+		{name: "no-store", headers: []string{"no-store"}, mustDbValidate: true},
+		{name: "legacy must-revalidate", headers: []string{"must-revalidate"}, mustRevalidate: true},
+		{name: "legacy must-db-revalidate", headers: []string{"must-db-revalidate"}, mustDbValidate: true},
+		{name: "max-age", headers: []string{"max-age=60"}, maxAge: seconds(60)},
+		{name: "quoted max-age", headers: []string{`max-age="30"`}, maxAge: seconds(30)},
+		{name: "max-age zero revalidates", headers: []string{"max-age=0"}, mustRevalidate: true},
+		{name: "only-if-cached", headers: []string{"only-if-cached"}, onlyIfCached: true},
+		{
+			name:         "combined directives",
+			headers:      []string{"max-age=120, only-if-cached"},
+			maxAge:       seconds(120),
+			onlyIfCached: true,
+		},
+		{
+			name:           "directives across headers",
+			headers:        []string{"No-Cache", " NO-STORE "},
+			mustRevalidate: true,
+			mustDbValidate: true,
+		},
+		{name: "negative max-age ignored", headers: []string{"max-age=-5"}},
+		{name: "non-numeric max-age ignored", headers: []string{"max-age=soon"}},
+		{name: "empty max-age ignored", headers: []string{"max-age="}},
+		{name: "unknown directive ignored", headers: []string{"private, foo=bar"}},
+		{name: "malformed list", headers: []string{",, ,no-cache,"}, mustRevalidate: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			for _, h := range tt.headers {
+				r.Header.Add("Cache-Control", h)
+			}
+
+			got := parseCacheControl(r)
+
+			if got.Result == nil {
+				t.Error("Result is nil, want a collector")
+			}
+			if got.MustRevalidate != tt.mustRevalidate {
+				t.Errorf("MustRevalidate = %v, want %v", got.MustRevalidate, tt.mustRevalidate)
+			}
+			if got.MustDbValidate != tt.mustDbValidate {
+				t.Errorf("MustDbValidate = %v, want %v", got.MustDbValidate, tt.mustDbValidate)
+			}
+			if got.OnlyIfCached != tt.onlyIfCached {
+				t.Errorf("OnlyIfCached = %v, want %v", got.OnlyIfCached, tt.onlyIfCached)
+			}
+			switch {
+			case tt.maxAge == nil && got.MaxAge != nil:
+				t.Errorf("MaxAge = %v, want nil", *got.MaxAge)
+			case tt.maxAge != nil && got.MaxAge == nil:
+				t.Errorf("MaxAge = nil, want %v", *tt.maxAge)
+			case tt.maxAge != nil && *got.MaxAge != *tt.maxAge:
+				t.Errorf("MaxAge = %v, want %v", *got.MaxAge, *tt.maxAge)
+			}
+		})
+	}
+}
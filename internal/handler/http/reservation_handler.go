@@ -0,0 +1,281 @@
+package rest
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"go-far/internal/infra/middleware"
+	"go-far/internal/infra/validator"
+	"go-far/internal/model/dto"
+	"go-far/internal/model/entity"
+	appErr "go-far/internal/model/errors"
+	"go-far/internal/util"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+// CreateReservation godoc
+//
+//	@Summary		Reserve car
+//	@Description	Book a car for the half-open period [starts_at, ends_at). Only the car's owner and admins may reserve it. Overlapping an active reservation of the same car returns 409.
+//	@Tags			reservations
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string							true	"Car ID"
+//	@Param			request	body		dto.CreateReservationRequest	true	"Reservation period"
+//	@Success		201		{object}	dto.HttpSuccessResp{data=entity.Reservation}
+//	@Failure		400		{object}	dto.HTTPErrorResp
+//	@Failure		401		{object}	dto.HTTPErrorResp
+//	@Failure		403		{object}	dto.HTTPErrorResp
+//	@Failure		404		{object}	dto.HTTPErrorResp
+//	@Failure		409		{object}	dto.HTTPErrorResp
+//	@Failure		500		{object}	dto.HTTPErrorResp
+//	@Router			/cars/{id}/reservations [post]
+func (e *rest) CreateReservation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	authUser, ok := middleware.GetAuthUser(ctx)
+	if !ok {
+		e.httpRespError(w, r, appErr.NewWithCode(appErr.CodeHTTPUnauthorized, "unauthenticated"))
+		return
+	}
+
+	carID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("invalid_car_id")
+		e.httpRespError(w, r, appErr.WrapWithCode(err, appErr.CodeHTTPBadRequest, "invalid_car_id"))
+		return
+	}
+
+	var req dto.CreateReservationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("invalid_request_body")
+		e.httpRespError(w, r, appErr.WrapWithCode(err, appErr.CodeHTTPUnmarshal, "invalid_request_body"))
+		return
+	}
+
+	if err := validator.ValidateRequest(&req); err != nil {
+		zerolog.Ctx(ctx).Warn().Err(err).Msg("validation_failed_create_reservation")
+		e.httpRespError(w, r, err)
+		return
+	}
+
+	isAdmin := authUser.Role == string(entity.RoleAdmin)
+
+	reservation, err := e.svc.Reservation.CreateReservation(ctx, carID, req, authUser.UserID, isAdmin)
+	if err != nil {
+		e.httpRespError(w, r, err)
+		return
+	}
+
+	e.httpRespSuccess(w, r, http.StatusCreated, reservation, nil)
+}
+
+// ListCarReservations godoc
+//
+//	@Summary		List car reservations
+//	@Description	List the reservations of a car, ordered by start. The owner and admins see all of them; other users only their own.
+//	@Tags			reservations
+//	@Produce		json
+//	@Param			id					path		string	true	"Car ID"
+//	@Param			from				query		string	false	"Only reservations overlapping [from, to) (RFC 3339, with to)"
+//	@Param			to					query		string	false	"End of the window (RFC 3339, with from)"
+//	@Param			include_cancelled	query		bool	false	"Also list cancelled reservations"
+//	@Param			page				query		int		false	"Page number"	default(1)
+//	@Param			page_size			query		int		false	"Page size"		default(10)
+//	@Success		200					{object}	dto.HttpSuccessResp{data=[]entity.Reservation}
+//	@Failure		400					{object}	dto.HTTPErrorResp
+//	@Failure		401					{object}	dto.HTTPErrorResp
+//	@Failure		500					{object}	dto.HTTPErrorResp
+//	@Router			/cars/{id}/reservations [get]
+func (e *rest) ListCarReservations(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	authUser, ok := middleware.GetAuthUser(ctx)
+	if !ok {
+		e.httpRespError(w, r, appErr.NewWithCode(appErr.CodeHTTPUnauthorized, "unauthenticated"))
+		return
+	}
+
+	carID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("invalid_car_id")
+		e.httpRespError(w, r, appErr.WrapWithCode(err, appErr.CodeHTTPBadRequest, "invalid_car_id"))
+		return
+	}
+
+	filter := util.DecodeURL[dto.ReservationFilter](r.URL.Query())
+	if err := validator.ValidateRequest(&filter); err != nil {
+		zerolog.Ctx(ctx).Warn().Err(err).Msg("validation_failed_list_reservations")
+		e.httpRespError(w, r, err)
+		return
+	}
+
+	isAdmin := authUser.Role == string(entity.RoleAdmin)
+
+	reservations, pagination, err := e.svc.Reservation.ListCarReservations(ctx, carID, &filter, authUser.UserID, isAdmin)
+	if err != nil {
+		e.httpRespError(w, r, err)
+		return
+	}
+
+	e.httpRespSuccess(w, r, http.StatusOK, reservations, pagination)
+}
+
+// ListMyReservations godoc
+//
+//	@Summary		List my reservations
+//	@Description	List the authenticated user's reservations, ordered by start
+//	@Tags			reservations
+//	@Produce		json
+//	@Param			from				query		string	false	"Only reservations overlapping [from, to) (RFC 3339, with to)"
+//	@Param			to					query		string	false	"End of the window (RFC 3339, with from)"
+//	@Param			include_cancelled	query		bool	false	"Also list cancelled reservations"
+//	@Param			page				query		int		false	"Page number"	default(1)
+//	@Param			page_size			query		int		false	"Page size"		default(10)
+//	@Success		200					{object}	dto.HttpSuccessResp{data=[]entity.Reservation}
+//	@Failure		400					{object}	dto.HTTPErrorResp
+//	@Failure		401					{object}	dto.HTTPErrorResp
+//	@Failure		500					{object}	dto.HTTPErrorResp
+//	@Router			/reservations [get]
+func (e *rest) ListMyReservations(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	authUser, ok := middleware.GetAuthUser(ctx)
+	if !ok {
+		e.httpRespError(w, r, appErr.NewWithCode(appErr.CodeHTTPUnauthorized, "unauthenticated"))
+		return
+	}
+
+	filter := util.DecodeURL[dto.ReservationFilter](r.URL.Query())
+	if err := validator.ValidateRequest(&filter); err != nil {
+		zerolog.Ctx(ctx).Warn().Err(err).Msg("validation_failed_list_reservations")
+		e.httpRespError(w, r, err)
+		return
+	}
+
+	reservations, pagination, err := e.svc.Reservation.ListUserReservations(ctx, &filter, authUser.UserID)
+	if err != nil {
+		e.httpRespError(w, r, err)
+		return
+	}
+
+	e.httpRespSuccess(w, r, http.StatusOK, reservations, pagination)
+}
+
+// GetReservation godoc
+//
+//	@Summary		Get reservation
+//	@Description	Get a reservation by ID. Visible to the reserving user, the car's owner and admins.
+//	@Tags			reservations
+//	@Produce		json
+//	@Param			id	path		string	true	"Reservation ID"
+//	@Success		200	{object}	dto.HttpSuccessResp{data=entity.Reservation}
+//	@Failure		400	{object}	dto.HTTPErrorResp
+//	@Failure		403	{object}	dto.HTTPErrorResp
+//	@Failure		404	{object}	dto.HTTPErrorResp
+//	@Failure		500	{object}	dto.HTTPErrorResp
+//	@Router			/reservations/{id} [get]
+func (e *rest) GetReservation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	authUser, ok := middleware.GetAuthUser(ctx)
+	if !ok {
+		e.httpRespError(w, r, appErr.NewWithCode(appErr.CodeHTTPUnauthorized, "unauthenticated"))
+		return
+	}
+
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("invalid_reservation_id")
+		e.httpRespError(w, r, appErr.WrapWithCode(err, appErr.CodeHTTPBadRequest, "invalid_reservation_id"))
+		return
+	}
+
+	isAdmin := authUser.Role == string(entity.RoleAdmin)
+
+	reservation, err := e.svc.Reservation.GetReservation(ctx, id, authUser.UserID, isAdmin)
+	if err != nil {
+		e.httpRespError(w, r, err)
+		return
+	}
+
+	e.httpRespSuccess(w, r, http.StatusOK, reservation, nil)
+}
+
+// CancelReservation godoc
+//
+//	@Summary		Cancel reservation
+//	@Description	Cancel an active reservation, freeing its period. Allowed for the reserving user, the car's owner and admins.
+//	@Tags			reservations
+//	@Produce		json
+//	@Param			id	path		string	true	"Reservation ID"
+//	@Success		200	{object}	dto.HttpSuccessResp{data=entity.Reservation}
+//	@Failure		400	{object}	dto.HTTPErrorResp
+//	@Failure		403	{object}	dto.HTTPErrorResp
+//	@Failure		404	{object}	dto.HTTPErrorResp
+//	@Failure		409	{object}	dto.HTTPErrorResp
+//	@Failure		500	{object}	dto.HTTPErrorResp
+//	@Router			/reservations/{id}/cancel [post]
+func (e *rest) CancelReservation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	authUser, ok := middleware.GetAuthUser(ctx)
+	if !ok {
+		e.httpRespError(w, r, appErr.NewWithCode(appErr.CodeHTTPUnauthorized, "unauthenticated"))
+		return
+	}
+
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("invalid_reservation_id")
+		e.httpRespError(w, r, appErr.WrapWithCode(err, appErr.CodeHTTPBadRequest, "invalid_reservation_id"))
+		return
+	}
+
+	isAdmin := authUser.Role == string(entity.RoleAdmin)
+
+	reservation, err := e.svc.Reservation.CancelReservation(ctx, id, authUser.UserID, isAdmin)
+	if err != nil {
+		e.httpRespError(w, r, err)
+		return
+	}
+
+	e.httpRespSuccess(w, r, http.StatusOK, reservation, nil)
+}
+
+// SearchAvailableCars godoc
+//
+//	@Summary		Search available cars
+//	@Description	List cars with no active reservation overlapping [from, to)
+//	@Tags			reservations
+//	@Produce		json
+//	@Param			from		query		string	true	"Start of the window (RFC 3339)"
+//	@Param			to			query		string	true	"End of the window (RFC 3339), after from"
+//	@Param			brand		query		string	false	"Brand contains"
+//	@Param			page		query		int		false	"Page number"	default(1)
+//	@Param			page_size	query		int		false	"Page size"		default(10)
+//	@Success		200			{object}	dto.HttpSuccessResp{data=[]entity.Car}
+//	@Failure		400			{object}	dto.HTTPErrorResp
+//	@Failure		401			{object}	dto.HTTPErrorResp
+//	@Failure		500			{object}	dto.HTTPErrorResp
+//	@Router			/cars/availability [get]
+func (e *rest) SearchAvailableCars(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	filter := util.DecodeURL[dto.AvailabilityFilter](r.URL.Query())
+	if err := validator.ValidateRequest(&filter); err != nil {
+		zerolog.Ctx(ctx).Warn().Err(err).Msg("validation_failed_available_cars")
+		e.httpRespError(w, r, err)
+		return
+	}
+
+	cars, pagination, err := e.svc.Reservation.SearchAvailableCars(ctx, &filter)
+	if err != nil {
+		e.httpRespError(w, r, err)
+		return
+	}
+
+	e.httpRespSuccess(w, r, http.StatusOK, cars, pagination)
+}
@@ -59,12 +59,20 @@ func (e *rest) Serve() {
 	limiter := e.mw.RoleLimiter()
 	e.mux.Handle("POST "+preference.RouteCars, limiter(http.HandlerFunc(e.CreateCar)))
 	e.mux.Handle("POST "+preference.RouteCarsBulk, limiter(http.HandlerFunc(e.CreateBulkCars)))
+	e.mux.Handle("POST "+preference.RouteCarsImport, limiter(http.HandlerFunc(e.ImportCars)))
+	e.mux.Handle("POST "+preference.RouteCarsDecodeVIN, limiter(http.HandlerFunc(e.DecodeVIN)))
 	e.mux.Handle("GET "+preference.RouteCarsByID, limiter(http.HandlerFunc(e.GetCar)))
+	e.mux.Handle("GET "+preference.RouteCarsByPlate, limiter(http.HandlerFunc(e.GetCarByPlate)))
 	e.mux.Handle("GET "+preference.RouteCarsOwner, limiter(http.HandlerFunc(e.GetCarWithOwner)))
 	e.mux.Handle("PUT "+preference.RouteCarsByID, limiter(http.HandlerFunc(e.UpdateCar)))
+	e.mux.Handle("PATCH "+preference.RouteCarsByID, limiter(http.HandlerFunc(e.PatchCar)))
 	e.mux.Handle("DELETE "+preference.RouteCarsByID, limiter(http.HandlerFunc(e.DeleteCar)))
-	e.mux.Handle("POST "+preference.RouteCarsTransfer, limiter(http.HandlerFunc(e.TransferCarOwnership)))
+	e.mux.Handle("POST "+preference.RouteCarsRestore, limiter(http.HandlerFunc(e.RestoreCar)))
 	e.mux.Handle("PUT "+preference.RouteCarsAvailability, limiter(http.HandlerFunc(e.BulkUpdateAvailability)))
+	e.mux.Handle("GET "+preference.RouteCarsHistory, limiter(http.HandlerFunc(e.GetCarHistory)))
+	e.mux.Handle("GET "+preference.RouteCarsShares, limiter(http.HandlerFunc(e.ListCarShares)))
+	e.mux.Handle("PUT "+preference.RouteCarsSharesByUser, limiter(http.HandlerFunc(e.ShareCar)))
+	e.mux.Handle("DELETE "+preference.RouteCarsSharesByUser, limiter(http.HandlerFunc(e.UnshareCar)))
 
 	// User car routes (authenticated, rate-limited by role)
 	e.mux.Handle("GET "+preference.RouteCarsByUser, limiter(http.HandlerFunc(e.ListCarsByUser)))
@@ -76,5 +84,56 @@ func (e *rest) Serve() {
 	e.mux.Handle("GET "+preference.RouteUsers, limiter(http.HandlerFunc(e.ListUsers)))
 	e.mux.Handle("GET "+preference.RouteUsersV2, limiter(http.HandlerFunc(e.ListUsersV2)))
 	e.mux.Handle("PUT "+preference.RouteUsersByID, limiter(http.HandlerFunc(e.UpdateUser)))
+	e.mux.Handle("PATCH "+preference.RouteUsersByID, limiter(http.HandlerFunc(e.PatchUser)))
 	e.mux.Handle("DELETE "+preference.RouteUsersByID, limiter(http.HandlerFunc(e.DeleteUser)))
+	e.mux.Handle("POST "+preference.RouteUsersRestore, limiter(http.HandlerFunc(e.RestoreUser)))
+
+	// Reservation routes (authenticated, rate-limited by role)
+	e.mux.Handle("GET "+preference.RouteCarsAvailabilitySearch, limiter(http.HandlerFunc(e.SearchAvailableCars)))
+	e.mux.Handle("POST "+preference.RouteCarReservations, limiter(http.HandlerFunc(e.CreateReservation)))
+	e.mux.Handle("GET "+preference.RouteCarReservations, limiter(http.HandlerFunc(e.ListCarReservations)))
+	e.mux.Handle("GET "+preference.RouteReservations, limiter(http.HandlerFunc(e.ListMyReservations)))
+	e.mux.Handle("GET "+preference.RouteReservationsByID, limiter(http.HandlerFunc(e.GetReservation)))
+	e.mux.Handle("POST "+preference.RouteReservationsCancel, limiter(http.HandlerFunc(e.CancelReservation)))
+
+	// Service record routes (authenticated, rate-limited by role)
+	e.mux.Handle("POST "+preference.RouteCarsServices, limiter(http.HandlerFunc(e.CreateServiceRecord)))
+	e.mux.Handle("GET "+preference.RouteCarsServices, limiter(http.HandlerFunc(e.ListServiceRecords)))
+	e.mux.Handle("GET "+preference.RouteCarsServicesByID, limiter(http.HandlerFunc(e.GetServiceRecord)))
+	e.mux.Handle("PUT "+preference.RouteCarsServicesByID, limiter(http.HandlerFunc(e.UpdateServiceRecord)))
+	e.mux.Handle("DELETE "+preference.RouteCarsServicesByID, limiter(http.HandlerFunc(e.DeleteServiceRecord)))
+
+	// Attachment routes (authenticated, rate-limited by role)
+	e.mux.Handle("POST "+preference.RouteCarsAttachments, limiter(http.HandlerFunc(e.UploadAttachment)))
+	e.mux.Handle("GET "+preference.RouteCarsAttachments, limiter(http.HandlerFunc(e.ListAttachments)))
+	e.mux.Handle("GET "+preference.RouteCarsAttachmentByID, limiter(http.HandlerFunc(e.GetAttachment)))
+	e.mux.Handle("DELETE "+preference.RouteCarsAttachmentByID, limiter(http.HandlerFunc(e.DeleteAttachment)))
+
+	// Attachment downloads (public, authorized by the signed URL, rate-limited by IP)
+	e.mux.Handle("GET "+preference.RouteAttachmentDownload, authLimiter(http.HandlerFunc(e.DownloadAttachment)))
+
+	// Transfer request routes (authenticated, rate-limited by role)
+	e.mux.Handle("POST "+preference.RouteCarsTransfer, limiter(http.HandlerFunc(e.CreateTransferRequest)))
+	e.mux.Handle("GET "+preference.RouteTransfersIncoming, limiter(http.HandlerFunc(e.ListIncomingTransferRequests)))
+	e.mux.Handle("GET "+preference.RouteTransfersOutgoing, limiter(http.HandlerFunc(e.ListOutgoingTransferRequests)))
+	e.mux.Handle("GET "+preference.RouteTransfersByID, limiter(http.HandlerFunc(e.GetTransferRequest)))
+	e.mux.Handle("POST "+preference.RouteTransfersAccept, limiter(http.HandlerFunc(e.AcceptTransferRequest)))
+	e.mux.Handle("POST "+preference.RouteTransfersReject, limiter(http.HandlerFunc(e.RejectTransferRequest)))
+	e.mux.Handle("POST "+preference.RouteTransfersCancel, limiter(http.HandlerFunc(e.CancelTransferRequest)))
+
+	// Export routes (admin only, dedicated per-user export rate limit)
+	exportLimiter := e.mw.ExportLimiter()
+	e.mux.Handle("GET "+preference.RouteUsersExport, exportLimiter(http.HandlerFunc(e.ExportUsers)))
+	e.mux.Handle("GET "+preference.RouteCarsExport, exportLimiter(http.HandlerFunc(e.ExportCars)))
+
+	// Search routes (authenticated, rate-limited by role)
+	e.mux.Handle("GET "+preference.RouteSearch, limiter(http.HandlerFunc(e.Search)))
+
+	// Statistics routes (admin only, rate-limited by role)
+	e.mux.Handle("GET "+preference.RouteStatsCarBrands, limiter(http.HandlerFunc(e.StatsCarsByBrand)))
+	e.mux.Handle("GET "+preference.RouteStatsCarYears, limiter(http.HandlerFunc(e.StatsCarsByYear)))
+	e.mux.Handle("GET "+preference.RouteStatsAvailability, limiter(http.HandlerFunc(e.StatsCarAvailability)))
+	e.mux.Handle("GET "+preference.RouteStatsCarsPerUser, limiter(http.HandlerFunc(e.StatsCarsPerUser)))
+	e.mux.Handle("GET "+preference.RouteStatsRegistrations, limiter(http.HandlerFunc(e.StatsRegistrations)))
+	e.mux.Handle("GET "+preference.RouteStatsLogins, limiter(http.HandlerFunc(e.StatsLogins)))
 }
@@ -0,0 +1,57 @@
+package rest
+
+import (
+	"net/http"
+
+	"go-far/internal/infra/middleware"
+	"go-far/internal/infra/validator"
+	"go-far/internal/model/dto"
+	"go-far/internal/model/entity"
+	appErr "go-far/internal/model/errors"
+	"go-far/internal/util"
+
+	"github.com/rs/zerolog"
+)
+
+// Search godoc
+//
+//	@Summary		Search users and cars
+//	@Description	Full-text search across users and cars, ranked by relevance with highlighted snippets. Admins search all records; other users only their own cars.
+//	@Tags			search
+//	@Produce		json
+//	@Param			q			query		string	true	"Search terms (web search syntax)"
+//	@Param			page		query		int		false	"Page number"	default(1)
+//	@Param			page_size	query		int		false	"Page size"		default(10)
+//	@Success		200			{object}	dto.HttpSuccessResp{data=[]entity.SearchResult}
+//	@Failure		400			{object}	dto.HTTPErrorResp
+//	@Failure		401			{object}	dto.HTTPErrorResp
+//	@Failure		500			{object}	dto.HTTPErrorResp
+//	@Router			/search [get]
+func (e *rest) Search(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	authUser, ok := middleware.GetAuthUser(ctx)
+	if !ok {
+		e.httpRespError(w, r, appErr.NewWithCode(appErr.CodeHTTPUnauthorized, "unauthenticated"))
+		return
+	}
+
+	filter := util.DecodeURL[dto.SearchFilter](r.URL.Query())
+
+	if err := validator.ValidateRequest(&filter); err != nil {
+		zerolog.Ctx(ctx).Warn().Err(err).Msg("validation_failed_search")
+		e.httpRespError(w, r, err)
+		return
+	}
+
+	filter.UserID = authUser.UserID
+	filter.IsAdmin = authUser.Role == string(entity.RoleAdmin)
+
+	results, pagination, err := e.svc.Search.Search(ctx, &filter)
+	if err != nil {
+		e.httpRespError(w, r, err)
+		return
+	}
+
+	e.httpRespSuccess(w, r, http.StatusOK, results, pagination)
+}
@@ -0,0 +1,252 @@
+package rest
+
+import (
+	"net/http"
+
+	"go-far/internal/infra/validator"
+	"go-far/internal/model/dto"
+	"go-far/internal/util"
+
+	"github.com/rs/zerolog"
+)
+
+// StatsCarsByBrand godoc
+//
+//	@Summary		Count cars by brand
+//	@Description	Number of live cars per brand, most common first (admin only). from and to bound the cars' creation time.
+//	@Tags			stats
+//	@Produce		json
+//	@Param			Cache-Control	header		string	false	"Request cache control"	Enums(no-cache, no-store, max-age=60, only-if-cached)
+//	@Param			from			query		string	false	"Earliest creation time (RFC 3339, inclusive)"
+//	@Param			to				query		string	false	"Latest creation time (RFC 3339, inclusive)"
+//	@Success		200				{object}	dto.HttpSuccessResp{data=[]entity.BrandCount}
+//	@Header			200				{string}	X-Cache	"HIT, MISS or BYPASS"
+//	@Header			200				{integer}	Age		"Seconds since the cached copy was stored"
+//	@Failure		400				{object}	dto.HTTPErrorResp
+//	@Failure		401				{object}	dto.HTTPErrorResp
+//	@Failure		403				{object}	dto.HTTPErrorResp
+//	@Failure		500				{object}	dto.HTTPErrorResp
+//	@Router			/admin/stats/cars/brands [get]
+func (e *rest) StatsCarsByBrand(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	filter, ok := e.parseStatsFilter(w, r)
+	if !ok {
+		return
+	}
+
+	cacheControl := parseCacheControl(r)
+
+	counts, err := e.svc.Stats.CarsByBrand(ctx, cacheControl, filter)
+	setCacheHeaders(w, cacheControl)
+	if err != nil {
+		e.httpRespError(w, r, err)
+		return
+	}
+
+	e.httpRespSuccess(w, r, http.StatusOK, counts, nil)
+}
+
+// StatsCarsByYear godoc
+//
+//	@Summary		Model year histogram
+//	@Description	Number of live cars per model year, oldest first (admin only). from and to bound the cars' creation time.
+//	@Tags			stats
+//	@Produce		json
+//	@Param			Cache-Control	header		string	false	"Request cache control"	Enums(no-cache, no-store, max-age=60, only-if-cached)
+//	@Param			from			query		string	false	"Earliest creation time (RFC 3339, inclusive)"
+//	@Param			to				query		string	false	"Latest creation time (RFC 3339, inclusive)"
+//	@Success		200				{object}	dto.HttpSuccessResp{data=[]entity.YearCount}
+//	@Header			200				{string}	X-Cache	"HIT, MISS or BYPASS"
+//	@Header			200				{integer}	Age		"Seconds since the cached copy was stored"
+//	@Failure		400				{object}	dto.HTTPErrorResp
+//	@Failure		401				{object}	dto.HTTPErrorResp
+//	@Failure		403				{object}	dto.HTTPErrorResp
+//	@Failure		500				{object}	dto.HTTPErrorResp
+//	@Router			/admin/stats/cars/years [get]
+func (e *rest) StatsCarsByYear(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	filter, ok := e.parseStatsFilter(w, r)
+	if !ok {
+		return
+	}
+
+	cacheControl := parseCacheControl(r)
+
+	counts, err := e.svc.Stats.CarsByYear(ctx, cacheControl, filter)
+	setCacheHeaders(w, cacheControl)
+	if err != nil {
+		e.httpRespError(w, r, err)
+		return
+	}
+
+	e.httpRespSuccess(w, r, http.StatusOK, counts, nil)
+}
+
+// StatsCarAvailability godoc
+//
+//	@Summary		Fleet availability
+//	@Description	Live cars split by whether an active reservation covers the current time, with the share of available ones (admin only). from and to bound the cars' creation time.
+//	@Tags			stats
+//	@Produce		json
+//	@Param			Cache-Control	header		string	false	"Request cache control"	Enums(no-cache, no-store, max-age=60, only-if-cached)
+//	@Param			from			query		string	false	"Earliest creation time (RFC 3339, inclusive)"
+//	@Param			to				query		string	false	"Latest creation time (RFC 3339, inclusive)"
+//	@Success		200				{object}	dto.HttpSuccessResp{data=entity.FleetAvailability}
+//	@Header			200				{string}	X-Cache	"HIT, MISS or BYPASS"
+//	@Header			200				{integer}	Age		"Seconds since the cached copy was stored"
+//	@Failure		400				{object}	dto.HTTPErrorResp
+//	@Failure		401				{object}	dto.HTTPErrorResp
+//	@Failure		403				{object}	dto.HTTPErrorResp
+//	@Failure		500				{object}	dto.HTTPErrorResp
+//	@Router			/admin/stats/cars/availability [get]
+func (e *rest) StatsCarAvailability(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	filter, ok := e.parseStatsFilter(w, r)
+	if !ok {
+		return
+	}
+
+	cacheControl := parseCacheControl(r)
+
+	availability, err := e.svc.Stats.CarAvailability(ctx, cacheControl, filter)
+	setCacheHeaders(w, cacheControl)
+	if err != nil {
+		e.httpRespError(w, r, err)
+		return
+	}
+
+	e.httpRespSuccess(w, r, http.StatusOK, availability, nil)
+}
+
+// StatsCarsPerUser godoc
+//
+//	@Summary		Cars per user distribution
+//	@Description	How many active users own 0, 1, 2, ... live cars (admin only). from and to bound the cars' creation time.
+//	@Tags			stats
+//	@Produce		json
+//	@Param			Cache-Control	header		string	false	"Request cache control"	Enums(no-cache, no-store, max-age=60, only-if-cached)
+//	@Param			from			query		string	false	"Earliest creation time (RFC 3339, inclusive)"
+//	@Param			to				query		string	false	"Latest creation time (RFC 3339, inclusive)"
+//	@Success		200				{object}	dto.HttpSuccessResp{data=[]entity.CarsPerUser}
+//	@Header			200				{string}	X-Cache	"HIT, MISS or BYPASS"
+//	@Header			200				{integer}	Age		"Seconds since the cached copy was stored"
+//	@Failure		400				{object}	dto.HTTPErrorResp
+//	@Failure		401				{object}	dto.HTTPErrorResp
+//	@Failure		403				{object}	dto.HTTPErrorResp
+//	@Failure		500				{object}	dto.HTTPErrorResp
+//	@Router			/admin/stats/cars/per-user [get]
+func (e *rest) StatsCarsPerUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	filter, ok := e.parseStatsFilter(w, r)
+	if !ok {
+		return
+	}
+
+	cacheControl := parseCacheControl(r)
+
+	buckets, err := e.svc.Stats.CarsPerUser(ctx, cacheControl, filter)
+	setCacheHeaders(w, cacheControl)
+	if err != nil {
+		e.httpRespError(w, r, err)
+		return
+	}
+
+	e.httpRespSuccess(w, r, http.StatusOK, buckets, nil)
+}
+
+// StatsRegistrations godoc
+//
+//	@Summary		User registration trend
+//	@Description	New users per UTC day, week or month, empty periods included (admin only). Without from the last 30 periods are returned; a trend spans at most 366 periods.
+//	@Tags			stats
+//	@Produce		json
+//	@Param			Cache-Control	header		string	false	"Request cache control"	Enums(no-cache, no-store, max-age=60, only-if-cached)
+//	@Param			from			query		string	false	"Start of the trend (RFC 3339, inclusive)"
+//	@Param			to				query		string	false	"End of the trend (RFC 3339, inclusive), defaults to now"
+//	@Param			interval		query		string	false	"Trend period"	Enums(day, week, month)	default(day)
+//	@Success		200				{object}	dto.HttpSuccessResp{data=[]entity.TrendPoint}
+//	@Header			200				{string}	X-Cache	"HIT, MISS or BYPASS"
+//	@Header			200				{integer}	Age		"Seconds since the cached copy was stored"
+//	@Failure		400				{object}	dto.HTTPErrorResp
+//	@Failure		401				{object}	dto.HTTPErrorResp
+//	@Failure		403				{object}	dto.HTTPErrorResp
+//	@Failure		500				{object}	dto.HTTPErrorResp
+//	@Router			/admin/stats/users/registrations [get]
+func (e *rest) StatsRegistrations(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	filter, ok := e.parseStatsFilter(w, r)
+	if !ok {
+		return
+	}
+
+	cacheControl := parseCacheControl(r)
+
+	points, err := e.svc.Stats.RegistrationTrend(ctx, cacheControl, filter)
+	setCacheHeaders(w, cacheControl)
+	if err != nil {
+		e.httpRespError(w, r, err)
+		return
+	}
+
+	e.httpRespSuccess(w, r, http.StatusOK, points, nil)
+}
+
+// StatsLogins godoc
+//
+//	@Summary		User login trend
+//	@Description	Successful logins and distinct users logging in per UTC day, week or month, empty periods included (admin only). Without from the last 30 periods are returned; a trend spans at most 366 periods.
+//	@Tags			stats
+//	@Produce		json
+//	@Param			Cache-Control	header		string	false	"Request cache control"	Enums(no-cache, no-store, max-age=60, only-if-cached)
+//	@Param			from			query		string	false	"Start of the trend (RFC 3339, inclusive)"
+//	@Param			to				query		string	false	"End of the trend (RFC 3339, inclusive), defaults to now"
+//	@Param			interval		query		string	false	"Trend period"	Enums(day, week, month)	default(day)
+//	@Success		200				{object}	dto.HttpSuccessResp{data=[]entity.LoginTrendPoint}
+//	@Header			200				{string}	X-Cache	"HIT, MISS or BYPASS"
+//	@Header			200				{integer}	Age		"Seconds since the cached copy was stored"
+//	@Failure		400				{object}	dto.HTTPErrorResp
+//	@Failure		401				{object}	dto.HTTPErrorResp
+//	@Failure		403				{object}	dto.HTTPErrorResp
+//	@Failure		500				{object}	dto.HTTPErrorResp
+//	@Router			/admin/stats/users/logins [get]
+func (e *rest) StatsLogins(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	filter, ok := e.parseStatsFilter(w, r)
+	if !ok {
+		return
+	}
+
+	cacheControl := parseCacheControl(r)
+
+	points, err := e.svc.Stats.LoginTrend(ctx, cacheControl, filter)
+	setCacheHeaders(w, cacheControl)
+	if err != nil {
+		e.httpRespError(w, r, err)
+		return
+	}
+
+	e.httpRespSuccess(w, r, http.StatusOK, points, nil)
+}
+
+// parseStatsFilter admits admins only and reads the statistics query
+// parameters, writing the error response when it fails.
+func (e *rest) parseStatsFilter(w http.ResponseWriter, r *http.Request) (*dto.StatsFilter, bool) {
+	if !e.requireAdmin(w, r) {
+		return nil, false
+	}
+
+	filter := util.DecodeURL[dto.StatsFilter](r.URL.Query())
+	if err := validator.ValidateRequest(&filter); err != nil {
+		zerolog.Ctx(r.Context()).Warn().Err(err).Msg("validation_failed_stats")
+		e.httpRespError(w, r, err)
+		return nil, false
+	}
+
+	return &filter, true
+}
@@ -0,0 +1,259 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"go-far/internal/infra/middleware"
+	"go-far/internal/infra/validator"
+	"go-far/internal/model/dto"
+	"go-far/internal/model/entity"
+	appErr "go-far/internal/model/errors"
+	"go-far/internal/util"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+// CreateTransferRequest godoc
+//
+//	@Summary		Request car transfer
+//	@Description	Offer a car to another user. The car changes hands only when the recipient accepts; the request expires after the configured TTL. A car has at most one pending request.
+//	@Tags			transfers
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string					true	"Car ID"
+//	@Param			request	body		dto.TransferCarRequest	true	"Recipient ID and optional reason"
+//	@Success		201		{object}	dto.HttpSuccessResp{data=entity.CarTransferRequest}
+//	@Failure		400		{object}	dto.HTTPErrorResp
+//	@Failure		401		{object}	dto.HTTPErrorResp
+//	@Failure		403		{object}	dto.HTTPErrorResp
+//	@Failure		404		{object}	dto.HTTPErrorResp
+//	@Failure		409		{object}	dto.HTTPErrorResp
+//	@Failure		500		{object}	dto.HTTPErrorResp
+//	@Router			/cars/{id}/transfer [post]
+func (e *rest) CreateTransferRequest(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	authUser, ok := middleware.GetAuthUser(ctx)
+	if !ok {
+		e.httpRespError(w, r, appErr.NewWithCode(appErr.CodeHTTPUnauthorized, "unauthenticated"))
+		return
+	}
+
+	carID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("invalid_car_id")
+		e.httpRespError(w, r, appErr.WrapWithCode(err, appErr.CodeHTTPBadRequest, "invalid_car_id"))
+		return
+	}
+
+	var req dto.TransferCarRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("invalid_request_body")
+		e.httpRespError(w, r, appErr.WrapWithCode(err, appErr.CodeHTTPUnmarshal, "invalid_request_body"))
+		return
+	}
+
+	req.Reason = strings.TrimSpace(req.Reason)
+
+	if err := validator.ValidateRequest(&req); err != nil {
+		zerolog.Ctx(ctx).Warn().Err(err).Msg("validation_failed_create_transfer_request")
+		e.httpRespError(w, r, err)
+		return
+	}
+
+	request, err := e.svc.Transfer.CreateTransferRequest(ctx, carID, req, authUser.UserID)
+	if err != nil {
+		e.httpRespError(w, r, err)
+		return
+	}
+
+	e.httpRespSuccess(w, r, http.StatusCreated, request, nil)
+}
+
+// ListIncomingTransferRequests godoc
+//
+//	@Summary		List incoming transfer requests
+//	@Description	List the transfer requests offered to the authenticated user, newest first
+//	@Tags			transfers
+//	@Produce		json
+//	@Param			status		query		string	false	"Only requests with this status"	Enums(pending, accepted, rejected, cancelled, expired)
+//	@Param			page		query		int		false	"Page number"						default(1)
+//	@Param			page_size	query		int		false	"Page size"							default(10)
+//	@Success		200			{object}	dto.HttpSuccessResp{data=[]entity.CarTransferRequest}
+//	@Failure		400			{object}	dto.HTTPErrorResp
+//	@Failure		401			{object}	dto.HTTPErrorResp
+//	@Failure		500			{object}	dto.HTTPErrorResp
+//	@Router			/transfer-requests/incoming [get]
+func (e *rest) ListIncomingTransferRequests(w http.ResponseWriter, r *http.Request) {
+	e.listTransferRequests(w, r, e.svc.Transfer.ListIncomingTransferRequests)
+}
+
+// ListOutgoingTransferRequests godoc
+//
+//	@Summary		List outgoing transfer requests
+//	@Description	List the transfer requests sent by the authenticated user, newest first
+//	@Tags			transfers
+//	@Produce		json
+//	@Param			status		query		string	false	"Only requests with this status"	Enums(pending, accepted, rejected, cancelled, expired)
+//	@Param			page		query		int		false	"Page number"						default(1)
+//	@Param			page_size	query		int		false	"Page size"							default(10)
+//	@Success		200			{object}	dto.HttpSuccessResp{data=[]entity.CarTransferRequest}
+//	@Failure		400			{object}	dto.HTTPErrorResp
+//	@Failure		401			{object}	dto.HTTPErrorResp
+//	@Failure		500			{object}	dto.HTTPErrorResp
+//	@Router			/transfer-requests/outgoing [get]
+func (e *rest) ListOutgoingTransferRequests(w http.ResponseWriter, r *http.Request) {
+	e.listTransferRequests(w, r, e.svc.Transfer.ListOutgoingTransferRequests)
+}
+
+// GetTransferRequest godoc
+//
+//	@Summary		Get transfer request
+//	@Description	Get a transfer request by ID. Visible to its sender, its recipient and admins.
+//	@Tags			transfers
+//	@Produce		json
+//	@Param			id	path		string	true	"Transfer request ID"
+//	@Success		200	{object}	dto.HttpSuccessResp{data=entity.CarTransferRequest}
+//	@Failure		400	{object}	dto.HTTPErrorResp
+//	@Failure		403	{object}	dto.HTTPErrorResp
+//	@Failure		404	{object}	dto.HTTPErrorResp
+//	@Failure		500	{object}	dto.HTTPErrorResp
+//	@Router			/transfer-requests/{id} [get]
+func (e *rest) GetTransferRequest(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	authUser, ok := middleware.GetAuthUser(ctx)
+	if !ok {
+		e.httpRespError(w, r, appErr.NewWithCode(appErr.CodeHTTPUnauthorized, "unauthenticated"))
+		return
+	}
+
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("invalid_transfer_request_id")
+		e.httpRespError(w, r, appErr.WrapWithCode(err, appErr.CodeHTTPBadRequest, "invalid_transfer_request_id"))
+		return
+	}
+
+	isAdmin := authUser.Role == string(entity.RoleAdmin)
+
+	request, err := e.svc.Transfer.GetTransferRequest(ctx, id, authUser.UserID, isAdmin)
+	if err != nil {
+		e.httpRespError(w, r, err)
+		return
+	}
+
+	e.httpRespSuccess(w, r, http.StatusOK, request, nil)
+}
+
+// AcceptTransferRequest godoc
+//
+//	@Summary		Accept transfer request
+//	@Description	Accept a pending transfer request as its recipient; the car becomes yours. Returns 409 if the request was already answered, has expired or the sender no longer owns the car.
+//	@Tags			transfers
+//	@Produce		json
+//	@Param			id	path		string	true	"Transfer request ID"
+//	@Success		200	{object}	dto.HttpSuccessResp{data=entity.CarTransferRequest}
+//	@Failure		400	{object}	dto.HTTPErrorResp
+//	@Failure		403	{object}	dto.HTTPErrorResp
+//	@Failure		404	{object}	dto.HTTPErrorResp
+//	@Failure		409	{object}	dto.HTTPErrorResp
+//	@Failure		500	{object}	dto.HTTPErrorResp
+//	@Router			/transfer-requests/{id}/accept [post]
+func (e *rest) AcceptTransferRequest(w http.ResponseWriter, r *http.Request) {
+	e.respondTransferRequest(w, r, e.svc.Transfer.AcceptTransferRequest)
+}
+
+// RejectTransferRequest godoc
+//
+//	@Summary		Reject transfer request
+//	@Description	Reject a pending transfer request as its recipient
+//	@Tags			transfers
+//	@Produce		json
+//	@Param			id	path		string	true	"Transfer request ID"
+//	@Success		200	{object}	dto.HttpSuccessResp{data=entity.CarTransferRequest}
+//	@Failure		400	{object}	dto.HTTPErrorResp
+//	@Failure		403	{object}	dto.HTTPErrorResp
+//	@Failure		404	{object}	dto.HTTPErrorResp
+//	@Failure		409	{object}	dto.HTTPErrorResp
+//	@Failure		500	{object}	dto.HTTPErrorResp
+//	@Router			/transfer-requests/{id}/reject [post]
+func (e *rest) RejectTransferRequest(w http.ResponseWriter, r *http.Request) {
+	e.respondTransferRequest(w, r, e.svc.Transfer.RejectTransferRequest)
+}
+
+// CancelTransferRequest godoc
+//
+//	@Summary		Cancel transfer request
+//	@Description	Withdraw a pending transfer request as its sender, before the recipient accepts
+//	@Tags			transfers
+//	@Produce		json
+//	@Param			id	path		string	true	"Transfer request ID"
+//	@Success		200	{object}	dto.HttpSuccessResp{data=entity.CarTransferRequest}
+//	@Failure		400	{object}	dto.HTTPErrorResp
+//	@Failure		403	{object}	dto.HTTPErrorResp
+//	@Failure		404	{object}	dto.HTTPErrorResp
+//	@Failure		409	{object}	dto.HTTPErrorResp
+//	@Failure		500	{object}	dto.HTTPErrorResp
+//	@Router			/transfer-requests/{id}/cancel [post]
+func (e *rest) CancelTransferRequest(w http.ResponseWriter, r *http.Request) {
+	e.respondTransferRequest(w, r, e.svc.Transfer.CancelTransferRequest)
+}
+
+// listTransferRequests serves the incoming and outgoing lists, which differ
+// only in the side of the request the caller is on.
+func (e *rest) listTransferRequests(w http.ResponseWriter, r *http.Request, list func(context.Context, *dto.TransferRequestFilter, string) ([]entity.CarTransferRequest, *dto.Pagination, error)) {
+	ctx := r.Context()
+
+	authUser, ok := middleware.GetAuthUser(ctx)
+	if !ok {
+		e.httpRespError(w, r, appErr.NewWithCode(appErr.CodeHTTPUnauthorized, "unauthenticated"))
+		return
+	}
+
+	filter := util.DecodeURL[dto.TransferRequestFilter](r.URL.Query())
+	if err := validator.ValidateRequest(&filter); err != nil {
+		zerolog.Ctx(ctx).Warn().Err(err).Msg("validation_failed_list_transfer_requests")
+		e.httpRespError(w, r, err)
+		return
+	}
+
+	requests, pagination, err := list(ctx, &filter, authUser.UserID)
+	if err != nil {
+		e.httpRespError(w, r, err)
+		return
+	}
+
+	e.httpRespSuccess(w, r, http.StatusOK, requests, pagination)
+}
+
+// respondTransferRequest serves accept, reject and cancel; the service checks
+// that the caller is on the right side of the request.
+func (e *rest) respondTransferRequest(w http.ResponseWriter, r *http.Request, respond func(context.Context, uuid.UUID, string) (*entity.CarTransferRequest, error)) {
+	ctx := r.Context()
+
+	authUser, ok := middleware.GetAuthUser(ctx)
+	if !ok {
+		e.httpRespError(w, r, appErr.NewWithCode(appErr.CodeHTTPUnauthorized, "unauthenticated"))
+		return
+	}
+
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("invalid_transfer_request_id")
+		e.httpRespError(w, r, appErr.WrapWithCode(err, appErr.CodeHTTPBadRequest, "invalid_transfer_request_id"))
+		return
+	}
+
+	request, err := respond(ctx, id, authUser.UserID)
+	if err != nil {
+		e.httpRespError(w, r, err)
+		return
+	}
+
+	e.httpRespSuccess(w, r, http.StatusOK, request, nil)
+}
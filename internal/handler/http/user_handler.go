@@ -3,6 +3,7 @@ package rest
 import (
 	"encoding/json"
 	"net/http"
+	"slices"
 
 	"go-far/internal/infra/middleware"
 	"go-far/internal/infra/validator"
@@ -59,10 +60,24 @@ func (e *rest) CreateUser(w http.ResponseWriter, r *http.Request) {
 //	@Description	Get a user by their ID
 //	@Tags			users
 //	@Produce		json
-//	@Param			id	path		string	true	"User ID"
-//	@Success		200	{object}	dto.HttpSuccessResp{data=entity.User}
-//	@Failure		404	{object}	dto.HTTPErrorResp
-//	@Failure		500	{object}	dto.HTTPErrorResp
+//	@Param			Cache-Control		header		string	false	"Request cache control"	Enums(no-cache, no-store, max-age=60, only-if-cached)
+//	@Param			id					path		string	true	"User ID"
+//	@Param			fields				query		string	false	"Comma separated columns to return (e.g. id,name,email)"
+//	@Param			include				query		string	false	"Embed related records"	Enums(cars)
+//	@Param			include_deleted		query		bool	false	"Also find a soft-deleted user, bypassing the cache (admin only, not with include)"
+//	@Param			If-None-Match		header		string	false	"ETag of a cached copy"
+//	@Param			If-Modified-Since	header		string	false	"Last-Modified of a cached copy"
+//	@Success		200					{object}	dto.HttpSuccessResp{data=entity.UserWithCars}
+//	@Header			200					{string}	X-Cache			"HIT, MISS or BYPASS"
+//	@Header			200					{integer}	Age				"Seconds since the cached copy was stored"
+//	@Header			200					{string}	ETag			"Strong entity tag of the response data"
+//	@Header			200					{string}	Last-Modified	"updated_at of the record, without include"
+//	@Success		304					"Not Modified"
+//	@Failure		400					{object}	dto.HTTPErrorResp
+//	@Failure		403					{object}	dto.HTTPErrorResp
+//	@Failure		404					{object}	dto.HTTPErrorResp
+//	@Failure		500					{object}	dto.HTTPErrorResp
+//	@Failure		504					{object}	dto.HTTPErrorResp
 //	@Router			/users/{id} [get]
 func (e *rest) GetUser(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -74,13 +89,55 @@ func (e *rest) GetUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	user, err := e.svc.User.GetUser(ctx, id.String())
+	sel := util.DecodeURL[dto.FieldSelection](r.URL.Query())
+
+	includes, err := parseIncludes(sel, preference.IncludeCars)
 	if err != nil {
 		e.httpRespError(w, r, err)
 		return
 	}
 
-	e.httpRespSuccess(w, r, http.StatusOK, user, nil)
+	fields, err := parseFields[entity.User](sel)
+	if err != nil {
+		e.httpRespError(w, r, err)
+		return
+	}
+
+	includeDeleted, err := parseIncludeDeleted(r)
+	if err != nil {
+		e.httpRespError(w, r, err)
+		return
+	}
+
+	if includeDeleted && len(includes) > 0 {
+		e.httpRespError(w, r, appErr.NewWithCode(appErr.CodeHTTPBadRequest, "include_deleted_with_include"))
+		return
+	}
+
+	cacheControl := parseCacheControl(r)
+
+	var user any
+	if slices.Contains(includes, preference.IncludeCars) {
+		user, err = e.svc.User.GetUserWithCars(ctx, cacheControl, id.String(), fields)
+	} else {
+		var plain *entity.User
+		if includeDeleted {
+			plain, err = e.svc.User.GetUserWithDeleted(ctx, id.String())
+		} else {
+			plain, err = e.svc.User.GetUserColumns(ctx, cacheControl, id.String(), fields)
+		}
+		if err == nil {
+			setLastModified(w, plain.UpdatedAt)
+		}
+		user = plain
+	}
+	setCacheHeaders(w, cacheControl)
+	if err != nil {
+		e.httpRespError(w, r, err)
+		return
+	}
+
+	e.httpRespSuccess(w, r, http.StatusOK, util.Project(user, fields), nil)
 }
 
 // ListUsers godoc
@@ -89,7 +146,7 @@ func (e *rest) GetUser(w http.ResponseWriter, r *http.Request) {
 //	@Description	Get a paginated list of users with optional filters
 //	@Tags			users
 //	@Produce		json
-//	@Param			Cache-Control	header		string	false	"Request cache control"	Enums(must-revalidate, must-db-revalidate)
+//	@Param			Cache-Control	header		string	false	"Request cache control"	Enums(no-cache, no-store, max-age=60, only-if-cached)
 //	@Param			name			query		string	false	"Filter by name"
 //	@Param			email			query		string	false	"Filter by email"
 //	@Param			min_age			query		int		false	"Minimum age"
@@ -98,9 +155,16 @@ func (e *rest) GetUser(w http.ResponseWriter, r *http.Request) {
 //	@Param			page_size		query		int		false	"Page size"		default(10)
 //	@Param			sort_by			query		string	false	"Sort by field"
 //	@Param			sort_dir		query		string	false	"Sort direction (asc/desc)"	default(asc)
-//	@Success		200				{object}	dto.HttpSuccessResp{data=[]entity.User}
+//	@Param			fields			query		string	false	"Comma separated columns to return (e.g. id,name,email)"
+//	@Param			include			query		string	false	"Embed related records"	Enums(cars)
+//	@Param			include_deleted	query		bool	false	"Also list soft-deleted users (admin only)"
+//	@Success		200				{object}	dto.HttpSuccessResp{data=[]entity.UserWithCars}
+//	@Header			200				{string}	X-Cache	"HIT, MISS or BYPASS"
+//	@Header			200				{integer}	Age		"Seconds since the cached copy was stored"
 //	@Failure		400				{object}	dto.HTTPErrorResp
+//	@Failure		403				{object}	dto.HTTPErrorResp
 //	@Failure		500				{object}	dto.HTTPErrorResp
+//	@Failure		504				{object}	dto.HTTPErrorResp
 //	@Router			/users [get]
 func (e *rest) ListUsers(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -112,10 +176,24 @@ func (e *rest) ListUsers(w http.ResponseWriter, r *http.Request) {
 	}
 
 	filter := util.DecodeURL[dto.UserFilter](r.URL.Query())
-	cacheControl := dto.CacheControl{}
+	sel := util.DecodeURL[dto.FieldSelection](r.URL.Query())
+	cacheControl := parseCacheControl(r)
 
-	if r.Header.Get(preference.CacheControl) == preference.CacheMustRevalidate {
-		cacheControl.MustRevalidate = true
+	includes, err := parseIncludes(sel, preference.IncludeCars)
+	if err != nil {
+		e.httpRespError(w, r, err)
+		return
+	}
+
+	fields, err := parseFields[entity.User](sel)
+	if err != nil {
+		e.httpRespError(w, r, err)
+		return
+	}
+
+	if filter.IncludeDeleted, err = parseIncludeDeleted(r); err != nil {
+		e.httpRespError(w, r, err)
+		return
 	}
 
 	// Non-admin users can only see their own profile
@@ -123,13 +201,24 @@ func (e *rest) ListUsers(w http.ResponseWriter, r *http.Request) {
 		filter.ID = authUser.UserID
 	}
 
-	users, pagination, err := e.svc.User.ListUsers(ctx, cacheControl, &filter)
+	filter.Columns = fields
+
+	var (
+		users      any
+		pagination *dto.Pagination
+	)
+	if slices.Contains(includes, preference.IncludeCars) {
+		users, pagination, err = e.svc.User.ListUsersWithCars(ctx, cacheControl, &filter)
+	} else {
+		users, pagination, err = e.svc.User.ListUsers(ctx, cacheControl, &filter)
+	}
+	setCacheHeaders(w, cacheControl)
 	if err != nil {
 		e.httpRespError(w, r, err)
 		return
 	}
 
-	e.httpRespSuccess(w, r, http.StatusOK, users, pagination)
+	e.httpRespSuccess(w, r, http.StatusOK, util.Project(users, fields), pagination)
 }
 
 // UpdateUser godoc
@@ -139,12 +228,17 @@ func (e *rest) ListUsers(w http.ResponseWriter, r *http.Request) {
 //	@Tags			users
 //	@Accept			json
 //	@Produce		json
-//	@Param			id		path		string					true	"User ID"
-//	@Param			user	body		dto.UpdateUserRequest	true	"User data"
-//	@Success		200		{object}	dto.HttpSuccessResp{data=entity.User}
-//	@Failure		400		{object}	dto.HTTPErrorResp
-//	@Failure		404		{object}	dto.HTTPErrorResp
-//	@Failure		500		{object}	dto.HTTPErrorResp
+//	@Param			id					path		string					true	"User ID"
+//	@Param			user				body		dto.UpdateUserRequest	true	"User data"
+//	@Param			If-Match			header		string					false	"ETag the change is based on"
+//	@Param			If-Unmodified-Since	header		string					false	"Last-Modified the change is based on"
+//	@Success		200					{object}	dto.HttpSuccessResp{data=entity.User}
+//	@Header			200					{string}	ETag	"Entity tag of the updated user"
+//	@Failure		400					{object}	dto.HTTPErrorResp
+//	@Failure		404					{object}	dto.HTTPErrorResp
+//	@Failure		409					{object}	dto.HTTPErrorResp{data=entity.User}
+//	@Failure		412					{object}	dto.HTTPErrorResp
+//	@Failure		500					{object}	dto.HTTPErrorResp
 //	@Router			/users/{id} [put]
 func (e *rest) UpdateUser(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -169,25 +263,94 @@ func (e *rest) UpdateUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	version, err := e.checkUserPreconditions(r, id.String())
+	if err != nil {
+		e.httpRespError(w, r, err)
+		return
+	}
+
+	// Bind a passed precondition to the version it was checked against, so a
+	// write landing in between still fails with 409.
+	if req.Version == nil {
+		req.Version = version
+	}
+
 	user, err := e.svc.User.UpdateUser(ctx, id.String(), req)
 	if err != nil {
 		e.httpRespError(w, r, err)
 		return
 	}
 
+	setLastModified(w, user.UpdatedAt)
+	e.httpRespSuccess(w, r, http.StatusOK, user, nil)
+}
+
+// PatchUser godoc
+//
+//	@Summary		Patch user
+//	@Description	Partially update a user with a JSON Merge Patch (RFC 7396) or a JSON Patch (RFC 6902). The patch applies to the fields of dto.UserPatch and the result is validated as a whole, so a null age clears it.
+//	@Tags			users
+//	@Accept			application/merge-patch+json
+//	@Accept			application/json-patch+json
+//	@Produce		json
+//	@Param			id					path		string			true	"User ID"
+//	@Param			patch				body		dto.UserPatch	true	"Merge patch object, or an array of JSON Patch operations"
+//	@Param			If-Match			header		string			false	"ETag the change is based on"
+//	@Param			If-Unmodified-Since	header		string			false	"Last-Modified the change is based on"
+//	@Success		200					{object}	dto.HttpSuccessResp{data=entity.User}
+//	@Header			200					{string}	ETag	"Entity tag of the updated user"
+//	@Failure		400					{object}	dto.HTTPErrorResp
+//	@Failure		404					{object}	dto.HTTPErrorResp
+//	@Failure		409					{object}	dto.HTTPErrorResp{data=entity.User}
+//	@Failure		412					{object}	dto.HTTPErrorResp
+//	@Failure		415					{object}	dto.HTTPErrorResp
+//	@Failure		422					{object}	dto.HTTPErrorResp
+//	@Failure		500					{object}	dto.HTTPErrorResp
+//	@Router			/users/{id} [patch]
+func (e *rest) PatchUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("invalid_user_id")
+		e.httpRespError(w, r, appErr.WrapWithCode(err, appErr.CodeHTTPBadRequest, "invalid_user_id"))
+		return
+	}
+
+	req, err := readPatch(r)
+	if err != nil {
+		e.httpRespError(w, r, err)
+		return
+	}
+
+	if req.Version, err = e.checkUserPreconditions(r, id.String()); err != nil {
+		e.httpRespError(w, r, err)
+		return
+	}
+
+	user, err := e.svc.User.PatchUser(ctx, id.String(), req)
+	if err != nil {
+		e.httpRespError(w, r, err)
+		return
+	}
+
+	setLastModified(w, user.UpdatedAt)
 	e.httpRespSuccess(w, r, http.StatusOK, user, nil)
 }
 
 // DeleteUser godoc
 //
 //	@Summary		Delete user
-//	@Description	Delete a user by ID
+//	@Description	Soft-delete a user by ID. The user is hidden from every read and can be restored by an admin until the purge job removes it.
 //	@Tags			users
 //	@Produce		json
-//	@Param			id	path		string	true	"User ID"
-//	@Success		200	{object}	dto.HttpSuccessResp
-//	@Failure		404	{object}	dto.HTTPErrorResp
-//	@Failure		500	{object}	dto.HTTPErrorResp
+//	@Param			id					path		string	true	"User ID"
+//	@Param			If-Match			header		string	false	"ETag the change is based on"
+//	@Param			If-Unmodified-Since	header		string	false	"Last-Modified the change is based on"
+//	@Success		200					{object}	dto.HttpSuccessResp
+//	@Failure		404					{object}	dto.HTTPErrorResp
+//	@Failure		412					{object}	dto.HTTPErrorResp
+//	@Failure		500					{object}	dto.HTTPErrorResp
 //	@Router			/users/{id} [delete]
 func (e *rest) DeleteUser(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -199,6 +362,11 @@ func (e *rest) DeleteUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if _, err := e.checkUserPreconditions(r, id.String()); err != nil {
+		e.httpRespError(w, r, err)
+		return
+	}
+
 	if err := e.svc.User.DeleteUser(ctx, id.String()); err != nil {
 		e.httpRespError(w, r, err)
 		return
@@ -206,3 +374,59 @@ func (e *rest) DeleteUser(w http.ResponseWriter, r *http.Request) {
 
 	e.httpRespSuccess(w, r, http.StatusOK, nil, nil)
 }
+
+// RestoreUser godoc
+//
+//	@Summary		Restore user
+//	@Description	Undo the soft delete of a user (admin only)
+//	@Tags			users
+//	@Produce		json
+//	@Param			id	path		string	true	"User ID"
+//	@Success		200	{object}	dto.HttpSuccessResp{data=entity.User}
+//	@Failure		400	{object}	dto.HTTPErrorResp
+//	@Failure		403	{object}	dto.HTTPErrorResp
+//	@Failure		404	{object}	dto.HTTPErrorResp
+//	@Failure		500	{object}	dto.HTTPErrorResp
+//	@Router			/users/{id}/restore [post]
+func (e *rest) RestoreUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if !e.requireAdmin(w, r) {
+		return
+	}
+
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("invalid_user_id")
+		e.httpRespError(w, r, appErr.WrapWithCode(err, appErr.CodeHTTPBadRequest, "invalid_user_id"))
+		return
+	}
+
+	user, err := e.svc.User.RestoreUser(ctx, id.String())
+	if err != nil {
+		e.httpRespError(w, r, err)
+		return
+	}
+
+	e.httpRespSuccess(w, r, http.StatusOK, user, nil)
+}
+
+// checkUserPreconditions enforces If-Match and If-Unmodified-Since against
+// the user as currently stored, bypassing the cache. It returns the version
+// the preconditions held for, or nil when none were sent.
+func (e *rest) checkUserPreconditions(r *http.Request, id string) (*int64, error) {
+	if !hasPreconditions(r) {
+		return nil, nil
+	}
+
+	current, err := e.svc.User.GetUser(r.Context(), dto.CacheControl{MustDbValidate: true}, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkPreconditions(r, current, current.UpdatedAt); err != nil {
+		return nil, err
+	}
+
+	return &current.Version, nil
+}
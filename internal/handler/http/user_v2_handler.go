@@ -25,7 +25,10 @@ func (e *rest) ListUsersV2(w http.ResponseWriter, r *http.Request) {
 		filter.ID = authUser.UserID
 	}
 
-	users, pagination, err := e.svc.User.ListUsersV2(ctx, &filter)
+	cacheControl := parseCacheControl(r)
+
+	users, pagination, err := e.svc.User.ListUsersV2(ctx, cacheControl, &filter)
+	setCacheHeaders(w, cacheControl)
 	if err != nil {
 		e.httpRespError(w, r, err)
 		return
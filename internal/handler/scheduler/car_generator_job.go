@@ -64,7 +64,6 @@ type carData struct {
 	Color        string
 	LicensePlate string
 	Year         int
-	IsAvailable  bool
 }
 
 var (
@@ -191,7 +190,6 @@ func (j *CarGeneratorJob) generateRandomCar(ctx context.Context) *carData {
 		Year:         year,
 		Color:        carInfo.Colors[util.RandomInt(len(carInfo.Colors))],
 		LicensePlate: licensePlate,
-		IsAvailable:  util.RandomFloat32() < 0.7,
 	}
 }
 
@@ -0,0 +1,77 @@
+package scheduler
+
+import (
+	"context"
+
+	cfg "go-far/internal/infra/scheduler"
+	"go-far/internal/preference"
+	"go-far/internal/service/transfer"
+
+	"github.com/rs/zerolog"
+)
+
+// defaultExpireTransfersBatchSize is used when batch_size is not positive.
+const defaultExpireTransfersBatchSize = 500
+
+// ExpireTransfersJob marks pending car transfer requests whose TTL has
+// passed as expired.
+type ExpireTransfersJob struct {
+	transferService transfer.TransferServiceItf
+	log             *zerolog.Logger
+	config          *cfg.ExpireTransfersJobOptions
+}
+
+func InitExpireTransfersJob(log *zerolog.Logger, transferService transfer.TransferServiceItf, opts *cfg.ExpireTransfersJobOptions) *ExpireTransfersJob {
+	config := *opts
+	if config.BatchSize <= 0 {
+		config.BatchSize = defaultExpireTransfersBatchSize
+	}
+
+	return &ExpireTransfersJob{
+		log:             log,
+		transferService: transferService,
+		config:          &config,
+	}
+}
+
+func (j *ExpireTransfersJob) logWithContext(ctx context.Context) *zerolog.Event {
+	event := j.log.Info()
+
+	traceID, _ := ctx.Value(preference.CONTEXT_KEY_LOG_TRACE_ID).(string)
+	spanID, _ := ctx.Value(preference.CONTEXT_KEY_LOG_SPAN_ID).(string)
+
+	if traceID != "" {
+		event = event.Str(string(preference.CONTEXT_KEY_LOG_TRACE_ID), traceID)
+	}
+	if spanID != "" {
+		event = event.Str(string(preference.CONTEXT_KEY_LOG_SPAN_ID), spanID)
+	}
+
+	return event
+}
+
+func (j *ExpireTransfersJob) Name() string {
+	return "expire_transfers"
+}
+
+func (j *ExpireTransfersJob) Schedule() string {
+	return j.config.Cron
+}
+
+func (j *ExpireTransfersJob) Run(ctx context.Context) error {
+	if !j.config.Enabled {
+		j.logWithContext(ctx).Msg("ExpireTransfersJob is disabled")
+		return nil
+	}
+
+	expired, err := j.transferService.ExpireTransferRequests(ctx, j.config.BatchSize)
+	if err != nil {
+		return err
+	}
+
+	j.logWithContext(ctx).
+		Int64("expired", expired).
+		Msg("Expiry of transfer requests completed")
+
+	return nil
+}
@@ -0,0 +1,71 @@
+package scheduler
+
+import (
+	"context"
+
+	cfg "go-far/internal/infra/scheduler"
+	"go-far/internal/preference"
+	"go-far/internal/service/servicerecord"
+
+	"github.com/rs/zerolog"
+)
+
+// FlagOverdueServicesJob flags cars not serviced within the configured
+// interval, and clears the flag of cars serviced since they were flagged.
+type FlagOverdueServicesJob struct {
+	serviceRecordService servicerecord.ServiceRecordServiceItf
+	log                  *zerolog.Logger
+	config               *cfg.FlagOverdueServicesJobOptions
+}
+
+func InitFlagOverdueServicesJob(log *zerolog.Logger, serviceRecordService servicerecord.ServiceRecordServiceItf, opts *cfg.FlagOverdueServicesJobOptions) *FlagOverdueServicesJob {
+	return &FlagOverdueServicesJob{
+		log:                  log,
+		serviceRecordService: serviceRecordService,
+		config:               opts,
+	}
+}
+
+func (j *FlagOverdueServicesJob) logWithContext(ctx context.Context) *zerolog.Event {
+	event := j.log.Info()
+
+	traceID, _ := ctx.Value(preference.CONTEXT_KEY_LOG_TRACE_ID).(string)
+	spanID, _ := ctx.Value(preference.CONTEXT_KEY_LOG_SPAN_ID).(string)
+
+	if traceID != "" {
+		event = event.Str(string(preference.CONTEXT_KEY_LOG_TRACE_ID), traceID)
+	}
+	if spanID != "" {
+		event = event.Str(string(preference.CONTEXT_KEY_LOG_SPAN_ID), spanID)
+	}
+
+	return event
+}
+
+func (j *FlagOverdueServicesJob) Name() string {
+	return "flag_overdue_services"
+}
+
+func (j *FlagOverdueServicesJob) Schedule() string {
+	return j.config.Cron
+}
+
+func (j *FlagOverdueServicesJob) Run(ctx context.Context) error {
+	if !j.config.Enabled {
+		j.logWithContext(ctx).Msg("FlagOverdueServicesJob is disabled")
+		return nil
+	}
+
+	flagged, cleared, err := j.serviceRecordService.FlagOverdueCars(ctx, j.config.Interval, j.config.BatchSize)
+	if err != nil {
+		return err
+	}
+
+	j.logWithContext(ctx).
+		Int64("flagged", flagged).
+		Int64("cleared", cleared).
+		Dur("interval", j.config.Interval).
+		Msg("Overdue service check completed")
+
+	return nil
+}
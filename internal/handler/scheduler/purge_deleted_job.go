@@ -0,0 +1,102 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	cfg "go-far/internal/infra/scheduler"
+	"go-far/internal/preference"
+	"go-far/internal/service/car"
+	"go-far/internal/service/user"
+
+	"github.com/rs/zerolog"
+)
+
+const (
+	defaultPurgeRetention = 30 * 24 * time.Hour
+	defaultPurgeBatchSize = 500
+)
+
+// PurgeDeletedJob hard-deletes users and cars that have stayed soft-deleted
+// for longer than the configured retention period.
+type PurgeDeletedJob struct {
+	userService user.UserServiceItf
+	carService  car.CarServiceItf
+	log         *zerolog.Logger
+	config      *cfg.PurgeDeletedJobOptions
+}
+
+// InitPurgeDeletedJob falls back to the default retention and batch size
+// when they are not positive: a zero retention would purge rows the moment
+// they are soft-deleted, and a zero batch size would never finish a batch.
+func InitPurgeDeletedJob(log *zerolog.Logger, userService user.UserServiceItf, carService car.CarServiceItf, opts *cfg.PurgeDeletedJobOptions) *PurgeDeletedJob {
+	config := *opts
+	if config.Retention <= 0 {
+		config.Retention = defaultPurgeRetention
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = defaultPurgeBatchSize
+	}
+
+	return &PurgeDeletedJob{
+		log:         log,
+		userService: userService,
+		carService:  carService,
+		config:      &config,
+	}
+}
+
+func (j *PurgeDeletedJob) logWithContext(ctx context.Context) *zerolog.Event {
+	event := j.log.Info()
+
+	traceID, _ := ctx.Value(preference.CONTEXT_KEY_LOG_TRACE_ID).(string)
+	spanID, _ := ctx.Value(preference.CONTEXT_KEY_LOG_SPAN_ID).(string)
+
+	if traceID != "" {
+		event = event.Str(string(preference.CONTEXT_KEY_LOG_TRACE_ID), traceID)
+	}
+	if spanID != "" {
+		event = event.Str(string(preference.CONTEXT_KEY_LOG_SPAN_ID), spanID)
+	}
+
+	return event
+}
+
+func (j *PurgeDeletedJob) Name() string {
+	return "purge_deleted"
+}
+
+func (j *PurgeDeletedJob) Schedule() string {
+	return j.config.Cron
+}
+
+func (j *PurgeDeletedJob) Run(ctx context.Context) error {
+	if !j.config.Enabled {
+		j.logWithContext(ctx).Msg("PurgeDeletedJob is disabled")
+		return nil
+	}
+
+	cutoff := time.Now().Add(-j.config.Retention)
+
+	j.logWithContext(ctx).
+		Time("deleted_before", cutoff).
+		Int("batch_size", j.config.BatchSize).
+		Msg("Purging soft-deleted records")
+
+	cars, err := j.carService.PurgeDeletedCars(ctx, cutoff, j.config.BatchSize)
+	if err != nil {
+		return err
+	}
+
+	users, err := j.userService.PurgeDeletedUsers(ctx, cutoff, j.config.BatchSize)
+	if err != nil {
+		return err
+	}
+
+	j.logWithContext(ctx).
+		Int64("cars", cars).
+		Int64("users", users).
+		Msg("Purge of soft-deleted records completed")
+
+	return nil
+}
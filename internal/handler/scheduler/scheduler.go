@@ -75,6 +75,30 @@ func (s *schedulerHandler) Serve() {
 		}
 	}
 
+	// Soft-delete purge
+	if s.jobs.PurgeDeletedJob != nil && s.jobs.PurgeDeletedJob.Enabled {
+		purgeJob := InitPurgeDeletedJob(s.log, s.svc.User, s.svc.Car, s.jobs.PurgeDeletedJob)
+		if err := s.sch.AddJob(purgeJob); err != nil {
+			s.log.Error().Err(err).Msg("Failed to add PurgeDeletedJob to scheduler")
+		}
+	}
+
+	// Transfer request expiry
+	if s.jobs.ExpireTransfersJob != nil && s.jobs.ExpireTransfersJob.Enabled {
+		expireJob := InitExpireTransfersJob(s.log, s.svc.Transfer, s.jobs.ExpireTransfersJob)
+		if err := s.sch.AddJob(expireJob); err != nil {
+			s.log.Error().Err(err).Msg("Failed to add ExpireTransfersJob to scheduler")
+		}
+	}
+
+	// Overdue car services
+	if s.jobs.FlagOverdueServicesJob != nil && s.jobs.FlagOverdueServicesJob.Enabled {
+		overdueJob := InitFlagOverdueServicesJob(s.log, s.svc.ServiceRecord, s.jobs.FlagOverdueServicesJob)
+		if err := s.sch.AddJob(overdueJob); err != nil {
+			s.log.Error().Err(err).Msg("Failed to add FlagOverdueServicesJob to scheduler")
+		}
+	}
+
 	// Start scheduler
 	s.sch.Start()
 }
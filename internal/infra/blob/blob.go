@@ -0,0 +1,71 @@
+package blob
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+const (
+	DriverLocal = "local"
+	DriverS3    = "s3"
+)
+
+// BlobStore keeps uploaded files by key. Keys are slash-separated paths
+// chosen by the caller.
+type BlobStore interface {
+	Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error
+	// Get returns the stored file; a missing key is a CodeHTTPNotFound error.
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete removes the file; deleting a missing key is not an error.
+	Delete(ctx context.Context, key string) error
+}
+
+// BlobOptions selects and configures the BlobStore implementation
+type BlobOptions struct {
+	Driver string        `yaml:"driver"`
+	Local  *LocalOptions `yaml:"local"`
+	S3     *S3Options    `yaml:"s3"`
+}
+
+// LocalOptions holds the local filesystem store configuration
+type LocalOptions struct {
+	Root string `yaml:"root"`
+}
+
+// S3Options holds the configuration of an S3-compatible store. Endpoint can
+// be any server speaking the S3 API, such as MinIO running locally.
+type S3Options struct {
+	Endpoint  string        `yaml:"endpoint"`
+	Region    string        `yaml:"region"`
+	Bucket    string        `yaml:"bucket"`
+	AccessKey string        `yaml:"access_key" env:"S3_ACCESS_KEY_GO_FAR"`
+	SecretKey string        `yaml:"secret_key" env:"S3_SECRET_KEY_GO_FAR"`
+	Timeout   time.Duration `yaml:"timeout"`
+	PathStyle bool          `yaml:"path_style"`
+}
+
+// InitBlobStore initializes the BlobStore named by opt.Driver
+func InitBlobStore(log *zerolog.Logger, opt *BlobOptions) BlobStore {
+	switch opt.Driver {
+	case DriverLocal:
+		store, err := newLocalStore(opt.Local)
+		if err != nil {
+			log.Panic().Err(err).Msg("Failed to initialize local blob store")
+		}
+		log.Info().Str("root", opt.Local.Root).Msg("Local blob store initialized")
+		return store
+	case DriverS3:
+		store, err := newS3Store(opt.S3)
+		if err != nil {
+			log.Panic().Err(err).Msg("Failed to initialize S3 blob store")
+		}
+		log.Info().Str("endpoint", opt.S3.Endpoint).Str("bucket", opt.S3.Bucket).Msg("S3 blob store initialized")
+		return store
+	default:
+		log.Panic().Str("driver", opt.Driver).Msg("Unknown blob store driver")
+		return nil
+	}
+}
@@ -0,0 +1,111 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	appErr "go-far/internal/model/errors"
+)
+
+// localStore keeps files under a root directory, one file per key.
+type localStore struct {
+	root string
+}
+
+func newLocalStore(opt *LocalOptions) (*localStore, error) {
+	if opt == nil || opt.Root == "" {
+		return nil, appErr.New("local blob store root is not set")
+	}
+
+	root, err := filepath.Abs(opt.Root)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(root, 0o750); err != nil {
+		return nil, err
+	}
+
+	return &localStore{root: root}, nil
+}
+
+// path maps key into the root, refusing keys that would leave it.
+func (s *localStore) path(key string) (string, error) {
+	path := filepath.Join(s.root, filepath.FromSlash(key))
+	if !strings.HasPrefix(path, s.root+string(filepath.Separator)) {
+		return "", appErr.NewWithCode(appErr.CodeHTTPBadRequest, "invalid_blob_key")
+	}
+
+	return path, nil
+}
+
+// Put writes to a temporary file first, so a failed upload never leaves a
+// partial file under key.
+func (s *localStore) Put(_ context.Context, key string, body io.Reader, _ int64, _ string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return appErr.Wrap(err, "create_blob_dir")
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return appErr.Wrap(err, "create_blob_file")
+	}
+	defer func() {
+		_ = os.Remove(tmp.Name())
+	}()
+
+	if _, err := io.Copy(tmp, body); err != nil {
+		_ = tmp.Close()
+		return appErr.Wrap(err, "write_blob_file")
+	}
+
+	if err := tmp.Close(); err != nil {
+		return appErr.Wrap(err, "write_blob_file")
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return appErr.Wrap(err, "rename_blob_file")
+	}
+
+	return nil
+}
+
+func (s *localStore) Get(_ context.Context, key string) (io.ReadCloser, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, appErr.WrapWithCode(err, appErr.CodeHTTPNotFound, "blob_not_found")
+	}
+	if err != nil {
+		return nil, appErr.Wrap(err, "open_blob_file")
+	}
+
+	return file, nil
+}
+
+func (s *localStore) Delete(_ context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return appErr.Wrap(err, "delete_blob_file")
+	}
+
+	return nil
+}
@@ -0,0 +1,241 @@
+package blob
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	appErr "go-far/internal/model/errors"
+)
+
+const (
+	s3Service         = "s3"
+	s3Algorithm       = "AWS4-HMAC-SHA256"
+	s3UnsignedPayload = "UNSIGNED-PAYLOAD"
+	s3DefaultTimeout  = 30 * time.Second
+)
+
+// s3Store talks to an S3-compatible API with Signature Version 4. Payloads
+// are sent unsigned so uploads stream without being hashed twice.
+type s3Store struct {
+	client   *http.Client
+	endpoint *url.URL
+	opt      S3Options
+}
+
+func newS3Store(opt *S3Options) (*s3Store, error) {
+	if opt == nil || opt.Endpoint == "" || opt.Bucket == "" {
+		return nil, appErr.New("S3 blob store endpoint and bucket must be set")
+	}
+
+	endpoint, err := url.Parse(strings.TrimSuffix(opt.Endpoint, "/"))
+	if err != nil {
+		return nil, err
+	}
+
+	timeout := opt.Timeout
+	if timeout == 0 {
+		timeout = s3DefaultTimeout
+	}
+
+	return &s3Store{
+		// Not the failsafe client: retrying an upload would resend a body
+		// that has already been consumed.
+		client:   &http.Client{Timeout: timeout},
+		endpoint: endpoint,
+		opt:      *opt,
+	}, nil
+}
+
+func (s *s3Store) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	req, err := s.newRequest(ctx, http.MethodPut, key, body)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = size
+	req.Header.Set("Content-Type", contentType)
+
+	resp, err := s.do(req)
+	if err != nil {
+		return err
+	}
+	defer drain(resp)
+
+	if resp.StatusCode != http.StatusOK {
+		return s3Error(resp, "put_blob_object")
+	}
+
+	return nil
+}
+
+func (s *s3Store) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	req, err := s.newRequest(ctx, http.MethodGet, key, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return resp.Body, nil
+	case http.StatusNotFound:
+		drain(resp)
+		return nil, appErr.NewWithCode(appErr.CodeHTTPNotFound, "blob_not_found")
+	default:
+		defer drain(resp)
+		return nil, s3Error(resp, "get_blob_object")
+	}
+}
+
+func (s *s3Store) Delete(ctx context.Context, key string) error {
+	req, err := s.newRequest(ctx, http.MethodDelete, key, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := s.do(req)
+	if err != nil {
+		return err
+	}
+	defer drain(resp)
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusNoContent, http.StatusNotFound:
+		return nil
+	default:
+		return s3Error(resp, "delete_blob_object")
+	}
+}
+
+// newRequest addresses key path-style (endpoint/bucket/key) or, by default,
+// virtual-hosted style (bucket.endpoint/key).
+func (s *s3Store) newRequest(ctx context.Context, method, key string, body io.Reader) (*http.Request, error) {
+	target := *s.endpoint
+	if s.opt.PathStyle {
+		target.Path += "/" + s.opt.Bucket + "/" + key
+	} else {
+		target.Host = s.opt.Bucket + "." + target.Host
+		target.Path += "/" + key
+	}
+	target.RawPath = s3EscapePath(target.Path)
+
+	req, err := http.NewRequestWithContext(ctx, method, target.String(), body)
+	if err != nil {
+		return nil, appErr.Wrap(err, "create_s3_request")
+	}
+
+	return req, nil
+}
+
+func (s *s3Store) do(req *http.Request) (*http.Response, error) {
+	s.sign(req, time.Now().UTC())
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, appErr.WrapWithCode(err, appErr.CodeHTTPServiceUnavailable, "blob_store_unavailable")
+	}
+
+	return resp, nil
+}
+
+// s3SignedHeaders are the headers covered by the signature, in canonical order.
+var s3SignedHeaders = []string{"host", "x-amz-content-sha256", "x-amz-date"}
+
+// sign adds a Signature Version 4 Authorization header covering the host
+// and the x-amz-* headers.
+func (s *s3Store) sign(req *http.Request, now time.Time) {
+	req.Header.Set("X-Amz-Date", now.Format("20060102T150405Z"))
+	req.Header.Set("X-Amz-Content-Sha256", s3UnsignedPayload)
+
+	canonicalRequest := s3CanonicalRequest(req, s3SignedHeaders, s3UnsignedPayload)
+	scope, signature := s3Signature(s.opt.SecretKey, s.opt.Region, now, canonicalRequest)
+
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s3Algorithm, s.opt.AccessKey, scope, strings.Join(s3SignedHeaders, ";"), signature))
+}
+
+// s3CanonicalRequest builds the canonical form of req that SigV4 signs.
+// signedHeaders are lower-case and sorted; host is taken from the URL. Query
+// strings are used as they are, since the store never sends more than one
+// parameter.
+func s3CanonicalRequest(req *http.Request, signedHeaders []string, payloadHash string) string {
+	var headers strings.Builder
+	for _, name := range signedHeaders {
+		value := req.Header.Get(name)
+		if name == "host" {
+			value = req.URL.Host
+		}
+		headers.WriteString(name + ":" + strings.TrimSpace(value) + "\n")
+	}
+
+	return strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		headers.String(),
+		strings.Join(signedHeaders, ";"),
+		payloadHash,
+	}, "\n")
+}
+
+// s3Signature signs canonicalRequest for the S3 service in region at now and
+// returns the credential scope with the hex signature.
+func s3Signature(secretKey, region string, now time.Time, canonicalRequest string) (scope, signature string) {
+	amzDate := now.Format("20060102T150405Z")
+	day := now.Format("20060102")
+
+	scope = day + "/" + region + "/" + s3Service + "/aws4_request"
+	canonicalHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := s3Algorithm + "\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(canonicalHash[:])
+
+	key := hmacSHA256([]byte("AWS4"+secretKey), day)
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, s3Service)
+	key = hmacSHA256(key, "aws4_request")
+
+	return scope, hex.EncodeToString(hmacSHA256(key, stringToSign))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// s3EscapePath percent-encodes everything but unreserved characters and
+// slashes, as the canonical request requires.
+func s3EscapePath(path string) string {
+	var sb strings.Builder
+	for i := range len(path) {
+		c := path[i]
+		switch {
+		case c >= 'A' && c <= 'Z', c >= 'a' && c <= 'z', c >= '0' && c <= '9',
+			c == '-', c == '_', c == '.', c == '~', c == '/':
+			sb.WriteByte(c)
+		default:
+			fmt.Fprintf(&sb, "%%%02X", c)
+		}
+	}
+	return sb.String()
+}
+
+func s3Error(resp *http.Response, msg string) error {
+	detail, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	return appErr.NewWithCode(appErr.CodeHTTPServiceUnavailable, "%s: status %d: %s", msg, resp.StatusCode, strings.TrimSpace(string(detail)))
+}
+
+func drain(resp *http.Response) {
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	_ = resp.Body.Close()
+}
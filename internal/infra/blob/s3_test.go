@@ -0,0 +1,229 @@
+package blob
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	appErr "go-far/internal/model/errors"
+)
+
+// TestS3SignatureVector checks the signer against the GET Object example of
+// the AWS Signature Version 4 documentation for S3.
+func TestS3SignatureVector(t *testing.T) {
+	const (
+		secretKey   = "wJalrXUtnFEMI/K7MDENG/bPxRfiCYEXAMPLEKEY"
+		emptyHash   = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+		wantRequest = "GET\n" +
+			"/test.txt\n" +
+			"\n" +
+			"host:examplebucket.s3.amazonaws.com\n" +
+			"range:bytes=0-9\n" +
+			"x-amz-content-sha256:" + emptyHash + "\n" +
+			"x-amz-date:20130524T000000Z\n" +
+			"\n" +
+			"host;range;x-amz-content-sha256;x-amz-date\n" +
+			emptyHash
+		wantSignature = "f0e8bdb87c964420e857bd35b5d6ed310bd44f0170aba48dd91039c6036bdb41"
+	)
+
+	req := httptest.NewRequest(http.MethodGet, "https://examplebucket.s3.amazonaws.com/test.txt", nil)
+	req.Header.Set("Range", "bytes=0-9")
+	req.Header.Set("X-Amz-Content-Sha256", emptyHash)
+	req.Header.Set("X-Amz-Date", "20130524T000000Z")
+
+	canonical := s3CanonicalRequest(req, []string{"host", "range", "x-amz-content-sha256", "x-amz-date"}, emptyHash)
+	if canonical != wantRequest {
+		t.Fatalf("canonical request:\n%s\nwant:\n%s", canonical, wantRequest)
+	}
+
+	scope, signature := s3Signature(secretKey, "us-east-1", time.Date(2013, 5, 24, 0, 0, 0, 0, time.UTC), canonical)
+	if scope != "20130524/us-east-1/s3/aws4_request" {
+		t.Errorf("scope = %s", scope)
+	}
+	if signature != wantSignature {
+		t.Errorf("signature = %s, want %s", signature, wantSignature)
+	}
+}
+
+func TestS3EscapePath(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{path: "/bucket/cars/1/photo.jpg", want: "/bucket/cars/1/photo.jpg"},
+		{path: "/bucket/a-b_c.d~e", want: "/bucket/a-b_c.d~e"},
+		{path: "/bucket/my photo.jpg", want: "/bucket/my%20photo.jpg"},
+		{path: "/bucket/a+b=c&d", want: "/bucket/a%2Bb%3Dc%26d"},
+		{path: "/bucket/fahrzeugschein-ü.pdf", want: "/bucket/fahrzeugschein-%C3%BC.pdf"},
+	}
+
+	for _, tt := range tests {
+		if got := s3EscapePath(tt.path); got != tt.want {
+			t.Errorf("s3EscapePath(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
+
+// fakeS3 is a path-style S3 stand-in that checks every request's signature
+// before serving it from memory, answering 403 to the ones that do not match.
+type fakeS3 struct {
+	t        *testing.T
+	opt      S3Options
+	mu       sync.Mutex
+	objects  map[string][]byte
+	types    map[string]string
+	rejected []string
+}
+
+func newFakeS3(t *testing.T, opt S3Options) *fakeS3 {
+	return &fakeS3{t: t, opt: opt, objects: make(map[string][]byte), types: make(map[string]string)}
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if !f.validSignature(r) {
+		f.rejected = append(f.rejected, r.Method+" "+r.URL.Path)
+		http.Error(w, "SignatureDoesNotMatch", http.StatusForbidden)
+		return
+	}
+
+	key := r.URL.Path
+	switch r.Method {
+	case http.MethodPut:
+		body, _ := io.ReadAll(r.Body)
+		if int64(len(body)) != r.ContentLength {
+			f.t.Errorf("PUT %s: read %d bytes, Content-Length %d", key, len(body), r.ContentLength)
+		}
+		f.objects[key] = body
+		f.types[key] = r.Header.Get("Content-Type")
+	case http.MethodGet:
+		body, ok := f.objects[key]
+		if !ok {
+			http.Error(w, "NoSuchKey", http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", f.types[key])
+		_, _ = w.Write(body)
+	case http.MethodDelete:
+		delete(f.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// validSignature recomputes the signature from the request as received.
+func (f *fakeS3) validSignature(r *http.Request) bool {
+	now, err := time.Parse("20060102T150405Z", r.Header.Get("X-Amz-Date"))
+	if err != nil {
+		return false
+	}
+
+	received := r.Clone(r.Context())
+	received.URL.Host = r.Host
+
+	_, signature := s3Signature(f.opt.SecretKey, f.opt.Region, now,
+		s3CanonicalRequest(received, s3SignedHeaders, r.Header.Get("X-Amz-Content-Sha256")))
+	want := s3Algorithm + " Credential=" + f.opt.AccessKey + "/" + now.Format("20060102") + "/" + f.opt.Region +
+		"/s3/aws4_request, SignedHeaders=host;x-amz-content-sha256;x-amz-date, Signature=" + signature
+
+	return r.Header.Get("Authorization") == want
+}
+
+func TestS3StoreRoundTrip(t *testing.T) {
+	opt := S3Options{Region: "eu-central-1", Bucket: "go-far", AccessKey: "AKIDEXAMPLE", SecretKey: "secret", PathStyle: true}
+	fake := newFakeS3(t, opt)
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	opt.Endpoint = server.URL + "/"
+	store, err := newS3Store(&opt)
+	if err != nil {
+		t.Fatalf("newS3Store: %v", err)
+	}
+
+	ctx := context.Background()
+	key := "cars/0192/photo 1+front.jpg"
+	content := []byte("\xff\xd8\xff\xe0 not really a jpeg")
+
+	if err := store.Put(ctx, key, bytes.NewReader(content), int64(len(content)), "image/jpeg"); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if _, ok := fake.objects["/go-far/"+key]; !ok {
+		t.Fatalf("object not stored under its path-style key, have %v", fake.objects)
+	}
+	if got := fake.types["/go-far/"+key]; got != "image/jpeg" {
+		t.Errorf("stored Content-Type = %q, want image/jpeg", got)
+	}
+
+	body, err := store.Get(ctx, key)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	got, _ := io.ReadAll(body)
+	_ = body.Close()
+	if !bytes.Equal(got, content) {
+		t.Errorf("Get returned %q, want %q", got, content)
+	}
+
+	if err := store.Delete(ctx, key); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := store.Get(ctx, key); appErr.ErrCode(err) != appErr.CodeHTTPNotFound {
+		t.Errorf("Get after Delete: err = %v, want CodeHTTPNotFound", err)
+	}
+	if err := store.Delete(ctx, key); err != nil {
+		t.Errorf("Delete of a missing key: %v", err)
+	}
+
+	if len(fake.rejected) > 0 {
+		t.Errorf("requests with a bad signature: %v", fake.rejected)
+	}
+}
+
+func TestS3StoreRejectedSignature(t *testing.T) {
+	opt := S3Options{Region: "eu-central-1", Bucket: "go-far", AccessKey: "AKIDEXAMPLE", SecretKey: "secret", PathStyle: true}
+	fake := newFakeS3(t, opt)
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	opt.Endpoint = server.URL
+	opt.SecretKey = "wrong"
+	store, err := newS3Store(&opt)
+	if err != nil {
+		t.Fatalf("newS3Store: %v", err)
+	}
+
+	err = store.Put(context.Background(), "k", strings.NewReader("x"), 1, "text/plain")
+	if appErr.ErrCode(err) != appErr.CodeHTTPServiceUnavailable || !strings.Contains(err.Error(), "status 403") {
+		t.Errorf("Put with a bad key: err = %v, want a 403 surfaced as CodeHTTPServiceUnavailable", err)
+	}
+	if len(fake.rejected) != 1 {
+		t.Errorf("rejected = %v, want the one PUT", fake.rejected)
+	}
+}
+
+func TestS3VirtualHostedRequest(t *testing.T) {
+	store, err := newS3Store(&S3Options{Endpoint: "https://s3.eu-central-1.amazonaws.com", Bucket: "go-far"})
+	if err != nil {
+		t.Fatalf("newS3Store: %v", err)
+	}
+
+	req, err := store.newRequest(context.Background(), http.MethodGet, "cars/1/a b.pdf", nil)
+	if err != nil {
+		t.Fatalf("newRequest: %v", err)
+	}
+
+	if got, want := req.URL.String(), "https://go-far.s3.eu-central-1.amazonaws.com/cars/1/a%20b.pdf"; got != want {
+		t.Errorf("URL = %s, want %s", got, want)
+	}
+}
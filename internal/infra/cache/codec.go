@@ -0,0 +1,80 @@
+package cache
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/golang/snappy"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// Codec converts cached values to and from the bytes stored in Redis.
+type Codec interface {
+	Marshal(v any) ([]byte, error)
+	Unmarshal(data []byte, v any) error
+}
+
+var (
+	// JSONCodec is readable in redis-cli and honours json tags, so fields
+	// hidden with `json:"-"` are never cached.
+	JSONCodec Codec = jsonCodec{}
+	// MsgpackCodec is smaller and faster than JSON. It reads json tags too, so
+	// both codecs cache the same fields.
+	MsgpackCodec Codec = msgpackCodec{}
+)
+
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(v any) ([]byte, error) { return json.Marshal(v) }
+
+func (jsonCodec) Unmarshal(data []byte, v any) error { return json.Unmarshal(data, v) }
+
+type msgpackCodec struct{}
+
+func (msgpackCodec) Marshal(v any) ([]byte, error) {
+	var buf bytes.Buffer
+
+	enc := msgpack.NewEncoder(&buf)
+	enc.SetCustomStructTag("json")
+
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (msgpackCodec) Unmarshal(data []byte, v any) error {
+	dec := msgpack.NewDecoder(bytes.NewReader(data))
+	dec.SetCustomStructTag("json")
+
+	return dec.Decode(v)
+}
+
+// SnappyCodec compresses the output of inner, trading a little CPU for less
+// Redis memory on large values such as list pages.
+func SnappyCodec(inner Codec) Codec {
+	return snappyCodec{inner: inner}
+}
+
+type snappyCodec struct {
+	inner Codec
+}
+
+func (c snappyCodec) Marshal(v any) ([]byte, error) {
+	raw, err := c.inner.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	return snappy.Encode(nil, raw), nil
+}
+
+func (c snappyCodec) Unmarshal(data []byte, v any) error {
+	raw, err := snappy.Decode(nil, data)
+	if err != nil {
+		return err
+	}
+
+	return c.inner.Unmarshal(raw, v)
+}
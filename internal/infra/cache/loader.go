@@ -0,0 +1,296 @@
+package cache
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"math/rand/v2"
+	"strconv"
+	"time"
+
+	"go-far/internal/infra/database"
+	"go-far/internal/model/dto"
+	appErr "go-far/internal/model/errors"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	// DefaultJitter spreads expirations by ±10% so keys cached together do not
+	// all expire in the same instant.
+	DefaultJitter = 0.1
+
+	entryValue     byte = 1
+	entryNotFound  byte = 0
+	entryHeaderLen      = 9
+)
+
+// LoaderOptions configures a Loader.
+type LoaderOptions struct {
+	// TTL is how long a loaded value stays cached.
+	TTL time.Duration
+	// Jitter is the fraction of TTL added or removed at random on each write.
+	Jitter float64
+	// NegativeTTL caches load errors carrying NotFoundCode, so lookups of
+	// missing rows do not reach the database every time. Zero disables it.
+	NegativeTTL  time.Duration
+	NotFoundCode appErr.Code
+	// Codec encodes values; it defaults to JSONCodec.
+	Codec Codec
+}
+
+// Loader is a read-through cache for values of type T, checking the Store's
+// L1 before Redis. Concurrent misses on the same key are coalesced into a
+// single load, so a hot key expiring sends one query to the database instead
+// of one per waiting request.
+type Loader[T any] struct {
+	store *Store
+	group singleflight.Group
+	opt   LoaderOptions
+}
+
+func NewLoader[T any](store *Store, opt LoaderOptions) *Loader[T] {
+	if opt.Codec == nil {
+		opt.Codec = JSONCodec
+	}
+
+	return &Loader[T]{
+		store: store,
+		opt:   opt,
+	}
+}
+
+// Get returns the value cached under base, versioned by tags, calling load on
+// a miss. control carries the client's Cache-Control directives and receives
+// the outcome. Cache failures are logged and fall back to load.
+func (l *Loader[T]) Get(ctx context.Context, control dto.CacheControl, base string, tags []string, load func(context.Context) (T, error)) (T, error) {
+	// Inside a transaction load may see uncommitted rows, which must neither
+	// be cached nor shared with other callers.
+	if control.MustDbValidate || database.InTx(ctx) {
+		control.Result.Record(dto.CacheBypass, 0)
+		return load(ctx)
+	}
+
+	local := l.store.local
+
+	if !control.MustRevalidate {
+		if hit, ok := l.readL1(ctx, base, control.MaxAge); ok {
+			control.Result.Record(dto.CacheHit, hit.age())
+			return hit.value, hit.err
+		}
+	}
+
+	epoch := local.Epoch()
+
+	key, err := l.store.Key(ctx, base, tags...)
+	if err != nil {
+		zerolog.Ctx(ctx).Warn().Err(err).Str("key", base).Msg("cache_key_err")
+		if control.OnlyIfCached {
+			var zero T
+			return zero, appErr.NewWithCode(appErr.CodeHTTPGatewayTimeout, "cache_unavailable")
+		}
+
+		control.Result.Record(dto.CacheBypass, 0)
+		return load(ctx)
+	}
+
+	entry := cacheEntry{key: key, base: base, tags: tags, epoch: epoch}
+
+	if control.MustRevalidate {
+		control.Result.Record(dto.CacheBypass, 0)
+
+		value, err := load(ctx)
+		l.write(ctx, entry, value, err)
+
+		return value, err
+	}
+
+	if hit, ok := l.readL2(ctx, entry, control.MaxAge); ok {
+		control.Result.Record(dto.CacheHit, hit.age())
+		return hit.value, hit.err
+	}
+
+	if control.OnlyIfCached {
+		var zero T
+		return zero, appErr.NewWithCode(appErr.CodeHTTPGatewayTimeout, "not_cached")
+	}
+
+	control.Result.Record(dto.CacheMiss, 0)
+
+	return l.loadShared(ctx, entry, load)
+}
+
+// cached is a decoded entry: a value, or the replayed not-found error.
+type cached[T any] struct {
+	storedAt time.Time
+	value    T
+	err      error
+}
+
+func (c cached[T]) age() time.Duration {
+	return max(time.Since(c.storedAt), 0)
+}
+
+func fresh(storedAt time.Time, maxAge *time.Duration) bool {
+	return maxAge == nil || time.Since(storedAt) <= *maxAge
+}
+
+func (l *Loader[T]) readL1(ctx context.Context, base string, maxAge *time.Duration) (cached[T], bool) {
+	local := l.store.local
+	if local == nil {
+		return cached[T]{}, false
+	}
+
+	if raw, ok := local.Get(base); ok {
+		hit, err := l.decode(raw)
+		if err == nil && fresh(hit.storedAt, maxAge) {
+			l.store.stats.record(TierL1, true)
+			return hit, true
+		}
+		if err != nil {
+			zerolog.Ctx(ctx).Warn().Err(err).Str("key", base).Msg("cache_l1_decode_err")
+		}
+	}
+
+	l.store.stats.record(TierL1, false)
+
+	return cached[T]{}, false
+}
+
+func (l *Loader[T]) readL2(ctx context.Context, entry cacheEntry, maxAge *time.Duration) (cached[T], bool) {
+	raw, err := l.store.redis0.Get(ctx, entry.key).Bytes()
+	switch {
+	case err == nil:
+		hit, decodeErr := l.decode(raw)
+		if decodeErr != nil {
+			zerolog.Ctx(ctx).Warn().Err(decodeErr).Str("key", entry.key).Msg("cache_read_err")
+			break
+		}
+
+		if fresh(hit.storedAt, maxAge) {
+			l.store.stats.record(TierL2, true)
+			l.store.local.Set(entry.base, entry.tags, raw, entry.epoch)
+			return hit, true
+		}
+	case errors.Is(err, redis.Nil):
+	default:
+		zerolog.Ctx(ctx).Warn().Err(err).Str("key", entry.key).Msg("cache_read_err")
+	}
+
+	l.store.stats.record(TierL2, false)
+
+	return cached[T]{}, false
+}
+
+// cacheEntry locates one value in both tiers.
+type cacheEntry struct {
+	key   string
+	base  string
+	tags  []string
+	epoch uint64
+}
+
+// loadShared runs load once per key across concurrent callers. The shared
+// load is detached from the first caller's cancellation so one client
+// disconnecting does not fail everyone waiting on it; each caller still stops
+// waiting when its own context ends.
+func (l *Loader[T]) loadShared(ctx context.Context, entry cacheEntry, load func(context.Context) (T, error)) (T, error) {
+	ch := l.group.DoChan(entry.key, func() (any, error) {
+		loadCtx := context.WithoutCancel(ctx)
+
+		value, err := load(loadCtx)
+		l.write(loadCtx, entry, value, err)
+
+		return value, err
+	})
+
+	select {
+	case res := <-ch:
+		value, _ := res.Val.(T)
+		return value, res.Err
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
+}
+
+// decode unpacks an entry laid out as kind, store time in Unix milliseconds
+// and payload. The error is a CodeCache* error when the entry is unusable.
+func (l *Loader[T]) decode(raw []byte) (cached[T], error) {
+	var hit cached[T]
+
+	if len(raw) < entryHeaderLen {
+		return hit, appErr.NewWithCode(appErr.CodeCacheDecode, "cache_short_entry")
+	}
+
+	hit.storedAt = time.UnixMilli(int64(binary.BigEndian.Uint64(raw[1:entryHeaderLen])))
+	payload := raw[entryHeaderLen:]
+
+	switch raw[0] {
+	case entryNotFound:
+		code, err := strconv.ParseUint(string(payload), 10, 16)
+		if err != nil {
+			return hit, appErr.WrapWithCode(err, appErr.CodeCacheDecode, "cache_not_found_entry")
+		}
+		hit.err = appErr.NewWithCode(appErr.Code(code), "not_found_cached")
+	case entryValue:
+		if err := l.opt.Codec.Unmarshal(payload, &hit.value); err != nil {
+			return hit, appErr.WrapWithCode(err, appErr.CodeCacheUnmarshal, "cache_unmarshal")
+		}
+	default:
+		return hit, appErr.NewWithCode(appErr.CodeCacheDecode, "cache_unknown_entry")
+	}
+
+	return hit, nil
+}
+
+// write stores a loaded value, or a not-found marker when negative caching is
+// on and err carries NotFoundCode, in both tiers. Other errors are never
+// cached.
+func (l *Loader[T]) write(ctx context.Context, entry cacheEntry, value T, err error) {
+	var (
+		raw []byte
+		ttl time.Duration
+	)
+
+	switch {
+	case err == nil:
+		data, marshalErr := l.opt.Codec.Marshal(value)
+		if marshalErr != nil {
+			zerolog.Ctx(ctx).Warn().Err(marshalErr).Str("key", entry.key).Msg("cache_marshal_err")
+			return
+		}
+		raw = append(entryHeader(entryValue), data...)
+		ttl = l.opt.TTL
+	case l.opt.NegativeTTL > 0 && appErr.ErrCode(err) == l.opt.NotFoundCode:
+		raw = append(entryHeader(entryNotFound), strconv.FormatUint(uint64(l.opt.NotFoundCode), 10)...)
+		ttl = l.opt.NegativeTTL
+	default:
+		return
+	}
+
+	if setErr := l.store.redis0.Set(ctx, entry.key, raw, jitter(ttl, l.opt.Jitter)).Err(); setErr != nil {
+		zerolog.Ctx(ctx).Warn().Err(setErr).Str("key", entry.key).Msg("cache_write_err")
+	}
+
+	l.store.local.Set(entry.base, entry.tags, raw, entry.epoch)
+}
+
+func entryHeader(kind byte) []byte {
+	header := make([]byte, entryHeaderLen)
+	header[0] = kind
+	binary.BigEndian.PutUint64(header[1:], uint64(time.Now().UnixMilli()))
+
+	return header
+}
+
+func jitter(ttl time.Duration, fraction float64) time.Duration {
+	if fraction <= 0 || ttl <= 0 {
+		return ttl
+	}
+
+	spread := float64(ttl) * fraction
+	return ttl + time.Duration((rand.Float64()*2-1)*spread)
+}
@@ -0,0 +1,186 @@
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// LocalOptions configures the in-process L1 cache.
+type LocalOptions struct {
+	Enabled    bool          `yaml:"enabled"`
+	MaxEntries int           `yaml:"max_entries"`
+	TTL        time.Duration `yaml:"ttl"`
+}
+
+// Local is a bounded LRU holding encoded entries for a short TTL. All
+// methods are safe on a nil *Local, which behaves as an always-empty cache.
+type Local struct {
+	mu    sync.Mutex
+	ttl   time.Duration
+	max   int
+	order *list.List
+	items map[string]*list.Element
+	byTag map[string]map[string]struct{}
+	// epoch counts invalidations. A reader notes it before going to L2 and
+	// Set drops the entry if an invalidation happened in between.
+	epoch uint64
+}
+
+type localEntry struct {
+	expiresAt time.Time
+	key       string
+	tags      []string
+	data      []byte
+}
+
+// NewLocal returns nil when the L1 is disabled.
+func NewLocal(opt *LocalOptions) *Local {
+	if opt == nil || !opt.Enabled || opt.MaxEntries <= 0 || opt.TTL <= 0 {
+		return nil
+	}
+
+	return &Local{
+		ttl:   opt.TTL,
+		max:   opt.MaxEntries,
+		order: list.New(),
+		items: make(map[string]*list.Element),
+		byTag: make(map[string]map[string]struct{}),
+	}
+}
+
+func (c *Local) Get(key string) ([]byte, bool) {
+	if c == nil {
+		return nil, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+
+	entry := elem.Value.(*localEntry)
+	if time.Now().After(entry.expiresAt) {
+		c.remove(elem)
+		return nil, false
+	}
+
+	c.order.MoveToFront(elem)
+
+	return entry.data, true
+}
+
+// Epoch returns the current invalidation epoch, to be passed to Set.
+func (c *Local) Epoch() uint64 {
+	if c == nil {
+		return 0
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.epoch
+}
+
+// Set stores data under key unless an invalidation happened since epoch was
+// read, evicting the least recently used entry when full.
+func (c *Local) Set(key string, tags []string, data []byte, epoch uint64) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if epoch != c.epoch {
+		return
+	}
+
+	if elem, ok := c.items[key]; ok {
+		c.remove(elem)
+	}
+
+	elem := c.order.PushFront(&localEntry{
+		expiresAt: time.Now().Add(c.ttl),
+		key:       key,
+		tags:      tags,
+		data:      data,
+	})
+	c.items[key] = elem
+
+	for _, tag := range tags {
+		keys, ok := c.byTag[tag]
+		if !ok {
+			keys = make(map[string]struct{})
+			c.byTag[tag] = keys
+		}
+		keys[key] = struct{}{}
+	}
+
+	for c.order.Len() > c.max {
+		c.remove(c.order.Back())
+	}
+}
+
+// Invalidate drops every entry carrying one of tags.
+func (c *Local) Invalidate(tags ...string) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.epoch++
+
+	for _, tag := range tags {
+		for key := range c.byTag[tag] {
+			if elem, ok := c.items[key]; ok {
+				c.remove(elem)
+			}
+		}
+	}
+}
+
+// Purge drops every entry.
+func (c *Local) Purge() {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.epoch++
+	c.order.Init()
+	clear(c.items)
+	clear(c.byTag)
+}
+
+// Len returns the number of entries, including expired ones not yet evicted.
+func (c *Local) Len() int {
+	if c == nil {
+		return 0
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.order.Len()
+}
+
+func (c *Local) remove(elem *list.Element) {
+	entry := c.order.Remove(elem).(*localEntry)
+	delete(c.items, entry.key)
+
+	for _, tag := range entry.tags {
+		keys := c.byTag[tag]
+		delete(keys, entry.key)
+		if len(keys) == 0 {
+			delete(c.byTag, tag)
+		}
+	}
+}
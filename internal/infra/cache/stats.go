@@ -0,0 +1,45 @@
+package cache
+
+import "sync/atomic"
+
+// Tier names a cache level in metrics.
+type Tier string
+
+const (
+	TierL1 Tier = "l1"
+	TierL2 Tier = "l2"
+)
+
+// Stats counts hits and misses per tier.
+type Stats struct {
+	l1Hits, l1Misses atomic.Uint64
+	l2Hits, l2Misses atomic.Uint64
+}
+
+// TierStats is a point-in-time copy of one tier's counters.
+type TierStats struct {
+	Tier   Tier
+	Hits   uint64
+	Misses uint64
+}
+
+// Snapshot returns the counters of both tiers.
+func (s *Stats) Snapshot() []TierStats {
+	return []TierStats{
+		{Tier: TierL1, Hits: s.l1Hits.Load(), Misses: s.l1Misses.Load()},
+		{Tier: TierL2, Hits: s.l2Hits.Load(), Misses: s.l2Misses.Load()},
+	}
+}
+
+func (s *Stats) record(tier Tier, hit bool) {
+	switch {
+	case tier == TierL1 && hit:
+		s.l1Hits.Add(1)
+	case tier == TierL1:
+		s.l1Misses.Add(1)
+	case hit:
+		s.l2Hits.Add(1)
+	default:
+		s.l2Misses.Add(1)
+	}
+}
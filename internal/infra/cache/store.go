@@ -0,0 +1,185 @@
+package cache
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	appErr "go-far/internal/model/errors"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog"
+)
+
+const (
+	// KeyVersion prefixes every tagged key. Bump it when the layout of cached
+	// values changes so entries written by older builds are never decoded.
+	KeyVersion = "v4"
+
+	tagVersionPrefix = "cache:tagver:"
+	// tagVersionTTL must outlive the longest cached entry: once a counter
+	// expires it restarts at zero, which is only safe when every entry
+	// written under the old numbers is gone.
+	tagVersionTTL = 24 * time.Hour
+
+	// invalidationChannel carries invalidated tags, one per line, to every
+	// instance so each drops them from its L1.
+	invalidationChannel = "cache:invalidate"
+)
+
+// Store is shared by every Loader. It owns the Redis L2, the optional
+// in-process L1 and the hit/miss counters of both tiers.
+//
+// L2 keys are versioned by tag. Each tag has a counter in Redis that is
+// folded into every key built for it. Invalidating a tag bumps its counter,
+// so entries written under an older version are never read again and simply
+// expire. A reader that loaded stale rows while a write was in flight also
+// stores them under the old version, where nobody looks.
+//
+// L1 entries are keyed by the unversioned base key, so an L1 hit costs no
+// Redis round trip at all. Invalidations are published over Redis pub/sub and
+// every instance drops the tagged entries; the short L1 TTL bounds staleness
+// if a message is lost.
+type Store struct {
+	redis0 *redis.Client
+	local  *Local
+	stats  *Stats
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+func InitStore(redis0 *redis.Client, opt *LocalOptions) *Store {
+	return &Store{
+		redis0: redis0,
+		local:  NewLocal(opt),
+		stats:  &Stats{},
+	}
+}
+
+// Stats returns the hit/miss counters of both tiers.
+func (s *Store) Stats() *Stats {
+	return s.stats
+}
+
+// Start subscribes to invalidations from other instances. It is a no-op
+// without an L1, since L2 versions are already shared through Redis.
+func (s *Store) Start(log *zerolog.Logger) {
+	if s.local == nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(log.WithContext(context.Background()))
+	s.cancel = cancel
+	s.done = make(chan struct{})
+
+	go s.listen(ctx)
+}
+
+// Stop ends the invalidation subscription.
+func (s *Store) Stop() {
+	if s.cancel == nil {
+		return
+	}
+
+	s.cancel()
+	<-s.done
+}
+
+func (s *Store) listen(ctx context.Context) {
+	defer close(s.done)
+
+	sub := s.redis0.Subscribe(ctx, invalidationChannel)
+	defer func() {
+		if err := sub.Close(); err != nil {
+			zerolog.Ctx(ctx).Warn().Err(err).Msg("close_cache_invalidation_subscription")
+		}
+	}()
+
+	messages := sub.ChannelWithSubscriptions()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg, ok := <-messages:
+			if !ok {
+				return
+			}
+
+			switch m := msg.(type) {
+			case *redis.Subscription:
+				// Messages published while disconnected are lost, so start
+				// over with an empty L1 after every (re)subscribe.
+				s.local.Purge()
+				zerolog.Ctx(ctx).Debug().Str("channel", m.Channel).Msg("cache_invalidation_subscribed")
+			case *redis.Message:
+				s.local.Invalidate(strings.Split(m.Payload, "\n")...)
+			}
+		}
+	}
+}
+
+// Key returns the versioned L2 key for base under the current version of
+// each tag, e.g. "v3:user:42@3".
+func (s *Store) Key(ctx context.Context, base string, tags ...string) (string, error) {
+	versions := make([]string, len(tags))
+	for i := range versions {
+		versions[i] = "0"
+	}
+
+	if len(tags) > 0 {
+		vals, err := s.redis0.MGet(ctx, tagVersionKeys(tags)...).Result()
+		if err != nil {
+			return "", appErr.WrapWithCode(err, appErr.CodeCacheGetSimpleKey, "get_cache_tag_version")
+		}
+
+		for i, val := range vals {
+			if v, ok := val.(string); ok {
+				versions[i] = v
+			}
+		}
+	}
+
+	return KeyVersion + ":" + base + "@" + strings.Join(versions, "."), nil
+}
+
+// Invalidate bumps the version of every tag, orphaning all L2 keys built
+// under the previous versions, and drops the tags from the L1 of every
+// instance.
+func (s *Store) Invalidate(ctx context.Context, tags ...string) error {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	_, err := s.redis0.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, key := range tagVersionKeys(tags) {
+			pipe.Incr(ctx, key)
+			pipe.Expire(ctx, key, tagVersionTTL)
+		}
+
+		if s.local != nil {
+			pipe.Publish(ctx, invalidationChannel, strings.Join(tags, "\n"))
+		}
+
+		return nil
+	})
+
+	// Dropped after the version bump so a concurrent read cannot refill the
+	// L1 from the old L2 entry.
+	s.local.Invalidate(tags...)
+
+	if err != nil {
+		return appErr.WrapWithCode(err, appErr.CodeCacheSetSimpleKey, "invalidate_cache_tags")
+	}
+
+	return nil
+}
+
+func tagVersionKeys(tags []string) []string {
+	keys := make([]string, len(tags))
+	for i, tag := range tags {
+		keys[i] = tagVersionPrefix + tag
+	}
+
+	return keys
+}
@@ -0,0 +1,161 @@
+package database
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// StreamBatchSize is the number of rows fetched per cursor round-trip.
+const StreamBatchSize = 500
+
+var cursorSeq atomic.Uint64
+
+// StreamCursor calls fn with a Cursor on a read-only, repeatable-read
+// transaction. Queries run through the Cursor are read batch by batch, so
+// memory use is bounded by one batch regardless of the result size, and the
+// snapshot stays consistent for the whole stream. Returning an error from fn
+// rolls the transaction back. Inside a WithinTx transaction the cursor runs in
+// a savepoint and sees that transaction's snapshot instead.
+func StreamCursor(ctx context.Context, pool *pgxpool.Pool, fn func(*Cursor) error) error {
+	var (
+		tx  pgx.Tx
+		err error
+	)
+	if outer, ok := TxFromContext(ctx); ok {
+		tx, err = outer.Begin(ctx)
+	} else {
+		tx, err = pool.BeginTx(ctx, pgx.TxOptions{
+			IsoLevel:   pgx.RepeatableRead,
+			AccessMode: pgx.ReadOnly,
+		})
+	}
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if err := fn(NewCursor(tx, StreamBatchSize)); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// Cursor runs Query through a server-side cursor, fetching batchSize rows per
+// round-trip. It is a DBTX, so any generated query streams when given a
+// Cursor.
+type Cursor struct {
+	tx        pgx.Tx
+	batchSize int
+}
+
+func NewCursor(tx pgx.Tx, batchSize int) *Cursor {
+	return &Cursor{tx: tx, batchSize: batchSize}
+}
+
+func (c *Cursor) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	return c.tx.Exec(ctx, sql, args...)
+}
+
+func (c *Cursor) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	return c.tx.QueryRow(ctx, sql, args...)
+}
+
+func (c *Cursor) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	name := "stream_cursor_" + strconv.FormatUint(cursorSeq.Add(1), 10)
+
+	sql = strings.TrimSuffix(strings.TrimSpace(sql), ";")
+	if _, err := c.tx.Exec(ctx, "DECLARE "+name+" NO SCROLL CURSOR FOR "+sql, args...); err != nil {
+		return nil, err
+	}
+
+	return &cursorRows{
+		ctx:       ctx,
+		tx:        c.tx,
+		fetch:     "FETCH FORWARD " + strconv.Itoa(c.batchSize) + " FROM " + name,
+		batchSize: c.batchSize,
+	}, nil
+}
+
+// cursorRows reads the cursor one FETCH at a time. A batch shorter than
+// batchSize is the last one.
+type cursorRows struct {
+	ctx       context.Context
+	tx        pgx.Tx
+	fetch     string
+	batchSize int
+
+	batch  pgx.Rows
+	read   int
+	done   bool
+	err    error
+	fields []pgconn.FieldDescription
+}
+
+func (r *cursorRows) Next() bool {
+	for !r.done && r.err == nil {
+		if r.batch == nil {
+			r.batch, r.err = r.tx.Query(r.ctx, r.fetch)
+			if r.err != nil {
+				return false
+			}
+			r.read = 0
+		}
+
+		if r.batch.Next() {
+			r.read++
+			return true
+		}
+
+		r.fields = r.batch.FieldDescriptions()
+		r.batch.Close()
+		r.err = r.batch.Err()
+		r.done = r.read < r.batchSize
+		r.batch = nil
+	}
+
+	return false
+}
+
+func (r *cursorRows) Close() {
+	if r.batch != nil {
+		r.batch.Close()
+		if r.err == nil {
+			r.err = r.batch.Err()
+		}
+		r.batch = nil
+	}
+	r.done = true
+}
+
+func (r *cursorRows) Err() error { return r.err }
+
+func (r *cursorRows) CommandTag() pgconn.CommandTag {
+	if r.batch != nil {
+		return r.batch.CommandTag()
+	}
+
+	return pgconn.CommandTag{}
+}
+
+func (r *cursorRows) FieldDescriptions() []pgconn.FieldDescription {
+	if r.batch != nil {
+		return r.batch.FieldDescriptions()
+	}
+
+	return r.fields
+}
+
+func (r *cursorRows) Scan(dest ...any) error { return r.batch.Scan(dest...) }
+
+func (r *cursorRows) Values() ([]any, error) { return r.batch.Values() }
+
+func (r *cursorRows) RawValues() [][]byte { return r.batch.RawValues() }
+
+func (r *cursorRows) Conn() *pgx.Conn { return r.tx.Conn() }
@@ -0,0 +1,121 @@
+package database
+
+import (
+	"context"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// fakeCursorTx serves FETCH statements from rows, recording every statement.
+type fakeCursorTx struct {
+	pgx.Tx
+	rows       []int
+	statements []string
+	declared   []any
+}
+
+func (tx *fakeCursorTx) Exec(_ context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	tx.statements = append(tx.statements, sql)
+	tx.declared = args
+
+	return pgconn.CommandTag{}, nil
+}
+
+func (tx *fakeCursorTx) Query(_ context.Context, sql string, _ ...any) (pgx.Rows, error) {
+	tx.statements = append(tx.statements, sql)
+
+	// FETCH FORWARD <n> FROM <cursor>
+	n, err := strconv.Atoi(strings.Fields(sql)[2])
+	if err != nil {
+		return nil, err
+	}
+
+	n = min(n, len(tx.rows))
+	batch := &fakeRows{values: tx.rows[:n], pos: -1}
+	tx.rows = tx.rows[n:]
+
+	return batch, nil
+}
+
+type fakeRows struct {
+	pgx.Rows
+	values []int
+	pos    int
+}
+
+func (r *fakeRows) Next() bool {
+	r.pos++
+	return r.pos < len(r.values)
+}
+
+func (r *fakeRows) Scan(dest ...any) error {
+	*dest[0].(*int) = r.values[r.pos]
+	return nil
+}
+
+func (r *fakeRows) Close()                                       {}
+func (r *fakeRows) Err() error                                   { return nil }
+func (r *fakeRows) FieldDescriptions() []pgconn.FieldDescription { return nil }
+
+func TestCursorFetchesInBatches(t *testing.T) {
+	tests := []struct {
+		name        string
+		rows        int
+		wantFetches int
+	}{
+		{name: "empty", rows: 0, wantFetches: 1},
+		{name: "short last batch", rows: 5, wantFetches: 3},
+		{name: "exact multiple", rows: 4, wantFetches: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var want []int
+			for i := range tt.rows {
+				want = append(want, i)
+			}
+
+			tx := &fakeCursorTx{rows: append([]int(nil), want...)}
+			rows, err := NewCursor(tx, 2).Query(context.Background(), "SELECT n FROM t WHERE n > $1;", 0)
+			if err != nil {
+				t.Fatalf("Query: %v", err)
+			}
+
+			var got []int
+			for rows.Next() {
+				var n int
+				if err := rows.Scan(&n); err != nil {
+					t.Fatalf("Scan: %v", err)
+				}
+				got = append(got, n)
+			}
+			rows.Close()
+
+			if err := rows.Err(); err != nil {
+				t.Fatalf("Err: %v", err)
+			}
+
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("rows = %v, want %v", got, want)
+			}
+
+			declare := tx.statements[0]
+			if !strings.HasPrefix(declare, "DECLARE stream_cursor_") || !strings.HasSuffix(declare, " NO SCROLL CURSOR FOR SELECT n FROM t WHERE n > $1") {
+				t.Errorf("declare = %q", declare)
+			}
+
+			if !reflect.DeepEqual(tx.declared, []any{0}) {
+				t.Errorf("declare args = %v, want [0]", tx.declared)
+			}
+
+			if fetches := len(tx.statements) - 1; fetches != tt.wantFetches {
+				t.Errorf("fetches = %d, want %d", fetches, tt.wantFetches)
+			}
+		})
+	}
+}
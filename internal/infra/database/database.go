@@ -6,6 +6,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/rs/zerolog"
 )
@@ -21,11 +22,14 @@ type DatabaseOptions struct {
 	ConnMaxIdleTime time.Duration `yaml:"conn_max_idle_time"`
 	MaxConns        int32         `yaml:"max_conns"`
 	MinConns        int32         `yaml:"min_conns"`
+	TxMaxRetries    int           `yaml:"tx_max_retries"`
 	Enabled         bool          `yaml:"enabled"`
 	SSLMode         bool          `yaml:"sslmode"`
 }
 
-func InitDB(log *zerolog.Logger, opt *DatabaseOptions) *pgxpool.Pool {
+// InitDB opens the connection pool. The optional afterConnect hook runs on
+// every new connection before it joins the pool, e.g. to prepare statements.
+func InitDB(log *zerolog.Logger, opt *DatabaseOptions, afterConnect func(context.Context, *pgx.Conn) error) *pgxpool.Pool {
 	if !opt.Enabled {
 		return nil
 	}
@@ -39,6 +43,7 @@ func InitDB(log *zerolog.Logger, opt *DatabaseOptions) *pgxpool.Pool {
 	config.MinConns = opt.MinConns
 	config.MaxConnLifetime = opt.ConnMaxLifetime
 	config.MaxConnIdleTime = opt.ConnMaxIdleTime
+	config.AfterConnect = afterConnect
 
 	pool, err := pgxpool.NewWithConfig(context.Background(), config)
 	if err != nil {
@@ -0,0 +1,235 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"time"
+
+	appErr "go-far/internal/model/errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rs/zerolog"
+)
+
+const (
+	// defaultTxMaxRetries is used when tx_max_retries is not configured.
+	defaultTxMaxRetries = 3
+	// txRetryBackoff is the base delay before a retry; it grows linearly
+	// with the attempt and is jittered so retried transactions spread out.
+	txRetryBackoff = 20 * time.Millisecond
+
+	pgSerializationFailure = "40001"
+	pgDeadlockDetected     = "40P01"
+)
+
+type txContextKey struct{}
+
+// txState is the transaction carried by a context. Callbacks registered with
+// AfterCommit are kept on the state of the transaction or savepoint they were
+// registered in, and move to the parent only when a savepoint is released, so
+// a savepoint that rolls back drops its own callbacks with its writes.
+type txState struct {
+	tx          pgx.Tx
+	afterCommit []func()
+}
+
+// beginner is the part of *pgxpool.Pool the TxManager uses.
+type beginner interface {
+	Begin(ctx context.Context) (pgx.Tx, error)
+}
+
+// DBTX is satisfied by *pgxpool.Pool, pgx.Tx and Cursor, so repositories and
+// the generated queries run the same way standalone, inside a transaction or
+// through a cursor.
+type DBTX interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// Transactor runs fn inside a transaction. Services depend on this rather
+// than on the TxManager so they can be wired without a database.
+type Transactor interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// TxManager starts transactions on the pool and hands them to repositories
+// through the context.
+type TxManager struct {
+	pool       beginner
+	maxRetries int
+}
+
+func InitTxManager(pool *pgxpool.Pool, opt *DatabaseOptions) *TxManager {
+	maxRetries := opt.TxMaxRetries
+	if maxRetries <= 0 {
+		maxRetries = defaultTxMaxRetries
+	}
+
+	return &TxManager{pool: pool, maxRetries: maxRetries}
+}
+
+// WithinTx runs fn in a transaction stored in the context passed to it, and
+// commits if fn returns nil. When ctx already carries a transaction, fn runs
+// in a savepoint instead, so a failing step can be undone without aborting
+// the caller's work. A top-level transaction that fails with a serialization
+// failure or deadlock is rolled back and fn is run again, up to the
+// configured number of retries, so fn must be safe to repeat.
+func (m *TxManager) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if parent, ok := ctx.Value(txContextKey{}).(*txState); ok {
+		return runSavepoint(ctx, parent, fn)
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := m.run(ctx, fn)
+		if err == nil || attempt > m.maxRetries || !isRetryable(err) {
+			return err
+		}
+
+		zerolog.Ctx(ctx).Warn().Err(err).Int("attempt", attempt).Msg("tx_retry")
+
+		delay := time.Duration(attempt) * txRetryBackoff
+		delay += rand.N(delay)
+
+		select {
+		case <-ctx.Done():
+			return appErr.Wrap(ctx.Err(), "tx_retry_canceled")
+		case <-time.After(delay):
+		}
+	}
+}
+
+func (m *TxManager) run(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	tx, err := m.pool.Begin(ctx)
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("tx_begin")
+		return appErr.WrapWithCode(err, appErr.CodeSQLTxBegin, "tx_begin")
+	}
+
+	state := &txState{tx: tx}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback(ctx)
+			panic(p)
+		}
+
+		if err != nil {
+			if rollbackErr := tx.Rollback(ctx); rollbackErr != nil {
+				zerolog.Ctx(ctx).Error().Err(rollbackErr).Msg("tx_rollback")
+			}
+		}
+	}()
+
+	if err = fn(context.WithValue(ctx, txContextKey{}, state)); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("tx_commit")
+		return appErr.WrapWithCode(err, appErr.CodeSQLTxCommit, "tx_commit")
+	}
+
+	for _, callback := range state.afterCommit {
+		callback()
+	}
+
+	return nil
+}
+
+func runSavepoint(ctx context.Context, parent *txState, fn func(ctx context.Context) error) (err error) {
+	savepoint, err := parent.tx.Begin(ctx)
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("tx_savepoint")
+		return appErr.WrapWithCode(err, appErr.CodeSQLTxBegin, "tx_savepoint")
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_ = savepoint.Rollback(ctx)
+			panic(p)
+		}
+
+		if err != nil {
+			if rollbackErr := savepoint.Rollback(ctx); rollbackErr != nil {
+				zerolog.Ctx(ctx).Error().Err(rollbackErr).Msg("tx_rollback_savepoint")
+			}
+		}
+	}()
+
+	state := &txState{tx: savepoint}
+	if err = fn(context.WithValue(ctx, txContextKey{}, state)); err != nil {
+		return err
+	}
+
+	if err = savepoint.Commit(ctx); err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("tx_release_savepoint")
+		return appErr.WrapWithCode(err, appErr.CodeSQLTxCommit, "tx_release_savepoint")
+	}
+
+	parent.afterCommit = append(parent.afterCommit, state.afterCommit...)
+
+	return nil
+}
+
+// TxFromContext returns the transaction started by WithinTx, if any.
+func TxFromContext(ctx context.Context) (pgx.Tx, bool) {
+	state, ok := ctx.Value(txContextKey{}).(*txState)
+	if !ok {
+		return nil, false
+	}
+
+	return state.tx, true
+}
+
+// InTx reports whether ctx carries a transaction.
+func InTx(ctx context.Context) bool {
+	_, ok := ctx.Value(txContextKey{}).(*txState)
+	return ok
+}
+
+// Begin starts a transaction for a repository method that needs one of its
+// own: a savepoint in the context's transaction, or a new one on pool.
+func Begin(ctx context.Context, pool *pgxpool.Pool) (pgx.Tx, error) {
+	if tx, ok := TxFromContext(ctx); ok {
+		return tx.Begin(ctx)
+	}
+
+	return pool.Begin(ctx)
+}
+
+// Conn returns what a repository runs its queries on: the context's
+// transaction, or pool outside one.
+func Conn(ctx context.Context, pool *pgxpool.Pool) DBTX {
+	if tx, ok := TxFromContext(ctx); ok {
+		return tx
+	}
+
+	return pool
+}
+
+// AfterCommit runs fn once the context's outermost transaction commits, or
+// straight away outside a transaction. It is dropped if the transaction, or a
+// savepoint it was registered in, rolls back. Repositories use it to
+// invalidate caches only for writes that became visible.
+func AfterCommit(ctx context.Context, fn func()) {
+	state, ok := ctx.Value(txContextKey{}).(*txState)
+	if !ok {
+		fn()
+		return
+	}
+
+	state.afterCommit = append(state.afterCommit, fn)
+}
+
+func isRetryable(err error) bool {
+	var pgErr *pgconn.PgError
+	if !errors.As(appErr.RootCause(err), &pgErr) {
+		return false
+	}
+
+	return pgErr.Code == pgSerializationFailure || pgErr.Code == pgDeadlockDetected
+}
@@ -0,0 +1,199 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	appErr "go-far/internal/model/errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// fakeTx records how a transaction or savepoint ended. Methods the
+// TxManager does not call are left to the nil embedded pgx.Tx.
+type fakeTx struct {
+	pgx.Tx
+	name string
+	log  *[]string
+}
+
+func (t *fakeTx) Begin(context.Context) (pgx.Tx, error) {
+	return &fakeTx{name: t.name + "/sp", log: t.log}, nil
+}
+
+func (t *fakeTx) Commit(context.Context) error {
+	*t.log = append(*t.log, "commit "+t.name)
+	return nil
+}
+
+func (t *fakeTx) Rollback(context.Context) error {
+	*t.log = append(*t.log, "rollback "+t.name)
+	return nil
+}
+
+type fakePool struct {
+	log   []string
+	begun int
+}
+
+func (p *fakePool) Begin(context.Context) (pgx.Tx, error) {
+	p.begun++
+	return &fakeTx{name: "tx", log: &p.log}, nil
+}
+
+func TestWithinTxRetries(t *testing.T) {
+	retryable := func(code string) error {
+		return appErr.Wrap(&pgconn.PgError{Code: code}, "write")
+	}
+
+	tests := []struct {
+		name     string
+		errs     []error // returned by the attempts in order, nil after that
+		attempts int
+		wantErr  bool
+	}{
+		{name: "serialization failure is retried", errs: []error{retryable(pgSerializationFailure)}, attempts: 2},
+		{name: "deadlock is retried", errs: []error{retryable(pgDeadlockDetected), retryable(pgDeadlockDetected)}, attempts: 3},
+		{name: "other errors are not retried", errs: []error{retryable("23505")}, attempts: 1, wantErr: true},
+		{name: "plain errors are not retried", errs: []error{errors.New("boom")}, attempts: 1, wantErr: true},
+		{
+			name:     "gives up after max retries",
+			errs:     slices.Repeat([]error{retryable(pgSerializationFailure)}, 5),
+			attempts: 3,
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pool := &fakePool{}
+			m := &TxManager{pool: pool, maxRetries: 2}
+
+			calls := 0
+			err := m.WithinTx(context.Background(), func(ctx context.Context) error {
+				calls++
+				if calls <= len(tt.errs) {
+					return tt.errs[calls-1]
+				}
+				return nil
+			})
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if calls != tt.attempts || pool.begun != tt.attempts {
+				t.Errorf("fn ran %d times in %d transactions, want %d", calls, pool.begun, tt.attempts)
+			}
+
+			rollbacks := 0
+			for _, entry := range pool.log {
+				if entry == "rollback tx" {
+					rollbacks++
+				}
+			}
+			wantRollbacks := tt.attempts
+			if !tt.wantErr {
+				wantRollbacks--
+			}
+			if rollbacks != wantRollbacks {
+				t.Errorf("rolled back %d times, want %d (log %v)", rollbacks, wantRollbacks, pool.log)
+			}
+		})
+	}
+}
+
+func TestWithinTxSavepoint(t *testing.T) {
+	errStep := errors.New("step failed")
+
+	t.Run("rolled back savepoint drops its callbacks", func(t *testing.T) {
+		pool := &fakePool{}
+		m := &TxManager{pool: pool, maxRetries: 2}
+		var ran []string
+
+		err := m.WithinTx(context.Background(), func(ctx context.Context) error {
+			AfterCommit(ctx, func() { ran = append(ran, "outer") })
+
+			stepErr := m.WithinTx(ctx, func(ctx context.Context) error {
+				AfterCommit(ctx, func() { ran = append(ran, "failed step") })
+				return errStep
+			})
+			if !errors.Is(stepErr, errStep) {
+				t.Errorf("savepoint err = %v, want %v", stepErr, errStep)
+			}
+
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("WithinTx: %v", err)
+		}
+
+		if want := []string{"outer"}; !slices.Equal(ran, want) {
+			t.Errorf("callbacks ran %v, want %v", ran, want)
+		}
+		if want := []string{"rollback tx/sp", "commit tx"}; !slices.Equal(pool.log, want) {
+			t.Errorf("log = %v, want %v", pool.log, want)
+		}
+	})
+
+	t.Run("released savepoint callbacks wait for the commit", func(t *testing.T) {
+		pool := &fakePool{}
+		m := &TxManager{pool: pool, maxRetries: 2}
+		var ran []string
+
+		err := m.WithinTx(context.Background(), func(ctx context.Context) error {
+			err := m.WithinTx(ctx, func(ctx context.Context) error {
+				err := m.WithinTx(ctx, func(ctx context.Context) error {
+					AfterCommit(ctx, func() { ran = append(ran, "nested step") })
+					return nil
+				})
+				AfterCommit(ctx, func() { ran = append(ran, "step") })
+				return err
+			})
+			if len(ran) != 0 {
+				t.Errorf("callbacks ran before commit: %v", ran)
+			}
+			return err
+		})
+		if err != nil {
+			t.Fatalf("WithinTx: %v", err)
+		}
+
+		if want := []string{"nested step", "step"}; !slices.Equal(ran, want) {
+			t.Errorf("callbacks ran %v, want %v", ran, want)
+		}
+	})
+
+	t.Run("rolled back transaction drops every callback", func(t *testing.T) {
+		pool := &fakePool{}
+		m := &TxManager{pool: pool, maxRetries: 2}
+		var ran []string
+
+		err := m.WithinTx(context.Background(), func(ctx context.Context) error {
+			if err := m.WithinTx(ctx, func(ctx context.Context) error {
+				AfterCommit(ctx, func() { ran = append(ran, "step") })
+				return nil
+			}); err != nil {
+				return err
+			}
+			return errStep
+		})
+		if !errors.Is(err, errStep) {
+			t.Fatalf("err = %v, want %v", err, errStep)
+		}
+
+		if len(ran) != 0 {
+			t.Errorf("callbacks ran %v, want none", ran)
+		}
+	})
+
+	t.Run("outside a transaction the callback runs at once", func(t *testing.T) {
+		ran := false
+		AfterCommit(context.Background(), func() { ran = true })
+		if !ran {
+			t.Error("callback did not run")
+		}
+	})
+}
@@ -41,13 +41,10 @@ func InitHttpServer(logger *zerolog.Logger, opt *HttpServerOptions, mw middlewar
 			maxBodyBytes = 1 << 20 // 1MB
 		}
 
-		// Apply request body size limit to prevent memory exhaustion attacks
+		// Apply request body size limit to prevent memory exhaustion attacks;
+		// upload handlers raise it per request with middleware.SetBodyLimit
 		if maxBodyBytes > 0 {
-			bodyLimitedHandler := handler // capture current value, not reference
-			handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-				r.Body = http.MaxBytesReader(w, r.Body, maxBodyBytes)
-				bodyLimitedHandler.ServeHTTP(w, r)
-			})
+			handler = middleware.MaxBody(maxBodyBytes)(handler)
 		}
 
 		handler = mw.CORS()(handler)
@@ -7,6 +7,9 @@ import (
 	"sync"
 	"time"
 
+	"go-far/internal/infra/cache"
+	"go-far/internal/infra/query"
+
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/collectors"
@@ -53,20 +56,27 @@ func getRedisClientName(index int, total int) string {
 	return ""
 }
 
-func registerRedisCollectors(reg *prometheus.Registry, log *zerolog.Logger, clients []*redis.Client) {
+// registerRedisCollectors attaches the cache tier stats to the first client,
+// the one backing the application cache.
+func registerRedisCollectors(reg *prometheus.Registry, log *zerolog.Logger, cacheStats *cache.Stats, clients []*redis.Client) {
 	for i, client := range clients {
 		if client == nil {
 			continue
 		}
 
+		var stats *cache.Stats
+		if i == 0 {
+			stats = cacheStats
+		}
+
 		name := getRedisClientName(i, len(clients))
-		if err := reg.Register(NewRedisPoolCollector(client, name)); err != nil {
+		if err := reg.Register(NewRedisPoolCollector(client, name, stats)); err != nil {
 			log.Warn().Err(err).Msg("Failed to register redis collector")
 		}
 	}
 }
 
-func InitMetrics(log *zerolog.Logger, pool *pgxpool.Pool, redisClients ...*redis.Client) Metrics {
+func InitMetrics(log *zerolog.Logger, pool *pgxpool.Pool, queryLoader *query.QueryLoader, cacheStats *cache.Stats, redisClients ...*redis.Client) Metrics {
 	onceMetrics.Do(func() {
 		reg = prometheus.NewRegistry()
 
@@ -105,12 +115,12 @@ func InitMetrics(log *zerolog.Logger, pool *pgxpool.Pool, redisClients ...*redis
 		}
 
 		if pool != nil {
-			if err := reg.Register(NewPgxPoolCollector(pool)); err != nil {
+			if err := reg.Register(NewPgxPoolCollector(pool, queryLoader)); err != nil {
 				log.Warn().Err(err).Msg("Failed to register pgx collector")
 			}
 		}
 
-		registerRedisCollectors(reg, log, redisClients)
+		registerRedisCollectors(reg, log, cacheStats, redisClients)
 	})
 
 	return metricsInst
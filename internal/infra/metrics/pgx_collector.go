@@ -1,12 +1,15 @@
 package metrics
 
 import (
+	"go-far/internal/infra/query"
+
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
 type PgxPoolCollector struct {
-	pool *pgxpool.Pool
+	pool        *pgxpool.Pool
+	queryLoader *query.QueryLoader
 
 	acquireTotal  *prometheus.Desc
 	totalConns    *prometheus.Desc
@@ -15,14 +18,21 @@ type PgxPoolCollector struct {
 	maxConns      *prometheus.Desc
 	waitCount     *prometheus.Desc
 	waitDuration  *prometheus.Desc
+
+	renderTotal     *prometheus.Desc
+	renderDuration  *prometheus.Desc
+	renderCacheHits *prometheus.Desc
+	renderCacheMiss *prometheus.Desc
+	preparedQueries *prometheus.Desc
 }
 
-func NewPgxPoolCollector(pool *pgxpool.Pool) *PgxPoolCollector {
-	const ns, sub = "pgx", "pool"
+func NewPgxPoolCollector(pool *pgxpool.Pool, queryLoader *query.QueryLoader) *PgxPoolCollector {
+	const ns, sub, querySub = "pgx", "pool", "query"
 	labels := prometheus.Labels{}
 
 	return &PgxPoolCollector{
-		pool: pool,
+		pool:        pool,
+		queryLoader: queryLoader,
 		acquireTotal: prometheus.NewDesc(
 			prometheus.BuildFQName(ns, sub, "acquire_total"),
 			"Total number of successful connection acquires",
@@ -58,6 +68,31 @@ func NewPgxPoolCollector(pool *pgxpool.Pool) *PgxPoolCollector {
 			"Cumulative time spent waiting for a connection",
 			nil, labels,
 		),
+		renderTotal: prometheus.NewDesc(
+			prometheus.BuildFQName(ns, querySub, "render_total"),
+			"Total number of query template compilations",
+			nil, labels,
+		),
+		renderDuration: prometheus.NewDesc(
+			prometheus.BuildFQName(ns, querySub, "render_duration_seconds_total"),
+			"Cumulative time spent rendering query templates",
+			nil, labels,
+		),
+		renderCacheHits: prometheus.NewDesc(
+			prometheus.BuildFQName(ns, querySub, "render_cache_hits_total"),
+			"Compilations served from pre-rendered SQL",
+			nil, labels,
+		),
+		renderCacheMiss: prometheus.NewDesc(
+			prometheus.BuildFQName(ns, querySub, "render_cache_misses_total"),
+			"Compilations that had to execute the template",
+			nil, labels,
+		),
+		preparedQueries: prometheus.NewDesc(
+			prometheus.BuildFQName(ns, querySub, "prepared_statements_total"),
+			"Pre-rendered queries prepared on pool connections, summed over every connection opened",
+			nil, labels,
+		),
 	}
 }
 
@@ -68,6 +103,14 @@ func (c *PgxPoolCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- c.maxConns
 	ch <- c.waitCount
 	ch <- c.waitDuration
+
+	if c.queryLoader != nil {
+		ch <- c.renderTotal
+		ch <- c.renderDuration
+		ch <- c.renderCacheHits
+		ch <- c.renderCacheMiss
+		ch <- c.preparedQueries
+	}
 }
 
 func (c *PgxPoolCollector) Collect(ch chan<- prometheus.Metric) {
@@ -80,4 +123,15 @@ func (c *PgxPoolCollector) Collect(ch chan<- prometheus.Metric) {
 	ch <- prometheus.MustNewConstMetric(c.maxConns, prometheus.GaugeValue, float64(stats.MaxConns()))
 	ch <- prometheus.MustNewConstMetric(c.waitCount, prometheus.CounterValue, float64(stats.EmptyAcquireCount()))
 	ch <- prometheus.MustNewConstMetric(c.waitDuration, prometheus.CounterValue, stats.AcquireDuration().Seconds())
+
+	if c.queryLoader == nil {
+		return
+	}
+
+	qs := c.queryLoader.Stats()
+	ch <- prometheus.MustNewConstMetric(c.renderTotal, prometheus.CounterValue, float64(qs.Renders))
+	ch <- prometheus.MustNewConstMetric(c.renderDuration, prometheus.CounterValue, qs.RenderDuration.Seconds())
+	ch <- prometheus.MustNewConstMetric(c.renderCacheHits, prometheus.CounterValue, float64(qs.CacheHits))
+	ch <- prometheus.MustNewConstMetric(c.renderCacheMiss, prometheus.CounterValue, float64(qs.CacheMisses))
+	ch <- prometheus.MustNewConstMetric(c.preparedQueries, prometheus.CounterValue, float64(qs.Prepared))
 }
@@ -1,6 +1,8 @@
 package metrics
 
 import (
+	"go-far/internal/infra/cache"
+
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/redis/go-redis/v9"
 )
@@ -8,6 +10,7 @@ import (
 type RedisPoolCollector struct {
 	client     *redis.Client
 	clientName string
+	cacheStats *cache.Stats
 
 	hits       *prometheus.Desc
 	misses     *prometheus.Desc
@@ -16,15 +19,21 @@ type RedisPoolCollector struct {
 	idleConns  *prometheus.Desc
 	staleConns *prometheus.Desc
 	maxConns   *prometheus.Desc
+
+	cacheHits   *prometheus.Desc
+	cacheMisses *prometheus.Desc
 }
 
-func NewRedisPoolCollector(client *redis.Client, clientName string) *RedisPoolCollector {
+// NewRedisPoolCollector reports pool stats of client and, when cacheStats is
+// not nil, cache hits and misses per tier (l1 in-process, l2 Redis).
+func NewRedisPoolCollector(client *redis.Client, clientName string, cacheStats *cache.Stats) *RedisPoolCollector {
 	const ns, sub = "redis", "pool"
 	labels := prometheus.Labels{"client": clientName}
 
 	return &RedisPoolCollector{
 		client:     client,
 		clientName: clientName,
+		cacheStats: cacheStats,
 		hits: prometheus.NewDesc(
 			prometheus.BuildFQName(ns, sub, "hits_total"),
 			"Times a connection was reused from the pool",
@@ -60,6 +69,16 @@ func NewRedisPoolCollector(client *redis.Client, clientName string) *RedisPoolCo
 			"Max pool size configured on the client",
 			nil, labels,
 		),
+		cacheHits: prometheus.NewDesc(
+			prometheus.BuildFQName(ns, "cache", "hits_total"),
+			"Cache reads served by the tier",
+			[]string{"tier"}, labels,
+		),
+		cacheMisses: prometheus.NewDesc(
+			prometheus.BuildFQName(ns, "cache", "misses_total"),
+			"Cache reads the tier could not serve",
+			[]string{"tier"}, labels,
+		),
 	}
 }
 
@@ -71,6 +90,11 @@ func (c *RedisPoolCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- c.idleConns
 	ch <- c.staleConns
 	ch <- c.maxConns
+
+	if c.cacheStats != nil {
+		ch <- c.cacheHits
+		ch <- c.cacheMisses
+	}
 }
 
 func (c *RedisPoolCollector) Collect(ch chan<- prometheus.Metric) {
@@ -83,4 +107,13 @@ func (c *RedisPoolCollector) Collect(ch chan<- prometheus.Metric) {
 	ch <- prometheus.MustNewConstMetric(c.idleConns, prometheus.GaugeValue, float64(s.IdleConns))
 	ch <- prometheus.MustNewConstMetric(c.staleConns, prometheus.CounterValue, float64(s.StaleConns))
 	ch <- prometheus.MustNewConstMetric(c.maxConns, prometheus.GaugeValue, float64(c.client.Options().PoolSize))
+
+	if c.cacheStats == nil {
+		return
+	}
+
+	for _, tier := range c.cacheStats.Snapshot() {
+		ch <- prometheus.MustNewConstMetric(c.cacheHits, prometheus.CounterValue, float64(tier.Hits), string(tier.Tier))
+		ch <- prometheus.MustNewConstMetric(c.cacheMisses, prometheus.CounterValue, float64(tier.Misses), string(tier.Tier))
+	}
 }
@@ -7,8 +7,11 @@ import (
 )
 
 type SchedulerJobsOptions struct {
-	UserGeneratorJob *UserGeneratorJobOptions
-	CarGeneratorJob  *CarGeneratorJobOptions
+	UserGeneratorJob       *UserGeneratorJobOptions
+	CarGeneratorJob        *CarGeneratorJobOptions
+	PurgeDeletedJob        *PurgeDeletedJobOptions
+	ExpireTransfersJob     *ExpireTransfersJobOptions
+	FlagOverdueServicesJob *FlagOverdueServicesJobOptions
 }
 
 type UserGeneratorJobOptions struct {
@@ -19,6 +22,18 @@ type CarGeneratorJobOptions struct {
 	Enabled bool
 }
 
+type PurgeDeletedJobOptions struct {
+	Enabled bool
+}
+
+type ExpireTransfersJobOptions struct {
+	Enabled bool
+}
+
+type FlagOverdueServicesJobOptions struct {
+	Enabled bool
+}
+
 type SchedulerMetrics struct {
 	jobExecutionTotal   *prometheus.CounterVec
 	jobFailureTotal     *prometheus.CounterVec
@@ -88,7 +103,7 @@ func (m *SchedulerMetrics) RecordExecution(job string, duration time.Duration, e
 }
 
 func getSchedulerJobNames(jobs *SchedulerJobsOptions) []string {
-	jobNames := make([]string, 0, 2)
+	jobNames := make([]string, 0, 5)
 	if jobs.UserGeneratorJob != nil {
 		jobNames = append(jobNames, "user_generator")
 	}
@@ -97,5 +112,17 @@ func getSchedulerJobNames(jobs *SchedulerJobsOptions) []string {
 		jobNames = append(jobNames, "car_generator")
 	}
 
+	if jobs.PurgeDeletedJob != nil {
+		jobNames = append(jobNames, "purge_deleted")
+	}
+
+	if jobs.ExpireTransfersJob != nil {
+		jobNames = append(jobNames, "expire_transfers")
+	}
+
+	if jobs.FlagOverdueServicesJob != nil {
+		jobNames = append(jobNames, "flag_overdue_services")
+	}
+
 	return jobNames
 }
@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"context"
+	"io"
+	"net/http"
+)
+
+// rawBodyKey holds the request body as read from the connection, before the
+// server-wide size limit wraps it.
+const rawBodyKey contextKey = "raw_request_body"
+
+// MaxBody caps every request body at n bytes. Upload handlers that accept
+// more raise their own cap with SetBodyLimit.
+func MaxBody(n int64) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			r = r.WithContext(context.WithValue(r.Context(), rawBodyKey, r.Body))
+			r.Body = http.MaxBytesReader(w, r.Body, n)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// SetBodyLimit replaces the MaxBody cap of r with n, which may be larger. It
+// must be called before the body is read.
+func SetBodyLimit(w http.ResponseWriter, r *http.Request, n int64) {
+	body, ok := r.Context().Value(rawBodyKey).(io.ReadCloser)
+	if !ok {
+		body = r.Body
+	}
+
+	r.Body = http.MaxBytesReader(w, body, n)
+}
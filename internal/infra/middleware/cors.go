@@ -25,6 +25,7 @@ func (mw *middleware) CORS() func(http.Handler) http.Handler {
 
 			w.Header().Set(preference.HeaderAccessControlAllowHeaders, "*")
 			w.Header().Set(preference.HeaderAccessControlAllowMethods, preference.AllowedMethods)
+			w.Header().Set(preference.HeaderAccessControlExposeHeaders, preference.ExposedHeaders)
 			w.Header().Set(preference.HeaderXFrameOptions, "DENY")
 			w.Header().Set(preference.HeaderContentSecurityPolicy, preference.CSPValue)
 			w.Header().Set(preference.HeaderXXSSProtection, "1; mode=block")
@@ -38,7 +39,7 @@ func (mw *middleware) CORS() func(http.Handler) http.Handler {
 				return
 			}
 
-			if !slices.Contains([]string{"GET", "POST", "PUT", "DELETE"}, r.Method) {
+			if !slices.Contains([]string{"GET", "POST", "PUT", "PATCH", "DELETE"}, r.Method) {
 				mw.writeJSONError(w, http.StatusMethodNotAllowed, "method not allowed")
 				return
 			}
@@ -0,0 +1,84 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	appErr "go-far/internal/model/errors"
+	"go-far/internal/preference"
+
+	"github.com/rs/zerolog"
+)
+
+// ExportLimiter returns a rate limiting middleware for export endpoints.
+// Exports hold a database connection for the whole stream, so they get their
+// own per-user budget instead of sharing the role limits of regular reads.
+func (mw *middleware) ExportLimiter() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			authUser, ok := GetAuthUser(r.Context())
+			if !ok {
+				mw.writeJSONError(w, http.StatusUnauthorized, "unauthenticated")
+				return
+			}
+
+			key := "ratelimit:export:" + authUser.UserID
+
+			now := time.Now()
+			limitResult, err := mw.evalExportRateLimit(r.Context(), key)
+			if err != nil {
+				zerolog.Ctx(r.Context()).Error().Err(err).Msg("eval export rate limit failed")
+				mw.writeJSONError(w, http.StatusInternalServerError, err.Error())
+				return
+			}
+
+			if !limitResult.Allowed {
+				mw.writeExportRateLimitExceeded(w, limitResult, now)
+				return
+			}
+
+			mw.setExportRateLimitHeaders(w, limitResult, now)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// evalExportRateLimit evaluates rate limit for export endpoints
+func (mw *middleware) evalExportRateLimit(ctx context.Context, key string) (rateLimitResult, error) {
+	result, err := mw.rdb.Eval(ctx, rateLimitLuaScript, []string{key},
+		mw.exportLimit,                 // rate limit
+		int(mw.exportPeriod.Seconds()), // window duration in seconds
+	).Result()
+	if err != nil {
+		return rateLimitResult{}, err
+	}
+
+	resultArr, ok := result.([]any)
+	if !ok || len(resultArr) < 3 {
+		return rateLimitResult{}, appErr.New("invalid rate limit response", appErr.CodeHTTPInternalServerError)
+	}
+
+	return parseRateLimitResult(resultArr), nil
+}
+
+// writeExportRateLimitExceeded writes the rate limit exceeded response for export endpoints
+func (mw *middleware) writeExportRateLimitExceeded(w http.ResponseWriter, result rateLimitResult, now time.Time) {
+	w.Header().Set(preference.HeaderContentType, preference.ContentTypeJSON)
+	w.Header().Set(preference.HeaderXRateLimitLimitExport, strconv.Itoa(mw.exportLimit))
+	w.Header().Set(preference.HeaderXRateLimitRemainingExport, "0")
+	w.Header().Set(preference.HeaderXRateLimitResetExport, formatTime(now, result.TTL))
+	w.WriteHeader(http.StatusTooManyRequests)
+	_ = json.NewEncoder(w).Encode(map[string]string{
+		"error": "export rate limit exceeded, please try again later",
+	})
+}
+
+// setExportRateLimitHeaders sets rate limit headers for export endpoints
+func (mw *middleware) setExportRateLimitHeaders(w http.ResponseWriter, result rateLimitResult, now time.Time) {
+	w.Header().Set(preference.HeaderXRateLimitLimitExport, strconv.Itoa(mw.exportLimit))
+	w.Header().Set(preference.HeaderXRateLimitRemainingExport, formatRemaining(int64(mw.exportLimit), result.Count))
+	w.Header().Set(preference.HeaderXRateLimitResetExport, formatTime(now, result.TTL))
+}
@@ -41,6 +41,12 @@ func (rw *responseWriter) WriteHeader(code int) {
 	rw.ResponseWriter.WriteHeader(code)
 }
 
+// Unwrap exposes the underlying writer to http.ResponseController, so
+// streaming handlers can flush and extend their write deadline.
+func (rw *responseWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
+
 // withStartTime adds start time to context
 func withStartTime(ctx context.Context, t time.Time) context.Context {
 	return context.WithValue(ctx, startTimeKey, t)
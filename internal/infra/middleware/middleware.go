@@ -22,6 +22,7 @@ type Middleware interface {
 	CORS() func(http.Handler) http.Handler
 	RoleLimiter() func(http.Handler) http.Handler
 	AuthLimiter() func(http.Handler) http.Handler
+	ExportLimiter() func(http.Handler) http.Handler
 }
 
 type middleware struct {
@@ -36,16 +37,19 @@ type middleware struct {
 	period         time.Duration
 	authLimit      int
 	authPeriod     time.Duration
+	exportLimit    int
+	exportPeriod   time.Duration
 	tracingEnabled bool
 	metrics        metrics.Metrics
 }
 
 // MiddlewareOptions holds middleware configuration
 type MiddlewareOptions struct {
-	PublicPaths   []string             `yaml:"public_paths"`
-	RateLimiter   RateLimiterOptions   `yaml:"rate_limiter"`
-	RoleRateLimit RoleRateLimitOptions `yaml:"role_rate_limit"`
-	AuthRateLimit AuthRateLimitOptions `yaml:"auth_rate_limit"`
+	PublicPaths     []string               `yaml:"public_paths"`
+	RateLimiter     RateLimiterOptions     `yaml:"rate_limiter"`
+	RoleRateLimit   RoleRateLimitOptions   `yaml:"role_rate_limit"`
+	AuthRateLimit   AuthRateLimitOptions   `yaml:"auth_rate_limit"`
+	ExportRateLimit ExportRateLimitOptions `yaml:"export_rate_limit"`
 }
 
 // RateLimiterOptions holds rate limiter configuration
@@ -60,6 +64,12 @@ type AuthRateLimitOptions struct {
 	Limit   int    `yaml:"limit"`
 }
 
+// ExportRateLimitOptions holds the per-user rate limit for export endpoints
+type ExportRateLimitOptions struct {
+	Command string `yaml:"command"`
+	Limit   int    `yaml:"limit"`
+}
+
 // RoleRateLimitOptions holds role-based rate limiter configuration
 type RoleRateLimitOptions struct {
 	Admin RoleRateLimit `yaml:"admin"`
@@ -110,6 +120,19 @@ func InitMiddleware(log *zerolog.Logger, opt *MiddlewareOptions, tkn token.Token
 			authLimit = 3
 		}
 
+		// --- Export rate limiter (optional, with defaults) ---
+		exportLimit := opt.ExportRateLimit.Limit
+		exportPeriod := time.Hour // default
+		if opt.ExportRateLimit.Command != "" {
+			if p, err := parsePeriod(opt.ExportRateLimit.Command); err == nil {
+				exportPeriod = p
+			}
+		}
+
+		if exportLimit == 0 {
+			exportLimit = 5
+		}
+
 		publicPathsMap := make(map[string]bool, len(opt.PublicPaths))
 		for _, p := range opt.PublicPaths {
 			publicPathsMap[p] = true
@@ -126,6 +149,8 @@ func InitMiddleware(log *zerolog.Logger, opt *MiddlewareOptions, tkn token.Token
 			authRateLimit:  opt.AuthRateLimit,
 			authLimit:      authLimit,
 			authPeriod:     authPeriod,
+			exportLimit:    exportLimit,
+			exportPeriod:   exportPeriod,
 			publicPaths:    publicPathsMap,
 			tracingEnabled: tracingEnabled,
 			metrics:        metricsInst,
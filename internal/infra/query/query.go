@@ -7,7 +7,9 @@ import (
 )
 
 type QueriesOptions struct {
-	Path string `yaml:"path"`
+	Path              string `yaml:"path"`
+	VerifyOnStartup   bool   `yaml:"verify_on_startup"`
+	PrepareStatements bool   `yaml:"prepare_statements"`
 }
 
 type QueryLoader struct {
@@ -15,6 +17,9 @@ type QueryLoader struct {
 	templates map[string]*template.Template // key: query name
 	rawSQL    map[string]string             // key: query name
 	fileMap   map[string]string             // query name -> file path (for error reporting)
+	annots    map[string]map[string]string  // query name -> `-- key: value` annotations
+	plans     map[string]*queryPlan         // query name -> pre-rendered SQL for data-independent templates
+	stats     queryStats
 }
 
 func InitQueryLoader(log *zerolog.Logger, opt *QueriesOptions) *QueryLoader {
@@ -23,11 +28,13 @@ func InitQueryLoader(log *zerolog.Logger, opt *QueriesOptions) *QueryLoader {
 		templates: make(map[string]*template.Template),
 		rawSQL:    make(map[string]string),
 		fileMap:   make(map[string]string),
+		annots:    make(map[string]map[string]string),
+		plans:     make(map[string]*queryPlan),
 	}
 	if err := ql.load(opt.Path); err != nil {
 		log.Panic().Err(err).Msg("Failed to load queries")
 	}
 
-	log.Debug().Msgf("✅ Queries loaded successfully, total queries: %d", len(ql.templates))
+	log.Debug().Msgf("✅ Queries loaded successfully, total queries: %d, pre-rendered: %d", len(ql.templates), len(ql.plans))
 	return ql
 }
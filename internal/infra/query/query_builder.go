@@ -21,6 +21,7 @@ type SQLBuilder struct {
 	suffixQuery string
 	page        int64
 	limit       int64
+	unpaged     bool
 }
 
 const (
@@ -42,7 +43,23 @@ const (
 	gt
 )
 
-var sortFieldPattern = regexp.MustCompile(`(?P<sign>-)?(?P<col>[a-zA-Z_]+),?`)
+var (
+	sortFieldPattern   = regexp.MustCompile(`(?P<sign>-)?(?P<col>[a-zA-Z_]+),?`)
+	placeholderPattern = regexp.MustCompile(`\$[0-9]+`)
+)
+
+// Clause is a built WHERE/ORDER BY/LIMIT tail split at its placeholders, so a
+// query template can splice it in with raw and arg and keep its own numbering:
+//
+//	{{ range .Where }}{{ raw .SQL }}{{ if .HasArg }}{{ arg .Arg }}{{ end }}{{ end }}
+type Clause []Term
+
+// Term is the SQL text up to and including one placeholder's argument.
+type Term struct {
+	SQL    string
+	Arg    any
+	HasArg bool
+}
 
 func NewSQLBuilder(paramTag, colTag, suffix string, page, limit int64) *SQLBuilder {
 	return &SQLBuilder{
@@ -68,6 +85,14 @@ func (qb *SQLBuilder) AliasPrefix(alias string, ptr any) *SQLBuilder {
 	return qb
 }
 
+// WithoutPagination drops the LIMIT/OFFSET clause, for callers that stream
+// the whole result set instead of reading a page.
+func (qb *SQLBuilder) WithoutPagination() *SQLBuilder {
+	qb.unpaged = true
+
+	return qb
+}
+
 func (qb *SQLBuilder) Build() (query string, sortByDisplay []string, args []any, err error) {
 	var (
 		sortBy    []string
@@ -119,6 +144,26 @@ func (qb *SQLBuilder) Build() (query string, sortByDisplay []string, args []any,
 	return buff.String(), sortByDisplay, args, nil
 }
 
+// BuildClause is Build for annotated queries: the tail comes back as a Clause
+// carrying its own args instead of $N placeholders.
+func (qb *SQLBuilder) BuildClause() (Clause, []string, error) {
+	query, sortByDisplay, args, err := qb.Build()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var clause Clause
+	last := 0
+	for i, loc := range placeholderPattern.FindAllStringIndex(query, -1) {
+		clause = append(clause, Term{SQL: query[last:loc[0]], Arg: args[i], HasArg: true})
+		last = loc[1]
+	}
+
+	clause = append(clause, Term{SQL: query[last:]})
+
+	return clause, sortByDisplay, nil
+}
+
 func (qb *SQLBuilder) buildColumnMapping(m map[string]string) {
 	for table, v := range qb.values {
 		alias := qb.getAlias(table)
@@ -190,7 +235,7 @@ func (qb *SQLBuilder) isSliceType(v any) bool {
 
 func (qb *SQLBuilder) isScalarType(v any) bool {
 	switch v.(type) {
-	case int, int64, string, float64, bool, time.Time:
+	case int, int64, string, float64, bool, *bool, time.Time:
 		return true
 	}
 
@@ -249,6 +294,10 @@ func (qb *SQLBuilder) extractScalarValue(vFieldItf any, paramTag string, qType *
 		if f {
 			return f, false
 		}
+	case *bool:
+		if f != nil {
+			return *f, false
+		}
 	case time.Time:
 		if !f.IsZero() {
 			return f, false
@@ -370,6 +419,10 @@ func (qb *SQLBuilder) appendOrderBy(buff *bytes.Buffer, sortBy []string) {
 }
 
 func (qb *SQLBuilder) appendLimitOffset(buff *bytes.Buffer, args *[]any, argIdx *int) {
+	if qb.unpaged {
+		return
+	}
+
 	qb.limit = util.ValidateLimit(qb.limit)
 	qb.page = util.ValidatePage(qb.page)
 
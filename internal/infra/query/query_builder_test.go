@@ -0,0 +1,54 @@
+package query
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+type clauseFilter struct {
+	Name   string `param:"name" db:"name"`
+	MinAge int64  `param:"age__gte" db:"age"`
+	SortBy string `param:"sort_by" db:"sort_by"`
+}
+
+// TestBuildClauseRenumbers splices a built clause after a template's own arg
+// and checks the builder's placeholders follow it.
+func TestBuildClauseRenumbers(t *testing.T) {
+	dir := t.TempDir()
+	sql := `-- name: FindPeople
+SELECT id, {{ arg .Org }} AS org FROM people{{ range .Where }}{{ raw .SQL }}{{ if .HasArg }}{{ arg .Arg }}{{ end }}{{ end }}
+`
+	if err := os.WriteFile(filepath.Join(dir, "people.sql"), []byte(sql), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	log := zerolog.Nop()
+	loader := InitQueryLoader(&log, &QueriesOptions{Path: dir})
+
+	filter := clauseFilter{Name: "ann", MinAge: 30, SortBy: "-name"}
+	qb := NewSQLBuilder("param", "db", "", 2, 10)
+	qb.AliasPrefix("-", &filter)
+
+	where, _, err := qb.BuildClause()
+	if err != nil {
+		t.Fatalf("BuildClause: %v", err)
+	}
+
+	got, args, err := loader.Compile("FindPeople", map[string]any{"Org": "acme", "Where": where})
+	if err != nil {
+		t.Fatalf("Compile: %v", err)
+	}
+
+	want := "SELECT id, $1 AS org FROM people WHERE 1=1 AND name=$2 AND age>=$3 ORDER BY name desc LIMIT $4 OFFSET $5;"
+	if got != want {
+		t.Errorf("sql =\n%q\nwant\n%q", got, want)
+	}
+
+	if wantArgs := []any{"acme", "ann", int64(30), int64(10), int64(10)}; !reflect.DeepEqual(args, wantArgs) {
+		t.Errorf("args = %v, want %v", args, wantArgs)
+	}
+}
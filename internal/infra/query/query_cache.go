@@ -0,0 +1,200 @@
+package query
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"text/template"
+	"text/template/parse"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// queryPlan is the pre-rendered form of a template whose SQL text does not
+// depend on its data: only `arg` placeholders, no conditionals, ranges or raw
+// output. Compiling it is a lookup plus reading the arg paths from the data.
+type queryPlan struct {
+	sql      string
+	argPaths [][]string
+}
+
+// QueryStats is a snapshot of the loader's render counters.
+type QueryStats struct {
+	Renders        uint64
+	RenderDuration time.Duration
+	CacheHits      uint64
+	CacheMisses    uint64
+	Prepared       uint64
+}
+
+type queryStats struct {
+	renders     atomic.Uint64
+	renderNanos atomic.Int64
+	cacheHits   atomic.Uint64
+	cacheMisses atomic.Uint64
+	prepared    atomic.Uint64
+}
+
+// buildPlan returns the plan for tmpl, or false when the template output
+// varies with its data and has to be executed on every call.
+func buildPlan(tmpl *template.Template) (*queryPlan, bool) {
+	if tmpl.Tree == nil || tmpl.Tree.Root == nil {
+		return nil, false
+	}
+
+	var sb strings.Builder
+	plan := &queryPlan{}
+
+	for _, node := range tmpl.Tree.Root.Nodes {
+		switch n := node.(type) {
+		case *parse.TextNode:
+			sb.Write(n.Text)
+		case *parse.ActionNode:
+			path, ok := argPath(n.Pipe)
+			if !ok {
+				return nil, false
+			}
+
+			plan.argPaths = append(plan.argPaths, path)
+			sb.WriteString("$" + strconv.Itoa(len(plan.argPaths)))
+		default:
+			return nil, false
+		}
+	}
+
+	plan.sql = sb.String()
+	return plan, true
+}
+
+// argPath matches `{{ arg .A.B }}` and `{{ arg $.A.B }}` and returns the field path.
+func argPath(p *parse.PipeNode) ([]string, bool) {
+	if p == nil || len(p.Decl) > 0 || len(p.Cmds) != 1 || len(p.Cmds[0].Args) != 2 {
+		return nil, false
+	}
+
+	ident, ok := p.Cmds[0].Args[0].(*parse.IdentifierNode)
+	if !ok || ident.Ident != "arg" {
+		return nil, false
+	}
+
+	switch n := p.Cmds[0].Args[1].(type) {
+	case *parse.FieldNode:
+		return n.Ident, true
+	case *parse.VariableNode:
+		if n.Ident[0] == "$" && len(n.Ident) > 1 {
+			return n.Ident[1:], true
+		}
+	}
+
+	return nil, false
+}
+
+// args resolves the plan's arg paths against data. It reports false for
+// anything the template engine would treat specially (methods, nil pointers,
+// missing keys), so the caller can fall back to executing the template and
+// get the engine's own error.
+func (p *queryPlan) args(data any) ([]any, bool) {
+	if len(p.argPaths) == 0 {
+		return nil, true
+	}
+
+	args := make([]any, 0, len(p.argPaths))
+	for _, path := range p.argPaths {
+		v, ok := resolvePath(reflect.ValueOf(data), path)
+		if !ok {
+			return nil, false
+		}
+		args = append(args, v)
+	}
+
+	return args, true
+}
+
+func resolvePath(v reflect.Value, path []string) (any, bool) {
+	for _, ident := range path {
+		for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+			if v.IsNil() {
+				return nil, false
+			}
+			v = v.Elem()
+		}
+
+		switch v.Kind() {
+		case reflect.Struct:
+			field, ok := v.Type().FieldByName(ident)
+			if !ok || !field.IsExported() {
+				return nil, false
+			}
+			v = v.FieldByIndex(field.Index)
+		case reflect.Map:
+			if v.Type().Key().Kind() != reflect.String {
+				return nil, false
+			}
+			v = v.MapIndex(reflect.ValueOf(ident).Convert(v.Type().Key()))
+			if !v.IsValid() {
+				return nil, false
+			}
+		default:
+			return nil, false
+		}
+	}
+
+	if v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil, true
+		}
+		v = v.Elem()
+	}
+
+	return v.Interface(), true
+}
+
+func (ql *QueryLoader) observeRender(start time.Time, hit bool) {
+	ql.stats.renders.Add(1)
+	ql.stats.renderNanos.Add(int64(time.Since(start)))
+	if hit {
+		ql.stats.cacheHits.Add(1)
+	} else {
+		ql.stats.cacheMisses.Add(1)
+	}
+}
+
+// Stats returns the render counters exported by the pgx metrics collector.
+func (ql *QueryLoader) Stats() QueryStats {
+	return QueryStats{
+		Renders:        ql.stats.renders.Load(),
+		RenderDuration: time.Duration(ql.stats.renderNanos.Load()),
+		CacheHits:      ql.stats.cacheHits.Load(),
+		CacheMisses:    ql.stats.cacheMisses.Load(),
+		Prepared:       ql.stats.prepared.Load(),
+	}
+}
+
+// PrepareStatements is a pgxpool AfterConnect hook that registers every
+// pre-rendered query as a named prepared statement on the new connection.
+// pgx keys statements prepared with name == sql by their text, so the SQL
+// returned from Compile runs against them without further changes.
+func (ql *QueryLoader) PrepareStatements(ctx context.Context, conn *pgx.Conn) error {
+	names := make([]string, 0, len(ql.plans))
+	for name := range ql.plans {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		sql := ql.plans[name].sql
+		if _, err := conn.Prepare(ctx, sql, sql); err != nil {
+			// A broken statement must not take the pool down; pgx falls back to
+			// its own statement cache and the query fails when it is used.
+			ql.log.Warn().Err(err).Str("query", name).Msg("Failed to prepare statement")
+			continue
+		}
+		ql.stats.prepared.Add(1)
+	}
+
+	return nil
+}
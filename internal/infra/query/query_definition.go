@@ -0,0 +1,49 @@
+package query
+
+import (
+	"sort"
+)
+
+// QueryDefinition describes a loaded query for tooling such as cmd/querygen.
+type QueryDefinition struct {
+	Name        string
+	File        string
+	SQL         string
+	Annotations map[string]string
+	Fields      []string // top-level data fields the template reads, sorted
+	RangesDot   bool     // template ranges over the data itself
+}
+
+// Definitions returns every loaded query ordered by file, then name.
+func (ql *QueryLoader) Definitions() []QueryDefinition {
+	defs := make([]QueryDefinition, 0, len(ql.templates))
+	for name, tmpl := range ql.templates {
+		root := &sampleShape{}
+		w := &shapeWalker{vars: map[string]*sampleShape{"$": root}}
+		w.walk(tmpl.Tree.Root, root)
+
+		fields := make([]string, 0, len(root.fields))
+		for field := range root.fields {
+			fields = append(fields, field)
+		}
+		sort.Strings(fields)
+
+		defs = append(defs, QueryDefinition{
+			Name:        name,
+			File:        ql.fileMap[name],
+			SQL:         ql.rawSQL[name],
+			Annotations: ql.annots[name],
+			Fields:      fields,
+			RangesDot:   root.elem != nil,
+		})
+	}
+
+	sort.Slice(defs, func(i, j int) bool {
+		if defs[i].File != defs[j].File {
+			return defs[i].File < defs[j].File
+		}
+		return defs[i].Name < defs[j].Name
+	})
+
+	return defs
+}
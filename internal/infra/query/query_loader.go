@@ -110,7 +110,7 @@ func (ql *QueryLoader) loadFileFromRoot(root *os.Root, relPath string) error {
 		}
 
 		name := strings.TrimSpace(lines[0])
-		sqlText := strings.TrimSpace(lines[1])
+		annotations, sqlText := splitAnnotations(strings.TrimSpace(lines[1]))
 		sqlText = strings.TrimSuffix(sqlText, ";")
 
 		// Check for duplicate query name across files
@@ -126,12 +126,40 @@ func (ql *QueryLoader) loadFileFromRoot(root *os.Root, relPath string) error {
 		ql.templates[name] = tmpl
 		ql.rawSQL[name] = sqlText
 		ql.fileMap[name] = relPath
+		ql.annots[name] = annotations
+		if plan, ok := buildPlan(tmpl); ok {
+			ql.plans[name] = plan
+		}
 		ql.log.Debug().Str("file", filepath.Base(relPath)).Str("query", name).Msg("Loaded query")
 	}
 
 	return nil
 }
 
+// splitAnnotations strips the `-- key: value` comment lines that directly
+// follow a `-- name:` header and returns them keyed by annotation name.
+func splitAnnotations(section string) (annotations map[string]string, sqlText string) {
+	annotations = make(map[string]string)
+
+	for {
+		line, rest, _ := strings.Cut(section, "\n")
+		trimmed := strings.TrimSpace(line)
+		if !strings.HasPrefix(trimmed, "--") {
+			break
+		}
+
+		key, value, ok := strings.Cut(strings.TrimSpace(strings.TrimPrefix(trimmed, "--")), ":")
+		if !ok {
+			break
+		}
+
+		annotations[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		section = rest
+	}
+
+	return annotations, strings.TrimSpace(section)
+}
+
 func (ql *QueryLoader) baseFuncMap() template.FuncMap {
 	return template.FuncMap{
 		"eq":  reflect.DeepEqual,
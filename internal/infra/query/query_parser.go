@@ -7,12 +7,13 @@ import (
 	"strings"
 	"sync"
 	"text/template"
+	"time"
 
 	appErr "go-far/internal/model/errors"
 	"go-far/internal/util"
 )
 
-var validatedTypes sync.Map // cache for validated struct types
+var identifierFields sync.Map // struct type -> indexes of fields holding identifiers
 
 func (ql *QueryLoader) Compile(name string, data any) (query string, args []any, err error) {
 	tmpl, ok := ql.templates[name]
@@ -24,9 +25,21 @@ func (ql *QueryLoader) Compile(name string, data any) (query string, args []any,
 		return "", nil, err
 	}
 
-	return ql.compileTemplate(tmpl, data)
+	start := time.Now()
+	if plan, ok := ql.plans[name]; ok {
+		if args, ok := plan.args(data); ok {
+			ql.observeRender(start, true)
+			return plan.sql, args, nil
+		}
+	}
+
+	query, args, err = ql.compileTemplate(tmpl, data)
+	ql.observeRender(start, false)
+	return query, args, err
 }
 
+// validateData checks every identifier passed to raw: string fields named like
+// sortby, sortdir or *column/*field, and []string fields named *columns.
 func validateData(data any) error {
 	if data == nil {
 		return nil
@@ -42,25 +55,44 @@ func validateData(data any) error {
 	}
 
 	t := v.Type()
-	if _, ok := validatedTypes.Load(t); ok {
-		return nil
-	}
+	for _, i := range fieldsToValidate(t) {
+		field := v.Field(i)
 
-	for i := range v.NumField() {
-		field := t.Field(i)
-		name := strings.ToLower(field.Name)
-		if util.IsColumnField(name) {
-			val := v.Field(i).String()
+		values := []string{field.String()}
+		if field.Kind() == reflect.Slice {
+			values = field.Interface().([]string)
+		}
+
+		for _, val := range values {
 			if val != "" && !util.IsValidIdentifier(val) {
-				return appErr.NewWithCode(appErr.CodeInvalidIdentifier, "invalid identifier: "+field.Name)
+				return appErr.NewWithCode(appErr.CodeInvalidIdentifier, "invalid identifier: "+t.Field(i).Name)
 			}
 		}
 	}
 
-	validatedTypes.Store(t, struct{}{})
 	return nil
 }
 
+func fieldsToValidate(t reflect.Type) []int {
+	if cached, ok := identifierFields.Load(t); ok {
+		return cached.([]int)
+	}
+
+	var fields []int
+	for i := range t.NumField() {
+		field := t.Field(i)
+		switch {
+		case field.Type.Kind() == reflect.String && util.IsColumnField(field.Name):
+			fields = append(fields, i)
+		case field.Type == reflect.TypeFor[[]string]() && strings.HasSuffix(strings.ToLower(field.Name), "columns"):
+			fields = append(fields, i)
+		}
+	}
+
+	identifierFields.Store(t, fields)
+	return fields
+}
+
 func (ql *QueryLoader) compileTemplate(tmpl *template.Template, data any) (query string, args []any, err error) {
 	var sb strings.Builder
 
@@ -0,0 +1,41 @@
+package query
+
+import (
+	"testing"
+
+	appErr "go-far/internal/model/errors"
+)
+
+type columnsData struct {
+	Columns []string
+	SortBy  string
+	ID      string
+}
+
+// TestValidateDataChecksEveryValue validates the same struct type twice, so
+// a value that only fails on a later call is still rejected.
+func TestValidateDataChecksEveryValue(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    any
+		wantErr bool
+	}{
+		{name: "valid columns", data: columnsData{Columns: []string{"id", "brand"}, SortBy: "brand"}},
+		{name: "injected column", data: columnsData{Columns: []string{"id", "brand; DROP TABLE cars"}}, wantErr: true},
+		{name: "injected sort", data: &columnsData{SortBy: "id desc, (SELECT 1)"}, wantErr: true},
+		{name: "free text in other fields", data: columnsData{ID: "not an identifier"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateData(tt.data)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("validateData() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr && appErr.ErrCode(err) != appErr.CodeInvalidIdentifier {
+				t.Errorf("error code = %v, want CodeInvalidIdentifier", appErr.ErrCode(err))
+			}
+		})
+	}
+}
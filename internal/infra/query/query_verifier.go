@@ -0,0 +1,296 @@
+package query
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"text/template/parse"
+
+	appErr "go-far/internal/model/errors"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// sampleShape describes how a template navigates its data so Verify can
+// synthesise a value that exercises every branch of the template.
+type sampleShape struct {
+	fields map[string]*sampleShape
+	elem   *sampleShape
+	isInt  bool
+}
+
+type shapeWalker struct {
+	vars map[string]*sampleShape
+}
+
+// intFuncs are template funcs whose operands must be ints.
+var intFuncs = map[string]bool{"gt": true, "lt": true, "gte": true, "lte": true}
+
+// Verify renders every named query with representative sample data and asks
+// Postgres to parse and describe it, so syntax errors, unknown columns and
+// mismatched parameter counts fail at startup instead of on the first request.
+func (ql *QueryLoader) Verify(ctx context.Context, pool *pgxpool.Pool) error {
+	conn, err := pool.Acquire(ctx)
+	if err != nil {
+		return appErr.WrapWithCode(err, appErr.CodeQueryVerify, "acquire connection for query verification")
+	}
+	defer conn.Release()
+
+	names := make([]string, 0, len(ql.templates))
+	for name := range ql.templates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var failures []string
+	for _, name := range names {
+		if verifyErr := ql.verifyQuery(ctx, conn.Conn().PgConn(), name); verifyErr != nil {
+			failures = append(failures, fmt.Sprintf("%s (%s): %s", name, ql.fileMap[name], verifyErr))
+		}
+	}
+
+	if len(failures) > 0 {
+		return appErr.NewWithCode(appErr.CodeQueryVerify, "%d of %d queries failed verification:\n  - %s",
+			len(failures), len(names), strings.Join(failures, "\n  - "))
+	}
+
+	ql.log.Info().Msgf("✅ Queries verified against database, total queries: %d", len(names))
+	return nil
+}
+
+func (ql *QueryLoader) verifyQuery(ctx context.Context, pgConn *pgconn.PgConn, name string) error {
+	data, err := ql.sampleData(name)
+	if err != nil {
+		return err
+	}
+
+	query, args, err := ql.compileTemplate(ql.templates[name], data)
+	if err != nil {
+		return appErr.RootCause(err)
+	}
+
+	// An empty statement name describes the query through the unnamed
+	// prepared statement, so nothing is left behind on the connection.
+	sd, err := pgConn.Prepare(ctx, "", query, nil)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			return fmt.Errorf("%s (SQLSTATE %s) at position %d", pgErr.Message, pgErr.Code, pgErr.Position)
+		}
+
+		return err
+	}
+
+	if len(sd.ParamOIDs) != len(args) {
+		return fmt.Errorf("template binds %d args but statement expects %d parameters", len(args), len(sd.ParamOIDs))
+	}
+
+	return nil
+}
+
+// sampleData builds representative data for a query by walking its template
+// tree, then overlays the optional `-- sample:` annotation for values that
+// cannot be guessed (e.g. identifiers passed through raw).
+func (ql *QueryLoader) sampleData(name string) (any, error) {
+	tmpl, ok := ql.templates[name]
+	if !ok {
+		return nil, fmt.Errorf("query %s not found", name)
+	}
+
+	root := &sampleShape{}
+	w := &shapeWalker{vars: map[string]*sampleShape{"$": root}}
+	w.walk(tmpl.Tree.Root, root)
+	data := root.value()
+
+	raw, ok := ql.annots[name]["sample"]
+	if !ok {
+		return data, nil
+	}
+
+	var override any
+	if err := json.Unmarshal([]byte(raw), &override); err != nil {
+		return nil, fmt.Errorf("invalid sample annotation: %w", err)
+	}
+
+	return mergeSample(data, normalizeSample(override)), nil
+}
+
+func (w *shapeWalker) walk(node parse.Node, dot *sampleShape) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			w.walk(child, dot)
+		}
+	case *parse.ActionNode:
+		w.pipe(n.Pipe, dot)
+	case *parse.IfNode:
+		w.pipe(n.Pipe, dot)
+		w.walk(n.List, dot)
+		w.walk(n.ElseList, dot)
+	case *parse.WithNode:
+		inner := w.pipe(n.Pipe, dot)
+		if inner == nil {
+			inner = dot
+		}
+		w.walk(n.List, inner)
+		w.walk(n.ElseList, dot)
+	case *parse.RangeNode:
+		w.walkRange(n, dot)
+	}
+}
+
+func (w *shapeWalker) walkRange(n *parse.RangeNode, dot *sampleShape) {
+	target := w.pipe(n.Pipe, dot)
+	if target == nil {
+		return
+	}
+
+	if target.elem == nil {
+		target.elem = &sampleShape{}
+	}
+
+	switch len(n.Pipe.Decl) {
+	case 1:
+		w.vars[n.Pipe.Decl[0].Ident[0]] = target.elem
+	case 2:
+		w.vars[n.Pipe.Decl[0].Ident[0]] = &sampleShape{isInt: true}
+		w.vars[n.Pipe.Decl[1].Ident[0]] = target.elem
+	}
+
+	w.walk(n.List, target.elem)
+	w.walk(n.ElseList, dot)
+}
+
+func (w *shapeWalker) pipe(p *parse.PipeNode, dot *sampleShape) *sampleShape {
+	if p == nil {
+		return nil
+	}
+
+	var last *sampleShape
+	for _, cmd := range p.Cmds {
+		last = w.command(cmd, dot)
+	}
+
+	return last
+}
+
+func (w *shapeWalker) command(cmd *parse.CommandNode, dot *sampleShape) *sampleShape {
+	if len(cmd.Args) == 0 {
+		return nil
+	}
+
+	ident, ok := cmd.Args[0].(*parse.IdentifierNode)
+	if !ok {
+		return w.arg(cmd.Args[0], dot)
+	}
+
+	for _, a := range cmd.Args[1:] {
+		if s := w.arg(a, dot); s != nil && intFuncs[ident.Ident] {
+			s.isInt = true
+		}
+	}
+
+	return nil
+}
+
+func (w *shapeWalker) arg(node parse.Node, dot *sampleShape) *sampleShape {
+	switch n := node.(type) {
+	case *parse.DotNode:
+		return dot
+	case *parse.FieldNode:
+		return dot.path(n.Ident)
+	case *parse.VariableNode:
+		if base, ok := w.vars[n.Ident[0]]; ok {
+			return base.path(n.Ident[1:])
+		}
+	case *parse.PipeNode:
+		return w.pipe(n, dot)
+	}
+
+	return nil
+}
+
+func (s *sampleShape) path(idents []string) *sampleShape {
+	cur := s
+	for _, ident := range idents {
+		if cur.fields == nil {
+			cur.fields = make(map[string]*sampleShape)
+		}
+
+		next, ok := cur.fields[ident]
+		if !ok {
+			next = &sampleShape{}
+			cur.fields[ident] = next
+		}
+		cur = next
+	}
+
+	return cur
+}
+
+// value renders the shape as data: two elements for ranged values (so
+// separators are exercised), maps for navigated values, and truthy scalars
+// so every optional clause is included.
+func (s *sampleShape) value() any {
+	switch {
+	case s.elem != nil:
+		return []any{s.elem.value(), s.elem.value()}
+	case len(s.fields) > 0:
+		m := make(map[string]any, len(s.fields))
+		for k, f := range s.fields {
+			m[k] = f.value()
+		}
+		return m
+	case s.isInt:
+		return 1
+	default:
+		return "sample"
+	}
+}
+
+// normalizeSample turns whole JSON numbers into ints so they satisfy the
+// int-typed comparison funcs.
+func normalizeSample(v any) any {
+	switch t := v.(type) {
+	case float64:
+		if t == math.Trunc(t) {
+			return int(t)
+		}
+	case map[string]any:
+		for k, val := range t {
+			t[k] = normalizeSample(val)
+		}
+	case []any:
+		for i, val := range t {
+			t[i] = normalizeSample(val)
+		}
+	}
+
+	return v
+}
+
+func mergeSample(base, override any) any {
+	baseMap, ok := base.(map[string]any)
+	if !ok {
+		return override
+	}
+
+	overrideMap, ok := override.(map[string]any)
+	if !ok {
+		return override
+	}
+
+	for k, v := range overrideMap {
+		baseMap[k] = mergeSample(baseMap[k], v)
+	}
+
+	return baseMap
+}
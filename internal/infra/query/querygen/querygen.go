@@ -0,0 +1,607 @@
+// Package querygen turns the annotated `-- name:` blocks in configs/queries
+// into typed Go wrappers, so renaming or removing a query (or one of its
+// params) breaks compilation instead of failing at runtime.
+//
+// Annotations follow the `-- name:` line:
+//
+//	-- params: ID uuid.UUID, UserID string   generates a XxxParams struct
+//	-- params: []*entity.Car                 passes the value as template data
+//	-- returns: one entity.Car               one|many with a struct, scalar or
+//	-- returns: many {CarID uuid.UUID}       inline field list; exec; execrows
+//	-- returns: each *entity.Car             calls fn once per row instead of
+//	                                         collecting a slice
+//
+// Struct results are scanned by mapping each selected column to the field of
+// the same name in CamelCase (license_plate -> LicensePlate, car_id -> CarID).
+// Inline results generate a XxxRow struct scanned positionally. A select list
+// built by the template (e.g. a ranged list of raw columns) is scanned by
+// name into the struct's db tags instead, leaving unselected fields zero.
+package querygen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+
+	"go-far/internal/infra/query"
+)
+
+type Options struct {
+	Package string
+	OutDir  string
+}
+
+type field struct {
+	Name string
+	Type string
+}
+
+type queryFunc struct {
+	Name      string
+	File      string
+	Kind      string // one, many, each, exec, execrows
+	ArgType   string // empty when the query takes no data
+	Params    []field
+	Result    string // Go type of a single row
+	RowFields []field
+	Scan      []string // scan targets relative to the row variable
+	Pointer   bool
+	ByName    bool // columns are only known once the template runs
+}
+
+type genFile struct {
+	Package string
+	Source  string
+	Imports []string
+	Names   []string
+	Funcs   []queryFunc
+}
+
+var (
+	knownImports = map[string]string{
+		"time":   "time",
+		"uuid":   "github.com/google/uuid",
+		"entity": "go-far/internal/model/entity",
+		"dto":    "go-far/internal/model/dto",
+		"query":  "go-far/internal/infra/query",
+	}
+
+	scalarTypes = map[string]bool{
+		"bool": true, "string": true, "int": true, "int32": true, "int64": true, "float64": true,
+		"time.Time": true, "uuid.UUID": true,
+	}
+
+	qualifierPattern = regexp.MustCompile(`\b([a-z][a-zA-Z0-9]*)\.[A-Z]`)
+	actionPattern    = regexp.MustCompile(`\{\{.*?\}\}`)
+	fieldPattern     = regexp.MustCompile(`^[A-Z][A-Za-z0-9]*$`)
+)
+
+// dbtxFile is written next to the per-file wrappers. It aliases the
+// connection type they take to database.DBTX, so callers pass a pool, a
+// transaction or a cursor without converting between packages.
+const dbtxFile = "dbtx.gen.go"
+
+// Generate writes one `<file>.gen.go` per SQL file in defs, plus dbtxFile,
+// into opt.OutDir.
+func Generate(defs []query.QueryDefinition, opt Options) error {
+	files := make(map[string]*genFile)
+	var order []string
+
+	for _, def := range defs {
+		gf, ok := files[def.File]
+		if !ok {
+			gf = &genFile{Package: opt.Package, Source: filepath.ToSlash(def.File)}
+			files[def.File] = gf
+			order = append(order, def.File)
+		}
+
+		gf.Names = append(gf.Names, def.Name)
+
+		fn, ok, err := buildFunc(def)
+		if err != nil {
+			return fmt.Errorf("%s (%s): %w", def.Name, def.File, err)
+		}
+		if ok {
+			gf.Funcs = append(gf.Funcs, fn)
+		}
+	}
+
+	for _, file := range order {
+		gf := files[file]
+		gf.Imports = collectImports(gf)
+
+		var buf bytes.Buffer
+		if err := fileTemplate.Execute(&buf, gf); err != nil {
+			return err
+		}
+
+		src, err := format.Source(buf.Bytes())
+		if err != nil {
+			return fmt.Errorf("format generated code for %s: %w\n%s", file, err, buf.String())
+		}
+
+		base := strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+		if err := os.WriteFile(filepath.Join(opt.OutDir, base+".gen.go"), src, 0o600); err != nil {
+			return err
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := dbtxTemplate.Execute(&buf, opt); err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(opt.OutDir, dbtxFile), buf.Bytes(), 0o600)
+}
+
+func buildFunc(def query.QueryDefinition) (queryFunc, bool, error) {
+	returns, ok := def.Annotations["returns"]
+	if !ok {
+		return queryFunc{}, false, nil
+	}
+
+	fn := queryFunc{Name: def.Name, File: filepath.Base(def.File)}
+
+	if err := fn.parseParams(def); err != nil {
+		return fn, false, err
+	}
+
+	kind, result, _ := strings.Cut(strings.TrimSpace(returns), " ")
+	fn.Kind = kind
+
+	switch kind {
+	case "exec", "execrows":
+		return fn, true, nil
+	case "one", "many", "each":
+	default:
+		return fn, false, fmt.Errorf("unknown returns kind %q", kind)
+	}
+
+	result = strings.TrimSpace(result)
+	if result == "" {
+		return fn, false, fmt.Errorf("returns %s needs a result type", kind)
+	}
+
+	columns, err := selectColumns(def.SQL)
+	if err != nil {
+		return fn, false, err
+	}
+
+	if err := fn.parseResult(result, columns); err != nil {
+		return fn, false, err
+	}
+
+	if kind == "each" && fn.ByName {
+		return fn, false, fmt.Errorf("returns each needs a fixed select list")
+	}
+
+	return fn, true, nil
+}
+
+func (fn *queryFunc) parseParams(def query.QueryDefinition) error {
+	params := strings.TrimSpace(def.Annotations["params"])
+	if params == "" {
+		if len(def.Fields) > 0 || def.RangesDot {
+			return fmt.Errorf("template reads %v but has no params annotation", def.Fields)
+		}
+		return nil
+	}
+
+	// A single bare type is passed through as the template data.
+	if !strings.Contains(params, " ") {
+		fn.ArgType = params
+		return nil
+	}
+
+	fields, err := parseFields(params)
+	if err != nil {
+		return err
+	}
+
+	declared := make(map[string]bool, len(fields))
+	for _, f := range fields {
+		declared[f.Name] = true
+	}
+
+	for _, used := range def.Fields {
+		if !declared[used] {
+			return fmt.Errorf("template reads .%s which is not declared in params", used)
+		}
+	}
+
+	fn.Params = fields
+	fn.ArgType = fn.Name + "Params"
+
+	return nil
+}
+
+func (fn *queryFunc) parseResult(result string, columns []string) error {
+	if strings.HasPrefix(result, "{") {
+		fields, err := parseFields(strings.Trim(result, "{}"))
+		if err != nil {
+			return err
+		}
+
+		if len(fields) != len(columns) {
+			return fmt.Errorf("result declares %d fields but query selects %d columns", len(fields), len(columns))
+		}
+
+		fn.RowFields = fields
+		fn.Result = fn.Name + "Row"
+		for _, f := range fields {
+			fn.Scan = append(fn.Scan, "."+f.Name)
+		}
+
+		return nil
+	}
+
+	fn.Pointer = strings.HasPrefix(result, "*")
+	fn.Result = strings.TrimPrefix(result, "*")
+
+	if scalarTypes[fn.Result] {
+		if len(columns) != 1 {
+			return fmt.Errorf("scalar result %s but query selects %d columns", fn.Result, len(columns))
+		}
+
+		fn.Scan = []string{""}
+		return nil
+	}
+
+	for _, col := range columns {
+		if strings.Contains(col, "$") {
+			fn.ByName, fn.Scan = true, nil
+			return nil
+		}
+		fn.Scan = append(fn.Scan, "."+fieldName(col))
+	}
+
+	return nil
+}
+
+func parseFields(s string) ([]field, error) {
+	var fields []field
+	for part := range strings.SplitSeq(s, ",") {
+		name, typ, ok := strings.Cut(strings.TrimSpace(part), " ")
+		if !ok || !fieldPattern.MatchString(name) {
+			return nil, fmt.Errorf("invalid field %q, want `Name Type`", strings.TrimSpace(part))
+		}
+		fields = append(fields, field{Name: name, Type: strings.TrimSpace(typ)})
+	}
+
+	return fields, nil
+}
+
+// selectColumns returns the output column names of a SELECT or of a
+// RETURNING clause, using the alias when one is given.
+func selectColumns(sql string) ([]string, error) {
+	text := actionPattern.ReplaceAllLiteralString(sql, "$1")
+	lower := strings.ToLower(text)
+
+	var list string
+	if i := strings.LastIndex(lower, "returning"); i >= 0 {
+		list = text[i+len("returning"):]
+	} else {
+		start := strings.Index(lower, "select")
+		if start < 0 {
+			return nil, fmt.Errorf("cannot find SELECT or RETURNING columns")
+		}
+
+		// A SELECT without a top-level FROM (e.g. of scalar subqueries) lists
+		// its columns up to the end of the statement.
+		end := topLevelFrom(lower[start:])
+		if end < 0 {
+			end = len(lower) - start
+		}
+
+		list = text[start+len("select") : start+end]
+	}
+
+	var columns []string
+	for _, item := range splitTopLevel(strings.TrimSuffix(strings.TrimSpace(list), ";")) {
+		columns = append(columns, columnName(strings.TrimSpace(item)))
+	}
+
+	return columns, nil
+}
+
+// topLevelFrom returns the index of the first FROM keyword outside parentheses.
+func topLevelFrom(s string) int {
+	depth := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		}
+		if depth == 0 && isSpace(s[i]) && strings.HasPrefix(s[i+1:], "from") &&
+			(i+5 == len(s) || isSpace(s[i+5])) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\n' || c == '\t' || c == '\r'
+}
+
+func splitTopLevel(s string) []string {
+	var (
+		parts []string
+		depth int
+		start int
+	)
+
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				parts = append(parts, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+
+	return append(parts, s[start:])
+}
+
+func columnName(item string) string {
+	lower := strings.ToLower(item)
+	if i := strings.LastIndex(lower, " as "); i >= 0 {
+		return strings.Trim(strings.TrimSpace(item[i+4:]), `"`)
+	}
+
+	if i := strings.Index(item, "("); i > 0 {
+		return strings.ToLower(strings.TrimSpace(item[:i]))
+	}
+
+	if i := strings.LastIndex(item, "."); i >= 0 {
+		item = item[i+1:]
+	}
+
+	return strings.Trim(strings.TrimSpace(item), `"`)
+}
+
+// initialisms are the column words written in capitals in Go field names.
+var initialisms = map[string]string{"id": "ID", "vin": "VIN"}
+
+// fieldName converts a snake_case column to the Go field name used by the
+// entity structs.
+func fieldName(column string) string {
+	var sb strings.Builder
+	for part := range strings.SplitSeq(column, "_") {
+		if initialism, ok := initialisms[part]; ok {
+			sb.WriteString(initialism)
+			continue
+		}
+		if part != "" {
+			sb.WriteString(strings.ToUpper(part[:1]) + part[1:])
+		}
+	}
+
+	return sb.String()
+}
+
+func collectImports(gf *genFile) []string {
+	set := map[string]bool{}
+	if len(gf.Funcs) > 0 {
+		set["context"] = true
+	}
+
+	add := func(typ string) {
+		for _, m := range qualifierPattern.FindAllStringSubmatch(typ, -1) {
+			if path, ok := knownImports[m[1]]; ok {
+				set[path] = true
+			}
+		}
+	}
+
+	for _, fn := range gf.Funcs {
+		if fn.ByName {
+			set["github.com/jackc/pgx/v5"] = true
+		}
+		add(fn.ArgType)
+		add(fn.Result)
+		for _, f := range fn.Params {
+			add(f.Type)
+		}
+		for _, f := range fn.RowFields {
+			add(f.Type)
+		}
+	}
+
+	var std, local []string
+	for path := range set {
+		if strings.Contains(path, ".") || strings.HasPrefix(path, "go-far/") {
+			local = append(local, path)
+		} else {
+			std = append(std, path)
+		}
+	}
+	sort.Strings(std)
+	sort.Strings(local)
+
+	// An empty entry renders as the blank line between import groups.
+	if len(std) > 0 && len(local) > 0 {
+		std = append(std, "")
+	}
+
+	return append(std, local...)
+}
+
+var dbtxTemplate = template.Must(template.New("dbtx").Parse(`// Code generated by querygen. DO NOT EDIT.
+
+package {{ .Package }}
+
+import "go-far/internal/infra/database"
+
+// DBTX is what the generated queries run on.
+type DBTX = database.DBTX
+`))
+
+var fileTemplate = template.Must(template.New("file").Parse(`// Code generated by querygen from {{ .Source }}. DO NOT EDIT.
+
+package {{ .Package }}
+{{ if .Imports }}
+import (
+{{- range .Imports }}
+{{ if . }}	"{{ . }}"{{ end }}
+{{- end }}
+)
+{{ end }}
+// Query names defined in {{ .Source }}.
+const (
+{{- range .Names }}
+	Query{{ . }} = "{{ . }}"
+{{- end }}
+)
+{{ range .Funcs }}
+{{- $fn := . }}
+{{- if .Params }}
+type {{ .Name }}Params struct {
+{{- range .Params }}
+	{{ .Name }} {{ .Type }}
+{{- end }}
+}
+{{ end }}
+{{- if .RowFields }}
+type {{ .Name }}Row struct {
+{{- range .RowFields }}
+	{{ .Name }} {{ .Type }}
+{{- end }}
+}
+{{ end }}
+// {{ .Name }} runs the {{ .Name }} query from {{ .File }}.
+{{- if eq .Kind "exec" }}
+func (q *Queries) {{ .Name }}(ctx context.Context, db DBTX{{ if .ArgType }}, arg {{ .ArgType }}{{ end }}) error {
+	query, args, err := q.compile(ctx, Query{{ .Name }}, {{ if .ArgType }}arg{{ else }}nil{{ end }})
+	if err != nil {
+		return err
+	}
+
+	_, err = db.Exec(ctx, query, args...)
+	return err
+}
+{{- else if eq .Kind "execrows" }}
+func (q *Queries) {{ .Name }}(ctx context.Context, db DBTX{{ if .ArgType }}, arg {{ .ArgType }}{{ end }}) (int64, error) {
+	query, args, err := q.compile(ctx, Query{{ .Name }}, {{ if .ArgType }}arg{{ else }}nil{{ end }})
+	if err != nil {
+		return 0, err
+	}
+
+	result, err := db.Exec(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected(), nil
+}
+{{- else if and (eq .Kind "one") .ByName }}
+func (q *Queries) {{ .Name }}(ctx context.Context, db DBTX{{ if .ArgType }}, arg {{ .ArgType }}{{ end }}) ({{ if .Pointer }}*{{ end }}{{ .Result }}, error) {
+	{{ if .Pointer }}var r *{{ .Result }}{{ else }}var r {{ .Result }}{{ end }}
+
+	query, args, err := q.compile(ctx, Query{{ .Name }}, {{ if .ArgType }}arg{{ else }}nil{{ end }})
+	if err != nil {
+		return r, err
+	}
+
+	rows, err := db.Query(ctx, query, args...)
+	if err != nil {
+		return r, err
+	}
+
+	return pgx.CollectExactlyOneRow(rows, pgx.{{ if .Pointer }}RowToAddrOfStructByNameLax{{ else }}RowToStructByNameLax{{ end }}[{{ .Result }}])
+}
+{{- else if eq .Kind "one" }}
+func (q *Queries) {{ .Name }}(ctx context.Context, db DBTX{{ if .ArgType }}, arg {{ .ArgType }}{{ end }}) ({{ if .Pointer }}*{{ end }}{{ .Result }}, error) {
+	{{ if .Pointer }}r := new({{ .Result }}){{ else }}var r {{ .Result }}{{ end }}
+
+	query, args, err := q.compile(ctx, Query{{ .Name }}, {{ if .ArgType }}arg{{ else }}nil{{ end }})
+	if err != nil {
+		return {{ if .Pointer }}nil{{ else }}r{{ end }}, err
+	}
+
+	if err := db.QueryRow(ctx, query, args...).Scan({{ range $i, $s := .Scan }}{{ if $i }}, {{ end }}&r{{ $s }}{{ end }}); err != nil {
+		return {{ if .Pointer }}nil{{ else }}r{{ end }}, err
+	}
+
+	return r, nil
+}
+{{- else if eq .Kind "each" }}
+func (q *Queries) {{ .Name }}(ctx context.Context, db DBTX{{ if .ArgType }}, arg {{ .ArgType }}{{ end }}, fn func({{ if .Pointer }}*{{ end }}{{ .Result }}) error) error {
+	query, args, err := q.compile(ctx, Query{{ .Name }}, {{ if .ArgType }}arg{{ else }}nil{{ end }})
+	if err != nil {
+		return err
+	}
+
+	rows, err := db.Query(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		{{ if .Pointer }}r := new({{ .Result }}){{ else }}var r {{ .Result }}{{ end }}
+		if err := rows.Scan({{ range $i, $s := .Scan }}{{ if $i }}, {{ end }}&r{{ $s }}{{ end }}); err != nil {
+			return err
+		}
+
+		if err := fn(r); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+{{- else if .ByName }}
+func (q *Queries) {{ .Name }}(ctx context.Context, db DBTX{{ if .ArgType }}, arg {{ .ArgType }}{{ end }}) ([]{{ if .Pointer }}*{{ end }}{{ .Result }}, error) {
+	query, args, err := q.compile(ctx, Query{{ .Name }}, {{ if .ArgType }}arg{{ else }}nil{{ end }})
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return pgx.CollectRows(rows, pgx.{{ if .Pointer }}RowToAddrOfStructByNameLax{{ else }}RowToStructByNameLax{{ end }}[{{ .Result }}])
+}
+{{- else }}
+func (q *Queries) {{ .Name }}(ctx context.Context, db DBTX{{ if .ArgType }}, arg {{ .ArgType }}{{ end }}) ([]{{ if .Pointer }}*{{ end }}{{ .Result }}, error) {
+	query, args, err := q.compile(ctx, Query{{ .Name }}, {{ if .ArgType }}arg{{ else }}nil{{ end }})
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []{{ if .Pointer }}*{{ end }}{{ .Result }}
+	for rows.Next() {
+		{{ if .Pointer }}r := new({{ .Result }}){{ else }}var r {{ .Result }}{{ end }}
+		if err := rows.Scan({{ range $i, $s := .Scan }}{{ if $i }}, {{ end }}&r{{ $s }}{{ end }}); err != nil {
+			return nil, err
+		}
+		items = append(items, r)
+	}
+
+	return items, rows.Err()
+}
+{{- end }}
+{{ end }}`))
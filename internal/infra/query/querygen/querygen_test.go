@@ -0,0 +1,51 @@
+package querygen
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go-far/internal/infra/query"
+
+	"github.com/rs/zerolog"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+// TestGenerateGolden runs the generator on testdata/golden_queries.sql and
+// compares the output with testdata/golden_queries.gen.golden and
+// testdata/dbtx.gen.golden. Run with -update after an intended change to the
+// generated code.
+func TestGenerateGolden(t *testing.T) {
+	log := zerolog.Nop()
+	loader := query.InitQueryLoader(&log, &query.QueriesOptions{Path: "testdata"})
+
+	out := t.TempDir()
+	if err := Generate(loader.Definitions(), Options{Package: "queries", OutDir: out}); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+
+	for _, name := range []string{"golden_queries", "dbtx"} {
+		got, err := os.ReadFile(filepath.Join(out, name+".gen.go"))
+		if err != nil {
+			t.Fatalf("read generated file: %v", err)
+		}
+
+		golden := filepath.Join("testdata", name+".gen.golden")
+		if *update {
+			if err := os.WriteFile(golden, got, 0o600); err != nil {
+				t.Fatalf("update golden file: %v", err)
+			}
+		}
+
+		want, err := os.ReadFile(golden)
+		if err != nil {
+			t.Fatalf("read golden file: %v", err)
+		}
+
+		if string(got) != string(want) {
+			t.Errorf("generated code differs from %s:\n--- got ---\n%s\n--- want ---\n%s", golden, got, want)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+-- name: GoldenGetCar
+-- params: ID uuid.UUID
+-- returns: one entity.Car
+SELECT id, brand, model, year
+FROM cars
+WHERE id = {{ arg .ID }} AND deleted_at IS NULL;
+
+-- name: GoldenListCars
+-- params: *dto.CarFilterV2
+-- returns: many entity.Car
+SELECT id, brand, model, year
+FROM cars
+WHERE deleted_at IS NULL
+{{ if .Brand }}
+    AND brand = {{ arg .Brand }}
+{{ end }}
+ORDER BY brand;
+
+-- name: GoldenCountByBrand
+-- params: MinYear int
+-- returns: many {Brand string, Total int64}
+SELECT brand, COUNT(*) AS total
+FROM cars
+WHERE year >= {{ arg .MinYear }}
+GROUP BY brand;
+
+-- name: GoldenCarExists
+-- params: ID uuid.UUID
+-- returns: one bool
+SELECT EXISTS (SELECT 1 FROM cars WHERE id = {{ arg .ID }}) AS exists;
+
+-- name: GoldenFilterCars
+-- params: Where query.Clause
+-- returns: many entity.Car
+SELECT id, brand
+FROM cars{{ range .Where }}{{ raw .SQL }}{{ if .HasArg }}{{ arg .Arg }}{{ end }}{{ end }}
+
+-- name: GoldenStreamCars
+-- params: Where query.Clause
+-- returns: each *entity.Car
+SELECT id, brand
+FROM cars{{ range .Where }}{{ raw .SQL }}{{ if .HasArg }}{{ arg .Arg }}{{ end }}{{ end }}
+
+-- name: GoldenTouchCar
+-- params: ID uuid.UUID, UpdatedAt time.Time
+-- returns: exec
+UPDATE cars SET updated_at = {{ arg .UpdatedAt }} WHERE id = {{ arg .ID }};
+
+-- name: GoldenPurgeCars
+-- params: Limit int
+-- returns: execrows
+DELETE FROM cars
+WHERE id IN (SELECT id FROM cars WHERE deleted_at IS NOT NULL LIMIT {{ arg .Limit }});
+
+-- name: GoldenUnannotated
+SELECT 1;
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	"go-far/internal/infra/cache"
 	"go-far/internal/preference"
 
 	"github.com/redis/go-redis/v9"
@@ -29,6 +30,8 @@ type RedisOptions struct {
 	MaxActiveConns  int           `yaml:"max_active_conns"`
 	PoolTimeout     time.Duration `yaml:"pool_timeout"`
 	Enabled         bool          `yaml:"enabled"`
+	// LocalCache is the optional in-process L1 in front of the Redis cache.
+	LocalCache *cache.LocalOptions `yaml:"local_cache"`
 }
 
 // InitRedis initializes a Redis client
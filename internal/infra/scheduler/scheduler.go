@@ -41,8 +41,11 @@ type SchedulerOptions struct {
 
 // SchedulerJobsOptions holds individual job configurations
 type SchedulerJobsOptions struct {
-	UserGeneratorJob *UserGeneratorJobOptions `yaml:"user_generator"`
-	CarGeneratorJob  *CarGeneratorJobOptions  `yaml:"car_generator"`
+	UserGeneratorJob       *UserGeneratorJobOptions       `yaml:"user_generator"`
+	CarGeneratorJob        *CarGeneratorJobOptions        `yaml:"car_generator"`
+	PurgeDeletedJob        *PurgeDeletedJobOptions        `yaml:"purge_deleted"`
+	ExpireTransfersJob     *ExpireTransfersJobOptions     `yaml:"expire_transfers"`
+	FlagOverdueServicesJob *FlagOverdueServicesJobOptions `yaml:"flag_overdue_services"`
 }
 
 // UserGeneratorJobOptions holds user generator job configuration
@@ -65,11 +68,37 @@ type CarGeneratorJobOptions struct {
 	Enabled     bool   `yaml:"enabled"`
 }
 
+// PurgeDeletedJobOptions holds soft-delete purge job configuration
+type PurgeDeletedJobOptions struct {
+	Cron      string        `yaml:"cron"`
+	Retention time.Duration `yaml:"retention"`
+	BatchSize int           `yaml:"batch_size"`
+	Enabled   bool          `yaml:"enabled"`
+}
+
+// ExpireTransfersJobOptions holds transfer request expiry job configuration
+type ExpireTransfersJobOptions struct {
+	Cron      string `yaml:"cron"`
+	BatchSize int    `yaml:"batch_size"`
+	Enabled   bool   `yaml:"enabled"`
+}
+
+// FlagOverdueServicesJobOptions holds overdue car service job configuration
+type FlagOverdueServicesJobOptions struct {
+	Cron      string        `yaml:"cron"`
+	Interval  time.Duration `yaml:"interval"`
+	BatchSize int           `yaml:"batch_size"`
+	Enabled   bool          `yaml:"enabled"`
+}
+
 // InitScheduler initializes the scheduler
 func InitScheduler(log *zerolog.Logger, opt *SchedulerOptions, tracingEnabled bool, reg *prometheus.Registry) (*Scheduler, *metricspkg.SchedulerMetrics) {
 	metrics := metricspkg.NewSchedulerMetrics(reg, &metricspkg.SchedulerJobsOptions{
-		UserGeneratorJob: convertUserJobToMetrics(opt.SchedulerJobs.UserGeneratorJob),
-		CarGeneratorJob:  convertCarJobToMetrics(opt.SchedulerJobs.CarGeneratorJob),
+		UserGeneratorJob:       convertUserJobToMetrics(opt.SchedulerJobs.UserGeneratorJob),
+		CarGeneratorJob:        convertCarJobToMetrics(opt.SchedulerJobs.CarGeneratorJob),
+		PurgeDeletedJob:        convertPurgeJobToMetrics(opt.SchedulerJobs.PurgeDeletedJob),
+		ExpireTransfersJob:     convertExpireTransfersJobToMetrics(opt.SchedulerJobs.ExpireTransfersJob),
+		FlagOverdueServicesJob: convertFlagOverdueServicesJobToMetrics(opt.SchedulerJobs.FlagOverdueServicesJob),
 	})
 
 	return &Scheduler{
@@ -97,6 +126,30 @@ func convertCarJobToMetrics(src *CarGeneratorJobOptions) *metricspkg.CarGenerato
 	return &metricspkg.CarGeneratorJobOptions{Enabled: src.Enabled}
 }
 
+func convertPurgeJobToMetrics(src *PurgeDeletedJobOptions) *metricspkg.PurgeDeletedJobOptions {
+	if src == nil {
+		return nil
+	}
+
+	return &metricspkg.PurgeDeletedJobOptions{Enabled: src.Enabled}
+}
+
+func convertExpireTransfersJobToMetrics(src *ExpireTransfersJobOptions) *metricspkg.ExpireTransfersJobOptions {
+	if src == nil {
+		return nil
+	}
+
+	return &metricspkg.ExpireTransfersJobOptions{Enabled: src.Enabled}
+}
+
+func convertFlagOverdueServicesJobToMetrics(src *FlagOverdueServicesJobOptions) *metricspkg.FlagOverdueServicesJobOptions {
+	if src == nil {
+		return nil
+	}
+
+	return &metricspkg.FlagOverdueServicesJobOptions{Enabled: src.Enabled}
+}
+
 // AddJob adds a job to the scheduler
 func (s *Scheduler) AddJob(job Job) error {
 	s.mu.Lock()
@@ -2,11 +2,13 @@ package validator
 
 import (
 	"errors"
+	"reflect"
 	"strings"
 	"sync"
 
 	"go-far/internal/model/entity"
 	appErrors "go-far/internal/model/errors"
+	"go-far/internal/util"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/rs/zerolog"
@@ -37,6 +39,38 @@ func InitValidator(log *zerolog.Logger) {
 			log.Error().Err(err).Msg("failed to register role validation")
 		}
 
+		err = validate.RegisterValidation("vin", func(fl validator.FieldLevel) bool {
+			return util.ValidVIN(util.NormalizeVIN(fl.Field().String()))
+		})
+		if err != nil {
+			log.Error().Err(err).Msg("failed to register vin validation")
+		}
+
+		err = validate.RegisterValidation("plate_region", func(fl validator.FieldLevel) bool {
+			_, ok := util.PlateFormatFor(fl.Field().String())
+			return ok
+		})
+		if err != nil {
+			log.Error().Err(err).Msg("failed to register plate_region validation")
+		}
+
+		// plate=Field checks a license plate against the format of the
+		// region held by the sibling Field, or the generic format without one.
+		err = validate.RegisterValidation("plate", func(fl validator.FieldLevel) bool {
+			var region string
+			if fl.Param() != "" {
+				field, kind, _, ok := fl.GetStructFieldOKAdvanced2(fl.Parent(), fl.Param())
+				if ok && kind == reflect.String {
+					region = field.String()
+				}
+			}
+
+			return util.ValidPlate(region, fl.Field().String())
+		})
+		if err != nil {
+			log.Error().Err(err).Msg("failed to register plate validation")
+		}
+
 		val = &Validator{Validate: validate}
 	})
 }
@@ -46,22 +80,34 @@ func ValidateRequest(req any) error {
 		return appErrors.NewWithCode(appErrors.CodeHTTPValidatorError, "validator not initialized")
 	}
 
-	err := val.Struct(req)
-	if err != nil {
-		var validationErrors validator.ValidationErrors
-		if errors.As(err, &validationErrors) {
-			var messages []string
-			for _, fe := range validationErrors {
-				messages = append(messages, formatValidationError(fe))
-			}
-
-			return appErrors.NewWithCode(appErrors.CodeHTTPValidatorError, strings.Join(messages, "; "))
-		}
+	if messages := Messages(req); len(messages) > 0 {
+		return appErrors.NewWithCode(appErrors.CodeHTTPValidatorError, strings.Join(messages, "; "))
 	}
 
 	return nil
 }
 
+// Messages validates req and returns one readable message per failed field,
+// for callers that report errors per item (e.g. per CSV row) rather than
+// failing the whole request. It returns nil when req is valid.
+func Messages(req any) []string {
+	if val == nil {
+		return []string{"validator not initialized"}
+	}
+
+	var validationErrors validator.ValidationErrors
+	if err := val.Struct(req); !errors.As(err, &validationErrors) {
+		return nil
+	}
+
+	messages := make([]string, 0, len(validationErrors))
+	for _, fe := range validationErrors {
+		messages = append(messages, formatValidationError(fe))
+	}
+
+	return messages
+}
+
 func formatValidationError(fe validator.FieldError) string {
 	switch fe.Tag() {
 	case "required":
@@ -84,6 +130,15 @@ func formatValidationError(fe validator.FieldError) string {
 		return fe.Field() + " must be one of: " + fe.Param()
 	case "role_valid":
 		return fe.Field() + " must be one of: admin, user, guest"
+	case "vin":
+		return fe.Field() + " must be a 17-character VIN with a valid check digit"
+	case "plate_region":
+		return fe.Field() + " must be one of: " + strings.Join(util.PlateRegions(), ", ")
+	case "plate":
+		if fe.Param() != "" {
+			return fe.Field() + " is not a valid license plate for " + fe.Param() + " (or the generic format without one)"
+		}
+		return fe.Field() + " is not a valid license plate"
 	default:
 		return fe.Field() + " failed " + fe.Tag() + " validation"
 	}
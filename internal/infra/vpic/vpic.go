@@ -0,0 +1,103 @@
+package vpic
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	appErr "go-far/internal/model/errors"
+
+	"github.com/rs/zerolog"
+)
+
+// VPICOptions holds the NHTSA vPIC API configuration. Pointing BaseURL at a
+// local server that serves the same paths stubs the API out, e.g. in tests.
+type VPICOptions struct {
+	BaseURL  string        `yaml:"base_url"`
+	CacheTTL time.Duration `yaml:"cache_ttl"`
+}
+
+// VPIC defines the NHTSA vPIC API client interface
+type VPIC interface {
+	DecodeVinValues(ctx context.Context, vin string) (*DecodeVinValuesResult, error)
+}
+
+// DecodeVinValuesResult is the flat decoding of a VIN. vPIC reports empty
+// strings for the variables it could not decode.
+type DecodeVinValuesResult struct {
+	VIN             string `json:"VIN"`
+	Make            string `json:"Make"`
+	Model           string `json:"Model"`
+	ModelYear       string `json:"ModelYear"`
+	Trim            string `json:"Trim"`
+	Manufacturer    string `json:"Manufacturer"`
+	BodyClass       string `json:"BodyClass"`
+	VehicleType     string `json:"VehicleType"`
+	FuelTypePrimary string `json:"FuelTypePrimary"`
+	ErrorCode       string `json:"ErrorCode"`
+	ErrorText       string `json:"ErrorText"`
+}
+
+type decodeVinValuesResponse struct {
+	Results []DecodeVinValuesResult `json:"Results"`
+	Count   int                     `json:"Count"`
+}
+
+type vpic struct {
+	log        *zerolog.Logger
+	httpClient *http.Client
+	baseURL    string
+}
+
+// InitVPIC initializes the vPIC client on top of httpClient, which is nil
+// when the HTTP client is disabled.
+func InitVPIC(log *zerolog.Logger, opt *VPICOptions, httpClient *http.Client) VPIC {
+	return &vpic{
+		log:        log,
+		httpClient: httpClient,
+		baseURL:    strings.TrimSuffix(opt.BaseURL, "/"),
+	}
+}
+
+// DecodeVinValues calls DecodeVinValues for vin. A VIN vPIC cannot tell the
+// make of is reported as unprocessable; an unreachable API as unavailable.
+func (v *vpic) DecodeVinValues(ctx context.Context, vin string) (*DecodeVinValuesResult, error) {
+	if v.httpClient == nil || v.baseURL == "" {
+		return nil, appErr.NewWithCode(appErr.CodeHTTPServiceUnavailable, "vin_decoder_unavailable")
+	}
+
+	endpoint := v.baseURL + "/DecodeVinValues/" + url.PathEscape(vin) + "?format=json"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, appErr.Wrap(err, "create_vpic_request")
+	}
+
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		v.log.Warn().Err(err).Msg("failed to call vPIC DecodeVinValues")
+		return nil, appErr.WrapWithCode(err, appErr.CodeHTTPServiceUnavailable, "vin_decoder_unavailable")
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		v.log.Warn().Int("status", resp.StatusCode).Msg("vPIC DecodeVinValues returned an error status")
+		return nil, appErr.NewWithCode(appErr.CodeHTTPServiceUnavailable, "vin_decoder_unavailable")
+	}
+
+	var decoded decodeVinValuesResponse
+	if err := json.NewDecoder(resp.Body).Decode(&decoded); err != nil {
+		v.log.Warn().Err(err).Msg("failed to decode vPIC DecodeVinValues response")
+		return nil, appErr.WrapWithCode(err, appErr.CodeHTTPServiceUnavailable, "vin_decoder_unavailable")
+	}
+
+	if len(decoded.Results) == 0 || strings.TrimSpace(decoded.Results[0].Make) == "" {
+		return nil, appErr.NewWithCode(appErr.CodeHTTPUnprocessableEntity, "vin_not_decoded")
+	}
+
+	return &decoded.Results[0], nil
+}
@@ -1,6 +1,75 @@
 package dto
 
+import (
+	"sync"
+	"time"
+)
+
+// CacheStatus is reported to clients in the X-Cache header.
+type CacheStatus string
+
+const (
+	CacheHit    CacheStatus = "HIT"
+	CacheMiss   CacheStatus = "MISS"
+	CacheBypass CacheStatus = "BYPASS"
+)
+
+// CacheControl holds the request Cache-Control directives that apply to the
+// server-side cache.
 type CacheControl struct {
+	// MaxAge is the oldest cached copy the client accepts; nil accepts any.
+	MaxAge *time.Duration
+	// Result collects how the cache served the request. Nil for internal
+	// reads that do not report back.
+	Result *CacheResult
+	// MustRevalidate skips cached copies and refreshes them from the
+	// database (no-cache, max-age=0).
 	MustRevalidate bool
+	// MustDbValidate reads from the database without reading or writing the
+	// cache (no-store).
 	MustDbValidate bool
+	// OnlyIfCached serves cached copies only; a miss fails with 504.
+	OnlyIfCached bool
+}
+
+// CacheResult is the combined outcome of every cached read of a request: a
+// single miss makes the response a MISS and Age is that of the oldest copy.
+type CacheResult struct {
+	mu     sync.Mutex
+	status CacheStatus
+	age    time.Duration
+}
+
+func (r *CacheResult) Record(status CacheStatus, age time.Duration) {
+	if r == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.status == "" || cacheStatusRank[status] > cacheStatusRank[r.status] {
+		r.status = status
+	}
+
+	r.age = max(r.age, age)
+}
+
+// Outcome returns the combined status, empty when nothing was recorded, and
+// the age of the oldest cached copy served.
+func (r *CacheResult) Outcome() (CacheStatus, time.Duration) {
+	if r == nil {
+		return "", 0
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.status, r.age
+}
+
+var cacheStatusRank = map[CacheStatus]int{
+	CacheHit:    1,
+	CacheMiss:   2,
+	CacheBypass: 3,
 }
@@ -1,6 +1,8 @@
 package dto
 
 import (
+	"time"
+
 	"go-far/internal/model/entity"
 
 	"github.com/google/uuid"
@@ -34,6 +36,9 @@ type CreateUserRequest struct {
 }
 
 type UpdateUserRequest struct {
+	// Version, when set, is the version the change is based on. The update
+	// fails with 409 if the user changed since.
+	Version  *int64      `json:"version,omitempty" validate:"omitempty,min=1"`
 	IsActive *bool       `json:"is_active" validate:"omitempty,boolean"`
 	Name     string      `json:"name" validate:"omitempty,min=2,max=100"`
 	Email    string      `json:"email" validate:"omitempty,email"`
@@ -41,11 +46,37 @@ type UpdateUserRequest struct {
 	Age      int         `json:"age" validate:"omitempty,min=1,max=150"`
 }
 
+// PatchRequest is a PATCH body in one of the accepted patch formats.
+type PatchRequest struct {
+	// Version, when set, is a version checked through If-Match. The patch is
+	// applied as if to that version so a write landing since fails with 409.
+	Version   *int64
+	MediaType string
+	Document  []byte
+}
+
+// UserPatch is the patchable view of a user. A PATCH document is applied to
+// the user's current values in this shape and the result is validated as a
+// whole, so a null age clears it while required members must stay set.
+// Version is the version the patch is based on, as in UpdateUserRequest.
+type UserPatch struct {
+	IsActive *bool       `json:"is_active" validate:"required"`
+	Age      *int        `json:"age" validate:"omitempty,min=1,max=150"`
+	Name     string      `json:"name" validate:"required,min=2,max=100"`
+	Email    string      `json:"email" validate:"required,email"`
+	Role     entity.Role `json:"role" validate:"required,role_valid"`
+	Version  int64       `json:"version" validate:"min=1"`
+}
+
+// CreateCarRequest checks the license plate against the format of
+// PlateRegion, or the generic format when no region is given.
 type CreateCarRequest struct {
 	Brand        string `json:"brand" validate:"required,min=2,max=100"`
 	Model        string `json:"model" validate:"required,min=2,max=100"`
 	Color        string `json:"color" validate:"omitempty,max=50"`
-	LicensePlate string `json:"license_plate" validate:"required,min=3,max=20"`
+	LicensePlate string `json:"license_plate" validate:"required,max=20,plate=PlateRegion"`
+	PlateRegion  string `json:"plate_region,omitempty" validate:"omitempty,plate_region"`
+	VIN          string `json:"vin,omitempty" validate:"omitempty,vin"`
 	Year         int    `json:"year" validate:"required,gte=1900,lte=2100"`
 }
 
@@ -53,20 +84,68 @@ type BulkCreateCarsRequest struct {
 	Cars []CreateCarRequest `json:"cars" validate:"required,min=1,max=50,dive"`
 }
 
+// UpdateCarRequest leaves the license plate format to the service, which
+// checks it against the region of the request or else the car's own.
 type UpdateCarRequest struct {
-	IsAvailable  *bool  `json:"is_available" validate:"omitempty,boolean"`
+	// Version, when set, is the version the change is based on. The update
+	// fails with 409 if the car changed since.
+	Version      *int64 `json:"version,omitempty" validate:"omitempty,min=1"`
 	Brand        string `json:"brand" validate:"omitempty,min=2,max=100"`
 	Model        string `json:"model" validate:"omitempty,min=2,max=100"`
 	Color        string `json:"color" validate:"omitempty,max=50"`
-	LicensePlate string `json:"license_plate" validate:"omitempty,min=3,max=20"`
+	LicensePlate string `json:"license_plate" validate:"omitempty,max=20"`
+	PlateRegion  string `json:"plate_region" validate:"omitempty,plate_region"`
+	VIN          string `json:"vin" validate:"omitempty,vin"`
 	Year         int    `json:"year" validate:"omitempty,gte=1900,lte=2100"`
 }
 
+// CarPatch is the patchable view of a car, applied and validated like
+// UserPatch. A null color, plate_region or vin clears it.
+type CarPatch struct {
+	VIN          *string `json:"vin" validate:"omitempty,vin"`
+	Brand        string  `json:"brand" validate:"required,min=2,max=100"`
+	Model        string  `json:"model" validate:"required,min=2,max=100"`
+	Color        string  `json:"color" validate:"omitempty,max=50"`
+	LicensePlate string  `json:"license_plate" validate:"required,max=20,plate=PlateRegion"`
+	PlateRegion  string  `json:"plate_region" validate:"omitempty,plate_region"`
+	Year         int     `json:"year" validate:"required,gte=1900,lte=2100"`
+	Version      int64   `json:"version" validate:"min=1"`
+}
+
+// DecodeVINRequest asks vPIC what a VIN says about the car.
+type DecodeVINRequest struct {
+	VIN string `json:"vin" validate:"required,vin"`
+}
+
 type TransferCarRequest struct {
 	NewUserID uuid.UUID `json:"new_user_id" validate:"required,uuid"`
+	Reason    string    `json:"reason,omitempty" validate:"omitempty,max=500"`
+}
+
+// ShareCarRequest grants a user access to a car. The owner role is only
+// handed over through a transfer.
+type ShareCarRequest struct {
+	Role string `json:"role" validate:"required,oneof=co-owner driver viewer"`
+}
+
+// AttachmentUploadForm is the form part of an attachment upload; the file
+// itself is read from the "file" part.
+type AttachmentUploadForm struct {
+	Kind string `validate:"required,oneof=photo document"`
+}
+
+// ServiceRecordRequest logs, or replaces, an entry of a car's maintenance
+// log. Only the date part of ServiceDate is kept.
+type ServiceRecordRequest struct {
+	ServiceDate time.Time `json:"service_date" validate:"required"`
+	Notes       *string   `json:"notes,omitempty" validate:"omitempty,max=1000"`
+	ServiceType string    `json:"service_type" validate:"required,oneof=oil_change tire_rotation brakes battery inspection repair other"`
+	Cost        float64   `json:"cost" validate:"min=0,max=9999999999"`
+	Odometer    int       `json:"odometer" validate:"min=0"`
 }
 
-type BulkUpdateAvailabilityRequest struct {
-	CarIDs      []uuid.UUID `json:"car_ids" validate:"required,min=1"`
-	IsAvailable bool        `json:"is_available" validate:"required,boolean"`
+// CreateReservationRequest books a car for [StartsAt, EndsAt).
+type CreateReservationRequest struct {
+	StartsAt time.Time `json:"starts_at" validate:"required"`
+	EndsAt   time.Time `json:"ends_at" validate:"required,gtfield=StartsAt"`
 }
@@ -1,5 +1,7 @@
 package dto
 
+import "time"
+
 type UserFilter struct {
 	ID       string `form:"id"`
 	Name     string `form:"name"`
@@ -10,6 +12,11 @@ type UserFilter struct {
 	MaxAge   int    `form:"max_age"`
 	Page     int64  `form:"page" validate:"min=1"`
 	PageSize int64  `form:"page_size" validate:"min=1,max=100"`
+	// Columns narrows the select list to a ?fields= selection; empty
+	// selects every column.
+	Columns []string `form:"-"`
+	// IncludeDeleted lists soft-deleted users too. Admins only.
+	IncludeDeleted bool `form:"include_deleted"`
 }
 
 type UserFilterV2 struct {
@@ -24,6 +31,93 @@ type UserFilterV2 struct {
 	PageSize int64  `param:"-"`
 }
 
+type CarFilterV2 struct {
+	ID           string `param:"id" db:"id"`
+	Brand        string `param:"brand" db:"brand"`
+	Model        string `param:"model" db:"model"`
+	Color        string `param:"color" db:"color"`
+	LicensePlate string `param:"license_plate" db:"license_plate"`
+	VIN          string `param:"vin" db:"vin"`
+	SortBy       string `param:"-"`
+	SortDir      string `param:"-"`
+	MinYear      int    `param:"min_year__gte" db:"year"`
+	MaxYear      int    `param:"max_year__lte" db:"year"`
+	IsAvailable  *bool  `param:"is_available" db:"is_available"`
+	Page         int64  `param:"-"`
+	PageSize     int64  `param:"-"`
+}
+
+// FieldSelection carries the sparse fieldset and relation parameters shared by
+// read endpoints, e.g. ?fields=id,name&include=cars.
+type FieldSelection struct {
+	Fields  string `form:"fields"`
+	Include string `form:"include"`
+}
+
+// DeletedFilter lets admins read a soft-deleted record by ID, e.g.
+// ?include_deleted=true.
+type DeletedFilter struct {
+	IncludeDeleted bool `form:"include_deleted"`
+}
+
+// ReservationFilter lists the reservations of a car or a user. With From and
+// To set, only reservations overlapping [From, To) are returned.
+type ReservationFilter struct {
+	From             time.Time `form:"from" validate:"required_with=To"`
+	To               time.Time `form:"to" validate:"required_with=From,omitempty,gtfield=From"`
+	CarID            string    `form:"-"`
+	UserID           string    `form:"-"`
+	Page             int64     `form:"page"`
+	PageSize         int64     `form:"page_size"`
+	IncludeCancelled bool      `form:"include_cancelled"`
+}
+
+// AvailabilityFilter searches for cars free for the whole window [From, To).
+type AvailabilityFilter struct {
+	From     time.Time `form:"from" validate:"required"`
+	To       time.Time `form:"to" validate:"required,gtfield=From"`
+	Brand    string    `form:"brand" validate:"omitempty,max=100"`
+	Page     int64     `form:"page"`
+	PageSize int64     `form:"page_size"`
+}
+
+// ServiceRecordFilter lists the service log of a car, newest first. From and
+// To bound the service date, both inclusive.
+type ServiceRecordFilter struct {
+	From     time.Time `form:"from"`
+	To       time.Time `form:"to" validate:"omitempty,gtefield=From"`
+	Type     string    `form:"type" validate:"omitempty,oneof=oil_change tire_rotation brakes battery inspection repair other"`
+	CarID    string    `form:"-"`
+	Page     int64     `form:"page"`
+	PageSize int64     `form:"page_size"`
+}
+
+// StatsFilter narrows the admin statistics to records created between From
+// and To, both inclusive. Interval is the period of the trend series.
+type StatsFilter struct {
+	From     time.Time `form:"from"`
+	To       time.Time `form:"to" validate:"omitempty,gtefield=From"`
+	Interval string    `form:"interval" validate:"omitempty,oneof=day week month"`
+}
+
+// TransferRequestFilter lists the transfer requests sent or received by a
+// user, optionally narrowed to one status.
+type TransferRequestFilter struct {
+	Status     string `form:"status" validate:"omitempty,oneof=pending accepted rejected cancelled expired"`
+	FromUserID string `form:"-"`
+	ToUserID   string `form:"-"`
+	Page       int64  `form:"page"`
+	PageSize   int64  `form:"page_size"`
+}
+
+type SearchFilter struct {
+	Q        string `form:"q" validate:"required,min=2,max=100"`
+	UserID   string `form:"-"`
+	IsAdmin  bool   `form:"-"`
+	Page     int64  `form:"page"`
+	PageSize int64  `form:"page_size"`
+}
+
 func (f *UserFilter) NamePattern() string {
 	return "%" + f.Name + "%"
 }
@@ -39,3 +133,59 @@ func (f *UserFilter) Limit() int64 {
 func (f *UserFilter) Offset() int64 {
 	return (f.Page - 1) * f.PageSize
 }
+
+func (f *ReservationFilter) HasWindow() bool {
+	return !f.From.IsZero() && !f.To.IsZero()
+}
+
+func (f *ReservationFilter) Limit() int64 {
+	return f.PageSize
+}
+
+func (f *ReservationFilter) Offset() int64 {
+	return (f.Page - 1) * f.PageSize
+}
+
+func (f *ServiceRecordFilter) HasFrom() bool {
+	return !f.From.IsZero()
+}
+
+func (f *ServiceRecordFilter) HasTo() bool {
+	return !f.To.IsZero()
+}
+
+func (f *ServiceRecordFilter) Limit() int64 {
+	return f.PageSize
+}
+
+func (f *ServiceRecordFilter) Offset() int64 {
+	return (f.Page - 1) * f.PageSize
+}
+
+func (f *StatsFilter) HasFrom() bool {
+	return !f.From.IsZero()
+}
+
+func (f *StatsFilter) HasTo() bool {
+	return !f.To.IsZero()
+}
+
+func (f *TransferRequestFilter) Limit() int64 {
+	return f.PageSize
+}
+
+func (f *TransferRequestFilter) Offset() int64 {
+	return (f.Page - 1) * f.PageSize
+}
+
+func (f *AvailabilityFilter) BrandPattern() string {
+	return "%" + f.Brand + "%"
+}
+
+func (f *AvailabilityFilter) Limit() int64 {
+	return f.PageSize
+}
+
+func (f *AvailabilityFilter) Offset() int64 {
+	return (f.Page - 1) * f.PageSize
+}
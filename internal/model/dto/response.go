@@ -35,9 +35,28 @@ type HttpSuccessResp struct {
 }
 
 type HTTPErrorResp struct {
+	// Data is the current server state on a 409 version conflict.
+	Data any  `json:"data,omitempty"`
 	Meta Meta `json:"metadata"`
 }
 
+// CarImportResult summarises a CSV car import. Row numbers are CSV line
+// numbers, so the header is line 1 and the first car is line 2.
+type CarImportResult struct {
+	Errors    []CarImportRowError `json:"errors"`
+	TotalRows int                 `json:"total_rows"`
+	Valid     int                 `json:"valid"`
+	Imported  int                 `json:"imported"`
+	Failed    int                 `json:"failed"`
+	DryRun    bool                `json:"dry_run"`
+}
+
+type CarImportRowError struct {
+	LicensePlate string   `json:"license_plate,omitempty"`
+	Errors       []string `json:"errors"`
+	Row          int      `json:"row"`
+}
+
 // HealthStatus represents the health check response
 type HealthStatus struct {
 	Status    string `json:"status"`
@@ -6,16 +6,27 @@ import (
 	"github.com/google/uuid"
 )
 
+// Car is a car record. The service summary fields are only filled in when a
+// single car is read by ID; lists leave them out.
 type Car struct {
-	CreatedAt    time.Time `db:"created_at" json:"created_at"`
-	UpdatedAt    time.Time `db:"updated_at" json:"updated_at"`
-	ID           string    `db:"id" json:"id"`
-	Brand        string    `db:"brand" json:"brand"`
-	Model        string    `db:"model" json:"model"`
-	Color        string    `db:"color" json:"color"`
-	LicensePlate string    `db:"license_plate" json:"license_plate"`
-	Year         int       `db:"year" json:"year"`
-	IsAvailable  bool      `db:"is_available" json:"is_available"`
+	CreatedAt           time.Time  `db:"created_at" json:"created_at"`
+	UpdatedAt           time.Time  `db:"updated_at" json:"updated_at"`
+	DeletedAt           *time.Time `db:"deleted_at" json:"deleted_at,omitempty"`
+	VIN                 *string    `db:"vin" json:"vin,omitempty"`
+	PlateRegion         *string    `db:"plate_region" json:"plate_region,omitempty"`
+	ServiceOverdueAt    *time.Time `db:"service_overdue_at" json:"service_overdue_at,omitempty"`
+	LastServiceDate     *time.Time `db:"last_service_date" json:"last_service_date,omitempty"`
+	ServiceCount        *int64     `db:"service_count" json:"service_count,omitempty"`
+	TotalServiceCost    *float64   `db:"total_service_cost" json:"total_service_cost,omitempty"`
+	LastServiceOdometer *int       `db:"last_service_odometer" json:"last_service_odometer,omitempty"`
+	ID                  string     `db:"id" json:"id"`
+	Brand               string     `db:"brand" json:"brand"`
+	Model               string     `db:"model" json:"model"`
+	Color               string     `db:"color" json:"color"`
+	LicensePlate        string     `db:"license_plate" json:"license_plate"`
+	Version             int64      `db:"version" json:"version"`
+	Year                int        `db:"year" json:"year"`
+	IsAvailable         bool       `db:"is_available" json:"is_available"`
 }
 
 type CarWithOwner struct {
@@ -24,6 +35,13 @@ type CarWithOwner struct {
 	Car
 }
 
+// OwnedCar is a car tagged with the user it is assigned to, used when
+// batch loading the cars of several users at once.
+type OwnedCar struct {
+	UserID string `db:"user_id" json:"user_id"`
+	Car
+}
+
 type UserCar struct {
 	UserID uuid.UUID `json:"user_id"`
 	CarID  uuid.UUID `json:"car_id"`
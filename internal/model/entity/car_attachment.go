@@ -0,0 +1,28 @@
+package entity
+
+import "time"
+
+type AttachmentKind string
+
+const (
+	AttachmentPhoto    AttachmentKind = "photo"
+	AttachmentDocument AttachmentKind = "document"
+)
+
+// CarAttachment is a photo or document attached to a car. The file itself is
+// in the blob store under StorageKey and is downloaded through DownloadURL, a
+// signed link that stops working at DownloadURLExpiresAt.
+type CarAttachment struct {
+	CreatedAt            time.Time      `db:"created_at" json:"created_at"`
+	DownloadURLExpiresAt *time.Time     `db:"-" json:"download_url_expires_at,omitempty"`
+	UploadedBy           *string        `db:"uploaded_by" json:"uploaded_by,omitempty"`
+	ID                   string         `db:"id" json:"id"`
+	CarID                string         `db:"car_id" json:"car_id"`
+	Kind                 AttachmentKind `db:"kind" json:"kind"`
+	FileName             string         `db:"file_name" json:"file_name"`
+	ContentType          string         `db:"content_type" json:"content_type"`
+	Checksum             string         `db:"checksum" json:"checksum"`
+	StorageKey           string         `db:"storage_key" json:"-"`
+	DownloadURL          string         `db:"-" json:"download_url,omitempty"`
+	SizeBytes            int64          `db:"size_bytes" json:"size_bytes"`
+}
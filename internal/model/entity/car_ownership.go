@@ -0,0 +1,47 @@
+package entity
+
+import "time"
+
+type CarOwnershipEvent string
+
+const (
+	CarOwnershipCreated     CarOwnershipEvent = "created"
+	CarOwnershipTransferred CarOwnershipEvent = "transferred"
+	CarOwnershipDeleted     CarOwnershipEvent = "deleted"
+	CarOwnershipRestored    CarOwnershipEvent = "restored"
+)
+
+// CarOwnershipRecord is one entry of a car's append-only ownership history.
+// FromUserID is empty when the car was created and ToUserID when it was
+// deleted; ActorID is the user who made the change, if known.
+type CarOwnershipRecord struct {
+	CreatedAt  time.Time         `db:"created_at" json:"created_at"`
+	FromUserID *string           `db:"from_user_id" json:"from_user_id,omitempty"`
+	ToUserID   *string           `db:"to_user_id" json:"to_user_id,omitempty"`
+	ActorID    *string           `db:"actor_id" json:"actor_id,omitempty"`
+	Reason     *string           `db:"reason" json:"reason,omitempty"`
+	ID         string            `db:"id" json:"id"`
+	CarID      string            `db:"car_id" json:"car_id"`
+	Event      CarOwnershipEvent `db:"event" json:"event"`
+}
+
+// NewCarOwnershipRecord builds a history entry; empty IDs and reason are
+// stored as NULL.
+func NewCarOwnershipRecord(carID string, event CarOwnershipEvent, fromUserID, toUserID, actorID, reason string) CarOwnershipRecord {
+	return CarOwnershipRecord{
+		CarID:      carID,
+		Event:      event,
+		FromUserID: nullableString(fromUserID),
+		ToUserID:   nullableString(toUserID),
+		ActorID:    nullableString(actorID),
+		Reason:     nullableString(reason),
+	}
+}
+
+func nullableString(s string) *string {
+	if s == "" {
+		return nil
+	}
+
+	return &s
+}
@@ -0,0 +1,30 @@
+package entity
+
+import "time"
+
+type ServiceType string
+
+const (
+	ServiceOilChange    ServiceType = "oil_change"
+	ServiceTireRotation ServiceType = "tire_rotation"
+	ServiceBrakes       ServiceType = "brakes"
+	ServiceBattery      ServiceType = "battery"
+	ServiceInspection   ServiceType = "inspection"
+	ServiceRepair       ServiceType = "repair"
+	ServiceOther        ServiceType = "other"
+)
+
+// ServiceRecord is one entry of a car's maintenance log. ServiceDate is a
+// calendar date; its time of day is always midnight UTC.
+type ServiceRecord struct {
+	ServiceDate time.Time   `db:"service_date" json:"service_date"`
+	CreatedAt   time.Time   `db:"created_at" json:"created_at"`
+	UpdatedAt   time.Time   `db:"updated_at" json:"updated_at"`
+	Notes       *string     `db:"notes" json:"notes,omitempty"`
+	CreatedBy   *string     `db:"created_by" json:"created_by,omitempty"`
+	ID          string      `db:"id" json:"id"`
+	CarID       string      `db:"car_id" json:"car_id"`
+	ServiceType ServiceType `db:"service_type" json:"service_type"`
+	Cost        float64     `db:"cost" json:"cost"`
+	Odometer    int         `db:"odometer" json:"odometer"`
+}
@@ -0,0 +1,27 @@
+package entity
+
+import "time"
+
+// CarRole is a user's role on a car they are linked to.
+type CarRole string
+
+const (
+	CarRoleOwner   CarRole = "owner"
+	CarRoleCoOwner CarRole = "co-owner"
+	CarRoleDriver  CarRole = "driver"
+	CarRoleViewer  CarRole = "viewer"
+)
+
+// CanEdit reports whether the role may change the car's details. Deleting,
+// transferring and sharing the car are left to the owner.
+func (r CarRole) CanEdit() bool {
+	return r == CarRoleOwner || r == CarRoleCoOwner
+}
+
+// CarShare is one user's access to a car.
+type CarShare struct {
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+	UserID    string    `db:"user_id" json:"user_id"`
+	CarID     string    `db:"car_id" json:"car_id"`
+	Role      CarRole   `db:"role" json:"role"`
+}
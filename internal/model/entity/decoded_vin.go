@@ -0,0 +1,16 @@
+package entity
+
+// DecodedVIN is what the NHTSA vPIC API knows about a VIN, used to pre-fill
+// a new car. Note carries vPIC's warning when the VIN decoded only partly.
+type DecodedVIN struct {
+	VIN          string `json:"vin"`
+	Brand        string `json:"brand"`
+	Model        string `json:"model"`
+	Trim         string `json:"trim,omitempty"`
+	Manufacturer string `json:"manufacturer,omitempty"`
+	BodyClass    string `json:"body_class,omitempty"`
+	VehicleType  string `json:"vehicle_type,omitempty"`
+	FuelType     string `json:"fuel_type,omitempty"`
+	Note         string `json:"note,omitempty"`
+	Year         int    `json:"year,omitempty"`
+}
@@ -0,0 +1,24 @@
+package entity
+
+import "time"
+
+type ReservationStatus string
+
+const (
+	ReservationActive    ReservationStatus = "active"
+	ReservationCancelled ReservationStatus = "cancelled"
+)
+
+// Reservation books a car for the half-open period [StartsAt, EndsAt).
+// Active reservations of the same car never overlap.
+type Reservation struct {
+	StartsAt    time.Time         `db:"starts_at" json:"starts_at"`
+	EndsAt      time.Time         `db:"ends_at" json:"ends_at"`
+	CreatedAt   time.Time         `db:"created_at" json:"created_at"`
+	UpdatedAt   time.Time         `db:"updated_at" json:"updated_at"`
+	CancelledAt *time.Time        `db:"cancelled_at" json:"cancelled_at,omitempty"`
+	ID          string            `db:"id" json:"id"`
+	CarID       string            `db:"car_id" json:"car_id"`
+	UserID      string            `db:"user_id" json:"user_id"`
+	Status      ReservationStatus `db:"status" json:"status"`
+}
@@ -0,0 +1,17 @@
+package entity
+
+// SearchType identifies the kind of record a search result points to.
+type SearchType string
+
+type SearchResult struct {
+	Type    SearchType `db:"type" json:"type"`
+	ID      string     `db:"id" json:"id"`
+	Title   string     `db:"title" json:"title"`
+	Snippet string     `db:"snippet" json:"snippet"`
+	Rank    float32    `db:"rank" json:"rank"`
+}
+
+const (
+	SearchTypeUser SearchType = "user"
+	SearchTypeCar  SearchType = "car"
+)
@@ -0,0 +1,45 @@
+package entity
+
+import "time"
+
+// BrandCount is the number of cars of one brand.
+type BrandCount struct {
+	Brand string `db:"brand" json:"brand"`
+	Count int64  `db:"count" json:"count"`
+}
+
+// YearCount is one bar of the model year histogram.
+type YearCount struct {
+	Year  int   `db:"year" json:"year"`
+	Count int64 `db:"count" json:"count"`
+}
+
+// FleetAvailability splits the fleet by current availability. Ratio is the
+// share of available cars, 0 for an empty fleet.
+type FleetAvailability struct {
+	Total       int64   `db:"total" json:"total"`
+	Available   int64   `db:"available" json:"available"`
+	Unavailable int64   `db:"-" json:"unavailable"`
+	Ratio       float64 `db:"-" json:"ratio"`
+}
+
+// CarsPerUser is one bucket of the cars-per-user distribution: UserCount
+// users own exactly CarCount cars.
+type CarsPerUser struct {
+	CarCount  int64 `db:"car_count" json:"car_count"`
+	UserCount int64 `db:"user_count" json:"user_count"`
+}
+
+// TrendPoint counts the events of the period starting at PeriodStart (UTC).
+type TrendPoint struct {
+	PeriodStart time.Time `db:"period_start" json:"period_start"`
+	Count       int64     `db:"count" json:"count"`
+}
+
+// LoginTrendPoint counts the logins of the period starting at PeriodStart
+// (UTC) and the distinct users behind them.
+type LoginTrendPoint struct {
+	PeriodStart time.Time `db:"period_start" json:"period_start"`
+	Logins      int64     `db:"logins" json:"logins"`
+	ActiveUsers int64     `db:"active_users" json:"active_users"`
+}
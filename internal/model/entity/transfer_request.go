@@ -0,0 +1,34 @@
+package entity
+
+import "time"
+
+type TransferRequestStatus string
+
+const (
+	TransferRequestPending   TransferRequestStatus = "pending"
+	TransferRequestAccepted  TransferRequestStatus = "accepted"
+	TransferRequestRejected  TransferRequestStatus = "rejected"
+	TransferRequestCancelled TransferRequestStatus = "cancelled"
+	TransferRequestExpired   TransferRequestStatus = "expired"
+)
+
+// CarTransferRequest offers a car from FromUserID to ToUserID. It stays
+// pending until the recipient accepts or rejects it, the sender cancels it,
+// or ExpiresAt passes.
+type CarTransferRequest struct {
+	ExpiresAt   time.Time             `db:"expires_at" json:"expires_at"`
+	CreatedAt   time.Time             `db:"created_at" json:"created_at"`
+	UpdatedAt   time.Time             `db:"updated_at" json:"updated_at"`
+	RespondedAt *time.Time            `db:"responded_at" json:"responded_at,omitempty"`
+	Reason      *string               `db:"reason" json:"reason,omitempty"`
+	ID          string                `db:"id" json:"id"`
+	CarID       string                `db:"car_id" json:"car_id"`
+	FromUserID  string                `db:"from_user_id" json:"from_user_id"`
+	ToUserID    string                `db:"to_user_id" json:"to_user_id"`
+	Status      TransferRequestStatus `db:"status" json:"status"`
+}
+
+// IsExpired reports whether a pending request can no longer be answered.
+func (r *CarTransferRequest) IsExpired(now time.Time) bool {
+	return r.Status == TransferRequestPending && !now.Before(r.ExpiresAt)
+}
@@ -6,15 +6,17 @@ import "time"
 type Role string
 
 type User struct {
-	CreatedAt time.Time `db:"created_at" json:"created_at"`
-	UpdatedAt time.Time `db:"updated_at" json:"updated_at"`
-	ID        string    `db:"id" json:"id"`
-	Email     string    `db:"email" json:"email"`
-	Name      string    `db:"name" json:"name"`
-	Password  string    `db:"password" json:"-"`
-	Role      Role      `db:"role" json:"role"`
-	Age       int       `db:"age" json:"age"`
-	IsActive  bool      `db:"is_active" json:"is_active"`
+	CreatedAt time.Time  `db:"created_at" json:"created_at"`
+	UpdatedAt time.Time  `db:"updated_at" json:"updated_at"`
+	DeletedAt *time.Time `db:"deleted_at" json:"deleted_at,omitempty"`
+	ID        string     `db:"id" json:"id"`
+	Email     string     `db:"email" json:"email"`
+	Name      string     `db:"name" json:"name"`
+	Password  string     `db:"password" json:"-"`
+	Role      Role       `db:"role" json:"role"`
+	Age       *int       `db:"age" json:"age"`
+	Version   int64      `db:"version" json:"version"`
+	IsActive  bool       `db:"is_active" json:"is_active"`
 }
 
 type UserWithCars struct {
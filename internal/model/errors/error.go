@@ -15,6 +15,17 @@ type AppError struct {
 	Code       Code    `json:"code"`
 }
 
+// ConflictError is the root cause of a CodeHTTPConflict raised when a write
+// loses an optimistic-concurrency race. Current is the record as now stored,
+// so the client can merge its change and retry.
+type ConflictError struct {
+	Current any
+}
+
+func (e *ConflictError) Error() string {
+	return "version_conflict"
+}
+
 func init() {
 	svcError = map[ServiceType]ErrorMessage{
 		COMMON: ErrorMessages,
@@ -36,6 +36,11 @@ const (
 	CodeHTTPParamDecode
 	CodeHTTPErrorOnReadBody
 	CodeHTTPExternalAPI
+	CodeHTTPNotAcceptable
+	CodeHTTPGatewayTimeout
+	CodeHTTPPreconditionFailed
+	CodeHTTPUnsupportedMediaType
+	CodeHTTPGone
 )
 
 const (
@@ -99,6 +104,7 @@ const (
 	CodeTemplateExecute
 	CodeSQLQueryNotFound
 	CodeInvalidIdentifier
+	CodeQueryVerify
 )
 
 var (
@@ -3,20 +3,25 @@ package errors
 import "net/http"
 
 var ErrorMessages = ErrorMessage{
-	CodeHTTPBadRequest:          ErrMsgBadRequest,
-	CodeHTTPNotFound:            ErrMsgNotFound,
-	CodeHTTPUnauthorized:        ErrMsgUnauthorized,
-	CodeHTTPInternalServerError: ErrMsgISE,
-	CodeHTTPUnmarshal:           ErrMsgBadRequest,
-	CodeHTTPMarshal:             ErrMsgISE,
-	CodeHTTPConflict:            ErrMsgConflict,
-	CodeHTTPForbidden:           ErrMsgForbidden,
-	CodeHTTPUnprocessableEntity: ErrMsgUnprocessable,
-	CodeHTTPTooManyRequest:      ErrMsgTooManyRequest,
-	CodeHTTPValidatorError:      ErrMsgBadRequest,
-	CodeHTTPServiceUnavailable:  ErrMsgServiceUnavailable,
-	CodeHTTPParamDecode:         ErrMsgBadRequest,
-	CodeHTTPErrorOnReadBody:     ErrMsgISE,
+	CodeHTTPBadRequest:           ErrMsgBadRequest,
+	CodeHTTPNotFound:             ErrMsgNotFound,
+	CodeHTTPUnauthorized:         ErrMsgUnauthorized,
+	CodeHTTPInternalServerError:  ErrMsgISE,
+	CodeHTTPUnmarshal:            ErrMsgBadRequest,
+	CodeHTTPMarshal:              ErrMsgISE,
+	CodeHTTPConflict:             ErrMsgConflict,
+	CodeHTTPForbidden:            ErrMsgForbidden,
+	CodeHTTPUnprocessableEntity:  ErrMsgUnprocessable,
+	CodeHTTPTooManyRequest:       ErrMsgTooManyRequest,
+	CodeHTTPValidatorError:       ErrMsgBadRequest,
+	CodeHTTPServiceUnavailable:   ErrMsgServiceUnavailable,
+	CodeHTTPParamDecode:          ErrMsgBadRequest,
+	CodeHTTPErrorOnReadBody:      ErrMsgISE,
+	CodeHTTPNotAcceptable:        ErrMsgNotAcceptable,
+	CodeHTTPGatewayTimeout:       ErrMsgGatewayTimeout,
+	CodeHTTPPreconditionFailed:   ErrMsgPreconditionFailed,
+	CodeHTTPUnsupportedMediaType: ErrMsgUnsupportedMediaType,
+	CodeHTTPGone:                 ErrMsgGone,
 
 	CodeSQLBuilder:                    ErrMsgISE,
 	CodeSQLRead:                       ErrMsgISE,
@@ -68,6 +73,7 @@ var ErrorMessages = ErrorMessage{
 	CodeTemplateExecute:   ErrMsgISE,
 	CodeSQLQueryNotFound:  ErrMsgNotFound,
 	CodeInvalidIdentifier: ErrMsgBadRequest,
+	CodeQueryVerify:       ErrMsgISE,
 }
 
 var (
@@ -111,6 +117,31 @@ var (
 		EN:         `Too Many Request For This Entity. Please Wait And Try Again.`,
 		ID:         `Permintaan Terlalu Banyak Untuk Entitas Ini. Mohon Tunggu Dan Coba Kembali.`,
 	}
+	ErrMsgNotAcceptable = Message{
+		StatusCode: http.StatusNotAcceptable,
+		EN:         `Requested Response Format Is Not Supported.`,
+		ID:         `Format Respons Yang Diminta Tidak Didukung.`,
+	}
+	ErrMsgPreconditionFailed = Message{
+		StatusCode: http.StatusPreconditionFailed,
+		EN:         `Data Has Been Modified Since It Was Last Read.`,
+		ID:         `Data Telah Diubah Sejak Terakhir Dibaca.`,
+	}
+	ErrMsgUnsupportedMediaType = Message{
+		StatusCode: http.StatusUnsupportedMediaType,
+		EN:         `Request Body Format Is Not Supported.`,
+		ID:         `Format Isi Permintaan Tidak Didukung.`,
+	}
+	ErrMsgGone = Message{
+		StatusCode: http.StatusGone,
+		EN:         `This Endpoint Has Been Removed.`,
+		ID:         `Endpoint Ini Telah Dihapus.`,
+	}
+	ErrMsgGatewayTimeout = Message{
+		StatusCode: http.StatusGatewayTimeout,
+		EN:         `Requested Data Is Not Cached.`,
+		ID:         `Data Yang Diminta Tidak Tersedia Di Cache.`,
+	}
 	ErrMsgServiceUnavailable = Message{
 		StatusCode: http.StatusServiceUnavailable,
 		EN:         `Service is unavailable.`,
@@ -36,26 +36,68 @@ const (
 	APP_LANG string = `x-app-lang`
 
 	// Cache Control Header
-	CacheControl        string = `cache-control`
-	CacheMustRevalidate string = `must-revalidate`
+	CacheControl          string = `cache-control`
+	CacheMustRevalidate   string = `must-revalidate`
+	CacheMustDbRevalidate string = `must-db-revalidate`
+	CacheNoCache          string = `no-cache`
+	CacheNoStore          string = `no-store`
+	CacheMaxAge           string = `max-age`
+	CacheOnlyIfCached     string = `only-if-cached`
 
 	// API Routes
-	RouteAuthRegister     string = "/auth/register"
-	RouteAuthLogin        string = "/auth/login"
-	RouteAuthRefresh      string = "/auth/refresh"
-	RouteUsers            string = "/users"
-	RouteUsersV2          string = "/v2/users"
-	RouteUsersByID        string = "/users/{id}"
-	RouteHealth           string = "/health"
-	RouteReady            string = "/ready"
-	RouteCars             string = "/cars"
-	RouteCarsByID         string = "/cars/{id}"
-	RouteCarsBulk         string = "/cars/bulk"
-	RouteCarsOwner        string = "/cars/{id}/owner"
-	RouteCarsTransfer     string = "/cars/{id}/transfer"
-	RouteCarsAvailability string = "/cars/availability"
-	RouteCarsByUser       string = "/users/{user_id}/cars"
-	RouteCarsByUserCount  string = "/users/{user_id}/cars/count"
+	RouteAuthRegister           string = "/auth/register"
+	RouteAuthLogin              string = "/auth/login"
+	RouteAuthRefresh            string = "/auth/refresh"
+	RouteUsers                  string = "/users"
+	RouteUsersV2                string = "/v2/users"
+	RouteUsersByID              string = "/users/{id}"
+	RouteUsersExport            string = "/users/export"
+	RouteUsersRestore           string = "/users/{id}/restore"
+	RouteHealth                 string = "/health"
+	RouteReady                  string = "/ready"
+	RouteCars                   string = "/cars"
+	RouteCarsByID               string = "/cars/{id}"
+	RouteCarsBulk               string = "/cars/bulk"
+	RouteCarsExport             string = "/cars/export"
+	RouteCarsImport             string = "/cars/import"
+	RouteCarsDecodeVIN          string = "/cars/decode-vin"
+	RouteCarsByPlate            string = "/plates/{plate}"
+	RouteCarsOwner              string = "/cars/{id}/owner"
+	RouteCarsTransfer           string = "/cars/{id}/transfer"
+	RouteCarsRestore            string = "/cars/{id}/restore"
+	RouteCarsShares             string = "/cars/{id}/shares"
+	RouteCarsSharesByUser       string = "/cars/{id}/shares/{user_id}"
+	RouteCarsServices           string = "/cars/{id}/services"
+	RouteCarsServicesByID       string = "/cars/{id}/services/{service_id}"
+	RouteCarsAttachments        string = "/cars/{id}/attachments"
+	RouteCarsAttachmentByID     string = "/cars/{id}/attachments/{attachment_id}"
+	RouteAttachmentDownload     string = "/attachments/{id}/download"
+	RouteCarsHistory            string = "/cars/{id}/history"
+	RouteCarsAvailability       string = "/cars/availability"
+	RouteCarsAvailabilitySearch string = "/cars/availability"
+	RouteCarsByUser             string = "/users/{user_id}/cars"
+	RouteCarsByUserCount        string = "/users/{user_id}/cars/count"
+	RouteCarReservations        string = "/cars/{id}/reservations"
+	RouteReservations           string = "/reservations"
+	RouteReservationsByID       string = "/reservations/{id}"
+	RouteReservationsCancel     string = "/reservations/{id}/cancel"
+	RouteTransfersIncoming      string = "/transfer-requests/incoming"
+	RouteTransfersOutgoing      string = "/transfer-requests/outgoing"
+	RouteTransfersByID          string = "/transfer-requests/{id}"
+	RouteTransfersAccept        string = "/transfer-requests/{id}/accept"
+	RouteTransfersReject        string = "/transfer-requests/{id}/reject"
+	RouteTransfersCancel        string = "/transfer-requests/{id}/cancel"
+	RouteSearch                 string = "/search"
+	RouteStatsCarBrands         string = "/admin/stats/cars/brands"
+	RouteStatsCarYears          string = "/admin/stats/cars/years"
+	RouteStatsAvailability      string = "/admin/stats/cars/availability"
+	RouteStatsCarsPerUser       string = "/admin/stats/cars/per-user"
+	RouteStatsRegistrations     string = "/admin/stats/users/registrations"
+	RouteStatsLogins            string = "/admin/stats/users/logins"
+
+	// Embeddable Relations (?include=)
+	IncludeCars  string = "cars"
+	IncludeOwner string = "owner"
 
 	// Limiter Error Message
 	FormatError  string = "please check the format with your input"
@@ -65,30 +107,49 @@ const (
 	ContextKeyAuthUser contextKey = "auth_user"
 
 	// HTTP Headers
-	HeaderContentType               string = "Content-Type"
-	HeaderXRateLimitLimitGlobal     string = "X-RateLimit-Limit-global"
-	HeaderXRateLimitRemainingGlobal string = "X-RateLimit-Remaining-global"
-	HeaderXRateLimitResetGlobal     string = "X-RateLimit-Reset-global"
-	HeaderXRateLimitLimitRoute      string = "X-RateLimit-Limit-route"
-	HeaderXRateLimitRemainingRoute  string = "X-RateLimit-Remaining-route"
-	HeaderXRateLimitResetRoute      string = "X-RateLimit-Reset-route"
-	HeaderAuthorization             string = "Authorization"
-	HeaderXRequestID                string = "X-Request-ID"
-	HeaderXForwardedFor             string = "X-Forwarded-For"
-	HeaderXRealIP                   string = "X-Real-IP"
-	HeaderAccessControlAllowOrigin  string = "Access-Control-Allow-Origin"
-	HeaderAccessControlAllowHeaders string = "Access-Control-Allow-Headers"
-	HeaderAccessControlAllowMethods string = "Access-Control-Allow-Methods"
-	HeaderXFrameOptions             string = "X-Frame-Options"
-	HeaderContentSecurityPolicy     string = "Content-Security-Policy"
-	HeaderXXSSProtection            string = "X-XSS-Protection"
-	HeaderStrictTransportSecurity   string = "Strict-Transport-Security"
-	HeaderReferrerPolicy            string = "Referrer-Policy"
-	HeaderXContentTypeOptions       string = "X-Content-Type-Options"
-	HeaderPermissionsPolicy         string = "Permissions-Policy"
+	HeaderContentType                string = "Content-Type"
+	HeaderXRateLimitLimitGlobal      string = "X-RateLimit-Limit-global"
+	HeaderXRateLimitRemainingGlobal  string = "X-RateLimit-Remaining-global"
+	HeaderXRateLimitResetGlobal      string = "X-RateLimit-Reset-global"
+	HeaderXRateLimitLimitRoute       string = "X-RateLimit-Limit-route"
+	HeaderXRateLimitRemainingRoute   string = "X-RateLimit-Remaining-route"
+	HeaderXRateLimitResetRoute       string = "X-RateLimit-Reset-route"
+	HeaderXRateLimitLimitExport      string = "X-RateLimit-Limit-export"
+	HeaderXRateLimitRemainingExport  string = "X-RateLimit-Remaining-export"
+	HeaderXRateLimitResetExport      string = "X-RateLimit-Reset-export"
+	HeaderAuthorization              string = "Authorization"
+	HeaderAccept                     string = "Accept"
+	HeaderContentDisposition         string = "Content-Disposition"
+	HeaderXCache                     string = "X-Cache"
+	HeaderAge                        string = "Age"
+	HeaderETag                       string = "ETag"
+	HeaderLastModified               string = "Last-Modified"
+	HeaderIfMatch                    string = "If-Match"
+	HeaderIfNoneMatch                string = "If-None-Match"
+	HeaderIfModifiedSince            string = "If-Modified-Since"
+	HeaderIfUnmodifiedSince          string = "If-Unmodified-Since"
+	HeaderXRequestID                 string = "X-Request-ID"
+	HeaderXForwardedFor              string = "X-Forwarded-For"
+	HeaderXRealIP                    string = "X-Real-IP"
+	HeaderAccessControlAllowOrigin   string = "Access-Control-Allow-Origin"
+	HeaderAccessControlAllowHeaders  string = "Access-Control-Allow-Headers"
+	HeaderAccessControlAllowMethods  string = "Access-Control-Allow-Methods"
+	HeaderAccessControlExposeHeaders string = "Access-Control-Expose-Headers"
+	HeaderXFrameOptions              string = "X-Frame-Options"
+	HeaderContentSecurityPolicy      string = "Content-Security-Policy"
+	HeaderXXSSProtection             string = "X-XSS-Protection"
+	HeaderStrictTransportSecurity    string = "Strict-Transport-Security"
+	HeaderReferrerPolicy             string = "Referrer-Policy"
+	HeaderXContentTypeOptions        string = "X-Content-Type-Options"
+	HeaderPermissionsPolicy          string = "Permissions-Policy"
 
 	// Content Types
-	ContentTypeJSON string = "application/json"
+	ContentTypeJSON   string = "application/json"
+	ContentTypeCSV    string = "text/csv"
+	ContentTypeNDJSON string = "application/x-ndjson"
+	// Patch document formats accepted by PATCH endpoints
+	ContentTypeMergePatch string = "application/merge-patch+json"
+	ContentTypeJSONPatch  string = "application/json-patch+json"
 
 	// Auth Error Messages
 	ErrInvalidToken string = "Invalid token"
@@ -102,7 +163,10 @@ const (
 	StatusNotReady string = "not ready"
 
 	// Allowed HTTP Methods
-	AllowedMethods string = "GET, POST, PUT, DELETE"
+	AllowedMethods string = "GET, POST, PUT, PATCH, DELETE"
+
+	// Response headers readable by browser clients beyond the CORS safelist
+	ExposedHeaders string = "ETag, X-Cache, Age"
 
 	// CORS Security Values
 	CSPValue               string = "default-src 'self'; connect-src *; font-src *; script-src-elem * 'unsafe-inline'; img-src * data:; style-src * 'unsafe-inline';"
@@ -0,0 +1,33 @@
+package attachment
+
+import (
+	"context"
+
+	"go-far/internal/infra/query"
+	"go-far/internal/model/entity"
+	"go-far/internal/repository/queries"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type AttachmentRepositoryItf interface {
+	Create(ctx context.Context, attachment *entity.CarAttachment) error
+	FindByID(ctx context.Context, carID, id uuid.UUID) (*entity.CarAttachment, error)
+	FindForDownload(ctx context.Context, id uuid.UUID) (*entity.CarAttachment, error)
+	FindByCarID(ctx context.Context, carID uuid.UUID) ([]entity.CarAttachment, error)
+	CountByCarID(ctx context.Context, carID uuid.UUID) (int, error)
+	Delete(ctx context.Context, carID, id uuid.UUID) (string, error)
+}
+
+type attachmentRepository struct {
+	sql0    *pgxpool.Pool
+	queries *queries.Queries
+}
+
+func InitAttachmentRepository(sql0 *pgxpool.Pool, queryLoader *query.QueryLoader) AttachmentRepositoryItf {
+	return &attachmentRepository{
+		sql0:    sql0,
+		queries: queries.New(queryLoader),
+	}
+}
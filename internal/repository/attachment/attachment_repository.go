@@ -0,0 +1,36 @@
+package attachment
+
+import (
+	"context"
+
+	"go-far/internal/model/entity"
+
+	"github.com/google/uuid"
+)
+
+func (r *attachmentRepository) Create(ctx context.Context, attachment *entity.CarAttachment) error {
+	return r.createSQLAttachment(ctx, attachment)
+}
+
+func (r *attachmentRepository) FindByID(ctx context.Context, carID, id uuid.UUID) (*entity.CarAttachment, error) {
+	return r.findSQLAttachmentByID(ctx, carID, id)
+}
+
+// FindForDownload looks an attachment up by ID alone, for signed download
+// links, which do not name the car.
+func (r *attachmentRepository) FindForDownload(ctx context.Context, id uuid.UUID) (*entity.CarAttachment, error) {
+	return r.findSQLAttachmentForDownload(ctx, id)
+}
+
+func (r *attachmentRepository) FindByCarID(ctx context.Context, carID uuid.UUID) ([]entity.CarAttachment, error) {
+	return r.findSQLAttachmentsByCarID(ctx, carID)
+}
+
+func (r *attachmentRepository) CountByCarID(ctx context.Context, carID uuid.UUID) (int, error) {
+	return r.countSQLAttachmentsByCarID(ctx, carID)
+}
+
+// Delete removes the attachment's row and returns the storage key of its file.
+func (r *attachmentRepository) Delete(ctx context.Context, carID, id uuid.UUID) (string, error) {
+	return r.deleteSQLAttachment(ctx, carID, id)
+}
@@ -0,0 +1,120 @@
+package attachment
+
+import (
+	"context"
+	"errors"
+
+	"go-far/internal/infra/database"
+	"go-far/internal/model/entity"
+	appErr "go-far/internal/model/errors"
+	"go-far/internal/repository/queries"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/rs/zerolog"
+)
+
+func (r *attachmentRepository) createSQLAttachment(ctx context.Context, attachment *entity.CarAttachment) error {
+	carID, err := uuid.Parse(attachment.CarID)
+	if err != nil {
+		return appErr.WrapWithCode(err, appErr.CodeHTTPBadRequest, "invalid_car_id")
+	}
+
+	var uploadedBy string
+	if attachment.UploadedBy != nil {
+		uploadedBy = *attachment.UploadedBy
+	}
+
+	created, err := r.queries.CreateAttachment(ctx, database.Conn(ctx, r.sql0), queries.CreateAttachmentParams{
+		CarID:       carID,
+		UploadedBy:  uploadedBy,
+		Kind:        attachment.Kind,
+		FileName:    attachment.FileName,
+		ContentType: attachment.ContentType,
+		SizeBytes:   attachment.SizeBytes,
+		Checksum:    attachment.Checksum,
+		StorageKey:  attachment.StorageKey,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			zerolog.Ctx(ctx).Debug().Str("car_id", attachment.CarID).Msg("car_not_found_for_attachment")
+			return appErr.WrapWithCode(err, appErr.CodeSQLEmptyRow, "car_not_found_for_attachment")
+		}
+
+		zerolog.Ctx(ctx).Error().Err(err).Str("car_id", attachment.CarID).Msg("create_attachment_err")
+		return appErr.WrapWithCode(err, appErr.CodeSQLCreate, "create_attachment_err")
+	}
+
+	*attachment = created
+
+	return nil
+}
+
+func (r *attachmentRepository) findSQLAttachmentByID(ctx context.Context, carID, id uuid.UUID) (*entity.CarAttachment, error) {
+	attachment, err := r.queries.FindAttachmentByID(ctx, database.Conn(ctx, r.sql0), queries.FindAttachmentByIDParams{CarID: carID, ID: id})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			zerolog.Ctx(ctx).Debug().Str("id", id.String()).Msg("attachment_not_found")
+			return nil, appErr.WrapWithCode(err, appErr.CodeSQLEmptyRow, "attachment_not_found")
+		}
+
+		zerolog.Ctx(ctx).Error().Err(err).Str("id", id.String()).Msg("find_attachment_err")
+		return nil, appErr.WrapWithCode(err, appErr.CodeSQLRowScan, "find_attachment_err")
+	}
+
+	return &attachment, nil
+}
+
+func (r *attachmentRepository) findSQLAttachmentForDownload(ctx context.Context, id uuid.UUID) (*entity.CarAttachment, error) {
+	attachment, err := r.queries.FindAttachmentForDownload(ctx, database.Conn(ctx, r.sql0), queries.FindAttachmentForDownloadParams{ID: id})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			zerolog.Ctx(ctx).Debug().Str("id", id.String()).Msg("attachment_not_found")
+			return nil, appErr.WrapWithCode(err, appErr.CodeSQLEmptyRow, "attachment_not_found")
+		}
+
+		zerolog.Ctx(ctx).Error().Err(err).Str("id", id.String()).Msg("find_attachment_err")
+		return nil, appErr.WrapWithCode(err, appErr.CodeSQLRowScan, "find_attachment_err")
+	}
+
+	return &attachment, nil
+}
+
+func (r *attachmentRepository) findSQLAttachmentsByCarID(ctx context.Context, carID uuid.UUID) ([]entity.CarAttachment, error) {
+	attachments, err := r.queries.FindAttachmentsByCarID(ctx, database.Conn(ctx, r.sql0), queries.FindAttachmentsByCarIDParams{CarID: carID})
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Str("car_id", carID.String()).Msg("find_attachments_err")
+		return nil, appErr.WrapWithCode(err, appErr.CodeSQLRowScan, "find_attachments_err")
+	}
+
+	if attachments == nil {
+		attachments = []entity.CarAttachment{}
+	}
+
+	return attachments, nil
+}
+
+func (r *attachmentRepository) countSQLAttachmentsByCarID(ctx context.Context, carID uuid.UUID) (int, error) {
+	count, err := r.queries.CountAttachmentsByCarID(ctx, database.Conn(ctx, r.sql0), queries.CountAttachmentsByCarIDParams{CarID: carID})
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Str("car_id", carID.String()).Msg("count_attachments_err")
+		return 0, appErr.WrapWithCode(err, appErr.CodeSQLRowScan, "count_attachments_err")
+	}
+
+	return count, nil
+}
+
+func (r *attachmentRepository) deleteSQLAttachment(ctx context.Context, carID, id uuid.UUID) (string, error) {
+	storageKey, err := r.queries.DeleteAttachment(ctx, database.Conn(ctx, r.sql0), queries.DeleteAttachmentParams{CarID: carID, ID: id})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			zerolog.Ctx(ctx).Debug().Str("id", id.String()).Msg("attachment_not_found_for_deletion")
+			return "", appErr.WrapWithCode(err, appErr.CodeSQLEmptyRow, "attachment_not_found_for_deletion")
+		}
+
+		zerolog.Ctx(ctx).Error().Err(err).Str("id", id.String()).Msg("delete_attachment_err")
+		return "", appErr.WrapWithCode(err, appErr.CodeSQLDelete, "delete_attachment_err")
+	}
+
+	return storageKey, nil
+}
@@ -4,43 +4,86 @@ import (
 	"context"
 	"time"
 
+	"go-far/internal/infra/cache"
 	"go-far/internal/infra/query"
+	"go-far/internal/model/dto"
 	"go-far/internal/model/entity"
+	appErr "go-far/internal/model/errors"
+	"go-far/internal/repository/queries"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgxpool"
-	"github.com/redis/go-redis/v9"
 )
 
 type CarRepositoryItf interface {
 	Create(ctx context.Context, car *entity.Car) error
 	CreateBulk(ctx context.Context, cars []*entity.Car) error
+	ImportBulk(ctx context.Context, ownerID uuid.UUID, cars []*entity.Car) ([]*entity.Car, error)
+	FindExistingLicensePlates(ctx context.Context, plates []string) (map[string]struct{}, error)
+	FindExistingVINs(ctx context.Context, vins []string) (map[string]struct{}, error)
+	FindIDByLicensePlate(ctx context.Context, plate string) (uuid.UUID, error)
 	AssignCarToUser(ctx context.Context, userID uuid.UUID, carID uuid.UUID) error
 	AssignCarsToUserBulk(ctx context.Context, userID uuid.UUID, carIDs []uuid.UUID) error
-	FindByID(ctx context.Context, id uuid.UUID) (*entity.Car, error)
-	FindByIDWithOwner(ctx context.Context, id uuid.UUID) (*entity.CarWithOwner, error)
-	FindByUserID(ctx context.Context, userID uuid.UUID) ([]*entity.Car, error)
+	FindByID(ctx context.Context, cacheControl dto.CacheControl, id uuid.UUID) (*entity.Car, error)
+	FindByIDColumns(ctx context.Context, id uuid.UUID, columns []string) (*entity.Car, error)
+	FindByIDWithDeleted(ctx context.Context, id uuid.UUID) (*entity.Car, error)
+	FindByIDWithOwner(ctx context.Context, cacheControl dto.CacheControl, id uuid.UUID) (*entity.CarWithOwner, error)
+	FindByIDWithOwnerColumns(ctx context.Context, id uuid.UUID, columns []string) (*entity.CarWithOwner, error)
+	FindByUserID(ctx context.Context, cacheControl dto.CacheControl, userID uuid.UUID) ([]*entity.Car, error)
+	FindByUserIDColumns(ctx context.Context, userID uuid.UUID, columns []string) ([]*entity.Car, error)
+	FindByUserIDs(ctx context.Context, userIDs []uuid.UUID) (map[string][]entity.Car, error)
 	CountByUserID(ctx context.Context, userID uuid.UUID) (int, error)
 	Update(ctx context.Context, id uuid.UUID, car *entity.Car) error
 	Delete(ctx context.Context, id uuid.UUID) error
+	Restore(ctx context.Context, id uuid.UUID) (*entity.Car, error)
+	Purge(ctx context.Context, deletedBefore time.Time, limit int) (int64, []string, error)
 	TransferOwnership(ctx context.Context, carID, newUserID uuid.UUID) error
-	BulkUpdateAvailability(ctx context.Context, carIDs []uuid.UUID, isAvailable bool) error
+	FindOwnerForUpdate(ctx context.Context, carID uuid.UUID) (uuid.UUID, error)
+	AppendOwnershipHistory(ctx context.Context, records []entity.CarOwnershipRecord) error
+	FindOwnershipHistory(ctx context.Context, carID uuid.UUID) ([]entity.CarOwnershipRecord, error)
 	IsCarOwnedByUser(ctx context.Context, carID uuid.UUID, userID string) (bool, error)
+	FindCarRole(ctx context.Context, carID uuid.UUID, userID string) (entity.CarRole, error)
+	FindShares(ctx context.Context, carID uuid.UUID) ([]entity.CarShare, error)
+	Share(ctx context.Context, carID, userID uuid.UUID, role entity.CarRole) (*entity.CarShare, error)
+	Unshare(ctx context.Context, carID, userID uuid.UUID) error
 	AreCarsOwnedByUser(ctx context.Context, carIDs []uuid.UUID, userID string) (map[uuid.UUID]bool, error)
+	ExportV2(ctx context.Context, filter *dto.CarFilterV2, fn func(*entity.Car) error) error
 }
 
 type carRepository struct {
 	sql0        *pgxpool.Pool
-	redis0      *redis.Client
-	queryLoader *query.QueryLoader
-	cacheTTL    time.Duration
+	cacheStore  *cache.Store
+	carLoader   *cache.Loader[*entity.Car]
+	ownerLoader *cache.Loader[*entity.CarWithOwner]
+	listLoader  *cache.Loader[[]*entity.Car]
+	queries     *queries.Queries
 }
 
-func InitCarRepository(sql0 *pgxpool.Pool, redis0 *redis.Client, queryLoader *query.QueryLoader, cacheTTL time.Duration) CarRepositoryItf {
+func InitCarRepository(sql0 *pgxpool.Pool, cacheStore *cache.Store, queryLoader *query.QueryLoader, cacheTTL time.Duration) CarRepositoryItf {
+	cacheTTL = min(cacheTTL, maxCarViewTTL)
+
 	return &carRepository{
-		sql0:        sql0,
-		redis0:      redis0,
-		queryLoader: queryLoader,
-		cacheTTL:    cacheTTL,
+		sql0:       sql0,
+		cacheStore: cacheStore,
+		carLoader: cache.NewLoader[*entity.Car](cacheStore, cache.LoaderOptions{
+			TTL:          cacheTTL,
+			Jitter:       cache.DefaultJitter,
+			NegativeTTL:  durationCarNotFound,
+			NotFoundCode: appErr.CodeSQLEmptyRow,
+			Codec:        cache.MsgpackCodec,
+		}),
+		ownerLoader: cache.NewLoader[*entity.CarWithOwner](cacheStore, cache.LoaderOptions{
+			TTL:          cacheTTL,
+			Jitter:       cache.DefaultJitter,
+			NegativeTTL:  durationCarNotFound,
+			NotFoundCode: appErr.CodeSQLEmptyRow,
+			Codec:        cache.MsgpackCodec,
+		}),
+		listLoader: cache.NewLoader[[]*entity.Car](cacheStore, cache.LoaderOptions{
+			TTL:    cacheTTL,
+			Jitter: cache.DefaultJitter,
+			Codec:  cache.SnappyCodec(cache.MsgpackCodec),
+		}),
+		queries: queries.New(queryLoader),
 	}
 }
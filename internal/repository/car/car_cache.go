@@ -0,0 +1,56 @@
+package car
+
+import (
+	"context"
+	"time"
+
+	"go-far/internal/infra/database"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+// durationCarNotFound is how long a lookup of a missing car is cached.
+const durationCarNotFound = 30 * time.Second
+
+// maxCarViewTTL caps how long car views stay cached. Their is_available is
+// derived from the clock as well as from reservations: reservation writes
+// invalidate it, but a booked period starting or ending does not, so a
+// cached car may report the old value for up to this long.
+const maxCarViewTTL = 30 * time.Second
+
+const (
+	cacheKeyCarOwner = "car:owner:"
+	cacheKeyUserCars = "car:user:"
+
+	// CacheTagCars covers every cached car list. Lists are not tagged per
+	// owner because deletes and transfers do not know the previous owner.
+	// Repositories whose writes change what a car view shows bump it too.
+	CacheTagCars = "cars"
+	// cacheTagUsers is the user repository's list tag. Cars embedding their
+	// owner carry it so user updates reach them.
+	cacheTagUsers = "users"
+)
+
+// CacheTagCar covers the cached lookups of the car with the given ID.
+func CacheTagCar(id string) string {
+	return "car:" + id
+}
+
+// invalidateCars bumps the car list tag and the tag of every car touched by a
+// successful write, once the surrounding transaction (if any) commits. A
+// failure is only logged: the write is committed, and stale entries still
+// expire on their own TTL.
+func (r *carRepository) invalidateCars(ctx context.Context, ids ...uuid.UUID) {
+	tags := make([]string, 0, len(ids)+1)
+	tags = append(tags, CacheTagCars)
+	for _, id := range ids {
+		tags = append(tags, CacheTagCar(id.String()))
+	}
+
+	database.AfterCommit(ctx, func() {
+		if err := r.cacheStore.Invalidate(ctx, tags...); err != nil {
+			zerolog.Ctx(ctx).Warn().Err(err).Strs("tags", tags).Msg("invalidate_car_cache")
+		}
+	})
+}
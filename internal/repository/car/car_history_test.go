@@ -0,0 +1,82 @@
+package car
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"go-far/internal/infra/query"
+	"go-far/internal/model/entity"
+	"go-far/internal/repository/queries"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rs/zerolog"
+)
+
+// TestOwnershipHistoryIsAppendOnly checks the forbid_car_ownership_history_change
+// trigger against a migrated database: a recorded entry can be read back but
+// not updated or deleted. It runs inside a transaction that is rolled back,
+// and is skipped unless TEST_DATABASE_URL is set.
+func TestOwnershipHistoryIsAppendOnly(t *testing.T) {
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+
+	ctx := context.Background()
+
+	pool, err := pgxpool.New(ctx, dsn)
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	defer pool.Close()
+
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		t.Fatalf("begin: %v", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	log := zerolog.Nop()
+	q := queries.New(query.InitQueryLoader(&log, &query.QueriesOptions{Path: "../../../configs/queries"}))
+
+	carID := uuid.New()
+	owner := uuid.NewString()
+	record := entity.CarOwnershipRecord{CarID: carID.String(), Event: entity.CarOwnershipCreated, ToUserID: &owner}
+
+	if err := q.AppendCarOwnershipHistory(ctx, tx, []entity.CarOwnershipRecord{record}); err != nil {
+		t.Fatalf("append: %v", err)
+	}
+
+	history, err := q.FindCarOwnershipHistory(ctx, tx, queries.FindCarOwnershipHistoryParams{CarID: carID})
+	if err != nil || len(history) != 1 {
+		t.Fatalf("history = %v, %v, want the appended entry", history, err)
+	}
+
+	statements := map[string]string{
+		"update": `UPDATE car_ownership_history SET reason = 'rewritten' WHERE id = $1`,
+		"delete": `DELETE FROM car_ownership_history WHERE id = $1`,
+	}
+
+	for name, sql := range statements {
+		t.Run(name, func(t *testing.T) {
+			savepoint, err := tx.Begin(ctx)
+			if err != nil {
+				t.Fatalf("savepoint: %v", err)
+			}
+			defer func() { _ = savepoint.Rollback(ctx) }()
+
+			_, err = savepoint.Exec(ctx, sql, history[0].ID)
+			if err == nil || !strings.Contains(err.Error(), "append-only") {
+				t.Errorf("%s err = %v, want the append-only trigger to reject it", name, err)
+			}
+		})
+	}
+
+	after, err := q.FindCarOwnershipHistory(ctx, tx, queries.FindCarOwnershipHistoryParams{CarID: carID})
+	if err != nil || len(after) != 1 || after[0].Reason != nil {
+		t.Errorf("history after rejected changes = %v, %v, want it unchanged", after, err)
+	}
+}
@@ -2,9 +2,10 @@ package car
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
+	"time"
 
+	"go-far/internal/infra/database"
+	"go-far/internal/model/dto"
 	"go-far/internal/model/entity"
 	appErr "go-far/internal/model/errors"
 
@@ -12,10 +13,8 @@ import (
 	"github.com/rs/zerolog"
 )
 
-const cacheKeyCar = "car:%s"
-
 func (r *carRepository) Create(ctx context.Context, car *entity.Car) error {
-	tx, err := r.sql0.Begin(ctx)
+	tx, err := database.Begin(ctx, r.sql0)
 	if err != nil {
 		zerolog.Ctx(ctx).Error().Err(err).Msg("tx_create_car")
 		return appErr.Wrap(err, "tx_create_car")
@@ -39,11 +38,13 @@ func (r *carRepository) Create(ctx context.Context, car *entity.Car) error {
 		return appErr.Wrap(err, "commit_create_car")
 	}
 
+	r.invalidateCars(ctx)
+
 	return nil
 }
 
 func (r *carRepository) CreateBulk(ctx context.Context, cars []*entity.Car) error {
-	tx, err := r.sql0.Begin(ctx)
+	tx, err := database.Begin(ctx, r.sql0)
 	if err != nil {
 		zerolog.Ctx(ctx).Error().Err(err).Msg("tx_create_bulk_cars")
 		return appErr.Wrap(err, "tx_create_bulk_cars")
@@ -67,46 +68,141 @@ func (r *carRepository) CreateBulk(ctx context.Context, cars []*entity.Car) erro
 		return appErr.Wrap(err, "commit_create_bulk_cars")
 	}
 
+	r.invalidateCars(ctx)
+
 	return nil
 }
 
-func (r *carRepository) AssignCarToUser(ctx context.Context, userID, carID uuid.UUID) error {
-	return r.assignCarToUserSQL(ctx, userID, carID)
+// ImportBulk inserts cars and assigns them to ownerID in one transaction.
+// Cars whose license plate already exists are skipped rather than failing the
+// batch; only the inserted cars are returned.
+func (r *carRepository) ImportBulk(ctx context.Context, ownerID uuid.UUID, cars []*entity.Car) ([]*entity.Car, error) {
+	tx, err := database.Begin(ctx, r.sql0)
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("tx_import_bulk_cars")
+		return nil, appErr.Wrap(err, "tx_import_bulk_cars")
+	}
+
+	defer func() {
+		if err != nil {
+			if rollbackErr := tx.Rollback(ctx); rollbackErr != nil {
+				zerolog.Ctx(ctx).Error().Err(rollbackErr).Msg("rollback_import_bulk_cars")
+			}
+		}
+	}()
+
+	inserted, err := r.importBulkSQLCars(ctx, tx, cars)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(inserted) > 0 {
+		userCars := make([]entity.UserCar, 0, len(inserted))
+		for _, car := range inserted {
+			carID, parseErr := uuid.Parse(car.ID)
+			if parseErr != nil {
+				err = parseErr
+				return nil, appErr.Wrap(err, "parse_imported_car_id")
+			}
+			userCars = append(userCars, entity.UserCar{UserID: ownerID, CarID: carID})
+		}
+
+		if err = r.queries.AssignCarToUserBulk(ctx, tx, userCars); err != nil {
+			zerolog.Ctx(ctx).Error().Err(err).Msg("assign_imported_cars_err")
+			return nil, appErr.Wrap(err, "assign_imported_cars_err")
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("commit_import_bulk_cars")
+		return nil, appErr.Wrap(err, "commit_import_bulk_cars")
+	}
+
+	r.invalidateCars(ctx)
+
+	return inserted, nil
 }
 
-func (r *carRepository) AssignCarsToUserBulk(ctx context.Context, userID uuid.UUID, carIDs []uuid.UUID) error {
-	return r.assignCarsToUserBulkSQL(ctx, userID, carIDs)
+// FindExistingLicensePlates returns which of the normalized plates are taken.
+func (r *carRepository) FindExistingLicensePlates(ctx context.Context, plates []string) (map[string]struct{}, error) {
+	return r.findExistingLicensePlatesSQL(ctx, plates)
 }
 
-func (r *carRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.Car, error) {
-	cacheKey := fmt.Sprintf(cacheKeyCar, id.String())
+func (r *carRepository) FindExistingVINs(ctx context.Context, vins []string) (map[string]struct{}, error) {
+	return r.findExistingVINsSQL(ctx, vins)
+}
 
-	cached, err := r.redis0.Get(ctx, cacheKey).Result()
-	if err == nil {
-		var car entity.Car
-		if unmarshallErr := json.Unmarshal([]byte(cached), &car); unmarshallErr == nil {
-			zerolog.Ctx(ctx).Debug().Str("id", id.String()).Msg("car_found_in_cache")
-			return &car, nil
-		}
+// FindIDByLicensePlate finds the live car holding a normalized plate. It is
+// not cached: the plate of a car can change, and the car itself is read through
+// FindByID.
+func (r *carRepository) FindIDByLicensePlate(ctx context.Context, plate string) (uuid.UUID, error) {
+	return r.findCarIDByLicensePlateSQL(ctx, plate)
+}
+
+func (r *carRepository) AssignCarToUser(ctx context.Context, userID, carID uuid.UUID) error {
+	if err := r.assignCarToUserSQL(ctx, userID, carID); err != nil {
+		return err
 	}
 
-	car, err := r.findCarSQLByID(ctx, id)
-	if err != nil {
-		return nil, err
+	r.invalidateCars(ctx, carID)
+
+	return nil
+}
+
+func (r *carRepository) AssignCarsToUserBulk(ctx context.Context, userID uuid.UUID, carIDs []uuid.UUID) error {
+	if err := r.assignCarsToUserBulkSQL(ctx, userID, carIDs); err != nil {
+		return err
 	}
 
-	data, _ := json.Marshal(car)
-	r.redis0.Set(ctx, cacheKey, data, r.cacheTTL)
+	r.invalidateCars(ctx, carIDs...)
 
-	return car, nil
+	return nil
+}
+
+func (r *carRepository) FindByID(ctx context.Context, cacheControl dto.CacheControl, id uuid.UUID) (*entity.Car, error) {
+	tag := CacheTagCar(id.String())
+
+	return r.carLoader.Get(ctx, cacheControl, tag, []string{tag}, func(ctx context.Context) (*entity.Car, error) {
+		return r.findCarSQLByID(ctx, id)
+	})
+}
+
+// FindByIDWithDeleted also finds soft-deleted cars. It is not cached, as
+// only admins read deleted records.
+func (r *carRepository) FindByIDWithDeleted(ctx context.Context, id uuid.UUID) (*entity.Car, error) {
+	return r.findCarSQLByIDWithDeleted(ctx, id)
+}
+
+// FindByIDColumns reads only the given columns of a car. It bypasses the
+// cache, which holds full rows.
+func (r *carRepository) FindByIDColumns(ctx context.Context, id uuid.UUID, columns []string) (*entity.Car, error) {
+	return r.findCarColumnsSQLByID(ctx, id, columns)
+}
+
+func (r *carRepository) FindByIDWithOwner(ctx context.Context, cacheControl dto.CacheControl, id uuid.UUID) (*entity.CarWithOwner, error) {
+	tags := []string{CacheTagCar(id.String()), cacheTagUsers}
+
+	return r.ownerLoader.Get(ctx, cacheControl, cacheKeyCarOwner+id.String(), tags, func(ctx context.Context) (*entity.CarWithOwner, error) {
+		return r.findCarByIDWithOwnerSQL(ctx, id)
+	})
+}
+
+func (r *carRepository) FindByIDWithOwnerColumns(ctx context.Context, id uuid.UUID, columns []string) (*entity.CarWithOwner, error) {
+	return r.findCarColumnsByIDWithOwnerSQL(ctx, id, columns)
+}
+
+func (r *carRepository) FindByUserID(ctx context.Context, cacheControl dto.CacheControl, userID uuid.UUID) ([]*entity.Car, error) {
+	return r.listLoader.Get(ctx, cacheControl, cacheKeyUserCars+userID.String(), []string{CacheTagCars}, func(ctx context.Context) ([]*entity.Car, error) {
+		return r.findCarByUserIDSQL(ctx, userID)
+	})
 }
 
-func (r *carRepository) FindByIDWithOwner(ctx context.Context, id uuid.UUID) (*entity.CarWithOwner, error) {
-	return r.findCarByIDWithOwnerSQL(ctx, id)
+func (r *carRepository) FindByUserIDColumns(ctx context.Context, userID uuid.UUID, columns []string) ([]*entity.Car, error) {
+	return r.findCarColumnsByUserIDSQL(ctx, userID, columns)
 }
 
-func (r *carRepository) FindByUserID(ctx context.Context, userID uuid.UUID) ([]*entity.Car, error) {
-	return r.findCarByUserIDSQL(ctx, userID)
+func (r *carRepository) FindByUserIDs(ctx context.Context, userIDs []uuid.UUID) (map[string][]entity.Car, error) {
+	return r.findCarsByUserIDsSQL(ctx, userIDs)
 }
 
 func (r *carRepository) CountByUserID(ctx context.Context, userID uuid.UUID) (int, error) {
@@ -119,8 +215,7 @@ func (r *carRepository) Update(ctx context.Context, id uuid.UUID, car *entity.Ca
 		return appErr.Wrap(err, "update_sql_car")
 	}
 
-	cacheKey := fmt.Sprintf(cacheKeyCar, id.String())
-	r.redis0.Del(ctx, cacheKey)
+	r.invalidateCars(ctx, id)
 
 	return nil
 }
@@ -131,42 +226,143 @@ func (r *carRepository) Delete(ctx context.Context, id uuid.UUID) error {
 		return err
 	}
 
-	cacheKey := fmt.Sprintf(cacheKeyCar, id.String())
-	r.redis0.Del(ctx, cacheKey)
+	r.invalidateCars(ctx, id)
 
 	return nil
 }
 
-func (r *carRepository) TransferOwnership(ctx context.Context, carID, newUserID uuid.UUID) error {
-	err := r.transferOwnershipSQL(ctx, carID, newUserID)
+func (r *carRepository) Restore(ctx context.Context, id uuid.UUID) (*entity.Car, error) {
+	car, err := r.restoreSQLCar(ctx, id)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	cacheKey := fmt.Sprintf(cacheKeyCar, carID.String())
-	r.redis0.Del(ctx, cacheKey)
+	r.invalidateCars(ctx, id)
 
-	return nil
+	return car, nil
 }
 
-func (r *carRepository) BulkUpdateAvailability(ctx context.Context, carIDs []uuid.UUID, isAvailable bool) error {
-	err := r.bulkUpdateAvailabilitySQL(ctx, carIDs, isAvailable)
+// Purge hard-deletes up to limit cars soft-deleted before deletedBefore and
+// returns how many were removed. Their users_cars links and attachment rows
+// cascade; the storage keys of those attachments are returned so the caller
+// can delete the files.
+func (r *carRepository) Purge(ctx context.Context, deletedBefore time.Time, limit int) (int64, []string, error) {
+	purged, err := r.purgeSQLCars(ctx, deletedBefore, limit)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	var storageKeys []string
+	for _, car := range purged {
+		storageKeys = append(storageKeys, car.StorageKeys...)
+	}
+
+	if len(purged) > 0 {
+		r.invalidateCars(ctx)
+	}
+
+	return int64(len(purged)), storageKeys, nil
+}
+
+// TransferOwnership moves the owner link to newUserID. A share the new owner
+// already had on the car is dropped in the same transaction, as a user holds
+// one role per car.
+func (r *carRepository) TransferOwnership(ctx context.Context, carID, newUserID uuid.UUID) error {
+	tx, err := database.Begin(ctx, r.sql0)
 	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("tx_transfer_ownership")
+		return appErr.Wrap(err, "tx_transfer_ownership")
+	}
+
+	defer func() {
+		if err != nil {
+			if rollbackErr := tx.Rollback(ctx); rollbackErr != nil {
+				zerolog.Ctx(ctx).Error().Err(rollbackErr).Msg("rollback_transfer_ownership")
+			}
+		}
+	}()
+
+	if _, err = r.deleteCarShareSQL(ctx, tx, carID, newUserID); err != nil {
 		return err
 	}
 
-	for _, id := range carIDs {
-		cacheKey := fmt.Sprintf(cacheKeyCar, id.String())
-		r.redis0.Del(ctx, cacheKey)
+	if err = r.transferOwnershipSQL(ctx, tx, carID, newUserID); err != nil {
+		return err
 	}
 
+	if err = tx.Commit(ctx); err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("commit_transfer_ownership")
+		return appErr.Wrap(err, "commit_transfer_ownership")
+	}
+
+	r.invalidateCars(ctx, carID)
+
 	return nil
 }
 
+// FindOwnerForUpdate returns the car's owner and locks the link until the
+// surrounding transaction ends.
+func (r *carRepository) FindOwnerForUpdate(ctx context.Context, carID uuid.UUID) (uuid.UUID, error) {
+	return r.findCarOwnerForUpdateSQL(ctx, carID)
+}
+
+// AppendOwnershipHistory records ownership changes. Callers write them in the
+// same transaction as the change itself.
+func (r *carRepository) AppendOwnershipHistory(ctx context.Context, records []entity.CarOwnershipRecord) error {
+	return r.appendOwnershipHistorySQL(ctx, records)
+}
+
+// FindOwnershipHistory is not cached: it is read rarely and only by the owner
+// or an admin.
+func (r *carRepository) FindOwnershipHistory(ctx context.Context, carID uuid.UUID) ([]entity.CarOwnershipRecord, error) {
+	return r.findOwnershipHistorySQL(ctx, carID)
+}
+
 func (r *carRepository) IsCarOwnedByUser(ctx context.Context, carID uuid.UUID, userID string) (bool, error) {
 	return r.checkCarOwnershipSQL(ctx, carID, userID)
 }
 
+// FindCarRole returns the user's role on the car, or "" when they have no
+// access to it.
+func (r *carRepository) FindCarRole(ctx context.Context, carID uuid.UUID, userID string) (entity.CarRole, error) {
+	return r.findCarRoleSQL(ctx, carID, userID)
+}
+
+func (r *carRepository) FindShares(ctx context.Context, carID uuid.UUID) ([]entity.CarShare, error) {
+	return r.findCarSharesSQL(ctx, carID)
+}
+
+// Share grants userID role on the car, or changes the role they already
+// have. It returns CodeSQLEmptyRow when the car or user does not exist or the
+// user is the owner.
+func (r *carRepository) Share(ctx context.Context, carID, userID uuid.UUID, role entity.CarRole) (*entity.CarShare, error) {
+	share, err := r.upsertCarShareSQL(ctx, carID, userID, role)
+	if err != nil {
+		return nil, err
+	}
+
+	r.invalidateCars(ctx, carID)
+
+	return share, nil
+}
+
+// Unshare revokes a non-owner's access to the car.
+func (r *carRepository) Unshare(ctx context.Context, carID, userID uuid.UUID) error {
+	rows, err := r.deleteCarShareSQL(ctx, database.Conn(ctx, r.sql0), carID, userID)
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		zerolog.Ctx(ctx).Debug().Str("car_id", carID.String()).Str("user_id", userID.String()).Msg("car_share_not_found")
+		return appErr.NewWithCode(appErr.CodeSQLEmptyRow, "car_share_not_found")
+	}
+
+	r.invalidateCars(ctx, carID)
+
+	return nil
+}
+
 func (r *carRepository) AreCarsOwnedByUser(ctx context.Context, carIDs []uuid.UUID, userID string) (map[uuid.UUID]bool, error) {
 	ownershipMap, err := r.checkCarsOwnershipSQL(ctx, carIDs, userID)
 	if err != nil {
@@ -176,3 +372,7 @@ func (r *carRepository) AreCarsOwnedByUser(ctx context.Context, carIDs []uuid.UU
 
 	return ownershipMap, nil
 }
+
+func (r *carRepository) ExportV2(ctx context.Context, filter *dto.CarFilterV2, fn func(*entity.Car) error) error {
+	return r.exportSQLCarsV2(ctx, filter, fn)
+}
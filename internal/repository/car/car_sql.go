@@ -5,30 +5,42 @@ import (
 	"errors"
 	"time"
 
+	"go-far/internal/infra/database"
+	"go-far/internal/infra/query"
+	"go-far/internal/model/dto"
 	"go-far/internal/model/entity"
 	appErr "go-far/internal/model/errors"
-	"go-far/internal/util"
+	"go-far/internal/repository/queries"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/rs/zerolog"
 )
 
+const (
+	// liveRowsOnly hides soft-deleted cars from the export.
+	liveRowsOnly = "deleted_at IS NULL"
+	// pgUniqueViolation is raised by the unique plate and VIN indexes.
+	pgUniqueViolation = "23505"
+	// plateIndex keeps normalized license plates unique.
+	plateIndex = "cars_license_plate_normalized_key"
+)
+
 func (r *carRepository) createSQLCar(ctx context.Context, tx pgx.Tx, car *entity.Car) error {
-	query, args, err := r.queryLoader.Compile("CreateCar", car)
+	created, err := r.queries.CreateCar(ctx, tx, car)
 	if err != nil {
-		zerolog.Ctx(ctx).Error().Err(err).Msg("build_create_car_query_err")
-		return appErr.WrapWithCode(err, appErr.CodeSQLQueryBuild, "build_create_car_query_err")
-	}
-
-	zerolog.Ctx(ctx).Debug().Str("query", util.CleanQuery(query)).Any("args", args).Msg("compiled_query")
+		if plateTaken(err) {
+			zerolog.Ctx(ctx).Debug().Str("license_plate", car.LicensePlate).Msg("license_plate_already_exists")
+			return appErr.WrapWithCode(err, appErr.CodeSQLConflict, "license_plate_already_exists")
+		}
 
-	err = tx.QueryRow(ctx, query, args...).Scan(&car.ID, &car.Brand, &car.Model, &car.Year, &car.Color, &car.LicensePlate, &car.IsAvailable, &car.CreatedAt, &car.UpdatedAt)
-	if err != nil {
 		zerolog.Ctx(ctx).Error().Err(err).Msg("create_car_err")
 		return appErr.Wrap(err, "create_car_err")
 	}
 
+	*car = created
+
 	return nil
 }
 
@@ -43,74 +55,168 @@ func (r *carRepository) createBulkSQLCars(ctx context.Context, tx pgx.Tx, cars [
 		car.UpdatedAt = now
 	}
 
-	query, args, err := r.queryLoader.Compile("CreateCarBulk", cars)
+	created, err := r.queries.CreateCarBulk(ctx, tx, cars)
 	if err != nil {
-		zerolog.Ctx(ctx).Error().Err(err).Msg("build_create_bulk_cars_query_err")
-		return appErr.WrapWithCode(err, appErr.CodeSQLQueryBuild, "build_create_bulk_cars_query_err")
-	}
-
-	zerolog.Ctx(ctx).Debug().Str("query", util.CleanQuery(query)).Any("args", args).Msg("compiled_query")
+		if plateTaken(err) {
+			zerolog.Ctx(ctx).Debug().Msg("license_plate_already_exists")
+			return appErr.WrapWithCode(err, appErr.CodeSQLConflict, "license_plate_already_exists")
+		}
 
-	_, err = tx.Exec(ctx, query, args...)
-	if err != nil {
 		zerolog.Ctx(ctx).Error().Err(err).Msg("create_bulk_cars_err")
 		return appErr.Wrap(err, "create_bulk_cars_err")
 	}
 
+	ids := make(map[string]string, len(created))
+	for _, row := range created {
+		ids[row.LicensePlate] = row.ID
+	}
+	assignCarIDs(cars, ids)
+
 	return nil
 }
 
-func (r *carRepository) updateSQLCar(ctx context.Context, id uuid.UUID, car *entity.Car) error {
-	data := map[string]any{
-		"ID":           id,
-		"Brand":        car.Brand,
-		"Model":        car.Model,
-		"Year":         car.Year,
-		"Color":        car.Color,
-		"LicensePlate": car.LicensePlate,
-		"IsAvailable":  car.IsAvailable,
-		"UpdatedAt":    time.Now(),
-	}
-
-	query, args, err := r.queryLoader.Compile("UpdateCar", data)
+// importBulkSQLCars inserts cars, skipping license plates or VINs that
+// already exist, and returns the cars that were actually inserted with their IDs set.
+func (r *carRepository) importBulkSQLCars(ctx context.Context, tx pgx.Tx, cars []*entity.Car) ([]*entity.Car, error) {
+	now := time.Now()
+	for _, car := range cars {
+		car.CreatedAt = now
+		car.UpdatedAt = now
+	}
+
+	created, err := r.queries.ImportCarBulk(ctx, tx, cars)
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("import_bulk_cars_err")
+		return nil, appErr.WrapWithCode(err, appErr.CodeSQLCreate, "import_bulk_cars_err")
+	}
+
+	ids := make(map[string]string, len(created))
+	for _, row := range created {
+		ids[row.LicensePlate] = row.ID
+	}
+	assignCarIDs(cars, ids)
+
+	inserted := make([]*entity.Car, 0, len(created))
+	for _, car := range cars {
+		if car.ID != "" {
+			inserted = append(inserted, car)
+		}
+	}
+
+	return inserted, nil
+}
+
+// assignCarIDs copies returned IDs (keyed by license plate) onto cars. Rows
+// are matched by their unique plate since a multi-row insert does not
+// guarantee the order of RETURNING.
+func assignCarIDs(cars []*entity.Car, ids map[string]string) {
+	for _, car := range cars {
+		car.ID = ids[car.LicensePlate]
+	}
+}
+
+func (r *carRepository) findExistingLicensePlatesSQL(ctx context.Context, plates []string) (map[string]struct{}, error) {
+	existing := make(map[string]struct{})
+	if len(plates) == 0 {
+		return existing, nil
+	}
+
+	found, err := r.queries.FindExistingLicensePlates(ctx, database.Conn(ctx, r.sql0), queries.FindExistingLicensePlatesParams{LicensePlates: plates})
 	if err != nil {
-		zerolog.Ctx(ctx).Error().Err(err).Msg("build_update_car_query_err")
-		return appErr.WrapWithCode(err, appErr.CodeSQLQueryBuild, "build_update_car_query_err")
+		zerolog.Ctx(ctx).Error().Err(err).Msg("find_existing_license_plates_err")
+		return nil, appErr.WrapWithCode(err, appErr.CodeSQLRead, "find_existing_license_plates_err")
+	}
+
+	for _, plate := range found {
+		existing[plate] = struct{}{}
 	}
 
-	zerolog.Ctx(ctx).Debug().Str("query", util.CleanQuery(query)).Any("args", args).Msg("compiled_query")
+	return existing, nil
+}
 
-	var updatedAt time.Time
-	err = r.sql0.QueryRow(ctx, query, args...).Scan(&updatedAt)
+// findCarIDByLicensePlateSQL finds the live car holding a normalized plate.
+func (r *carRepository) findCarIDByLicensePlateSQL(ctx context.Context, plate string) (uuid.UUID, error) {
+	id, err := r.queries.FindCarIDByLicensePlate(ctx, database.Conn(ctx, r.sql0), queries.FindCarIDByLicensePlateParams{LicensePlate: plate})
 	if err != nil {
-		if err == pgx.ErrNoRows { //nolint:errorlint
-			zerolog.Ctx(ctx).Debug().Str("id", id.String()).Msg("car_not_found_for_update")
-			return appErr.NewWithCode(appErr.CodeSQLEmptyRow, "car_not_found_for_update")
+		if errors.Is(err, pgx.ErrNoRows) {
+			zerolog.Ctx(ctx).Debug().Str("license_plate", plate).Msg("car_not_found")
+			return uuid.Nil, appErr.WrapWithCode(err, appErr.CodeSQLEmptyRow, "car_not_found")
 		}
-		zerolog.Ctx(ctx).Error().Err(err).Str("id", id.String()).Msg("update_car_err")
-		return appErr.WrapWithCode(err, appErr.CodeSQLUpdate, "update_car_err")
+
+		zerolog.Ctx(ctx).Error().Err(err).Str("license_plate", plate).Msg("find_car_by_license_plate_err")
+		return uuid.Nil, appErr.WrapWithCode(err, appErr.CodeSQLRead, "find_car_by_license_plate_err")
 	}
 
-	return nil
+	return uuid.Parse(id)
 }
 
-func (r *carRepository) deleteSQLCar(ctx context.Context, id uuid.UUID) error {
-	query, args, err := r.queryLoader.Compile("DeleteCar", map[string]any{"ID": id})
+func (r *carRepository) findExistingVINsSQL(ctx context.Context, vins []string) (map[string]struct{}, error) {
+	existing := make(map[string]struct{})
+	if len(vins) == 0 {
+		return existing, nil
+	}
+
+	found, err := r.queries.FindExistingVINs(ctx, database.Conn(ctx, r.sql0), queries.FindExistingVINsParams{VINs: vins})
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("find_existing_vins_err")
+		return nil, appErr.WrapWithCode(err, appErr.CodeSQLRead, "find_existing_vins_err")
+	}
+
+	for _, vin := range found {
+		existing[vin] = struct{}{}
+	}
+
+	return existing, nil
+}
+
+// updateSQLCar writes car only if its version is still the stored one.
+// Otherwise the row is re-read to tell a deleted car from a lost race.
+func (r *carRepository) updateSQLCar(ctx context.Context, id uuid.UUID, car *entity.Car) error {
+	row, err := r.queries.UpdateCar(ctx, database.Conn(ctx, r.sql0), queries.UpdateCarParams{
+		ID:           id,
+		Brand:        car.Brand,
+		Model:        car.Model,
+		Year:         car.Year,
+		Color:        car.Color,
+		LicensePlate: car.LicensePlate,
+		PlateRegion:  car.PlateRegion,
+		VIN:          car.VIN,
+		UpdatedAt:    time.Now(),
+		Version:      car.Version,
+	})
 	if err != nil {
-		zerolog.Ctx(ctx).Error().Err(err).Msg("build_delete_car_query_err")
-		return appErr.WrapWithCode(err, appErr.CodeSQLQueryBuild, "build_delete_car_query_err")
+		if plateTaken(err) {
+			zerolog.Ctx(ctx).Debug().Str("id", id.String()).Str("license_plate", car.LicensePlate).Msg("license_plate_already_exists")
+			return appErr.WrapWithCode(err, appErr.CodeSQLConflict, "license_plate_already_exists")
+		}
+
+		if !errors.Is(err, pgx.ErrNoRows) {
+			zerolog.Ctx(ctx).Error().Err(err).Str("id", id.String()).Msg("update_car_err")
+			return appErr.WrapWithCode(err, appErr.CodeSQLUpdate, "update_car_err")
+		}
+
+		current, findErr := r.findCarSQLByID(ctx, id)
+		if findErr != nil {
+			zerolog.Ctx(ctx).Debug().Str("id", id.String()).Msg("car_not_found_for_update")
+			return appErr.Wrap(findErr, "car_not_found_for_update")
+		}
+
+		zerolog.Ctx(ctx).Debug().Str("id", id.String()).Int64("version", car.Version).Int64("current_version", current.Version).Msg("car_version_conflict")
+		return appErr.WrapWithCode(&appErr.ConflictError{Current: current}, appErr.CodeHTTPConflict, "car_version_conflict")
 	}
 
-	zerolog.Ctx(ctx).Debug().Str("query", util.CleanQuery(query)).Any("args", args).Msg("compiled_query")
+	car.UpdatedAt, car.Version = row.UpdatedAt, row.Version
+
+	return nil
+}
 
-	result, err := r.sql0.Exec(ctx, query, args...)
+func (r *carRepository) deleteSQLCar(ctx context.Context, id uuid.UUID) error {
+	rows, err := r.queries.DeleteCar(ctx, database.Conn(ctx, r.sql0), queries.DeleteCarParams{ID: id})
 	if err != nil {
 		zerolog.Ctx(ctx).Error().Err(err).Str("id", id.String()).Msg("delete_car_err")
 		return appErr.WrapWithCode(err, appErr.CodeSQLDelete, "delete_car_err")
 	}
 
-	rows := result.RowsAffected()
-
 	if rows == 0 {
 		zerolog.Ctx(ctx).Debug().Str("id", id.String()).Msg("car_not_found_for_deletion")
 		return appErr.NewWithCode(appErr.CodeSQLEmptyRow, "car_not_found_for_deletion")
@@ -120,19 +226,41 @@ func (r *carRepository) deleteSQLCar(ctx context.Context, id uuid.UUID) error {
 }
 
 func (r *carRepository) findCarSQLByID(ctx context.Context, id uuid.UUID) (*entity.Car, error) {
-	query, args, err := r.queryLoader.Compile("FindCarByID", map[string]any{"ID": id})
+	car, err := r.queries.FindCarByID(ctx, database.Conn(ctx, r.sql0), queries.FindCarByIDParams{ID: id})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			zerolog.Ctx(ctx).Debug().Str("id", id.String()).Msg("car_not_found")
+			return nil, appErr.WrapWithCode(err, appErr.CodeSQLEmptyRow, "car_not_found")
+		}
+
+		zerolog.Ctx(ctx).Error().Err(err).Str("id", id.String()).Msg("find_car_err")
+		return nil, appErr.WrapWithCode(err, appErr.CodeSQLRowScan, "find_car_err")
+	}
+
+	return &car, nil
+}
+
+func (r *carRepository) findCarColumnsSQLByID(ctx context.Context, id uuid.UUID, columns []string) (*entity.Car, error) {
+	car, err := r.queries.FindCarColumnsByID(ctx, database.Conn(ctx, r.sql0), queries.FindCarColumnsByIDParams{Columns: columns, ID: id})
 	if err != nil {
-		zerolog.Ctx(ctx).Error().Err(err).Msg("build_find_car_query_err")
-		return nil, appErr.WrapWithCode(err, appErr.CodeSQLQueryBuild, "build_find_car_query_err")
+		if errors.Is(err, pgx.ErrNoRows) {
+			zerolog.Ctx(ctx).Debug().Str("id", id.String()).Msg("car_not_found")
+			return nil, appErr.WrapWithCode(err, appErr.CodeSQLEmptyRow, "car_not_found")
+		}
+
+		zerolog.Ctx(ctx).Error().Err(err).Str("id", id.String()).Msg("find_car_columns_err")
+		return nil, appErr.WrapWithCode(err, appErr.CodeSQLRowScan, "find_car_columns_err")
 	}
 
-	zerolog.Ctx(ctx).Debug().Str("query", util.CleanQuery(query)).Any("args", args).Msg("compiled_query")
+	return &car, nil
+}
 
-	var car entity.Car
-	err = r.sql0.QueryRow(ctx, query, args...).Scan(&car)
+func (r *carRepository) findCarSQLByIDWithDeleted(ctx context.Context, id uuid.UUID) (*entity.Car, error) {
+	car, err := r.queries.FindCarByIDWithDeleted(ctx, database.Conn(ctx, r.sql0), queries.FindCarByIDWithDeletedParams{ID: id})
 	if err != nil {
-		if err == pgx.ErrNoRows { //nolint:errorlint
-			return nil, appErr.WrapWithCode(err, appErr.CodeSQLRowScan, "find_car_err")
+		if errors.Is(err, pgx.ErrNoRows) {
+			zerolog.Ctx(ctx).Debug().Str("id", id.String()).Msg("car_not_found")
+			return nil, appErr.WrapWithCode(err, appErr.CodeSQLEmptyRow, "car_not_found")
 		}
 
 		zerolog.Ctx(ctx).Error().Err(err).Str("id", id.String()).Msg("find_car_err")
@@ -142,46 +270,72 @@ func (r *carRepository) findCarSQLByID(ctx context.Context, id uuid.UUID) (*enti
 	return &car, nil
 }
 
-func (r *carRepository) findCarByUserIDSQL(ctx context.Context, userID uuid.UUID) ([]*entity.Car, error) {
-	query, args, err := r.queryLoader.Compile("FindCarsByUserID", map[string]any{"UserID": userID})
+func (r *carRepository) restoreSQLCar(ctx context.Context, id uuid.UUID) (*entity.Car, error) {
+	car, err := r.queries.RestoreCar(ctx, database.Conn(ctx, r.sql0), queries.RestoreCarParams{ID: id})
 	if err != nil {
-		zerolog.Ctx(ctx).Error().Err(err).Msg("build_find_cars_query_err")
-		return nil, appErr.WrapWithCode(err, appErr.CodeSQLQueryBuild, "build_find_cars_query_err")
+		if errors.Is(err, pgx.ErrNoRows) {
+			zerolog.Ctx(ctx).Debug().Str("id", id.String()).Msg("deleted_car_not_found")
+			return nil, appErr.WrapWithCode(err, appErr.CodeSQLEmptyRow, "deleted_car_not_found")
+		}
+
+		zerolog.Ctx(ctx).Error().Err(err).Str("id", id.String()).Msg("restore_car_err")
+		return nil, appErr.WrapWithCode(err, appErr.CodeSQLUpdate, "restore_car_err")
 	}
 
-	zerolog.Ctx(ctx).Debug().Str("query", util.CleanQuery(query)).Any("args", args).Msg("compiled_query")
+	return &car, nil
+}
+
+func (r *carRepository) purgeSQLCars(ctx context.Context, deletedBefore time.Time, limit int) ([]queries.PurgeCarsRow, error) {
+	purged, err := r.queries.PurgeCars(ctx, database.Conn(ctx, r.sql0), queries.PurgeCarsParams{DeletedBefore: deletedBefore, Limit: limit})
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Time("deleted_before", deletedBefore).Msg("purge_cars_err")
+		return nil, appErr.WrapWithCode(err, appErr.CodeSQLDelete, "purge_cars_err")
+	}
+
+	return purged, nil
+}
 
-	rows, err := r.sql0.Query(ctx, query, args...)
+func (r *carRepository) findCarByUserIDSQL(ctx context.Context, userID uuid.UUID) ([]*entity.Car, error) {
+	cars, err := r.queries.FindCarsByUserID(ctx, database.Conn(ctx, r.sql0), queries.FindCarsByUserIDParams{UserID: userID})
 	if err != nil {
 		zerolog.Ctx(ctx).Error().Err(err).Str("user_id", userID.String()).Msg("find_cars_by_user_err")
 		return nil, appErr.WrapWithCode(err, appErr.CodeSQLRowScan, "find_cars_by_user_err")
 	}
-	defer rows.Close()
 
-	var cars []*entity.Car
-	for rows.Next() {
-		var car entity.Car
-		if err := rows.Scan(&car.ID, &car.Brand, &car.Model, &car.Year, &car.Color, &car.LicensePlate, &car.IsAvailable, &car.CreatedAt, &car.UpdatedAt); err != nil {
-			zerolog.Ctx(ctx).Error().Err(err).Msg("scan_car_err")
-			return nil, appErr.WrapWithCode(err, appErr.CodeSQLRowScan, "scan_car_err")
-		}
-		cars = append(cars, &car)
+	return cars, nil
+}
+
+func (r *carRepository) findCarColumnsByUserIDSQL(ctx context.Context, userID uuid.UUID, columns []string) ([]*entity.Car, error) {
+	cars, err := r.queries.FindCarColumnsByUserID(ctx, database.Conn(ctx, r.sql0), queries.FindCarColumnsByUserIDParams{Columns: columns, UserID: userID})
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Str("user_id", userID.String()).Msg("find_car_columns_by_user_err")
+		return nil, appErr.WrapWithCode(err, appErr.CodeSQLRowScan, "find_car_columns_by_user_err")
 	}
 
 	return cars, nil
 }
 
-func (r *carRepository) countCarsByUserIDSQL(ctx context.Context, userID uuid.UUID) (int, error) {
-	query, args, err := r.queryLoader.Compile("CountCarsByUserID", map[string]any{"UserID": userID})
+func (r *carRepository) findCarsByUserIDsSQL(ctx context.Context, userIDs []uuid.UUID) (map[string][]entity.Car, error) {
+	carsByUser := make(map[string][]entity.Car, len(userIDs))
+	if len(userIDs) == 0 {
+		return carsByUser, nil
+	}
+
+	owned, err := r.queries.FindCarsByUserIDs(ctx, database.Conn(ctx, r.sql0), queries.FindCarsByUserIDsParams{UserIDs: userIDs})
 	if err != nil {
-		zerolog.Ctx(ctx).Error().Err(err).Msg("build_count_cars_query_err")
-		return 0, appErr.WrapWithCode(err, appErr.CodeSQLQueryBuild, "build_count_cars_query_err")
+		zerolog.Ctx(ctx).Error().Err(err).Int("users", len(userIDs)).Msg("find_cars_by_user_ids_err")
+		return nil, appErr.WrapWithCode(err, appErr.CodeSQLRead, "find_cars_by_user_ids_err")
 	}
 
-	zerolog.Ctx(ctx).Debug().Str("query", util.CleanQuery(query)).Any("args", args).Msg("compiled_query")
+	for _, oc := range owned {
+		carsByUser[oc.UserID] = append(carsByUser[oc.UserID], oc.Car)
+	}
+
+	return carsByUser, nil
+}
 
-	var count int
-	err = r.sql0.QueryRow(ctx, query, args...).Scan(&count)
+func (r *carRepository) countCarsByUserIDSQL(ctx context.Context, userID uuid.UUID) (int, error) {
+	count, err := r.queries.CountCarsByUserID(ctx, database.Conn(ctx, r.sql0), queries.CountCarsByUserIDParams{UserID: userID})
 	if err != nil {
 		zerolog.Ctx(ctx).Error().Err(err).Str("user_id", userID.String()).Msg("count_cars_by_user_err")
 		return 0, appErr.WrapWithCode(err, appErr.CodeSQLRowScan, "count_cars_by_user_err")
@@ -191,18 +345,10 @@ func (r *carRepository) countCarsByUserIDSQL(ctx context.Context, userID uuid.UU
 }
 
 func (r *carRepository) assignCarToUserSQL(ctx context.Context, userID, carID uuid.UUID) error {
-	query, args, err := r.queryLoader.Compile("AssignCarToUser", map[string]any{
-		"UserID": userID,
-		"CarID":  carID,
+	err := r.queries.AssignCarToUser(ctx, database.Conn(ctx, r.sql0), queries.AssignCarToUserParams{
+		UserID: userID,
+		CarID:  carID,
 	})
-	if err != nil {
-		zerolog.Ctx(ctx).Error().Err(err).Msg("build_assign_car_to_user_query_err")
-		return appErr.WrapWithCode(err, appErr.CodeSQLQueryBuild, "build_assign_car_to_user_query_err")
-	}
-
-	zerolog.Ctx(ctx).Debug().Str("query", util.CleanQuery(query)).Any("args", args).Msg("compiled_query")
-
-	_, err = r.sql0.Exec(ctx, query, args...)
 	if err != nil {
 		zerolog.Ctx(ctx).Error().Err(err).Str("user_id", userID.String()).Str("car_id", carID.String()).Msg("assign_car_to_user_err")
 		return appErr.Wrap(err, "assign_car_to_user_err")
@@ -217,16 +363,7 @@ func (r *carRepository) assignCarsToUserBulkSQL(ctx context.Context, userID uuid
 		userCars[i] = entity.UserCar{UserID: userID, CarID: carID}
 	}
 
-	query, args, err := r.queryLoader.Compile("AssignCarToUserBulk", userCars)
-	if err != nil {
-		zerolog.Ctx(ctx).Error().Err(err).Msg("build_assign_cars_bulk_query_err")
-		return appErr.WrapWithCode(err, appErr.CodeSQLQueryBuild, "build_assign_cars_bulk_query_err")
-	}
-
-	zerolog.Ctx(ctx).Debug().Str("query", util.CleanQuery(query)).Any("args", args).Msg("compiled_query")
-
-	_, err = r.sql0.Exec(ctx, query, args...)
-	if err != nil {
+	if err := r.queries.AssignCarToUserBulk(ctx, database.Conn(ctx, r.sql0), userCars); err != nil {
 		zerolog.Ctx(ctx).Error().Err(err).Str("user_id", userID.String()).Msg("assign_cars_to_user_bulk_err")
 		return appErr.Wrap(err, "assign_cars_to_user_bulk_err")
 	}
@@ -235,16 +372,7 @@ func (r *carRepository) assignCarsToUserBulkSQL(ctx context.Context, userID uuid
 }
 
 func (r *carRepository) findCarByIDWithOwnerSQL(ctx context.Context, id uuid.UUID) (*entity.CarWithOwner, error) {
-	query, args, err := r.queryLoader.Compile("FindCarByIDWithOwner", map[string]any{"ID": id})
-	if err != nil {
-		zerolog.Ctx(ctx).Error().Err(err).Msg("build_find_car_with_owner_query_err")
-		return nil, appErr.WrapWithCode(err, appErr.CodeSQLQueryBuild, "build_find_car_with_owner_query_err")
-	}
-
-	zerolog.Ctx(ctx).Debug().Str("query", util.CleanQuery(query)).Any("args", args).Msg("compiled_query")
-
-	var carWithOwner entity.CarWithOwner
-	err = r.sql0.QueryRow(ctx, query, args...).Scan(&carWithOwner)
+	carWithOwner, err := r.queries.FindCarByIDWithOwner(ctx, database.Conn(ctx, r.sql0), queries.FindCarByIDWithOwnerParams{ID: id})
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			zerolog.Ctx(ctx).Debug().Str("id", id.String()).Msg("car_not_found")
@@ -258,28 +386,31 @@ func (r *carRepository) findCarByIDWithOwnerSQL(ctx context.Context, id uuid.UUI
 	return &carWithOwner, nil
 }
 
-func (r *carRepository) transferOwnershipSQL(ctx context.Context, carID, newUserID uuid.UUID) error {
-	data := map[string]any{
-		"CarID":     carID,
-		"NewUserID": newUserID,
-	}
-
-	query, args, err := r.queryLoader.Compile("TransferCarOwnership", data)
+func (r *carRepository) findCarColumnsByIDWithOwnerSQL(ctx context.Context, id uuid.UUID, columns []string) (*entity.CarWithOwner, error) {
+	carWithOwner, err := r.queries.FindCarColumnsByIDWithOwner(ctx, database.Conn(ctx, r.sql0), queries.FindCarColumnsByIDWithOwnerParams{Columns: columns, ID: id})
 	if err != nil {
-		zerolog.Ctx(ctx).Error().Err(err).Msg("build_transfer_ownership_query_err")
-		return appErr.WrapWithCode(err, appErr.CodeSQLQueryBuild, "build_transfer_ownership_query_err")
+		if errors.Is(err, pgx.ErrNoRows) {
+			zerolog.Ctx(ctx).Debug().Str("id", id.String()).Msg("car_not_found")
+			return nil, appErr.WrapWithCode(err, appErr.CodeSQLEmptyRow, "car_not_found")
+		}
+
+		zerolog.Ctx(ctx).Error().Err(err).Str("id", id.String()).Msg("find_car_columns_with_owner_err")
+		return nil, appErr.WrapWithCode(err, appErr.CodeSQLRowScan, "find_car_columns_with_owner_err")
 	}
 
-	zerolog.Ctx(ctx).Debug().Str("query", util.CleanQuery(query)).Any("args", args).Msg("compiled_query")
+	return &carWithOwner, nil
+}
 
-	result, err := r.sql0.Exec(ctx, query, args...)
+func (r *carRepository) transferOwnershipSQL(ctx context.Context, db queries.DBTX, carID, newUserID uuid.UUID) error {
+	rows, err := r.queries.TransferCarOwnership(ctx, db, queries.TransferCarOwnershipParams{
+		CarID:     carID,
+		NewUserID: newUserID,
+	})
 	if err != nil {
 		zerolog.Ctx(ctx).Error().Err(err).Str("car_id", carID.String()).Msg("transfer_ownership_err")
 		return appErr.WrapWithCode(err, appErr.CodeSQLUpdate, "transfer_ownership_err")
 	}
 
-	rows := result.RowsAffected()
-
 	if rows == 0 {
 		zerolog.Ctx(ctx).Debug().Str("car_id", carID.String()).Msg("car_not_found_for_transfer")
 		return appErr.NewWithCode(appErr.CodeSQLEmptyRow, "car_not_found_for_transfer")
@@ -288,57 +419,112 @@ func (r *carRepository) transferOwnershipSQL(ctx context.Context, carID, newUser
 	return nil
 }
 
-func (r *carRepository) bulkUpdateAvailabilitySQL(ctx context.Context, carIDs []uuid.UUID, isAvailable bool) error {
-	if len(carIDs) == 0 {
+func (r *carRepository) findCarOwnerForUpdateSQL(ctx context.Context, carID uuid.UUID) (uuid.UUID, error) {
+	ownerID, err := r.queries.FindCarOwnerForUpdate(ctx, database.Conn(ctx, r.sql0), queries.FindCarOwnerForUpdateParams{CarID: carID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			zerolog.Ctx(ctx).Debug().Str("car_id", carID.String()).Msg("car_owner_not_found")
+			return uuid.Nil, appErr.WrapWithCode(err, appErr.CodeSQLEmptyRow, "car_owner_not_found")
+		}
+
+		zerolog.Ctx(ctx).Error().Err(err).Str("car_id", carID.String()).Msg("find_car_owner_err")
+		return uuid.Nil, appErr.WrapWithCode(err, appErr.CodeSQLRowScan, "find_car_owner_err")
+	}
+
+	return ownerID, nil
+}
+
+func (r *carRepository) appendOwnershipHistorySQL(ctx context.Context, records []entity.CarOwnershipRecord) error {
+	if len(records) == 0 {
 		return nil
 	}
 
-	idStrs := make([]string, len(carIDs))
-	for i, id := range carIDs {
-		idStrs[i] = id.String()
+	if err := r.queries.AppendCarOwnershipHistory(ctx, database.Conn(ctx, r.sql0), records); err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("append_car_ownership_history_err")
+		return appErr.WrapWithCode(err, appErr.CodeSQLCreate, "append_car_ownership_history_err")
 	}
 
-	data := map[string]any{
-		"CarIDs":      idStrs,
-		"IsAvailable": isAvailable,
-		"UpdatedAt":   time.Now(),
+	return nil
+}
+
+func (r *carRepository) findOwnershipHistorySQL(ctx context.Context, carID uuid.UUID) ([]entity.CarOwnershipRecord, error) {
+	records, err := r.queries.FindCarOwnershipHistory(ctx, database.Conn(ctx, r.sql0), queries.FindCarOwnershipHistoryParams{CarID: carID})
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Str("car_id", carID.String()).Msg("find_car_ownership_history_err")
+		return nil, appErr.WrapWithCode(err, appErr.CodeSQLRead, "find_car_ownership_history_err")
 	}
 
-	query, args, err := r.queryLoader.Compile("BulkUpdateCarAvailability", data)
+	return records, nil
+}
+
+func (r *carRepository) findCarRoleSQL(ctx context.Context, carID uuid.UUID, userID string) (entity.CarRole, error) {
+	role, err := r.queries.FindCarRole(ctx, database.Conn(ctx, r.sql0), queries.FindCarRoleParams{
+		CarID:  carID,
+		UserID: userID,
+	})
 	if err != nil {
-		zerolog.Ctx(ctx).Error().Err(err).Msg("build_bulk_update_availability_query_err")
-		return appErr.WrapWithCode(err, appErr.CodeSQLQueryBuild, "build_bulk_update_availability_query_err")
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", nil
+		}
+
+		zerolog.Ctx(ctx).Error().Err(err).Str("car_id", carID.String()).Str("user_id", userID).Msg("find_car_role_err")
+		return "", appErr.WrapWithCode(err, appErr.CodeSQLRowScan, "find_car_role_err")
 	}
 
-	zerolog.Ctx(ctx).Debug().Str("query", util.CleanQuery(query)).Any("args", args).Msg("compiled_query")
+	return entity.CarRole(role), nil
+}
 
-	result, err := r.sql0.Exec(ctx, query, args...)
+func (r *carRepository) findCarSharesSQL(ctx context.Context, carID uuid.UUID) ([]entity.CarShare, error) {
+	shares, err := r.queries.FindCarShares(ctx, database.Conn(ctx, r.sql0), queries.FindCarSharesParams{CarID: carID})
 	if err != nil {
-		zerolog.Ctx(ctx).Error().Err(err).Msg("bulk_update_availability_err")
-		return appErr.WrapWithCode(err, appErr.CodeSQLUpdate, "bulk_update_availability_err")
+		zerolog.Ctx(ctx).Error().Err(err).Str("car_id", carID.String()).Msg("find_car_shares_err")
+		return nil, appErr.WrapWithCode(err, appErr.CodeSQLRead, "find_car_shares_err")
 	}
 
-	rows := result.RowsAffected()
+	if shares == nil {
+		shares = []entity.CarShare{}
+	}
 
-	zerolog.Ctx(ctx).Debug().Int64("rows_affected", rows).Msg("bulk_update_availability_success")
+	return shares, nil
+}
 
-	return nil
+func (r *carRepository) upsertCarShareSQL(ctx context.Context, carID, userID uuid.UUID, role entity.CarRole) (*entity.CarShare, error) {
+	share, err := r.queries.UpsertCarShare(ctx, database.Conn(ctx, r.sql0), queries.UpsertCarShareParams{
+		CarID:  carID,
+		UserID: userID,
+		Role:   role,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			zerolog.Ctx(ctx).Debug().Str("car_id", carID.String()).Str("user_id", userID.String()).Msg("car_or_user_not_found_for_share")
+			return nil, appErr.WrapWithCode(err, appErr.CodeSQLEmptyRow, "car_or_user_not_found_for_share")
+		}
+
+		zerolog.Ctx(ctx).Error().Err(err).Str("car_id", carID.String()).Str("user_id", userID.String()).Msg("share_car_err")
+		return nil, appErr.WrapWithCode(err, appErr.CodeSQLCreate, "share_car_err")
+	}
+
+	return &share, nil
 }
 
-func (r *carRepository) checkCarOwnershipSQL(ctx context.Context, carID uuid.UUID, userID string) (bool, error) {
-	query, args, err := r.queryLoader.Compile("CheckCarOwnership", map[string]any{
-		"CarID":  carID,
-		"UserID": userID,
+func (r *carRepository) deleteCarShareSQL(ctx context.Context, db queries.DBTX, carID, userID uuid.UUID) (int64, error) {
+	rows, err := r.queries.DeleteCarShare(ctx, db, queries.DeleteCarShareParams{
+		CarID:  carID,
+		UserID: userID,
 	})
 	if err != nil {
-		zerolog.Ctx(ctx).Error().Err(err).Msg("build_check_car_ownership_query_err")
-		return false, appErr.WrapWithCode(err, appErr.CodeSQLQueryBuild, "build_check_car_ownership_query_err")
+		zerolog.Ctx(ctx).Error().Err(err).Str("car_id", carID.String()).Str("user_id", userID.String()).Msg("delete_car_share_err")
+		return 0, appErr.WrapWithCode(err, appErr.CodeSQLDelete, "delete_car_share_err")
 	}
 
-	zerolog.Ctx(ctx).Debug().Str("query", util.CleanQuery(query)).Any("args", args).Msg("compiled_query")
+	return rows, nil
+}
 
-	var count int
-	err = r.sql0.QueryRow(ctx, query, args...).Scan(&count)
+func (r *carRepository) checkCarOwnershipSQL(ctx context.Context, carID uuid.UUID, userID string) (bool, error) {
+	count, err := r.queries.CheckCarOwnership(ctx, database.Conn(ctx, r.sql0), queries.CheckCarOwnershipParams{
+		CarID:  carID,
+		UserID: userID,
+	})
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return false, nil
@@ -356,31 +542,17 @@ func (r *carRepository) checkCarsOwnershipSQL(ctx context.Context, carIDs []uuid
 		return make(map[uuid.UUID]bool), nil
 	}
 
-	query, args, err := r.queryLoader.Compile("CheckCarsOwnership", map[string]any{
-		"CarIDs": carIDs,
-		"UserID": userID,
+	owned, err := r.queries.CheckCarsOwnership(ctx, database.Conn(ctx, r.sql0), queries.CheckCarsOwnershipParams{
+		CarIDs: carIDs,
+		UserID: userID,
 	})
-	if err != nil {
-		zerolog.Ctx(ctx).Error().Err(err).Msg("build_check_cars_ownership_query_err")
-		return nil, appErr.WrapWithCode(err, appErr.CodeSQLQueryBuild, "build_check_cars_ownership_query_err")
-	}
-
-	zerolog.Ctx(ctx).Debug().Str("query", util.CleanQuery(query)).Any("args", args).Msg("compiled_query")
-
-	rows, err := r.sql0.Query(ctx, query, args...)
 	if err != nil {
 		zerolog.Ctx(ctx).Error().Err(err).Msg("check_cars_ownership_err")
 		return nil, appErr.WrapWithCode(err, appErr.CodeSQLRead, "check_cars_ownership_err")
 	}
-	defer rows.Close()
 
-	ownedSet := make(map[uuid.UUID]struct{})
-	for rows.Next() {
-		var id uuid.UUID
-		if err := rows.Scan(&id); err != nil {
-			zerolog.Ctx(ctx).Error().Err(err).Msg("scan_owned_car_err")
-			return nil, appErr.WrapWithCode(err, appErr.CodeSQLRowScan, "scan_owned_car_err")
-		}
+	ownedSet := make(map[uuid.UUID]struct{}, len(owned))
+	for _, id := range owned {
 		ownedSet[id] = struct{}{}
 	}
 
@@ -391,3 +563,31 @@ func (r *carRepository) checkCarsOwnershipSQL(ctx context.Context, carIDs []uuid
 
 	return ownershipMap, nil
 }
+
+func (r *carRepository) exportSQLCarsV2(ctx context.Context, filter *dto.CarFilterV2, fn func(*entity.Car) error) error {
+	qb := query.NewSQLBuilder("param", "db", liveRowsOnly, 0, 0).WithoutPagination()
+	qb.AliasPrefix("-", filter)
+
+	where, _, err := qb.BuildClause()
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("build_export_cars_query_err")
+		return appErr.WrapWithCode(err, appErr.CodeSQLQueryBuild, "build_export_cars_query_err")
+	}
+
+	err = database.StreamCursor(ctx, r.sql0, func(cursor *database.Cursor) error {
+		return r.queries.ExportCars(ctx, cursor, queries.ExportCarsParams{Where: where}, fn)
+	})
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("export_cars_err")
+		return appErr.WrapWithCode(err, appErr.CodeSQLRead, "export_cars_err")
+	}
+
+	return nil
+}
+
+// plateTaken reports whether err is a write rejected by the normalized plate
+// index.
+func plateTaken(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation && pgErr.ConstraintName == plateIndex
+}
@@ -0,0 +1,36 @@
+// Package queries holds the typed wrappers generated from configs/queries.
+// Edit the annotations in the SQL files and run `make querygen` instead of
+// touching the *.gen.go files.
+package queries
+
+//go:generate go run go-far/cmd/querygen -in ../../../configs/queries/ -out .
+
+import (
+	"context"
+
+	"go-far/internal/infra/query"
+	appErr "go-far/internal/model/errors"
+	"go-far/internal/util"
+
+	"github.com/rs/zerolog"
+)
+
+type Queries struct {
+	queryLoader *query.QueryLoader
+}
+
+func New(queryLoader *query.QueryLoader) *Queries {
+	return &Queries{queryLoader: queryLoader}
+}
+
+func (q *Queries) compile(ctx context.Context, name string, data any) (string, []any, error) {
+	sqlText, args, err := q.queryLoader.Compile(name, data)
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Str("query_name", name).Msg("build_query_err")
+		return "", nil, appErr.WrapWithCode(err, appErr.CodeSQLQueryBuild, "build_query_err")
+	}
+
+	zerolog.Ctx(ctx).Debug().Str("query", util.CleanQuery(sqlText)).Any("args", args).Msg("compiled_query")
+
+	return sqlText, args, nil
+}
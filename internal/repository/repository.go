@@ -3,32 +3,80 @@ package repository
 import (
 	"time"
 
+	"go-far/internal/infra/cache"
 	"go-far/internal/infra/query"
+	"go-far/internal/infra/vpic"
+	"go-far/internal/repository/attachment"
 	"go-far/internal/repository/car"
+	"go-far/internal/repository/reservation"
+	"go-far/internal/repository/search"
+	"go-far/internal/repository/servicerecord"
+	"go-far/internal/repository/stats"
+	"go-far/internal/repository/transfer"
 	"go-far/internal/repository/user"
+	"go-far/internal/repository/vin"
 
 	"github.com/jackc/pgx/v5/pgxpool"
-	"github.com/redis/go-redis/v9"
 )
 
 type Repository struct {
-	User user.UserRepositoryItf
-	Car  car.CarRepositoryItf
+	User          user.UserRepositoryItf
+	Car           car.CarRepositoryItf
+	Search        search.SearchRepositoryItf
+	Reservation   reservation.ReservationRepositoryItf
+	Transfer      transfer.TransferRepositoryItf
+	ServiceRecord servicerecord.ServiceRecordRepositoryItf
+	VIN           vin.VINRepositoryItf
+	Attachment    attachment.AttachmentRepositoryItf
+	Stats         stats.StatsRepositoryItf
 }
 
-func InitRepository(sql0 *pgxpool.Pool, redis0 *redis.Client, queryLoader *query.QueryLoader, cacheTTL time.Duration) *Repository {
+func InitRepository(sql0 *pgxpool.Pool, cacheStore *cache.Store, queryLoader *query.QueryLoader, cacheTTL time.Duration, vpicClient vpic.VPIC, vinCacheTTL, statsCacheTTL time.Duration) *Repository {
 	return &Repository{
 		User: user.InitUserRepository(
 			sql0,
-			redis0,
+			cacheStore,
 			queryLoader,
 			cacheTTL,
 		),
 		Car: car.InitCarRepository(
 			sql0,
-			redis0,
+			cacheStore,
 			queryLoader,
 			cacheTTL,
 		),
+		Search: search.InitSearchRepository(
+			sql0,
+			queryLoader,
+		),
+		Reservation: reservation.InitReservationRepository(
+			sql0,
+			cacheStore,
+			queryLoader,
+		),
+		Transfer: transfer.InitTransferRepository(
+			sql0,
+			queryLoader,
+		),
+		ServiceRecord: servicerecord.InitServiceRecordRepository(
+			sql0,
+			cacheStore,
+			queryLoader,
+		),
+		VIN: vin.InitVINRepository(
+			vpicClient,
+			cacheStore,
+			vinCacheTTL,
+		),
+		Attachment: attachment.InitAttachmentRepository(
+			sql0,
+			queryLoader,
+		),
+		Stats: stats.InitStatsRepository(
+			sql0,
+			cacheStore,
+			queryLoader,
+			statsCacheTTL,
+		),
 	}
 }
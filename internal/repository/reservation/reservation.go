@@ -0,0 +1,36 @@
+package reservation
+
+import (
+	"context"
+
+	"go-far/internal/infra/cache"
+	"go-far/internal/infra/query"
+	"go-far/internal/model/dto"
+	"go-far/internal/model/entity"
+	"go-far/internal/repository/queries"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type ReservationRepositoryItf interface {
+	Create(ctx context.Context, carID uuid.UUID, userID string, req dto.CreateReservationRequest) (*entity.Reservation, error)
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.Reservation, error)
+	FindAll(ctx context.Context, filter *dto.ReservationFilter) ([]entity.Reservation, *dto.Pagination, error)
+	Cancel(ctx context.Context, id uuid.UUID) (*entity.Reservation, error)
+	FindAvailableCars(ctx context.Context, filter *dto.AvailabilityFilter) ([]*entity.Car, *dto.Pagination, error)
+}
+
+type reservationRepository struct {
+	sql0       *pgxpool.Pool
+	cacheStore *cache.Store
+	queries    *queries.Queries
+}
+
+func InitReservationRepository(sql0 *pgxpool.Pool, cacheStore *cache.Store, queryLoader *query.QueryLoader) ReservationRepositoryItf {
+	return &reservationRepository{
+		sql0:       sql0,
+		cacheStore: cacheStore,
+		queries:    queries.New(queryLoader),
+	}
+}
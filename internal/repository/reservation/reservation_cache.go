@@ -0,0 +1,22 @@
+package reservation
+
+import (
+	"context"
+
+	"go-far/internal/infra/database"
+	"go-far/internal/repository/car"
+
+	"github.com/rs/zerolog"
+)
+
+// invalidateCar drops the cached views of carID, lists included, since
+// reservations change the is_available they show.
+func (r *reservationRepository) invalidateCar(ctx context.Context, carID string) {
+	tags := []string{car.CacheTagCars, car.CacheTagCar(carID)}
+
+	database.AfterCommit(ctx, func() {
+		if err := r.cacheStore.Invalidate(ctx, tags...); err != nil {
+			zerolog.Ctx(ctx).Warn().Err(err).Strs("tags", tags).Msg("invalidate_reservation_car_cache")
+		}
+	})
+}
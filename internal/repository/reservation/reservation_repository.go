@@ -0,0 +1,44 @@
+package reservation
+
+import (
+	"context"
+
+	"go-far/internal/model/dto"
+	"go-far/internal/model/entity"
+
+	"github.com/google/uuid"
+)
+
+func (r *reservationRepository) Create(ctx context.Context, carID uuid.UUID, userID string, req dto.CreateReservationRequest) (*entity.Reservation, error) {
+	reservation, err := r.createSQLReservation(ctx, carID, userID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	r.invalidateCar(ctx, reservation.CarID)
+
+	return reservation, nil
+}
+
+func (r *reservationRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.Reservation, error) {
+	return r.findSQLReservationByID(ctx, id)
+}
+
+func (r *reservationRepository) FindAll(ctx context.Context, filter *dto.ReservationFilter) ([]entity.Reservation, *dto.Pagination, error) {
+	return r.findAllSQLReservations(ctx, filter)
+}
+
+func (r *reservationRepository) Cancel(ctx context.Context, id uuid.UUID) (*entity.Reservation, error) {
+	reservation, err := r.cancelSQLReservation(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	r.invalidateCar(ctx, reservation.CarID)
+
+	return reservation, nil
+}
+
+func (r *reservationRepository) FindAvailableCars(ctx context.Context, filter *dto.AvailabilityFilter) ([]*entity.Car, *dto.Pagination, error) {
+	return r.findSQLAvailableCars(ctx, filter)
+}
@@ -0,0 +1,143 @@
+package reservation
+
+import (
+	"context"
+	"errors"
+
+	"go-far/internal/infra/database"
+	"go-far/internal/model/dto"
+	"go-far/internal/model/entity"
+	appErr "go-far/internal/model/errors"
+	"go-far/internal/repository/queries"
+	"go-far/internal/util"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/rs/zerolog"
+)
+
+// pgExclusionViolation is raised by reservations_no_overlap.
+const pgExclusionViolation = "23P01"
+
+func (r *reservationRepository) createSQLReservation(ctx context.Context, carID uuid.UUID, userID string, req dto.CreateReservationRequest) (*entity.Reservation, error) {
+	reservation, err := r.queries.CreateReservation(ctx, database.Conn(ctx, r.sql0), queries.CreateReservationParams{
+		CarID:    carID,
+		UserID:   userID,
+		StartsAt: req.StartsAt,
+		EndsAt:   req.EndsAt,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			zerolog.Ctx(ctx).Debug().Str("car_id", carID.String()).Msg("car_not_found_for_reservation")
+			return nil, appErr.WrapWithCode(err, appErr.CodeSQLEmptyRow, "car_not_found_for_reservation")
+		}
+
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgExclusionViolation {
+			zerolog.Ctx(ctx).Debug().Str("car_id", carID.String()).Msg("car_already_reserved")
+			return nil, appErr.WrapWithCode(err, appErr.CodeSQLConflict, "car_already_reserved")
+		}
+
+		zerolog.Ctx(ctx).Error().Err(err).Str("car_id", carID.String()).Msg("create_reservation_err")
+		return nil, appErr.WrapWithCode(err, appErr.CodeSQLCreate, "create_reservation_err")
+	}
+
+	return &reservation, nil
+}
+
+func (r *reservationRepository) findSQLReservationByID(ctx context.Context, id uuid.UUID) (*entity.Reservation, error) {
+	reservation, err := r.queries.FindReservationByID(ctx, database.Conn(ctx, r.sql0), queries.FindReservationByIDParams{ID: id})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			zerolog.Ctx(ctx).Debug().Str("id", id.String()).Msg("reservation_not_found")
+			return nil, appErr.WrapWithCode(err, appErr.CodeSQLEmptyRow, "reservation_not_found")
+		}
+
+		zerolog.Ctx(ctx).Error().Err(err).Str("id", id.String()).Msg("find_reservation_err")
+		return nil, appErr.WrapWithCode(err, appErr.CodeSQLRowScan, "find_reservation_err")
+	}
+
+	return &reservation, nil
+}
+
+func (r *reservationRepository) findAllSQLReservations(ctx context.Context, filter *dto.ReservationFilter) ([]entity.Reservation, *dto.Pagination, error) {
+	filter.Page = util.ValidatePage(filter.Page)
+	filter.PageSize = util.ValidateLimit(filter.PageSize)
+
+	pagination := dto.Pagination{
+		CurrentPage: filter.Page,
+		SortBy:      "starts_at",
+		SortDir:     "ASC",
+	}
+
+	results, err := r.queries.FindReservations(ctx, database.Conn(ctx, r.sql0), filter)
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("find_reservations_err")
+		return nil, &pagination, appErr.WrapWithCode(err, appErr.CodeSQLRowScan, "find_reservations_err")
+	}
+
+	total, err := r.queries.CountReservations(ctx, database.Conn(ctx, r.sql0), filter)
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("count_reservations_err")
+		return nil, &pagination, appErr.WrapWithCode(err, appErr.CodeSQLRowScan, "count_reservations_err")
+	}
+
+	if results == nil {
+		results = []entity.Reservation{}
+	}
+
+	pagination.CurrentElements = int64(len(results))
+	pagination.TotalElements = total
+	pagination.TotalPages = (total + filter.PageSize - 1) / filter.PageSize
+
+	return results, &pagination, nil
+}
+
+func (r *reservationRepository) cancelSQLReservation(ctx context.Context, id uuid.UUID) (*entity.Reservation, error) {
+	reservation, err := r.queries.CancelReservation(ctx, database.Conn(ctx, r.sql0), queries.CancelReservationParams{ID: id})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			zerolog.Ctx(ctx).Debug().Str("id", id.String()).Msg("active_reservation_not_found")
+			return nil, appErr.WrapWithCode(err, appErr.CodeSQLEmptyRow, "active_reservation_not_found")
+		}
+
+		zerolog.Ctx(ctx).Error().Err(err).Str("id", id.String()).Msg("cancel_reservation_err")
+		return nil, appErr.WrapWithCode(err, appErr.CodeSQLUpdate, "cancel_reservation_err")
+	}
+
+	return &reservation, nil
+}
+
+func (r *reservationRepository) findSQLAvailableCars(ctx context.Context, filter *dto.AvailabilityFilter) ([]*entity.Car, *dto.Pagination, error) {
+	filter.Page = util.ValidatePage(filter.Page)
+	filter.PageSize = util.ValidateLimit(filter.PageSize)
+
+	pagination := dto.Pagination{
+		CurrentPage: filter.Page,
+		SortBy:      "created_at",
+		SortDir:     "DESC",
+	}
+
+	cars, err := r.queries.FindAvailableCars(ctx, database.Conn(ctx, r.sql0), filter)
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("find_available_cars_err")
+		return nil, &pagination, appErr.WrapWithCode(err, appErr.CodeSQLRowScan, "find_available_cars_err")
+	}
+
+	total, err := r.queries.CountAvailableCars(ctx, database.Conn(ctx, r.sql0), filter)
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("count_available_cars_err")
+		return nil, &pagination, appErr.WrapWithCode(err, appErr.CodeSQLRowScan, "count_available_cars_err")
+	}
+
+	if cars == nil {
+		cars = []*entity.Car{}
+	}
+
+	pagination.CurrentElements = int64(len(cars))
+	pagination.TotalElements = total
+	pagination.TotalPages = (total + filter.PageSize - 1) / filter.PageSize
+
+	return cars, &pagination, nil
+}
@@ -0,0 +1,98 @@
+package reservation
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"go-far/internal/infra/query"
+	"go-far/internal/model/dto"
+	"go-far/internal/repository/queries"
+
+	"github.com/rs/zerolog"
+)
+
+var windowPattern = regexp.MustCompile(`r\.status = 'active'\s+AND r\.period && tstzrange\(\$([0-9]+), \$([0-9]+), '\[\)'\)`)
+
+// TestAvailabilitySearch renders the availability queries with the
+// configs/queries templates: a car is listed only when no active reservation
+// overlaps the half-open window [from, to), and the brand filter is optional.
+func TestAvailabilitySearch(t *testing.T) {
+	log := zerolog.Nop()
+	loader := query.InitQueryLoader(&log, &query.QueriesOptions{Path: "../../../configs/queries"})
+
+	from := time.Date(2026, 11, 3, 9, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+
+	tests := []struct {
+		name      string
+		brand     string
+		wantBrand bool
+	}{
+		{name: "window only"},
+		{name: "with brand", brand: "VW", wantBrand: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter := &dto.AvailabilityFilter{From: from, To: to, Brand: tt.brand, Page: 1, PageSize: 10}
+
+			for _, name := range []string{queries.QueryFindAvailableCars, queries.QueryCountAvailableCars} {
+				sql, args, err := loader.Compile(name, filter)
+				if err != nil {
+					t.Fatalf("compile %s: %v", name, err)
+				}
+
+				if !strings.Contains(sql, "NOT EXISTS") || !strings.Contains(sql, "c.deleted_at IS NULL") {
+					t.Errorf("%s does not exclude reserved or deleted cars:\n%s", name, sql)
+				}
+
+				m := windowPattern.FindStringSubmatch(sql)
+				if m == nil {
+					t.Fatalf("%s does not match active reservations against the window:\n%s", name, sql)
+				}
+				if arg(args, m[1]) != from || arg(args, m[2]) != to {
+					t.Errorf("%s binds the window to %v and %v, want %v and %v", name, arg(args, m[1]), arg(args, m[2]), from, to)
+				}
+
+				if got := strings.Contains(sql, "c.brand ILIKE"); got != tt.wantBrand {
+					t.Errorf("%s filters by brand = %v, want %v", name, got, tt.wantBrand)
+				}
+			}
+		})
+	}
+}
+
+// TestReservationListsHideCancelled checks that cancelled reservations are
+// listed only with include_cancelled=true.
+func TestReservationListsHideCancelled(t *testing.T) {
+	log := zerolog.Nop()
+	loader := query.InitQueryLoader(&log, &query.QueriesOptions{Path: "../../../configs/queries"})
+
+	for _, includeCancelled := range []bool{false, true} {
+		filter := &dto.ReservationFilter{CarID: "0190a3b4-0000-7000-8000-0000000000c1", IncludeCancelled: includeCancelled, Page: 1, PageSize: 10}
+
+		for _, name := range []string{queries.QueryFindReservations, queries.QueryCountReservations} {
+			sql, _, err := loader.Compile(name, filter)
+			if err != nil {
+				t.Fatalf("compile %s: %v", name, err)
+			}
+
+			if got := strings.Contains(sql, "status = 'active'"); got == includeCancelled {
+				t.Errorf("%s with include_cancelled=%v filters on active = %v", name, includeCancelled, got)
+			}
+		}
+	}
+}
+
+// arg returns the value bound to placeholder $n, or nil when there is none.
+func arg(args []any, n string) any {
+	i, err := strconv.Atoi(n)
+	if err != nil || i < 1 || i > len(args) {
+		return nil
+	}
+
+	return args[i-1]
+}
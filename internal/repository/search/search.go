@@ -0,0 +1,28 @@
+package search
+
+import (
+	"context"
+
+	"go-far/internal/infra/query"
+	"go-far/internal/model/dto"
+	"go-far/internal/model/entity"
+	"go-far/internal/repository/queries"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type SearchRepositoryItf interface {
+	Search(ctx context.Context, filter *dto.SearchFilter) ([]entity.SearchResult, *dto.Pagination, error)
+}
+
+type searchRepository struct {
+	sql0    *pgxpool.Pool
+	queries *queries.Queries
+}
+
+func InitSearchRepository(sql0 *pgxpool.Pool, queryLoader *query.QueryLoader) SearchRepositoryItf {
+	return &searchRepository{
+		sql0:    sql0,
+		queries: queries.New(queryLoader),
+	}
+}
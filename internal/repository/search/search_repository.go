@@ -0,0 +1,18 @@
+package search
+
+import (
+	"context"
+
+	"go-far/internal/model/dto"
+	"go-far/internal/model/entity"
+	appErr "go-far/internal/model/errors"
+)
+
+func (r *searchRepository) Search(ctx context.Context, filter *dto.SearchFilter) ([]entity.SearchResult, *dto.Pagination, error) {
+	results, pagination, err := r.searchSQL(ctx, filter)
+	if err != nil {
+		return nil, pagination, appErr.Wrap(err, "search_sql")
+	}
+
+	return results, pagination, nil
+}
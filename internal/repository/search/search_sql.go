@@ -0,0 +1,57 @@
+package search
+
+import (
+	"context"
+
+	"go-far/internal/infra/database"
+	"go-far/internal/model/dto"
+	"go-far/internal/model/entity"
+	appErr "go-far/internal/model/errors"
+	"go-far/internal/repository/queries"
+	"go-far/internal/util"
+
+	"github.com/rs/zerolog"
+)
+
+func (r *searchRepository) searchSQL(ctx context.Context, filter *dto.SearchFilter) ([]entity.SearchResult, *dto.Pagination, error) {
+	filter.Page = util.ValidatePage(filter.Page)
+	filter.PageSize = util.ValidateLimit(filter.PageSize)
+
+	pagination := dto.Pagination{
+		CurrentPage: filter.Page,
+		SortBy:      "rank",
+		SortDir:     "DESC",
+	}
+
+	results, err := r.queries.SearchAll(ctx, database.Conn(ctx, r.sql0), queries.SearchAllParams{
+		Query:   filter.Q,
+		UserID:  filter.UserID,
+		IsAdmin: filter.IsAdmin,
+		Limit:   filter.PageSize,
+		Offset:  (filter.Page - 1) * filter.PageSize,
+	})
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Str("q", filter.Q).Msg("search_err")
+		return nil, &pagination, appErr.WrapWithCode(err, appErr.CodeSQLRead, "search_err")
+	}
+
+	total, err := r.queries.CountSearchAll(ctx, database.Conn(ctx, r.sql0), queries.CountSearchAllParams{
+		Query:   filter.Q,
+		UserID:  filter.UserID,
+		IsAdmin: filter.IsAdmin,
+	})
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Str("q", filter.Q).Msg("count_search_err")
+		return nil, &pagination, appErr.WrapWithCode(err, appErr.CodeSQLRowScan, "count_search_err")
+	}
+
+	if results == nil {
+		results = []entity.SearchResult{}
+	}
+
+	pagination.CurrentElements = int64(len(results))
+	pagination.TotalElements = total
+	pagination.TotalPages = (total + filter.PageSize - 1) / filter.PageSize
+
+	return results, &pagination, nil
+}
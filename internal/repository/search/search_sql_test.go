@@ -0,0 +1,83 @@
+package search
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"go-far/internal/infra/query"
+	"go-far/internal/repository/queries"
+
+	"github.com/rs/zerolog"
+)
+
+var userJoinPattern = regexp.MustCompile(`uc\.user_id = \$([0-9]+)`)
+
+// TestSearchVisibility renders the search queries with the configs/queries
+// templates: admins search users and every car, other users only the cars
+// linked to them in users_cars.
+func TestSearchVisibility(t *testing.T) {
+	log := zerolog.Nop()
+	loader := query.InitQueryLoader(&log, &query.QueriesOptions{Path: "../../../configs/queries"})
+
+	const userID = "0190a3b4-0000-7000-8000-000000000001"
+
+	tests := []struct {
+		name    string
+		isAdmin bool
+	}{
+		{name: "admin", isAdmin: true},
+		{name: "user", isAdmin: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			searchSQL, searchArgs, err := loader.Compile(queries.QuerySearchAll, queries.SearchAllParams{
+				Query: "golf", UserID: userID, IsAdmin: tt.isAdmin, Limit: 10,
+			})
+			if err != nil {
+				t.Fatalf("compile SearchAll: %v", err)
+			}
+
+			countSQL, countArgs, err := loader.Compile(queries.QueryCountSearchAll, queries.CountSearchAllParams{
+				Query: "golf", UserID: userID, IsAdmin: tt.isAdmin,
+			})
+			if err != nil {
+				t.Fatalf("compile CountSearchAll: %v", err)
+			}
+
+			checkVisibility(t, "SearchAll", searchSQL, searchArgs, "FROM users u", tt.isAdmin, userID)
+			checkVisibility(t, "CountSearchAll", countSQL, countArgs, "FROM users WHERE", tt.isAdmin, userID)
+		})
+	}
+}
+
+func checkVisibility(t *testing.T, name, sql string, args []any, usersBranch string, isAdmin bool, userID string) {
+	t.Helper()
+
+	if got := strings.Contains(sql, usersBranch); got != isAdmin {
+		t.Errorf("%s searches users = %v, want %v", name, got, isAdmin)
+	}
+
+	m := userJoinPattern.FindStringSubmatch(sql)
+	if isAdmin {
+		if m != nil {
+			t.Errorf("%s restricts admins to their own cars", name)
+		}
+		for _, a := range args {
+			if a == userID {
+				t.Errorf("%s binds the user ID for admins", name)
+			}
+		}
+		return
+	}
+
+	if m == nil {
+		t.Fatalf("%s does not join users_cars for non-admins:\n%s", name, sql)
+	}
+
+	if n, _ := strconv.Atoi(m[1]); n > len(args) || args[n-1] != userID {
+		t.Errorf("%s does not bind users_cars.user_id to the caller: %s, args %v", name, m[0], args)
+	}
+}
@@ -0,0 +1,39 @@
+package servicerecord
+
+import (
+	"context"
+	"time"
+
+	"go-far/internal/infra/cache"
+	"go-far/internal/infra/query"
+	"go-far/internal/model/dto"
+	"go-far/internal/model/entity"
+	"go-far/internal/repository/queries"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type ServiceRecordRepositoryItf interface {
+	Create(ctx context.Context, carID uuid.UUID, userID string, req dto.ServiceRecordRequest) (*entity.ServiceRecord, error)
+	FindByID(ctx context.Context, carID, id uuid.UUID) (*entity.ServiceRecord, error)
+	FindAll(ctx context.Context, filter *dto.ServiceRecordFilter) ([]entity.ServiceRecord, *dto.Pagination, error)
+	Update(ctx context.Context, carID, id uuid.UUID, req dto.ServiceRecordRequest) (*entity.ServiceRecord, error)
+	Delete(ctx context.Context, carID, id uuid.UUID) error
+	FlagOverdueCars(ctx context.Context, cutoff time.Time, limit int) (int64, error)
+	UnflagServicedCars(ctx context.Context, cutoff time.Time) (int64, error)
+}
+
+type serviceRecordRepository struct {
+	sql0       *pgxpool.Pool
+	cacheStore *cache.Store
+	queries    *queries.Queries
+}
+
+func InitServiceRecordRepository(sql0 *pgxpool.Pool, cacheStore *cache.Store, queryLoader *query.QueryLoader) ServiceRecordRepositoryItf {
+	return &serviceRecordRepository{
+		sql0:       sql0,
+		cacheStore: cacheStore,
+		queries:    queries.New(queryLoader),
+	}
+}
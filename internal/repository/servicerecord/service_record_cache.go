@@ -0,0 +1,42 @@
+package servicerecord
+
+import (
+	"context"
+
+	"go-far/internal/infra/database"
+	"go-far/internal/repository/car"
+
+	"github.com/rs/zerolog"
+)
+
+// invalidateCar drops the cached lookups of carID, which carry its service
+// summary.
+func (r *serviceRecordRepository) invalidateCar(ctx context.Context, carID string) {
+	r.invalidate(ctx, car.CacheTagCar(carID))
+}
+
+// invalidateFlagged drops the cached views of cars whose overdue flag changed,
+// lists included since flagging bumps the car's updated_at.
+func (r *serviceRecordRepository) invalidateFlagged(ctx context.Context, carIDs []string) {
+	if len(carIDs) == 0 {
+		return
+	}
+
+	tags := make([]string, 0, len(carIDs)+1)
+	tags = append(tags, car.CacheTagCars)
+	for _, id := range carIDs {
+		tags = append(tags, car.CacheTagCar(id))
+	}
+
+	r.invalidate(ctx, tags...)
+}
+
+// invalidate bumps tags once the surrounding transaction (if any) commits.
+// Service records themselves are not cached.
+func (r *serviceRecordRepository) invalidate(ctx context.Context, tags ...string) {
+	database.AfterCommit(ctx, func() {
+		if err := r.cacheStore.Invalidate(ctx, tags...); err != nil {
+			zerolog.Ctx(ctx).Warn().Err(err).Strs("tags", tags).Msg("invalidate_service_record_car_cache")
+		}
+	})
+}
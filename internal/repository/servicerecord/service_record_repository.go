@@ -0,0 +1,77 @@
+package servicerecord
+
+import (
+	"context"
+	"time"
+
+	"go-far/internal/model/dto"
+	"go-far/internal/model/entity"
+
+	"github.com/google/uuid"
+)
+
+func (r *serviceRecordRepository) Create(ctx context.Context, carID uuid.UUID, userID string, req dto.ServiceRecordRequest) (*entity.ServiceRecord, error) {
+	record, err := r.createSQLServiceRecord(ctx, carID, userID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	r.invalidateCar(ctx, record.CarID)
+
+	return record, nil
+}
+
+func (r *serviceRecordRepository) FindByID(ctx context.Context, carID, id uuid.UUID) (*entity.ServiceRecord, error) {
+	return r.findSQLServiceRecordByID(ctx, carID, id)
+}
+
+func (r *serviceRecordRepository) FindAll(ctx context.Context, filter *dto.ServiceRecordFilter) ([]entity.ServiceRecord, *dto.Pagination, error) {
+	return r.findAllSQLServiceRecords(ctx, filter)
+}
+
+func (r *serviceRecordRepository) Update(ctx context.Context, carID, id uuid.UUID, req dto.ServiceRecordRequest) (*entity.ServiceRecord, error) {
+	record, err := r.updateSQLServiceRecord(ctx, carID, id, req)
+	if err != nil {
+		return nil, err
+	}
+
+	r.invalidateCar(ctx, record.CarID)
+
+	return record, nil
+}
+
+func (r *serviceRecordRepository) Delete(ctx context.Context, carID, id uuid.UUID) error {
+	if err := r.deleteSQLServiceRecord(ctx, carID, id); err != nil {
+		return err
+	}
+
+	r.invalidateCar(ctx, carID.String())
+
+	return nil
+}
+
+// FlagOverdueCars flags at most limit cars last serviced before cutoff and
+// returns how many were flagged.
+func (r *serviceRecordRepository) FlagOverdueCars(ctx context.Context, cutoff time.Time, limit int) (int64, error) {
+	carIDs, err := r.flagSQLOverdueCars(ctx, cutoff, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	r.invalidateFlagged(ctx, carIDs)
+
+	return int64(len(carIDs)), nil
+}
+
+// UnflagServicedCars clears the flag of cars serviced on or after cutoff and
+// returns how many were cleared.
+func (r *serviceRecordRepository) UnflagServicedCars(ctx context.Context, cutoff time.Time) (int64, error) {
+	carIDs, err := r.unflagSQLServicedCars(ctx, cutoff)
+	if err != nil {
+		return 0, err
+	}
+
+	r.invalidateFlagged(ctx, carIDs)
+
+	return int64(len(carIDs)), nil
+}
@@ -0,0 +1,156 @@
+package servicerecord
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go-far/internal/infra/database"
+	"go-far/internal/model/dto"
+	"go-far/internal/model/entity"
+	appErr "go-far/internal/model/errors"
+	"go-far/internal/repository/queries"
+	"go-far/internal/util"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/rs/zerolog"
+)
+
+func (r *serviceRecordRepository) createSQLServiceRecord(ctx context.Context, carID uuid.UUID, userID string, req dto.ServiceRecordRequest) (*entity.ServiceRecord, error) {
+	record, err := r.queries.CreateServiceRecord(ctx, database.Conn(ctx, r.sql0), queries.CreateServiceRecordParams{
+		CarID:       carID,
+		CreatedBy:   userID,
+		ServiceDate: req.ServiceDate,
+		Odometer:    req.Odometer,
+		ServiceType: req.ServiceType,
+		Cost:        req.Cost,
+		Notes:       req.Notes,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			zerolog.Ctx(ctx).Debug().Str("car_id", carID.String()).Msg("car_not_found_for_service_record")
+			return nil, appErr.WrapWithCode(err, appErr.CodeSQLEmptyRow, "car_not_found_for_service_record")
+		}
+
+		zerolog.Ctx(ctx).Error().Err(err).Str("car_id", carID.String()).Msg("create_service_record_err")
+		return nil, appErr.WrapWithCode(err, appErr.CodeSQLCreate, "create_service_record_err")
+	}
+
+	return &record, nil
+}
+
+func (r *serviceRecordRepository) findSQLServiceRecordByID(ctx context.Context, carID, id uuid.UUID) (*entity.ServiceRecord, error) {
+	record, err := r.queries.FindServiceRecordByID(ctx, database.Conn(ctx, r.sql0), queries.FindServiceRecordByIDParams{CarID: carID, ID: id})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			zerolog.Ctx(ctx).Debug().Str("id", id.String()).Msg("service_record_not_found")
+			return nil, appErr.WrapWithCode(err, appErr.CodeSQLEmptyRow, "service_record_not_found")
+		}
+
+		zerolog.Ctx(ctx).Error().Err(err).Str("id", id.String()).Msg("find_service_record_err")
+		return nil, appErr.WrapWithCode(err, appErr.CodeSQLRowScan, "find_service_record_err")
+	}
+
+	return &record, nil
+}
+
+func (r *serviceRecordRepository) findAllSQLServiceRecords(ctx context.Context, filter *dto.ServiceRecordFilter) ([]entity.ServiceRecord, *dto.Pagination, error) {
+	filter.Page = util.ValidatePage(filter.Page)
+	filter.PageSize = util.ValidateLimit(filter.PageSize)
+
+	pagination := dto.Pagination{
+		CurrentPage: filter.Page,
+		SortBy:      "service_date",
+		SortDir:     "DESC",
+	}
+
+	results, err := r.queries.FindServiceRecords(ctx, database.Conn(ctx, r.sql0), filter)
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("find_service_records_err")
+		return nil, &pagination, appErr.WrapWithCode(err, appErr.CodeSQLRowScan, "find_service_records_err")
+	}
+
+	total, err := r.queries.CountServiceRecords(ctx, database.Conn(ctx, r.sql0), filter)
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("count_service_records_err")
+		return nil, &pagination, appErr.WrapWithCode(err, appErr.CodeSQLRowScan, "count_service_records_err")
+	}
+
+	if results == nil {
+		results = []entity.ServiceRecord{}
+	}
+
+	pagination.CurrentElements = int64(len(results))
+	pagination.TotalElements = total
+	pagination.TotalPages = (total + filter.PageSize - 1) / filter.PageSize
+
+	return results, &pagination, nil
+}
+
+func (r *serviceRecordRepository) updateSQLServiceRecord(ctx context.Context, carID, id uuid.UUID, req dto.ServiceRecordRequest) (*entity.ServiceRecord, error) {
+	record, err := r.queries.UpdateServiceRecord(ctx, database.Conn(ctx, r.sql0), queries.UpdateServiceRecordParams{
+		CarID:       carID,
+		ID:          id,
+		ServiceDate: req.ServiceDate,
+		Odometer:    req.Odometer,
+		ServiceType: req.ServiceType,
+		Cost:        req.Cost,
+		Notes:       req.Notes,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			zerolog.Ctx(ctx).Debug().Str("id", id.String()).Msg("service_record_not_found")
+			return nil, appErr.WrapWithCode(err, appErr.CodeSQLEmptyRow, "service_record_not_found")
+		}
+
+		zerolog.Ctx(ctx).Error().Err(err).Str("id", id.String()).Msg("update_service_record_err")
+		return nil, appErr.WrapWithCode(err, appErr.CodeSQLUpdate, "update_service_record_err")
+	}
+
+	return &record, nil
+}
+
+func (r *serviceRecordRepository) deleteSQLServiceRecord(ctx context.Context, carID, id uuid.UUID) error {
+	rows, err := r.queries.DeleteServiceRecord(ctx, database.Conn(ctx, r.sql0), queries.DeleteServiceRecordParams{CarID: carID, ID: id})
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Str("id", id.String()).Msg("delete_service_record_err")
+		return appErr.WrapWithCode(err, appErr.CodeSQLDelete, "delete_service_record_err")
+	}
+
+	if rows == 0 {
+		zerolog.Ctx(ctx).Debug().Str("id", id.String()).Msg("service_record_not_found")
+		return appErr.NewWithCode(appErr.CodeSQLEmptyRow, "service_record_not_found")
+	}
+
+	return nil
+}
+
+func (r *serviceRecordRepository) flagSQLOverdueCars(ctx context.Context, cutoff time.Time, limit int) ([]string, error) {
+	ids, err := r.queries.FlagServiceOverdueCars(ctx, database.Conn(ctx, r.sql0), queries.FlagServiceOverdueCarsParams{Cutoff: cutoff, Limit: limit})
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("flag_service_overdue_cars_err")
+		return nil, appErr.WrapWithCode(err, appErr.CodeSQLUpdate, "flag_service_overdue_cars_err")
+	}
+
+	return uuidStrings(ids), nil
+}
+
+func (r *serviceRecordRepository) unflagSQLServicedCars(ctx context.Context, cutoff time.Time) ([]string, error) {
+	ids, err := r.queries.UnflagServicedCars(ctx, database.Conn(ctx, r.sql0), queries.UnflagServicedCarsParams{Cutoff: cutoff})
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("unflag_serviced_cars_err")
+		return nil, appErr.WrapWithCode(err, appErr.CodeSQLUpdate, "unflag_serviced_cars_err")
+	}
+
+	return uuidStrings(ids), nil
+}
+
+func uuidStrings(ids []uuid.UUID) []string {
+	out := make([]string, len(ids))
+	for i, id := range ids {
+		out[i] = id.String()
+	}
+
+	return out
+}
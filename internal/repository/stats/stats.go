@@ -0,0 +1,65 @@
+package stats
+
+import (
+	"context"
+	"time"
+
+	"go-far/internal/infra/cache"
+	"go-far/internal/infra/query"
+	"go-far/internal/model/dto"
+	"go-far/internal/model/entity"
+	"go-far/internal/repository/queries"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	cacheKeyBrands        = "stats:brands:"
+	cacheKeyYears         = "stats:years:"
+	cacheKeyAvailability  = "stats:availability:"
+	cacheKeyCarsPerUser   = "stats:cars_per_user:"
+	cacheKeyRegistrations = "stats:registrations:"
+	cacheKeyLogins        = "stats:logins:"
+)
+
+type StatsRepositoryItf interface {
+	CarsByBrand(ctx context.Context, cacheControl dto.CacheControl, filter *dto.StatsFilter) ([]entity.BrandCount, error)
+	CarsByYear(ctx context.Context, cacheControl dto.CacheControl, filter *dto.StatsFilter) ([]entity.YearCount, error)
+	CarAvailability(ctx context.Context, cacheControl dto.CacheControl, filter *dto.StatsFilter) (*entity.FleetAvailability, error)
+	CarsPerUser(ctx context.Context, cacheControl dto.CacheControl, filter *dto.StatsFilter) ([]entity.CarsPerUser, error)
+	RegistrationTrend(ctx context.Context, cacheControl dto.CacheControl, filter *dto.StatsFilter) ([]entity.TrendPoint, error)
+	LoginTrend(ctx context.Context, cacheControl dto.CacheControl, filter *dto.StatsFilter) ([]entity.LoginTrendPoint, error)
+}
+
+type statsRepository struct {
+	sql0               *pgxpool.Pool
+	queries            *queries.Queries
+	brandLoader        *cache.Loader[[]entity.BrandCount]
+	yearLoader         *cache.Loader[[]entity.YearCount]
+	availabilityLoader *cache.Loader[*entity.FleetAvailability]
+	carsPerUserLoader  *cache.Loader[[]entity.CarsPerUser]
+	registrationLoader *cache.Loader[[]entity.TrendPoint]
+	loginLoader        *cache.Loader[[]entity.LoginTrendPoint]
+}
+
+// InitStatsRepository builds the statistics repository. The aggregates span
+// the whole fleet, so no write invalidates them: entries are untagged and
+// cacheTTL alone bounds how stale they get, which is why it is kept short.
+func InitStatsRepository(sql0 *pgxpool.Pool, cacheStore *cache.Store, queryLoader *query.QueryLoader, cacheTTL time.Duration) StatsRepositoryItf {
+	opts := cache.LoaderOptions{
+		TTL:    cacheTTL,
+		Jitter: cache.DefaultJitter,
+		Codec:  cache.MsgpackCodec,
+	}
+
+	return &statsRepository{
+		sql0:               sql0,
+		queries:            queries.New(queryLoader),
+		brandLoader:        cache.NewLoader[[]entity.BrandCount](cacheStore, opts),
+		yearLoader:         cache.NewLoader[[]entity.YearCount](cacheStore, opts),
+		availabilityLoader: cache.NewLoader[*entity.FleetAvailability](cacheStore, opts),
+		carsPerUserLoader:  cache.NewLoader[[]entity.CarsPerUser](cacheStore, opts),
+		registrationLoader: cache.NewLoader[[]entity.TrendPoint](cacheStore, opts),
+		loginLoader:        cache.NewLoader[[]entity.LoginTrendPoint](cacheStore, opts),
+	}
+}
@@ -0,0 +1,58 @@
+package stats
+
+import (
+	"context"
+	"time"
+
+	"go-far/internal/model/dto"
+	"go-far/internal/model/entity"
+)
+
+func (r *statsRepository) CarsByBrand(ctx context.Context, cacheControl dto.CacheControl, filter *dto.StatsFilter) ([]entity.BrandCount, error) {
+	return r.brandLoader.Get(ctx, cacheControl, cacheKeyBrands+cacheKey(filter), nil, func(ctx context.Context) ([]entity.BrandCount, error) {
+		return r.findSQLCarsByBrand(ctx, filter)
+	})
+}
+
+func (r *statsRepository) CarsByYear(ctx context.Context, cacheControl dto.CacheControl, filter *dto.StatsFilter) ([]entity.YearCount, error) {
+	return r.yearLoader.Get(ctx, cacheControl, cacheKeyYears+cacheKey(filter), nil, func(ctx context.Context) ([]entity.YearCount, error) {
+		return r.findSQLCarsByYear(ctx, filter)
+	})
+}
+
+func (r *statsRepository) CarAvailability(ctx context.Context, cacheControl dto.CacheControl, filter *dto.StatsFilter) (*entity.FleetAvailability, error) {
+	return r.availabilityLoader.Get(ctx, cacheControl, cacheKeyAvailability+cacheKey(filter), nil, func(ctx context.Context) (*entity.FleetAvailability, error) {
+		return r.findSQLCarAvailability(ctx, filter)
+	})
+}
+
+func (r *statsRepository) CarsPerUser(ctx context.Context, cacheControl dto.CacheControl, filter *dto.StatsFilter) ([]entity.CarsPerUser, error) {
+	return r.carsPerUserLoader.Get(ctx, cacheControl, cacheKeyCarsPerUser+cacheKey(filter), nil, func(ctx context.Context) ([]entity.CarsPerUser, error) {
+		return r.findSQLCarsPerUser(ctx, filter)
+	})
+}
+
+func (r *statsRepository) RegistrationTrend(ctx context.Context, cacheControl dto.CacheControl, filter *dto.StatsFilter) ([]entity.TrendPoint, error) {
+	return r.registrationLoader.Get(ctx, cacheControl, cacheKeyRegistrations+cacheKey(filter), nil, func(ctx context.Context) ([]entity.TrendPoint, error) {
+		return r.findSQLRegistrationTrend(ctx, filter)
+	})
+}
+
+func (r *statsRepository) LoginTrend(ctx context.Context, cacheControl dto.CacheControl, filter *dto.StatsFilter) ([]entity.LoginTrendPoint, error) {
+	return r.loginLoader.Get(ctx, cacheControl, cacheKeyLogins+cacheKey(filter), nil, func(ctx context.Context) ([]entity.LoginTrendPoint, error) {
+		return r.findSQLLoginTrend(ctx, filter)
+	})
+}
+
+// cacheKey identifies a filter; an open bound is left empty.
+func cacheKey(filter *dto.StatsFilter) string {
+	var from, to string
+	if filter.HasFrom() {
+		from = filter.From.UTC().Format(time.RFC3339)
+	}
+	if filter.HasTo() {
+		to = filter.To.UTC().Format(time.RFC3339)
+	}
+
+	return from + "|" + to + "|" + filter.Interval
+}
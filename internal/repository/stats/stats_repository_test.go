@@ -0,0 +1,173 @@
+package stats
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"go-far/internal/infra/cache"
+	"go-far/internal/infra/query"
+	"go-far/internal/model/dto"
+	"go-far/internal/model/entity"
+	appErr "go-far/internal/model/errors"
+	"go-far/internal/repository/queries"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog"
+)
+
+// newTestRepository builds the repository over an L1-only cache and
+// unreachable Redis and Postgres: a read served from the database fails,
+// so a successful one came from the cache.
+func newTestRepository(t *testing.T) *statsRepository {
+	t.Helper()
+
+	redis0 := redis.NewClient(&redis.Options{Addr: "127.0.0.1:1", MaxRetries: -1, DialTimeout: 100 * time.Millisecond})
+	t.Cleanup(func() { _ = redis0.Close() })
+
+	pool, err := pgxpool.New(context.Background(), "postgres://stats@127.0.0.1:1/stats?connect_timeout=1")
+	if err != nil {
+		t.Fatalf("pool: %v", err)
+	}
+	t.Cleanup(pool.Close)
+
+	log := zerolog.Nop()
+	store := cache.InitStore(redis0, &cache.LocalOptions{Enabled: true, MaxEntries: 16, TTL: time.Minute})
+	loader := query.InitQueryLoader(&log, &query.QueriesOptions{Path: "../../../configs/queries"})
+
+	repo, _ := InitStatsRepository(pool, store, loader, time.Minute).(*statsRepository)
+
+	return repo
+}
+
+// TestStatsServedFromCache seeds the entry CarsByBrand reads and checks that
+// equal windows share it while no-store goes to the database.
+func TestStatsServedFromCache(t *testing.T) {
+	repo := newTestRepository(t)
+	ctx := context.Background()
+
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 6, 30, 0, 0, 0, 0, time.UTC)
+	seeded := []entity.BrandCount{{Brand: "VW", Count: 3}, {Brand: "Audi", Count: 1}}
+
+	_, err := repo.brandLoader.Get(ctx, dto.CacheControl{}, cacheKeyBrands+cacheKey(&dto.StatsFilter{From: from, To: to}), nil, func(context.Context) ([]entity.BrandCount, error) {
+		return seeded, nil
+	})
+	if err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+
+	jakarta := time.FixedZone("WIB", 7*60*60)
+	tests := []struct {
+		name    string
+		control dto.CacheControl
+		filter  dto.StatsFilter
+		wantHit bool
+	}{
+		{name: "same window", filter: dto.StatsFilter{From: from, To: to}, wantHit: true},
+		{name: "same instants in another zone", filter: dto.StatsFilter{From: from.In(jakarta), To: to.In(jakarta)}, wantHit: true},
+		{name: "other window", filter: dto.StatsFilter{From: from, To: to.AddDate(0, 0, 1)}},
+		{name: "open end", filter: dto.StatsFilter{From: from}},
+		{name: "no-store", control: dto.CacheControl{MustDbValidate: true}, filter: dto.StatsFilter{From: from, To: to}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := &dto.CacheResult{}
+			tt.control.Result = result
+
+			counts, err := repo.CarsByBrand(ctx, tt.control, &tt.filter)
+			status, _ := result.Outcome()
+
+			if !tt.wantHit {
+				if appErr.ErrCode(err) != appErr.CodeSQLRead || status == dto.CacheHit {
+					t.Errorf("err = %v, cache %q, want a database read", err, status)
+				}
+				return
+			}
+
+			if err != nil || status != dto.CacheHit {
+				t.Fatalf("err = %v, cache %q, want a hit", err, status)
+			}
+
+			if !reflect.DeepEqual(counts, seeded) {
+				t.Errorf("counts = %+v, want %+v", counts, seeded)
+			}
+		})
+	}
+}
+
+// TestStatsQueriesApplyWindow renders the fleet aggregates: soft-deleted
+// cars never count and each set bound narrows the cars by creation.
+func TestStatsQueriesApplyWindow(t *testing.T) {
+	log := zerolog.Nop()
+	loader := query.InitQueryLoader(&log, &query.QueriesOptions{Path: "../../../configs/queries"})
+
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 6, 30, 0, 0, 0, 0, time.UTC)
+
+	filters := []dto.StatsFilter{{}, {From: from}, {To: to}, {From: from, To: to}}
+	names := []string{queries.QueryStatsCarsByBrand, queries.QueryStatsCarsByYear, queries.QueryStatsCarAvailability, queries.QueryStatsCarsPerUser}
+
+	for _, filter := range filters {
+		for _, name := range names {
+			sql, args, err := loader.Compile(name, &filter)
+			if err != nil {
+				t.Fatalf("compile %s: %v", name, err)
+			}
+
+			if !strings.Contains(sql, "deleted_at IS NULL") {
+				t.Errorf("%s counts deleted cars:\n%s", name, sql)
+			}
+
+			if got := strings.Contains(sql, "created_at >="); got != filter.HasFrom() {
+				t.Errorf("%s with from %v filters on it = %v", name, filter.From, got)
+			}
+			if got := strings.Contains(sql, "created_at <="); got != filter.HasTo() {
+				t.Errorf("%s with to %v filters on it = %v", name, filter.To, got)
+			}
+
+			wantArgs := 0
+			if filter.HasFrom() {
+				wantArgs++
+			}
+			if filter.HasTo() {
+				wantArgs++
+			}
+			if len(args) != wantArgs {
+				t.Errorf("%s binds %v, want %d args", name, args, wantArgs)
+			}
+		}
+	}
+
+	sql, _, err := loader.Compile(queries.QueryStatsCarsPerUser, &dto.StatsFilter{})
+	if err != nil {
+		t.Fatalf("compile %s: %v", queries.QueryStatsCarsPerUser, err)
+	}
+	if !strings.Contains(sql, "LEFT JOIN users_cars uc ON uc.user_id = u.id AND uc.role = 'owner'") {
+		t.Errorf("%s does not count owned cars of every user:\n%s", queries.QueryStatsCarsPerUser, sql)
+	}
+}
+
+func TestCacheKey(t *testing.T) {
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 6, 30, 0, 0, 0, 0, time.UTC)
+
+	keys := map[string]dto.StatsFilter{}
+	for _, filter := range []dto.StatsFilter{
+		{},
+		{From: from},
+		{To: from},
+		{From: from, To: to},
+		{From: from, To: to, Interval: "week"},
+	} {
+		key := cacheKey(&filter)
+		if other, ok := keys[key]; ok {
+			t.Errorf("%+v and %+v share the cache key %q", filter, other, key)
+		}
+		keys[key] = filter
+	}
+}
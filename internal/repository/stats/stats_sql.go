@@ -0,0 +1,72 @@
+package stats
+
+import (
+	"context"
+
+	"go-far/internal/infra/database"
+	"go-far/internal/model/dto"
+	"go-far/internal/model/entity"
+	appErr "go-far/internal/model/errors"
+
+	"github.com/rs/zerolog"
+)
+
+func (r *statsRepository) findSQLCarsByBrand(ctx context.Context, filter *dto.StatsFilter) ([]entity.BrandCount, error) {
+	counts, err := r.queries.StatsCarsByBrand(ctx, database.Conn(ctx, r.sql0), filter)
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("stats_cars_by_brand_err")
+		return nil, appErr.WrapWithCode(err, appErr.CodeSQLRead, "stats_cars_by_brand_err")
+	}
+
+	return counts, nil
+}
+
+func (r *statsRepository) findSQLCarsByYear(ctx context.Context, filter *dto.StatsFilter) ([]entity.YearCount, error) {
+	counts, err := r.queries.StatsCarsByYear(ctx, database.Conn(ctx, r.sql0), filter)
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("stats_cars_by_year_err")
+		return nil, appErr.WrapWithCode(err, appErr.CodeSQLRead, "stats_cars_by_year_err")
+	}
+
+	return counts, nil
+}
+
+func (r *statsRepository) findSQLCarAvailability(ctx context.Context, filter *dto.StatsFilter) (*entity.FleetAvailability, error) {
+	availability, err := r.queries.StatsCarAvailability(ctx, database.Conn(ctx, r.sql0), filter)
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("stats_car_availability_err")
+		return nil, appErr.WrapWithCode(err, appErr.CodeSQLRead, "stats_car_availability_err")
+	}
+
+	return &availability, nil
+}
+
+func (r *statsRepository) findSQLCarsPerUser(ctx context.Context, filter *dto.StatsFilter) ([]entity.CarsPerUser, error) {
+	buckets, err := r.queries.StatsCarsPerUser(ctx, database.Conn(ctx, r.sql0), filter)
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("stats_cars_per_user_err")
+		return nil, appErr.WrapWithCode(err, appErr.CodeSQLRead, "stats_cars_per_user_err")
+	}
+
+	return buckets, nil
+}
+
+func (r *statsRepository) findSQLRegistrationTrend(ctx context.Context, filter *dto.StatsFilter) ([]entity.TrendPoint, error) {
+	points, err := r.queries.StatsRegistrationTrend(ctx, database.Conn(ctx, r.sql0), filter)
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Str("interval", filter.Interval).Msg("stats_registration_trend_err")
+		return nil, appErr.WrapWithCode(err, appErr.CodeSQLRead, "stats_registration_trend_err")
+	}
+
+	return points, nil
+}
+
+func (r *statsRepository) findSQLLoginTrend(ctx context.Context, filter *dto.StatsFilter) ([]entity.LoginTrendPoint, error) {
+	points, err := r.queries.StatsLoginTrend(ctx, database.Conn(ctx, r.sql0), filter)
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Str("interval", filter.Interval).Msg("stats_login_trend_err")
+		return nil, appErr.WrapWithCode(err, appErr.CodeSQLRead, "stats_login_trend_err")
+	}
+
+	return points, nil
+}
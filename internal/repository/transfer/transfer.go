@@ -0,0 +1,35 @@
+package transfer
+
+import (
+	"context"
+	"time"
+
+	"go-far/internal/infra/query"
+	"go-far/internal/model/dto"
+	"go-far/internal/model/entity"
+	"go-far/internal/repository/queries"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type TransferRepositoryItf interface {
+	Create(ctx context.Context, carID uuid.UUID, fromUserID string, req dto.TransferCarRequest, expiresAt time.Time) (*entity.CarTransferRequest, error)
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.CarTransferRequest, error)
+	FindAll(ctx context.Context, filter *dto.TransferRequestFilter) ([]entity.CarTransferRequest, *dto.Pagination, error)
+	Respond(ctx context.Context, id uuid.UUID, status entity.TransferRequestStatus) (*entity.CarTransferRequest, error)
+	ExpireForCar(ctx context.Context, carID uuid.UUID) error
+	Expire(ctx context.Context, limit int) (int64, error)
+}
+
+type transferRepository struct {
+	sql0    *pgxpool.Pool
+	queries *queries.Queries
+}
+
+func InitTransferRepository(sql0 *pgxpool.Pool, queryLoader *query.QueryLoader) TransferRepositoryItf {
+	return &transferRepository{
+		sql0:    sql0,
+		queries: queries.New(queryLoader),
+	}
+}
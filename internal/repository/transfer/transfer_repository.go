@@ -0,0 +1,41 @@
+package transfer
+
+import (
+	"context"
+	"time"
+
+	"go-far/internal/model/dto"
+	"go-far/internal/model/entity"
+
+	"github.com/google/uuid"
+)
+
+func (r *transferRepository) Create(ctx context.Context, carID uuid.UUID, fromUserID string, req dto.TransferCarRequest, expiresAt time.Time) (*entity.CarTransferRequest, error) {
+	return r.createSQLTransferRequest(ctx, carID, fromUserID, req, expiresAt)
+}
+
+func (r *transferRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.CarTransferRequest, error) {
+	return r.findSQLTransferRequestByID(ctx, id)
+}
+
+func (r *transferRepository) FindAll(ctx context.Context, filter *dto.TransferRequestFilter) ([]entity.CarTransferRequest, *dto.Pagination, error) {
+	return r.findAllSQLTransferRequests(ctx, filter)
+}
+
+// Respond moves a pending, unexpired request to status. It returns
+// CodeSQLEmptyRow when the request is no longer pending.
+func (r *transferRepository) Respond(ctx context.Context, id uuid.UUID, status entity.TransferRequestStatus) (*entity.CarTransferRequest, error) {
+	return r.respondSQLTransferRequest(ctx, id, status)
+}
+
+// ExpireForCar marks the car's overdue pending request expired, so a new one
+// can be created before the expiry job has run.
+func (r *transferRepository) ExpireForCar(ctx context.Context, carID uuid.UUID) error {
+	return r.expireSQLCarTransferRequests(ctx, carID)
+}
+
+// Expire marks up to limit overdue pending requests expired and returns how
+// many were changed.
+func (r *transferRepository) Expire(ctx context.Context, limit int) (int64, error) {
+	return r.expireSQLTransferRequests(ctx, limit)
+}
@@ -0,0 +1,139 @@
+package transfer
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go-far/internal/infra/database"
+	"go-far/internal/model/dto"
+	"go-far/internal/model/entity"
+	appErr "go-far/internal/model/errors"
+	"go-far/internal/repository/queries"
+	"go-far/internal/util"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/rs/zerolog"
+)
+
+// pgUniqueViolation is raised by idx_car_transfer_requests_pending_car.
+const pgUniqueViolation = "23505"
+
+func (r *transferRepository) createSQLTransferRequest(ctx context.Context, carID uuid.UUID, fromUserID string, req dto.TransferCarRequest, expiresAt time.Time) (*entity.CarTransferRequest, error) {
+	var reason *string
+	if req.Reason != "" {
+		reason = &req.Reason
+	}
+
+	request, err := r.queries.CreateTransferRequest(ctx, database.Conn(ctx, r.sql0), queries.CreateTransferRequestParams{
+		CarID:      carID,
+		FromUserID: fromUserID,
+		ToUserID:   req.NewUserID,
+		Reason:     reason,
+		ExpiresAt:  expiresAt,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			zerolog.Ctx(ctx).Debug().Str("car_id", carID.String()).Msg("car_or_recipient_not_found_for_transfer")
+			return nil, appErr.WrapWithCode(err, appErr.CodeSQLEmptyRow, "car_or_recipient_not_found_for_transfer")
+		}
+
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation {
+			zerolog.Ctx(ctx).Debug().Str("car_id", carID.String()).Msg("car_transfer_already_pending")
+			return nil, appErr.WrapWithCode(err, appErr.CodeSQLConflict, "car_transfer_already_pending")
+		}
+
+		zerolog.Ctx(ctx).Error().Err(err).Str("car_id", carID.String()).Msg("create_transfer_request_err")
+		return nil, appErr.WrapWithCode(err, appErr.CodeSQLCreate, "create_transfer_request_err")
+	}
+
+	return &request, nil
+}
+
+func (r *transferRepository) findSQLTransferRequestByID(ctx context.Context, id uuid.UUID) (*entity.CarTransferRequest, error) {
+	request, err := r.queries.FindTransferRequestByID(ctx, database.Conn(ctx, r.sql0), queries.FindTransferRequestByIDParams{ID: id})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			zerolog.Ctx(ctx).Debug().Str("id", id.String()).Msg("transfer_request_not_found")
+			return nil, appErr.WrapWithCode(err, appErr.CodeSQLEmptyRow, "transfer_request_not_found")
+		}
+
+		zerolog.Ctx(ctx).Error().Err(err).Str("id", id.String()).Msg("find_transfer_request_err")
+		return nil, appErr.WrapWithCode(err, appErr.CodeSQLRowScan, "find_transfer_request_err")
+	}
+
+	return &request, nil
+}
+
+func (r *transferRepository) findAllSQLTransferRequests(ctx context.Context, filter *dto.TransferRequestFilter) ([]entity.CarTransferRequest, *dto.Pagination, error) {
+	filter.Page = util.ValidatePage(filter.Page)
+	filter.PageSize = util.ValidateLimit(filter.PageSize)
+
+	pagination := dto.Pagination{
+		CurrentPage: filter.Page,
+		SortBy:      "created_at",
+		SortDir:     "DESC",
+	}
+
+	results, err := r.queries.FindTransferRequests(ctx, database.Conn(ctx, r.sql0), filter)
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("find_transfer_requests_err")
+		return nil, &pagination, appErr.WrapWithCode(err, appErr.CodeSQLRowScan, "find_transfer_requests_err")
+	}
+
+	total, err := r.queries.CountTransferRequests(ctx, database.Conn(ctx, r.sql0), filter)
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("count_transfer_requests_err")
+		return nil, &pagination, appErr.WrapWithCode(err, appErr.CodeSQLRowScan, "count_transfer_requests_err")
+	}
+
+	if results == nil {
+		results = []entity.CarTransferRequest{}
+	}
+
+	pagination.CurrentElements = int64(len(results))
+	pagination.TotalElements = total
+	pagination.TotalPages = (total + filter.PageSize - 1) / filter.PageSize
+
+	return results, &pagination, nil
+}
+
+func (r *transferRepository) respondSQLTransferRequest(ctx context.Context, id uuid.UUID, status entity.TransferRequestStatus) (*entity.CarTransferRequest, error) {
+	request, err := r.queries.RespondTransferRequest(ctx, database.Conn(ctx, r.sql0), queries.RespondTransferRequestParams{
+		ID:     id,
+		Status: status,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			zerolog.Ctx(ctx).Debug().Str("id", id.String()).Msg("pending_transfer_request_not_found")
+			return nil, appErr.WrapWithCode(err, appErr.CodeSQLEmptyRow, "pending_transfer_request_not_found")
+		}
+
+		zerolog.Ctx(ctx).Error().Err(err).Str("id", id.String()).Msg("respond_transfer_request_err")
+		return nil, appErr.WrapWithCode(err, appErr.CodeSQLUpdate, "respond_transfer_request_err")
+	}
+
+	return &request, nil
+}
+
+func (r *transferRepository) expireSQLCarTransferRequests(ctx context.Context, carID uuid.UUID) error {
+	if _, err := r.queries.ExpireCarTransferRequests(ctx, database.Conn(ctx, r.sql0), queries.ExpireCarTransferRequestsParams{CarID: carID}); err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Str("car_id", carID.String()).Msg("expire_car_transfer_requests_err")
+		return appErr.WrapWithCode(err, appErr.CodeSQLUpdate, "expire_car_transfer_requests_err")
+	}
+
+	return nil
+}
+
+func (r *transferRepository) expireSQLTransferRequests(ctx context.Context, limit int) (int64, error) {
+	rows, err := r.queries.ExpireTransferRequests(ctx, database.Conn(ctx, r.sql0), queries.ExpireTransferRequestsParams{Limit: limit})
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("expire_transfer_requests_err")
+		return 0, appErr.WrapWithCode(err, appErr.CodeSQLUpdate, "expire_transfer_requests_err")
+	}
+
+	return rows, nil
+}
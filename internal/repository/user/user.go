@@ -4,36 +4,58 @@ import (
 	"context"
 	"time"
 
+	"go-far/internal/infra/cache"
 	"go-far/internal/infra/query"
 	"go-far/internal/model/dto"
 	"go-far/internal/model/entity"
+	appErr "go-far/internal/model/errors"
+	"go-far/internal/repository/queries"
 
 	"github.com/jackc/pgx/v5/pgxpool"
-	"github.com/redis/go-redis/v9"
 )
 
 type UserRepositoryItf interface {
 	Create(ctx context.Context, user *entity.User) (*entity.User, error)
-	FindByID(ctx context.Context, id string) (*entity.User, error)
+	FindByID(ctx context.Context, cacheControl dto.CacheControl, id string) (*entity.User, error)
+	FindByIDColumns(ctx context.Context, id string, columns []string) (*entity.User, error)
+	FindByIDWithDeleted(ctx context.Context, id string) (*entity.User, error)
 	FindByEmail(ctx context.Context, email string) (*entity.User, error)
 	FindAll(ctx context.Context, cacheControl dto.CacheControl, filter *dto.UserFilter) (*[]entity.User, *dto.Pagination, error)
-	FindAllV2(ctx context.Context, filter *dto.UserFilterV2) (*[]entity.User, *dto.Pagination, error)
+	FindAllV2(ctx context.Context, cacheControl dto.CacheControl, filter *dto.UserFilterV2) (*[]entity.User, *dto.Pagination, error)
+	ExportV2(ctx context.Context, filter *dto.UserFilterV2, fn func(*entity.User) error) error
 	Update(ctx context.Context, user *entity.User) error
 	Delete(ctx context.Context, id string) error
+	Restore(ctx context.Context, id string) (*entity.User, error)
+	Purge(ctx context.Context, deletedBefore time.Time, limit int) (int64, error)
+	RecordLogin(ctx context.Context, userID string) error
 }
 
 type userRepository struct {
 	sql0        *pgxpool.Pool
-	redis0      *redis.Client
+	cacheStore  *cache.Store
+	userLoader  *cache.Loader[*entity.User]
+	listLoader  *cache.Loader[*userListPage]
 	queryLoader *query.QueryLoader
-	cacheTTL    time.Duration
+	queries     *queries.Queries
 }
 
-func InitUserRepository(sql0 *pgxpool.Pool, redis0 *redis.Client, queryLoader *query.QueryLoader, cacheTTL time.Duration) UserRepositoryItf {
+func InitUserRepository(sql0 *pgxpool.Pool, cacheStore *cache.Store, queryLoader *query.QueryLoader, cacheTTL time.Duration) UserRepositoryItf {
 	return &userRepository{
-		sql0:        sql0,
-		redis0:      redis0,
+		sql0:       sql0,
+		cacheStore: cacheStore,
+		userLoader: cache.NewLoader[*entity.User](cacheStore, cache.LoaderOptions{
+			TTL:          cacheTTL,
+			Jitter:       cache.DefaultJitter,
+			NegativeTTL:  durationUserNotFound,
+			NotFoundCode: appErr.CodeSQLEmptyRow,
+			Codec:        cache.MsgpackCodec,
+		}),
+		listLoader: cache.NewLoader[*userListPage](cacheStore, cache.LoaderOptions{
+			TTL:    durationUserExpiration,
+			Jitter: cache.DefaultJitter,
+			Codec:  cache.SnappyCodec(cache.JSONCodec),
+		}),
 		queryLoader: queryLoader,
-		cacheTTL:    cacheTTL,
+		queries:     queries.New(queryLoader),
 	}
 }
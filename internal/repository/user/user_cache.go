@@ -3,107 +3,94 @@ package user
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
+	"go-far/internal/infra/database"
 	"go-far/internal/model/dto"
 	"go-far/internal/model/entity"
 	appErr "go-far/internal/model/errors"
 
-	"github.com/golang/snappy"
-	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog"
 )
 
 const (
-	userByParamHashKey     string        = "user:param"
-	userPaginationHashKey  string        = "user:pagination"
+	userListCacheKey       string        = "user:list:"
+	userListV2CacheKey     string        = "user:list:v2:"
 	durationUserExpiration time.Duration = 5 * time.Minute
-)
+	// durationUserNotFound is how long a lookup of a missing user is cached.
+	durationUserNotFound time.Duration = 30 * time.Second
 
-func (d *userRepository) setCacheFindAllUser(ctx context.Context, filter *dto.UserFilter, result *[]entity.User, pagination *dto.Pagination) error {
-	cacheKey := generateCacheKey(filter)
+	// cacheTagUsers covers every cached user list page. Any write that can
+	// add, remove or change a listed user invalidates it.
+	cacheTagUsers = "users"
+)
 
-	var encJSON []byte
+type userListPage struct {
+	Users      []entity.User  `json:"users"`
+	Pagination dto.Pagination `json:"pagination"`
+}
 
-	rawJSON, err := json.Marshal(result)
-	if err != nil {
-		return appErr.WrapWithCode(err, appErr.CodeCacheMarshal, "set_cache_find_all_user_marshal")
-	}
+// cacheTagUser covers the cached single-user lookup of id.
+func cacheTagUser(id string) string {
+	return "user:" + id
+}
 
-	encJSON = snappy.Encode(encJSON, rawJSON)
+// invalidateCache bumps the given tags after a successful write, once the
+// surrounding transaction (if any) commits. A failure is only logged: the
+// write is committed, and stale entries still expire on their own TTL.
+func (d *userRepository) invalidateCache(ctx context.Context, tags ...string) {
+	database.AfterCommit(ctx, func() {
+		if err := d.cacheStore.Invalidate(ctx, tags...); err != nil {
+			zerolog.Ctx(ctx).Warn().Err(err).Strs("tags", tags).Msg("invalidate_user_cache")
+		}
+	})
+}
 
-	if hsetErr := d.redis0.HSet(ctx, userByParamHashKey, cacheKey, encJSON).Err(); hsetErr != nil {
-		return appErr.WrapWithCode(hsetErr, appErr.CodeCacheSetHashKey, "set_cache_find_all_user")
-	}
+// findAllCached serves a list page through the list loader.
+func (d *userRepository) findAllCached(ctx context.Context, cacheControl dto.CacheControl, filter *dto.UserFilter) (*[]entity.User, *dto.Pagination, error) {
+	base := userListCacheKey + generateCacheKey(filter)
 
-	if expireErr := d.redis0.Expire(ctx, userByParamHashKey, durationUserExpiration).Err(); expireErr != nil {
-		return appErr.WrapWithCode(expireErr, appErr.CodeCacheSetExpiration, "set_cache_find_all_user_expiration")
-	}
+	page, err := d.listLoader.Get(ctx, cacheControl, base, []string{cacheTagUsers}, func(ctx context.Context) (*userListPage, error) {
+		users, pagination, err := d.findAllSQLUser(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
 
-	rawJSON, err = json.Marshal(pagination)
+		return &userListPage{Users: *users, Pagination: *pagination}, nil
+	})
 	if err != nil {
-		return appErr.WrapWithCode(err, appErr.CodeCacheMarshal, "set_cache_find_all_user_pagination_marshal")
-	}
-
-	encJSON = []byte{}
-	encJSON = snappy.Encode(encJSON, rawJSON)
-
-	if err := d.redis0.HSet(ctx, userPaginationHashKey, cacheKey, encJSON).Err(); err != nil {
-		return appErr.WrapWithCode(err, appErr.CodeCacheSetHashKey, "set_cache_find_all_user_pagination")
-	}
-
-	if err := d.redis0.Expire(ctx, userPaginationHashKey, durationUserExpiration).Err(); err != nil {
-		return appErr.WrapWithCode(err, appErr.CodeCacheSetExpiration, "set_cache_find_all_user_pagination_expiration")
+		return nil, nil, err
 	}
 
-	return nil
+	return &page.Users, &page.Pagination, nil
 }
 
-func (d *userRepository) getCacheFindAllUser(ctx context.Context, filter *dto.UserFilter) (*[]entity.User, *dto.Pagination, error) {
-	var (
-		results    []entity.User
-		pagination dto.Pagination
-	)
-
-	cacheKey := generateCacheKey(filter)
-
-	resultRaw, err := d.redis0.HGet(ctx, userByParamHashKey, cacheKey).Bytes()
-	if errors.Is(err, redis.Nil) {
-		return nil, nil, err
-	} else if err != nil {
-		return nil, nil, appErr.WrapWithCode(err, appErr.CodeCacheGetHashKey, "get_cache_find_all_user")
-	}
-
-	var decJSON []byte
-	decJSON, err = snappy.Decode(decJSON, resultRaw)
+// findAllV2Cached is findAllCached for the V2 listing, keyed by the
+// filter's JSON form since its fields map straight to query parameters.
+func (d *userRepository) findAllV2Cached(ctx context.Context, cacheControl dto.CacheControl, filter *dto.UserFilterV2) (*[]entity.User, *dto.Pagination, error) {
+	key, err := json.Marshal(filter)
 	if err != nil {
-		return nil, nil, appErr.WrapWithCode(err, appErr.CodeCacheDecode, "get_cache_find_all_user")
+		return nil, nil, appErr.WrapWithCode(err, appErr.CodeCacheMarshal, "user_v2_cache_key")
 	}
 
-	if unmarshallErr := json.Unmarshal(decJSON, &results); unmarshallErr != nil {
-		return nil, nil, appErr.WrapWithCode(unmarshallErr, appErr.CodeCacheUnmarshal, "get_cache_find_all_user")
-	}
+	base := userListV2CacheKey + string(key)
 
-	paginationRaw, err := d.redis0.HGet(ctx, userPaginationHashKey, cacheKey).Bytes()
-	if errors.Is(err, redis.Nil) {
-		return nil, nil, err
-	} else if err != nil {
-		return nil, nil, appErr.WrapWithCode(err, appErr.CodeCacheGetHashKey, "get_cache_find_all_user_pagination")
-	}
+	page, err := d.listLoader.Get(ctx, cacheControl, base, []string{cacheTagUsers}, func(ctx context.Context) (*userListPage, error) {
+		users, pagination, err := d.findAllSQLUserV2(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
 
-	decJSON = []byte{}
-	decJSON, err = snappy.Decode(decJSON, paginationRaw)
+		return &userListPage{Users: *users, Pagination: *pagination}, nil
+	})
 	if err != nil {
-		return nil, nil, appErr.WrapWithCode(err, appErr.CodeCacheDecode, "get_cache_find_all_user_pagination")
-	}
-
-	if err := json.Unmarshal(decJSON, &pagination); err != nil {
-		return nil, nil, appErr.WrapWithCode(err, appErr.CodeCacheUnmarshal, "get_cache_find_all_user_pagination")
+		return nil, nil, err
 	}
 
-	return &results, &pagination, nil
+	return &page.Users, &page.Pagination, nil
 }
 
 func generateCacheKey(filter *dto.UserFilter) string {
@@ -113,16 +100,16 @@ func generateCacheKey(filter *dto.UserFilter) string {
 		"email:" + filter.Email,
 	}
 	if filter.MinAge > 0 {
-		keys = append(keys, "min_age:0")
+		keys = append(keys, "min_age:"+strconv.Itoa(filter.MinAge))
 	}
 	if filter.MaxAge > 0 {
-		keys = append(keys, "max_age:0")
+		keys = append(keys, "max_age:"+strconv.Itoa(filter.MaxAge))
 	}
 	if filter.Page > 0 {
-		keys = append(keys, "page:0")
+		keys = append(keys, "page:"+strconv.FormatInt(filter.Page, 10))
 	}
 	if filter.PageSize > 0 {
-		keys = append(keys, "page_size:0")
+		keys = append(keys, "page_size:"+strconv.FormatInt(filter.PageSize, 10))
 	}
 	if filter.SortBy != "" {
 		keys = append(keys, "sort_by:"+filter.SortBy)
@@ -130,6 +117,12 @@ func generateCacheKey(filter *dto.UserFilter) string {
 	if filter.SortDir != "" {
 		keys = append(keys, "sort_dir:"+filter.SortDir)
 	}
+	if len(filter.Columns) > 0 {
+		keys = append(keys, "columns:"+strings.Join(filter.Columns, ","))
+	}
+	if filter.IncludeDeleted {
+		keys = append(keys, "include_deleted:true")
+	}
 
 	sort.Strings(keys)
 	return hashStrings(keys)
@@ -2,19 +2,18 @@ package user
 
 import (
 	"context"
-	"encoding/json"
-	"errors"
+	"time"
 
+	"go-far/internal/infra/database"
 	"go-far/internal/model/dto"
 	"go-far/internal/model/entity"
 	appErr "go-far/internal/model/errors"
 
-	"github.com/redis/go-redis/v9"
 	"github.com/rs/zerolog"
 )
 
 func (d *userRepository) Create(ctx context.Context, user *entity.User) (*entity.User, error) {
-	tx, err := d.sql0.Begin(ctx)
+	tx, err := database.Begin(ctx, d.sql0)
 	if err != nil {
 		zerolog.Ctx(ctx).Error().Err(err).Msg("tx_create_user")
 		return user, appErr.Wrap(err, "tx_create_user")
@@ -39,30 +38,29 @@ func (d *userRepository) Create(ctx context.Context, user *entity.User) (*entity
 		return nil, appErr.Wrap(err, "commit_create_user")
 	}
 
+	d.invalidateCache(ctx, cacheTagUsers)
+
 	return user, nil
 }
 
-func (d *userRepository) FindByID(ctx context.Context, id string) (*entity.User, error) {
-	cacheKey := "user:" + id
-	cached, err := d.redis0.Get(ctx, cacheKey).Result()
-	if err == nil {
-		var user entity.User
+func (d *userRepository) FindByID(ctx context.Context, cacheControl dto.CacheControl, id string) (*entity.User, error) {
+	tag := cacheTagUser(id)
 
-		if unmarshallErr := json.Unmarshal([]byte(cached), &user); unmarshallErr == nil {
-			zerolog.Ctx(ctx).Debug().Str("id", id).Msg("data_found_in_cache")
-			return &user, nil
-		}
-	}
-
-	user, err := d.findUserSQLByID(ctx, id)
-	if err != nil {
-		return nil, err
-	}
+	return d.userLoader.Get(ctx, cacheControl, tag, []string{tag}, func(ctx context.Context) (*entity.User, error) {
+		return d.findUserSQLByID(ctx, id)
+	})
+}
 
-	data, _ := json.Marshal(user)
-	d.redis0.Set(ctx, cacheKey, data, d.cacheTTL)
+// FindByIDWithDeleted also finds soft-deleted users. It is not cached, as
+// only admins read deleted records.
+func (d *userRepository) FindByIDWithDeleted(ctx context.Context, id string) (*entity.User, error) {
+	return d.findUserSQLByIDWithDeleted(ctx, id)
+}
 
-	return user, nil
+// FindByIDColumns reads only the given columns of a user. It bypasses the
+// cache, which holds full rows.
+func (d *userRepository) FindByIDColumns(ctx context.Context, id string, columns []string) (*entity.User, error) {
+	return d.findUserColumnsSQLByID(ctx, id, columns)
 }
 
 func (d *userRepository) FindByEmail(ctx context.Context, email string) (*entity.User, error) {
@@ -75,46 +73,56 @@ func (d *userRepository) FindByEmail(ctx context.Context, email string) (*entity
 }
 
 func (d *userRepository) FindAll(ctx context.Context, cacheControl dto.CacheControl, filter *dto.UserFilter) (*[]entity.User, *dto.Pagination, error) {
-	if cacheControl.MustRevalidate {
-		result, pagination, err := d.findAllSQLUser(ctx, filter)
-		if err != nil {
-			return result, pagination, err
-		}
-
-		if err = d.setCacheFindAllUser(ctx, filter, result, pagination); err != nil {
-			zerolog.Ctx(ctx).Warn().Err(err).Send()
-		}
+	return d.findAllCached(ctx, cacheControl, filter)
+}
 
-		return result, pagination, nil
+func (d *userRepository) Update(ctx context.Context, user *entity.User) error {
+	if err := d.updateSQLUser(ctx, user); err != nil {
+		return err
 	}
 
-	result, pagination, err := d.getCacheFindAllUser(ctx, filter)
-	if errors.Is(err, redis.Nil) {
-		zerolog.Ctx(ctx).Warn().Err(err).Send()
+	d.invalidateCache(ctx, cacheTagUser(user.ID), cacheTagUsers)
 
-		result, pagination, err = d.findAllSQLUser(ctx, filter)
-		if err != nil {
-			return result, pagination, err
-		}
+	return nil
+}
 
-		if err = d.setCacheFindAllUser(ctx, filter, result, pagination); err != nil {
-			zerolog.Ctx(ctx).Warn().Err(err).Send()
-		}
+func (d *userRepository) Delete(ctx context.Context, id string) error {
+	if err := d.deleteSQLUser(ctx, id); err != nil {
+		return err
+	}
 
-		return result, pagination, nil
-	} else if err != nil {
-		zerolog.Ctx(ctx).Warn().Err(err).Send()
+	d.invalidateCache(ctx, cacheTagUser(id), cacheTagUsers)
 
-		return d.findAllSQLUser(ctx, filter)
+	return nil
+}
+
+func (d *userRepository) Restore(ctx context.Context, id string) (*entity.User, error) {
+	user, err := d.restoreSQLUser(ctx, id)
+	if err != nil {
+		return nil, err
 	}
 
-	return result, pagination, nil
+	d.invalidateCache(ctx, cacheTagUser(id), cacheTagUsers)
+
+	return user, nil
 }
 
-func (d *userRepository) Update(ctx context.Context, user *entity.User) error {
-	return d.updateSQLUser(ctx, user)
+// Purge hard-deletes up to limit users soft-deleted before deletedBefore and
+// returns how many were removed. Their users_cars links cascade.
+func (d *userRepository) Purge(ctx context.Context, deletedBefore time.Time, limit int) (int64, error) {
+	rows, err := d.purgeSQLUsers(ctx, deletedBefore, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	if rows > 0 {
+		d.invalidateCache(ctx, cacheTagUsers)
+	}
+
+	return rows, nil
 }
 
-func (d *userRepository) Delete(ctx context.Context, id string) error {
-	return d.deleteSQLUser(ctx, id)
+// RecordLogin logs a successful login of userID for the login trend.
+func (d *userRepository) RecordLogin(ctx context.Context, userID string) error {
+	return d.createSQLUserLogin(ctx, userID)
 }
@@ -4,10 +4,13 @@ import (
 	"context"
 	"errors"
 	"strings"
+	"time"
 
+	"go-far/internal/infra/database"
 	"go-far/internal/model/dto"
 	"go-far/internal/model/entity"
 	appErr "go-far/internal/model/errors"
+	"go-far/internal/repository/queries"
 	"go-far/internal/util"
 
 	"github.com/jackc/pgx/v5"
@@ -54,42 +57,52 @@ func normalizeString(s string) string {
 }
 
 func (d *userRepository) findUserSQLByID(ctx context.Context, id string) (*entity.User, error) {
-	var user entity.User
-
-	query, args, err := d.queryLoader.Compile("FindUserByID", map[string]any{"ID": id})
+	user, err := d.queries.FindUserByID(ctx, database.Conn(ctx, d.sql0), queries.FindUserByIDParams{ID: id})
 	if err != nil {
-		zerolog.Ctx(ctx).Error().Err(err).Msg("build_find_user_query_err")
-		return nil, appErr.WrapWithCode(err, appErr.CodeSQLQueryBuild, "build_find_user_query_err")
+		if errors.Is(err, pgx.ErrNoRows) {
+			zerolog.Ctx(ctx).Debug().Str("id", id).Msg("user_not_found")
+			return nil, appErr.WrapWithCode(err, appErr.CodeSQLEmptyRow, "user_not_found")
+		}
+
+		zerolog.Ctx(ctx).Error().Err(err).Str("id", id).Msg("find_user_err")
+		return nil, appErr.WrapWithCode(err, appErr.CodeSQLRowScan, "find_user_err")
 	}
 
-	zerolog.Ctx(ctx).Debug().Str("query", util.CleanQuery(query)).Any("args", args).Msg("compiled_query")
+	return &user, nil
+}
 
-	err = d.sql0.QueryRow(ctx, query, args...).Scan(&user.ID, &user.Email, &user.Name, &user.Password, &user.Age, &user.Role, &user.IsActive, &user.CreatedAt, &user.UpdatedAt)
+func (d *userRepository) findUserColumnsSQLByID(ctx context.Context, id string, columns []string) (*entity.User, error) {
+	user, err := d.queries.FindUserColumnsByID(ctx, database.Conn(ctx, d.sql0), queries.FindUserColumnsByIDParams{Columns: columns, ID: id})
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			zerolog.Ctx(ctx).Debug().Str("id", id).Msg("user_not_found")
 			return nil, appErr.WrapWithCode(err, appErr.CodeSQLEmptyRow, "user_not_found")
 		}
 
-		zerolog.Ctx(ctx).Error().Err(err).Str("id", id).Msg("find_user_err")
-		return nil, appErr.WrapWithCode(err, appErr.CodeSQLRowScan, "find_user_err")
+		zerolog.Ctx(ctx).Error().Err(err).Str("id", id).Msg("find_user_columns_err")
+		return nil, appErr.WrapWithCode(err, appErr.CodeSQLRowScan, "find_user_columns_err")
 	}
 
 	return &user, nil
 }
 
-func (d *userRepository) findUserSQLByEmail(ctx context.Context, email string) (*entity.User, error) {
-	var user entity.User
-
-	query, args, err := d.queryLoader.Compile("FindUserByEmail", map[string]any{"Email": email})
+func (d *userRepository) findUserSQLByIDWithDeleted(ctx context.Context, id string) (*entity.User, error) {
+	user, err := d.queries.FindUserByIDWithDeleted(ctx, database.Conn(ctx, d.sql0), queries.FindUserByIDWithDeletedParams{ID: id})
 	if err != nil {
-		zerolog.Ctx(ctx).Error().Err(err).Msg("build_find_user_by_email_query_err")
-		return nil, appErr.WrapWithCode(err, appErr.CodeSQLQueryBuild, "build_find_user_by_email_query_err")
+		if errors.Is(err, pgx.ErrNoRows) {
+			zerolog.Ctx(ctx).Debug().Str("id", id).Msg("user_not_found")
+			return nil, appErr.WrapWithCode(err, appErr.CodeSQLEmptyRow, "user_not_found")
+		}
+
+		zerolog.Ctx(ctx).Error().Err(err).Str("id", id).Msg("find_user_err")
+		return nil, appErr.WrapWithCode(err, appErr.CodeSQLRowScan, "find_user_err")
 	}
 
-	zerolog.Ctx(ctx).Debug().Str("query", util.CleanQuery(query)).Any("args", args).Msg("compiled_query")
+	return &user, nil
+}
 
-	err = d.sql0.QueryRow(ctx, query, args...).Scan(&user.ID, &user.Email, &user.Name, &user.Password, &user.Age, &user.Role, &user.IsActive, &user.CreatedAt, &user.UpdatedAt)
+func (d *userRepository) findUserSQLByEmail(ctx context.Context, email string) (*entity.User, error) {
+	user, err := d.queries.FindUserByEmail(ctx, database.Conn(ctx, d.sql0), queries.FindUserByEmailParams{Email: email})
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, appErr.NewWithCode(appErr.CodeHTTPUnauthorized, "Invalid credentials")
@@ -103,28 +116,17 @@ func (d *userRepository) findUserSQLByEmail(ctx context.Context, email string) (
 }
 
 func (d *userRepository) createSQLUser(ctx context.Context, tx pgx.Tx, user *entity.User) (pgx.Tx, *entity.User, error) {
-	query, args, err := d.queryLoader.Compile("CreateUser", user)
-	if err != nil {
-		zerolog.Ctx(ctx).Error().Err(err).Msg("build_create_user_query_err")
-		return tx, user, appErr.WrapWithCode(err, appErr.CodeSQLQueryBuild, "build_create_user_query_err")
-	}
-
-	zerolog.Ctx(ctx).Debug().Str("query", util.CleanQuery(query)).Any("args", args).Msg("compiled_query")
-
-	err = tx.QueryRow(ctx, query, args...).Scan(&user.ID, &user.CreatedAt, &user.UpdatedAt)
+	row, err := d.queries.CreateUser(ctx, tx, user)
 	if err != nil {
 		return tx, user, appErr.Wrap(err, "create_sql_user")
 	}
 
+	user.ID, user.CreatedAt, user.UpdatedAt, user.Version = row.ID, row.CreatedAt, row.UpdatedAt, row.Version
+
 	return tx, user, nil
 }
 
 func (d *userRepository) findAllSQLUser(ctx context.Context, filter *dto.UserFilter) (*[]entity.User, *dto.Pagination, error) {
-	var (
-		results      []entity.User
-		totalRecords int64
-	)
-
 	filter.Page = util.ValidatePage(filter.Page)
 	filter.PageSize = util.ValidatePage(filter.PageSize)
 	filter.SortBy = sanitizeSortBy(filter.SortBy)
@@ -138,40 +140,13 @@ func (d *userRepository) findAllSQLUser(ctx context.Context, filter *dto.UserFil
 		SortBy:          filter.SortBy,
 	}
 
-	query, args, err := d.queryLoader.Compile("FindAllUsersBase", filter)
-	if err != nil {
-		zerolog.Ctx(ctx).Error().Err(err).Msg("build_find_users_query_err")
-		return nil, &pagination, appErr.WrapWithCode(err, appErr.CodeSQLQueryBuild, "build_find_users_query_err")
-	}
-
-	zerolog.Ctx(ctx).Debug().Str("query", util.CleanQuery(query)).Any("args", args).Msg("compiled_query")
-
-	rows, err := d.sql0.Query(ctx, query, args...)
+	results, err := d.queries.FindAllUsersBase(ctx, database.Conn(ctx, d.sql0), filter)
 	if err != nil {
 		zerolog.Ctx(ctx).Error().Err(err).Msg("find_users_err")
 		return nil, &pagination, appErr.WrapWithCode(err, appErr.CodeSQLRowScan, "find_users_err")
 	}
-	defer rows.Close()
 
-	for rows.Next() {
-		var user entity.User
-		if scanErr := rows.Scan(&user.ID, &user.Email, &user.Name, &user.Age, &user.Role, &user.IsActive, &user.CreatedAt, &user.UpdatedAt); scanErr != nil {
-			zerolog.Ctx(ctx).Error().Err(scanErr).Msg("scan_user_err")
-			return nil, &pagination, appErr.WrapWithCode(scanErr, appErr.CodeSQLRowScan, "scan_user_err")
-		}
-		user.Password = ""
-		results = append(results, user)
-	}
-
-	countQuery, countArgs, err := d.queryLoader.Compile("CountUsersBase", filter)
-	if err != nil {
-		zerolog.Ctx(ctx).Error().Err(err).Msg("count_users_query_err")
-		return nil, &pagination, appErr.WrapWithCode(err, appErr.CodeSQLQueryBuild, "count_users_query_err")
-	}
-
-	zerolog.Ctx(ctx).Debug().Str("query", util.CleanQuery(countQuery)).Any("args", countArgs).Msg("compiled_query")
-
-	err = d.sql0.QueryRow(ctx, countQuery, countArgs...).Scan(&totalRecords)
+	totalRecords, err := d.queries.CountUsersBase(ctx, database.Conn(ctx, d.sql0), filter)
 	if err != nil {
 		zerolog.Ctx(ctx).Error().Err(err).Msg("count_users_err")
 		return nil, &pagination, appErr.WrapWithCode(err, appErr.CodeSQLRowScan, "count_users_err")
@@ -193,58 +168,76 @@ func (d *userRepository) findAllSQLUser(ctx context.Context, filter *dto.UserFil
 	return &results, &pagination, nil
 }
 
+// updateSQLUser writes user only if its version is still the stored one.
+// Otherwise the row is re-read to tell a deleted user from a lost race.
 func (d *userRepository) updateSQLUser(ctx context.Context, user *entity.User) error {
-	query, args, err := d.queryLoader.Compile("UpdateUser", user)
+	row, err := d.queries.UpdateUser(ctx, database.Conn(ctx, d.sql0), user)
 	if err != nil {
-		zerolog.Ctx(ctx).Error().Err(err).Msg("build_update_user_query_err")
-		return appErr.WrapWithCode(err, appErr.CodeSQLQueryBuild, "build_update_user_query_err")
+		if !errors.Is(err, pgx.ErrNoRows) {
+			zerolog.Ctx(ctx).Error().Err(err).Str("id", user.ID).Msg("update_user_err")
+			return appErr.WrapWithCode(err, appErr.CodeSQLUpdate, "update_user_err")
+		}
+
+		current, findErr := d.findUserSQLByID(ctx, user.ID)
+		if findErr != nil {
+			zerolog.Ctx(ctx).Debug().Str("id", user.ID).Msg("user_not_found_for_update")
+			return appErr.Wrap(findErr, "user_not_found_for_update")
+		}
+
+		zerolog.Ctx(ctx).Debug().Str("id", user.ID).Int64("version", user.Version).Int64("current_version", current.Version).Msg("user_version_conflict")
+		return appErr.WrapWithCode(&appErr.ConflictError{Current: current}, appErr.CodeHTTPConflict, "user_version_conflict")
 	}
 
-	zerolog.Ctx(ctx).Debug().Str("query", util.CleanQuery(query)).Any("args", args).Msg("compiled_query")
+	user.UpdatedAt, user.Version = row.UpdatedAt, row.Version
+
+	return nil
+}
 
-	result, err := d.sql0.Exec(ctx, query, args...)
+func (d *userRepository) deleteSQLUser(ctx context.Context, id string) error {
+	rows, err := d.queries.DeleteUser(ctx, database.Conn(ctx, d.sql0), queries.DeleteUserParams{ID: id})
 	if err != nil {
-		zerolog.Ctx(ctx).Error().Err(err).Str("id", user.ID).Msg("update_user_err")
-		return appErr.WrapWithCode(err, appErr.CodeSQLUpdate, "update_user_err")
+		zerolog.Ctx(ctx).Error().Err(err).Str("id", id).Msg("failed_to_delete_user")
+		return appErr.WrapWithCode(err, appErr.CodeSQLDelete, "failed_to_delete_user")
 	}
 
-	rows := result.RowsAffected()
-
 	if rows == 0 {
-		zerolog.Ctx(ctx).Debug().Str("id", user.ID).Msg("user_not_found_for_update")
-		return appErr.NewWithCode(appErr.CodeSQLEmptyRow, "user_not_found_for_update")
+		zerolog.Ctx(ctx).Debug().Str("id", id).Msg("user_not_found_for_deletion")
+		return appErr.NewWithCode(appErr.CodeSQLEmptyRow, "user_not_found_for_deletion")
 	}
 
-	cacheKey := "user:" + user.ID
-	d.redis0.Del(ctx, cacheKey)
-
 	return nil
 }
 
-func (d *userRepository) deleteSQLUser(ctx context.Context, id string) error {
-	query, args, err := d.queryLoader.Compile("DeleteUser", map[string]any{"ID": id})
+func (d *userRepository) restoreSQLUser(ctx context.Context, id string) (*entity.User, error) {
+	user, err := d.queries.RestoreUser(ctx, database.Conn(ctx, d.sql0), queries.RestoreUserParams{ID: id})
 	if err != nil {
-		zerolog.Ctx(ctx).Error().Err(err).Msg("build_delete_user_query_err")
-		return appErr.WrapWithCode(err, appErr.CodeSQLQueryBuild, "build_delete_user_query_err")
+		if errors.Is(err, pgx.ErrNoRows) {
+			zerolog.Ctx(ctx).Debug().Str("id", id).Msg("deleted_user_not_found")
+			return nil, appErr.WrapWithCode(err, appErr.CodeSQLEmptyRow, "deleted_user_not_found")
+		}
+
+		zerolog.Ctx(ctx).Error().Err(err).Str("id", id).Msg("restore_user_err")
+		return nil, appErr.WrapWithCode(err, appErr.CodeSQLUpdate, "restore_user_err")
 	}
 
-	zerolog.Ctx(ctx).Debug().Str("query", util.CleanQuery(query)).Any("args", args).Msg("compiled_query")
+	return &user, nil
+}
 
-	result, err := d.sql0.Exec(ctx, query, args...)
+func (d *userRepository) purgeSQLUsers(ctx context.Context, deletedBefore time.Time, limit int) (int64, error) {
+	rows, err := d.queries.PurgeUsers(ctx, database.Conn(ctx, d.sql0), queries.PurgeUsersParams{DeletedBefore: deletedBefore, Limit: limit})
 	if err != nil {
-		zerolog.Ctx(ctx).Error().Err(err).Str("id", id).Msg("failed_to_delete_user")
-		return appErr.WrapWithCode(err, appErr.CodeSQLDelete, "failed_to_delete_user")
+		zerolog.Ctx(ctx).Error().Err(err).Time("deleted_before", deletedBefore).Msg("purge_users_err")
+		return 0, appErr.WrapWithCode(err, appErr.CodeSQLDelete, "purge_users_err")
 	}
 
-	rows := result.RowsAffected()
+	return rows, nil
+}
 
-	if rows == 0 {
-		zerolog.Ctx(ctx).Debug().Str("id", id).Msg("user_not_found_for_deletion")
-		return appErr.NewWithCode(appErr.CodeSQLEmptyRow, "user_not_found_for_deletion")
+func (d *userRepository) createSQLUserLogin(ctx context.Context, userID string) error {
+	if err := d.queries.CreateUserLogin(ctx, database.Conn(ctx, d.sql0), queries.CreateUserLoginParams{UserID: userID}); err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Str("user_id", userID).Msg("create_user_login_err")
+		return appErr.WrapWithCode(err, appErr.CodeSQLCreate, "create_user_login_err")
 	}
 
-	cacheKey := "user:" + id
-	d.redis0.Del(ctx, cacheKey)
-
 	return nil
 }
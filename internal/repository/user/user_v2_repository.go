@@ -7,11 +7,15 @@ import (
 	"go-far/internal/model/entity"
 )
 
-func (d *userRepository) FindAllV2(ctx context.Context, filter *dto.UserFilterV2) (*[]entity.User, *dto.Pagination, error) {
-	result, pagination, err := d.findAllSQLUserV2(ctx, filter)
+func (d *userRepository) FindAllV2(ctx context.Context, cacheControl dto.CacheControl, filter *dto.UserFilterV2) (*[]entity.User, *dto.Pagination, error) {
+	result, pagination, err := d.findAllV2Cached(ctx, cacheControl, filter)
 	if err != nil {
 		return nil, pagination, err
 	}
 
 	return result, pagination, nil
 }
+
+func (d *userRepository) ExportV2(ctx context.Context, filter *dto.UserFilterV2, fn func(*entity.User) error) error {
+	return d.exportSQLUserV2(ctx, filter, fn)
+}
@@ -3,15 +3,20 @@ package user
 import (
 	"context"
 
+	"go-far/internal/infra/database"
 	"go-far/internal/infra/query"
 	"go-far/internal/model/dto"
 	"go-far/internal/model/entity"
 	appErr "go-far/internal/model/errors"
+	"go-far/internal/repository/queries"
 	"go-far/internal/util"
 
 	"github.com/rs/zerolog"
 )
 
+// liveRowsOnly hides soft-deleted users from the V2 listing and export.
+const liveRowsOnly = "deleted_at IS NULL"
+
 func (d *userRepository) findAllSQLUserV2(ctx context.Context, filter *dto.UserFilterV2) (*[]entity.User, *dto.Pagination, error) {
 	filter.Page = util.ValidatePage(filter.Page)
 	filter.PageSize = util.ValidateLimit(filter.PageSize)
@@ -28,44 +33,44 @@ func (d *userRepository) findAllSQLUserV2(ctx context.Context, filter *dto.UserF
 	filter.SortBy = sanitizeSortByV2(filter.SortBy)
 	filter.SortDir = sanitizeSortDirV2(filter.SortDir)
 
-	baseQuery, _, err := d.queryLoader.Compile("FindUsersBaseV2", nil)
-	if err != nil {
-		zerolog.Ctx(ctx).Error().Err(err).Msg("build_users_query_err")
-		return nil, &pagination, appErr.WrapWithCode(err, appErr.CodeSQLQueryBuild, "build_users_query_err")
-	}
-
-	qb := query.NewSQLBuilder("param", "db", "", filter.Page, filter.PageSize)
+	qb := query.NewSQLBuilder("param", "db", liveRowsOnly, filter.Page, filter.PageSize)
 	qb.AliasPrefix("-", filter)
 
-	queryExt, _, args, err := qb.Build()
+	where, _, err := qb.BuildClause()
 	if err != nil {
 		zerolog.Ctx(ctx).Error().Err(err).Msg("build_users_query_err")
 		return nil, &pagination, appErr.WrapWithCode(err, appErr.CodeSQLQueryBuild, "build_users_query_err")
 	}
 
-	fullQuery := baseQuery + queryExt
-	zerolog.Ctx(ctx).Debug().Str("query", util.CleanQuery(fullQuery)).Any("args", args).Msg("compiled_query")
-
-	var results []entity.User
-	rows, err := d.sql0.Query(ctx, fullQuery, args...)
+	results, err := d.queries.FindUsersBaseV2(ctx, database.Conn(ctx, d.sql0), queries.FindUsersBaseV2Params{Where: where})
 	if err != nil {
 		zerolog.Ctx(ctx).Error().Err(err).Msg("find_users_err")
 		return nil, &pagination, appErr.WrapWithCode(err, appErr.CodeSQLRowScan, "find_users_err")
 	}
-	defer rows.Close()
 
-	for rows.Next() {
-		var user entity.User
-		if err := rows.Scan(&user.ID, &user.Email, &user.Name, &user.Age, &user.Role, &user.IsActive, &user.CreatedAt, &user.UpdatedAt); err != nil {
-			zerolog.Ctx(ctx).Error().Err(err).Msg("scan_user_err")
-			return nil, &pagination, appErr.WrapWithCode(err, appErr.CodeSQLRowScan, "scan_user_err")
-		}
+	userPtr := &results
+	return userPtr, &pagination, nil
+}
+
+func (d *userRepository) exportSQLUserV2(ctx context.Context, filter *dto.UserFilterV2, fn func(*entity.User) error) error {
+	qb := query.NewSQLBuilder("param", "db", liveRowsOnly, 0, 0).WithoutPagination()
+	qb.AliasPrefix("-", filter)
 
-		results = append(results, user)
+	where, _, err := qb.BuildClause()
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("build_export_users_query_err")
+		return appErr.WrapWithCode(err, appErr.CodeSQLQueryBuild, "build_export_users_query_err")
 	}
 
-	userPtr := &results
-	return userPtr, &pagination, nil
+	err = database.StreamCursor(ctx, d.sql0, func(cursor *database.Cursor) error {
+		return d.queries.ExportUsers(ctx, cursor, queries.ExportUsersParams{Where: where}, fn)
+	})
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("export_users_err")
+		return appErr.WrapWithCode(err, appErr.CodeSQLRead, "export_users_err")
+	}
+
+	return nil
 }
 
 func sanitizeSortByV2(sort string) string {
@@ -0,0 +1,42 @@
+package vin
+
+import (
+	"context"
+	"time"
+
+	"go-far/internal/infra/cache"
+	"go-far/internal/infra/vpic"
+	"go-far/internal/model/dto"
+	"go-far/internal/model/entity"
+	appErr "go-far/internal/model/errors"
+)
+
+const (
+	// durationVINNotDecoded is how long a VIN vPIC could not decode is
+	// cached; vPIC's data changes rarely, but new models do get added.
+	durationVINNotDecoded = time.Hour
+
+	cacheKeyVIN = "vin:"
+)
+
+type VINRepositoryItf interface {
+	Decode(ctx context.Context, cacheControl dto.CacheControl, vin string) (*entity.DecodedVIN, error)
+}
+
+type vinRepository struct {
+	vpic      vpic.VPIC
+	vinLoader *cache.Loader[*entity.DecodedVIN]
+}
+
+func InitVINRepository(vpicClient vpic.VPIC, cacheStore *cache.Store, cacheTTL time.Duration) VINRepositoryItf {
+	return &vinRepository{
+		vpic: vpicClient,
+		vinLoader: cache.NewLoader[*entity.DecodedVIN](cacheStore, cache.LoaderOptions{
+			TTL:          cacheTTL,
+			Jitter:       cache.DefaultJitter,
+			NegativeTTL:  durationVINNotDecoded,
+			NotFoundCode: appErr.CodeHTTPUnprocessableEntity,
+			Codec:        cache.MsgpackCodec,
+		}),
+	}
+}
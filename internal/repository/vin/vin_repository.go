@@ -0,0 +1,44 @@
+package vin
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"go-far/internal/model/dto"
+	"go-far/internal/model/entity"
+)
+
+// Decode returns vPIC's decoding of vin, read through the cache. Decodings
+// never change for a VIN, so entries are not tagged and only expire.
+func (r *vinRepository) Decode(ctx context.Context, cacheControl dto.CacheControl, vin string) (*entity.DecodedVIN, error) {
+	return r.vinLoader.Get(ctx, cacheControl, cacheKeyVIN+vin, nil, func(ctx context.Context) (*entity.DecodedVIN, error) {
+		result, err := r.vpic.DecodeVinValues(ctx, vin)
+		if err != nil {
+			return nil, err
+		}
+
+		decoded := &entity.DecodedVIN{
+			VIN:          vin,
+			Brand:        strings.TrimSpace(result.Make),
+			Model:        strings.TrimSpace(result.Model),
+			Trim:         strings.TrimSpace(result.Trim),
+			Manufacturer: strings.TrimSpace(result.Manufacturer),
+			BodyClass:    strings.TrimSpace(result.BodyClass),
+			VehicleType:  strings.TrimSpace(result.VehicleType),
+			FuelType:     strings.TrimSpace(result.FuelTypePrimary),
+		}
+
+		if year, err := strconv.Atoi(strings.TrimSpace(result.ModelYear)); err == nil {
+			decoded.Year = year
+		}
+
+		// vPIC answers "0" when the VIN decoded cleanly; other codes come
+		// with a description of what could not be decoded.
+		if result.ErrorCode != "" && result.ErrorCode != "0" {
+			decoded.Note = strings.TrimSpace(result.ErrorText)
+		}
+
+		return decoded, nil
+	})
+}
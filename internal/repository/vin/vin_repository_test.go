@@ -0,0 +1,139 @@
+package vin
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go-far/internal/infra/vpic"
+	"go-far/internal/model/dto"
+	"go-far/internal/model/entity"
+	appErr "go-far/internal/model/errors"
+
+	"github.com/rs/zerolog"
+)
+
+const testVIN = "1HGCM82633A004352"
+
+// TestDecode runs Decode against a stub of vPIC's DecodeVinValues endpoint.
+// The requests bypass the cache, so no store is needed.
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		want   *entity.DecodedVIN
+		code   appErr.Code
+	}{
+		{
+			name:   "decoded",
+			status: http.StatusOK,
+			body: `{"Count":1,"Results":[{"VIN":"1HGCM82633A004352","Make":"HONDA","Model":"Accord",
+				"ModelYear":"2003","Trim":"EX-V6","Manufacturer":"AMERICAN HONDA MOTOR CO., INC.",
+				"BodyClass":"Coupe","VehicleType":"PASSENGER CAR","FuelTypePrimary":"Gasoline","ErrorCode":"0","ErrorText":"0 - VIN decoded clean."}]}`,
+			want: &entity.DecodedVIN{
+				VIN: testVIN, Brand: "HONDA", Model: "Accord", Year: 2003, Trim: "EX-V6",
+				Manufacturer: "AMERICAN HONDA MOTOR CO., INC.", BodyClass: "Coupe",
+				VehicleType: "PASSENGER CAR", FuelType: "Gasoline",
+			},
+		},
+		{
+			name:   "partial decoding keeps vPIC's warning",
+			status: http.StatusOK,
+			body:   `{"Count":1,"Results":[{"Make":"HONDA","Model":"","ModelYear":"","ErrorCode":"8","ErrorText":" 8 - No detailed data available currently. "}]}`,
+			want: &entity.DecodedVIN{
+				VIN: testVIN, Brand: "HONDA", Note: "8 - No detailed data available currently.",
+			},
+		},
+		{
+			name:   "empty make is unprocessable",
+			status: http.StatusOK,
+			body:   `{"Count":1,"Results":[{"Make":" ","ErrorCode":"11","ErrorText":"11 - Incorrect Model Year"}]}`,
+			code:   appErr.CodeHTTPUnprocessableEntity,
+		},
+		{
+			name:   "no results is unprocessable",
+			status: http.StatusOK,
+			body:   `{"Count":0,"Results":[]}`,
+			code:   appErr.CodeHTTPUnprocessableEntity,
+		},
+		{
+			name:   "error status is unavailable",
+			status: http.StatusInternalServerError,
+			body:   `oops`,
+			code:   appErr.CodeHTTPServiceUnavailable,
+		},
+		{
+			name:   "malformed body is unavailable",
+			status: http.StatusOK,
+			body:   `{"Results":`,
+			code:   appErr.CodeHTTPServiceUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/DecodeVinValues/"+testVIN || r.URL.Query().Get("format") != "json" {
+					t.Errorf("unexpected request %s", r.URL)
+				}
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			repo := newTestRepository(server.URL, server.Client())
+
+			got, err := repo.Decode(context.Background(), bypassCache(), testVIN)
+
+			if tt.code != 0 {
+				if got := appErr.ErrCode(err); got != tt.code {
+					t.Fatalf("error code = %v, want %v (err: %v)", got, tt.code, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Decode: %v", err)
+			}
+			if *got != *tt.want {
+				t.Errorf("got %+v, want %+v", *got, *tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeUnreachable(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	url := server.URL
+	server.Close()
+
+	tests := []struct {
+		name   string
+		client *http.Client
+	}{
+		{name: "server down", client: &http.Client{Timeout: time.Second}},
+		{name: "http client disabled", client: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newTestRepository(url, tt.client)
+
+			_, err := repo.Decode(context.Background(), bypassCache(), testVIN)
+			if got := appErr.ErrCode(err); got != appErr.CodeHTTPServiceUnavailable {
+				t.Errorf("error code = %v, want %v (err: %v)", got, appErr.CodeHTTPServiceUnavailable, err)
+			}
+		})
+	}
+}
+
+func newTestRepository(baseURL string, client *http.Client) VINRepositoryItf {
+	log := zerolog.Nop()
+	return InitVINRepository(vpic.InitVPIC(&log, &vpic.VPICOptions{BaseURL: baseURL}, client), nil, time.Hour)
+}
+
+func bypassCache() dto.CacheControl {
+	return dto.CacheControl{MustDbValidate: true, Result: &dto.CacheResult{}}
+}
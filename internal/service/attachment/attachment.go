@@ -0,0 +1,48 @@
+package attachment
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"go-far/internal/infra/blob"
+	"go-far/internal/model/entity"
+	"go-far/internal/repository/attachment"
+	"go-far/internal/repository/car"
+
+	"github.com/google/uuid"
+)
+
+// AttachmentOptions holds the upload limits and the settings of the signed
+// download links.
+type AttachmentOptions struct {
+	SigningKey   []byte
+	URLTTL       time.Duration
+	MaxFileBytes int64
+	MaxPerCar    int
+}
+
+type AttachmentServiceItf interface {
+	UploadAttachment(ctx context.Context, carID uuid.UUID, kind entity.AttachmentKind, fileName string, file io.ReadSeeker, size int64, userID string) (*entity.CarAttachment, error)
+	ListAttachments(ctx context.Context, carID uuid.UUID, userID string, isAdmin bool) ([]entity.CarAttachment, error)
+	GetAttachment(ctx context.Context, carID, id uuid.UUID, userID string, isAdmin bool) (*entity.CarAttachment, error)
+	DeleteAttachment(ctx context.Context, carID, id uuid.UUID, userID string) error
+	OpenDownload(ctx context.Context, id uuid.UUID, expires int64, signature string) (*entity.CarAttachment, io.ReadCloser, error)
+	MaxFileBytes() int64
+}
+
+type attachmentService struct {
+	attachmentRepository attachment.AttachmentRepositoryItf
+	carRepository        car.CarRepositoryItf
+	blobStore            blob.BlobStore
+	opt                  AttachmentOptions
+}
+
+func InitAttachmentService(attachmentRepository attachment.AttachmentRepositoryItf, carRepository car.CarRepositoryItf, blobStore blob.BlobStore, opt AttachmentOptions) AttachmentServiceItf {
+	return &attachmentService{
+		attachmentRepository: attachmentRepository,
+		carRepository:        carRepository,
+		blobStore:            blobStore,
+		opt:                  opt,
+	}
+}
@@ -0,0 +1,292 @@
+package attachment
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"go-far/internal/model/entity"
+	appErr "go-far/internal/model/errors"
+	"go-far/internal/preference"
+	carService "go-far/internal/service/car"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+const (
+	// sniffLen is how much of a file http.DetectContentType looks at.
+	sniffLen = 512
+	// maxFileNameLength matches car_attachments.file_name.
+	maxFileNameLength = 255
+)
+
+// allowedContentTypes lists, per kind, the sniffed types an upload may have.
+// The client's Content-Type is never trusted.
+var allowedContentTypes = map[entity.AttachmentKind][]string{
+	entity.AttachmentPhoto:    {"image/jpeg", "image/png", "image/webp"},
+	entity.AttachmentDocument: {"application/pdf", "image/jpeg", "image/png"},
+}
+
+// UploadAttachment stores the file and then its metadata. Should the insert
+// fail, the stored file is removed again.
+func (s *attachmentService) UploadAttachment(ctx context.Context, carID uuid.UUID, kind entity.AttachmentKind, fileName string, file io.ReadSeeker, size int64, userID string) (*entity.CarAttachment, error) {
+	if err := carService.RequireCarEditor(ctx, s.carRepository, carID, userID); err != nil {
+		return nil, err
+	}
+
+	if size <= 0 {
+		return nil, appErr.NewWithCode(appErr.CodeHTTPBadRequest, "file is empty")
+	}
+
+	if size > s.opt.MaxFileBytes {
+		return nil, appErr.NewWithCode(appErr.CodeHTTPBadRequest, "file exceeds %d bytes", s.opt.MaxFileBytes)
+	}
+
+	count, err := s.attachmentRepository.CountByCarID(ctx, carID)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.opt.MaxPerCar > 0 && count >= s.opt.MaxPerCar {
+		return nil, appErr.NewWithCode(appErr.CodeHTTPConflict, "car already has %d attachments", s.opt.MaxPerCar)
+	}
+
+	contentType, err := sniffContentType(file, kind)
+	if err != nil {
+		return nil, err
+	}
+
+	checksum, err := sha256Hex(file)
+	if err != nil {
+		return nil, err
+	}
+
+	storageID, err := uuid.NewV7()
+	if err != nil {
+		return nil, appErr.Wrap(err, "generate_storage_key")
+	}
+
+	uploadedBy := userID
+	att := &entity.CarAttachment{
+		CarID:       carID.String(),
+		Kind:        kind,
+		FileName:    sanitizeFileName(fileName),
+		ContentType: contentType,
+		SizeBytes:   size,
+		Checksum:    checksum,
+		StorageKey:  "cars/" + carID.String() + "/" + storageID.String(),
+		UploadedBy:  &uploadedBy,
+	}
+
+	if err := s.blobStore.Put(ctx, att.StorageKey, file, size, contentType); err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Str("key", att.StorageKey).Msg("store_attachment_err")
+		return nil, err
+	}
+
+	if err := s.attachmentRepository.Create(ctx, att); err != nil {
+		s.deleteBlob(ctx, att.StorageKey)
+		return nil, err
+	}
+
+	s.sign(att)
+
+	return att, nil
+}
+
+// ListAttachments is open to every user with a role on the car, and to admins.
+func (s *attachmentService) ListAttachments(ctx context.Context, carID uuid.UUID, userID string, isAdmin bool) ([]entity.CarAttachment, error) {
+	if err := s.checkCanView(ctx, carID, userID, isAdmin); err != nil {
+		return nil, err
+	}
+
+	attachments, err := s.attachmentRepository.FindByCarID(ctx, carID)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range attachments {
+		s.sign(&attachments[i])
+	}
+
+	return attachments, nil
+}
+
+func (s *attachmentService) GetAttachment(ctx context.Context, carID, id uuid.UUID, userID string, isAdmin bool) (*entity.CarAttachment, error) {
+	if err := s.checkCanView(ctx, carID, userID, isAdmin); err != nil {
+		return nil, err
+	}
+
+	att, err := s.attachmentRepository.FindByID(ctx, carID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	s.sign(att)
+
+	return att, nil
+}
+
+// DeleteAttachment removes the metadata first, so a file that fails to be
+// deleted is only left orphaned in storage, never listed without content.
+func (s *attachmentService) DeleteAttachment(ctx context.Context, carID, id uuid.UUID, userID string) error {
+	if err := carService.RequireCarEditor(ctx, s.carRepository, carID, userID); err != nil {
+		return err
+	}
+
+	storageKey, err := s.attachmentRepository.Delete(ctx, carID, id)
+	if err != nil {
+		return err
+	}
+
+	s.deleteBlob(ctx, storageKey)
+
+	return nil
+}
+
+// OpenDownload checks a signed link and opens the attachment it points to.
+// The caller closes the returned reader.
+func (s *attachmentService) OpenDownload(ctx context.Context, id uuid.UUID, expires int64, signature string) (*entity.CarAttachment, io.ReadCloser, error) {
+	expected := s.signature(id.String(), expires)
+	if !hmac.Equal([]byte(signature), []byte(expected)) {
+		return nil, nil, appErr.NewWithCode(appErr.CodeHTTPForbidden, "invalid download link")
+	}
+
+	if time.Now().Unix() > expires {
+		return nil, nil, appErr.NewWithCode(appErr.CodeHTTPForbidden, "download link has expired")
+	}
+
+	att, err := s.attachmentRepository.FindForDownload(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	body, err := s.blobStore.Get(ctx, att.StorageKey)
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Str("key", att.StorageKey).Msg("open_attachment_err")
+		return nil, nil, err
+	}
+
+	expiresAt := time.Unix(expires, 0).UTC()
+	att.DownloadURLExpiresAt = &expiresAt
+
+	return att, body, nil
+}
+
+func (s *attachmentService) MaxFileBytes() int64 {
+	return s.opt.MaxFileBytes
+}
+
+// sign sets a download link valid for the configured TTL.
+func (s *attachmentService) sign(att *entity.CarAttachment) {
+	expiresAt := time.Now().Add(s.opt.URLTTL).Truncate(time.Second).UTC()
+	expires := expiresAt.Unix()
+
+	att.DownloadURL = strings.Replace(preference.RouteAttachmentDownload, "{id}", att.ID, 1) +
+		"?expires=" + strconv.FormatInt(expires, 10) +
+		"&signature=" + s.signature(att.ID, expires)
+	att.DownloadURLExpiresAt = &expiresAt
+}
+
+func (s *attachmentService) signature(id string, expires int64) string {
+	mac := hmac.New(sha256.New, s.opt.SigningKey)
+	mac.Write([]byte(id + ":" + strconv.FormatInt(expires, 10)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// deleteBlob removes a stored file, only logging a failure: the metadata is
+// already gone, so at worst the file is orphaned.
+func (s *attachmentService) deleteBlob(ctx context.Context, key string) {
+	if err := s.blobStore.Delete(ctx, key); err != nil {
+		zerolog.Ctx(ctx).Warn().Err(err).Str("key", key).Msg("delete_attachment_blob_err")
+	}
+}
+
+// checkCanView lets admins and anyone with a role on the car read its
+// attachments.
+func (s *attachmentService) checkCanView(ctx context.Context, carID uuid.UUID, userID string, isAdmin bool) error {
+	if isAdmin {
+		return nil
+	}
+
+	role, err := s.carRepository.FindCarRole(ctx, carID, userID)
+	if err != nil {
+		return err
+	}
+
+	if role == "" {
+		return appErr.NewWithCode(appErr.CodeHTTPForbidden, "you do not have permission to view the attachments of this car")
+	}
+
+	return nil
+}
+
+// sniffContentType detects the file's type from its first bytes and checks
+// it is allowed for kind. The file is rewound afterwards.
+func sniffContentType(file io.ReadSeeker, kind entity.AttachmentKind) (string, error) {
+	head := make([]byte, sniffLen)
+	n, err := io.ReadFull(file, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return "", appErr.WrapWithCode(err, appErr.CodeHTTPBadRequest, "read_attachment")
+	}
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return "", appErr.Wrap(err, "rewind_attachment")
+	}
+
+	contentType, _, _ := strings.Cut(http.DetectContentType(head[:n]), ";")
+	if !slices.Contains(allowedContentTypes[kind], contentType) {
+		return "", appErr.NewWithCode(appErr.CodeHTTPUnsupportedMediaType, "%s is not allowed for a %s; allowed: %s",
+			contentType, kind, strings.Join(allowedContentTypes[kind], ", "))
+	}
+
+	return contentType, nil
+}
+
+func sha256Hex(file io.ReadSeeker) (string, error) {
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", appErr.WrapWithCode(err, appErr.CodeHTTPBadRequest, "read_attachment")
+	}
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return "", appErr.Wrap(err, "rewind_attachment")
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// sanitizeFileName keeps the base name of what the client sent, without
+// control characters and within the column's length.
+func sanitizeFileName(name string) string {
+	name = path.Base(strings.ReplaceAll(name, "\\", "/"))
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, name)
+	name = strings.TrimSpace(name)
+
+	for utf8.RuneCountInString(name) > maxFileNameLength {
+		_, size := utf8.DecodeLastRuneInString(name)
+		name = name[:len(name)-size]
+	}
+
+	if name == "" || name == "." || name == "/" {
+		return "attachment"
+	}
+
+	return name
+}
@@ -2,10 +2,15 @@ package car
 
 import (
 	"context"
+	"io"
+	"time"
 
+	"go-far/internal/infra/blob"
+	"go-far/internal/infra/database"
 	"go-far/internal/model/dto"
 	"go-far/internal/model/entity"
 	"go-far/internal/repository/car"
+	"go-far/internal/repository/vin"
 
 	"github.com/google/uuid"
 )
@@ -13,22 +18,39 @@ import (
 type CarServiceItf interface {
 	CreateCar(ctx context.Context, req dto.CreateCarRequest, ownerUserID string) (*entity.Car, error)
 	CreateBulkCars(ctx context.Context, req dto.BulkCreateCarsRequest, ownerUserID string) ([]*entity.Car, error)
-	GetCar(ctx context.Context, id uuid.UUID) (*entity.Car, error)
-	GetCarWithOwner(ctx context.Context, id uuid.UUID) (*entity.CarWithOwner, error)
-	ListCarsByUser(ctx context.Context, userID uuid.UUID) ([]*entity.Car, error)
+	ImportCarsCSV(ctx context.Context, src io.Reader, ownerUserID string, dryRun bool) (*dto.CarImportResult, error)
+	GetCar(ctx context.Context, cacheControl dto.CacheControl, id uuid.UUID) (*entity.Car, error)
+	GetCarByPlate(ctx context.Context, cacheControl dto.CacheControl, plate string) (*entity.Car, error)
+	GetCarWithDeleted(ctx context.Context, id uuid.UUID) (*entity.Car, error)
+	GetCarColumns(ctx context.Context, cacheControl dto.CacheControl, id uuid.UUID, columns []string) (*entity.Car, error)
+	GetCarWithOwner(ctx context.Context, cacheControl dto.CacheControl, id uuid.UUID, columns []string) (*entity.CarWithOwner, error)
+	ListCarsByUser(ctx context.Context, cacheControl dto.CacheControl, userID uuid.UUID, columns []string) ([]*entity.Car, error)
 	CountCarsByUser(ctx context.Context, userID uuid.UUID) (int, error)
 	UpdateCar(ctx context.Context, id uuid.UUID, req *dto.UpdateCarRequest, userID string) (*entity.Car, error)
-	DeleteCar(ctx context.Context, id uuid.UUID, userID string) error
-	TransferCarOwnership(ctx context.Context, carID, newUserID uuid.UUID, userID string) error
-	BulkUpdateAvailability(ctx context.Context, req dto.BulkUpdateAvailabilityRequest, userID string) error
+	PatchCar(ctx context.Context, id uuid.UUID, req dto.PatchRequest, userID string) (*entity.Car, error)
+	DeleteCar(ctx context.Context, id uuid.UUID, userID, reason string) error
+	RestoreCar(ctx context.Context, id uuid.UUID, actorID string) (*entity.Car, error)
+	PurgeDeletedCars(ctx context.Context, deletedBefore time.Time, batchSize int) (int64, error)
+	GetCarHistory(ctx context.Context, carID uuid.UUID, userID string, isAdmin bool) ([]entity.CarOwnershipRecord, error)
+	ListCarShares(ctx context.Context, carID uuid.UUID, userID string, isAdmin bool) ([]entity.CarShare, error)
+	ShareCar(ctx context.Context, carID, targetUserID uuid.UUID, req dto.ShareCarRequest, userID string) (*entity.CarShare, error)
+	UnshareCar(ctx context.Context, carID, targetUserID uuid.UUID, userID string) error
+	ExportCars(ctx context.Context, filter *dto.CarFilterV2, fn func(*entity.Car) error) error
+	DecodeVIN(ctx context.Context, cacheControl dto.CacheControl, vin string) (*entity.DecodedVIN, error)
 }
 
 type carService struct {
 	carRepository car.CarRepositoryItf
+	vinRepository vin.VINRepositoryItf
+	tx            database.Transactor
+	blobStore     blob.BlobStore
 }
 
-func InitCarService(carRepository car.CarRepositoryItf) CarServiceItf {
+func InitCarService(carRepository car.CarRepositoryItf, vinRepository vin.VINRepositoryItf, tx database.Transactor, blobStore blob.BlobStore) CarServiceItf {
 	return &carService{
 		carRepository: carRepository,
+		vinRepository: vinRepository,
+		tx:            tx,
+		blobStore:     blobStore,
 	}
 }
@@ -0,0 +1,303 @@
+package car
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"io"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"go-far/internal/infra/validator"
+	"go-far/internal/model/dto"
+	"go-far/internal/model/entity"
+	appErr "go-far/internal/model/errors"
+	"go-far/internal/util"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+const (
+	carImportMaxRows   = 10000
+	carImportChunkSize = 500
+)
+
+// carImportRequired lists the CSV columns an import must have; color,
+// plate_region and vin are optional.
+var carImportRequired = []string{"brand", "model", "year", "license_plate"}
+
+type carImportRow struct {
+	car  *entity.Car
+	line int
+}
+
+func (s *carService) ImportCarsCSV(ctx context.Context, src io.Reader, ownerUserID string, dryRun bool) (*dto.CarImportResult, error) {
+	ownerID, err := uuid.Parse(ownerUserID)
+	if err != nil {
+		return nil, appErr.WrapWithCode(err, appErr.CodeHTTPBadRequest, "invalid_owner_id")
+	}
+
+	reader := csv.NewReader(src)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	columns, err := readImportHeader(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &dto.CarImportResult{DryRun: dryRun, Errors: []dto.CarImportRowError{}}
+	rows := make([]carImportRow, 0)
+	seen := make(map[string]int)
+	seenVINs := make(map[string]int)
+
+	for {
+		record, readErr := reader.Read()
+		if errors.Is(readErr, io.EOF) {
+			break
+		}
+
+		result.TotalRows++
+		if result.TotalRows > carImportMaxRows {
+			return nil, appErr.NewWithCode(appErr.CodeHTTPBadRequest, "import exceeds %d rows", carImportMaxRows)
+		}
+
+		if readErr != nil {
+			var line int
+			var parseErr *csv.ParseError
+			if errors.As(readErr, &parseErr) {
+				line = parseErr.StartLine
+			}
+			result.Errors = append(result.Errors, dto.CarImportRowError{Row: line, Errors: []string{readErr.Error()}})
+			continue
+		}
+
+		line, _ := reader.FieldPos(0)
+
+		req, messages := parseImportRecord(record, columns)
+		plate := util.NormalizePlate(req.LicensePlate)
+		if first, ok := seen[plate]; ok && plate != "" {
+			messages = append(messages, "LicensePlate duplicates row "+strconv.Itoa(first))
+		} else {
+			seen[plate] = line
+		}
+
+		vin := storedVIN(req.VIN)
+		if vin != nil {
+			if first, ok := seenVINs[*vin]; ok {
+				messages = append(messages, "VIN duplicates row "+strconv.Itoa(first))
+			} else {
+				seenVINs[*vin] = line
+			}
+		}
+
+		if len(messages) > 0 {
+			result.Errors = append(result.Errors, dto.CarImportRowError{Row: line, LicensePlate: req.LicensePlate, Errors: messages})
+			continue
+		}
+
+		rows = append(rows, carImportRow{
+			line: line,
+			car: &entity.Car{
+				Brand:        req.Brand,
+				Model:        req.Model,
+				Year:         req.Year,
+				Color:        req.Color,
+				LicensePlate: req.LicensePlate,
+				PlateRegion:  storedPlateRegion(req.PlateRegion),
+				VIN:          vin,
+			},
+		})
+	}
+
+	rows, err = s.dropExistingPlates(ctx, rows, result)
+	if err != nil {
+		return nil, err
+	}
+
+	result.Valid = len(rows)
+
+	if !dryRun {
+		s.importRows(ctx, ownerID, rows, result)
+	}
+
+	sort.SliceStable(result.Errors, func(i, j int) bool {
+		return result.Errors[i].Row < result.Errors[j].Row
+	})
+	result.Failed = len(result.Errors)
+
+	return result, nil
+}
+
+// readImportHeader maps the header names to their column index.
+func readImportHeader(reader *csv.Reader) (map[string]int, error) {
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, appErr.NewWithCode(appErr.CodeHTTPBadRequest, "import file is empty")
+	}
+	if err != nil {
+		return nil, appErr.WrapWithCode(err, appErr.CodeHTTPBadRequest, "invalid_csv_header")
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		columns[name] = i
+	}
+
+	var missing []string
+	for _, name := range carImportRequired {
+		if _, ok := columns[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+
+	if len(missing) > 0 {
+		return nil, appErr.NewWithCode(appErr.CodeHTTPBadRequest, "missing CSV columns: %s", strings.Join(missing, ", "))
+	}
+
+	return columns, nil
+}
+
+// parseImportRecord builds the request for one row and validates it with the
+// same rules as POST /cars.
+func parseImportRecord(record []string, columns map[string]int) (dto.CreateCarRequest, []string) {
+	cell := func(name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	req := dto.CreateCarRequest{
+		Brand:        cell("brand"),
+		Model:        cell("model"),
+		Color:        cell("color"),
+		LicensePlate: cell("license_plate"),
+		PlateRegion:  cell("plate_region"),
+		VIN:          cell("vin"),
+	}
+
+	numericYear := true
+	if raw := cell("year"); raw != "" {
+		year, err := strconv.Atoi(raw)
+		numericYear = err == nil
+		req.Year = year
+	}
+
+	messages := validator.Messages(&req)
+	if !numericYear {
+		messages = slices.DeleteFunc(messages, func(msg string) bool {
+			return strings.HasPrefix(msg, "Year ")
+		})
+		messages = append(messages, "Year must be a number")
+	}
+
+	return req, messages
+}
+
+// dropExistingPlates reports and removes rows whose plate or VIN is already
+// taken.
+func (s *carService) dropExistingPlates(ctx context.Context, rows []carImportRow, result *dto.CarImportResult) ([]carImportRow, error) {
+	plates := make([]string, len(rows))
+	vins := make([]string, 0, len(rows))
+	for i, row := range rows {
+		plates[i] = util.NormalizePlate(row.car.LicensePlate)
+		if row.car.VIN != nil {
+			vins = append(vins, *row.car.VIN)
+		}
+	}
+
+	existing, err := s.carRepository.FindExistingLicensePlates(ctx, plates)
+	if err != nil {
+		return nil, err
+	}
+
+	existingVINs, err := s.carRepository.FindExistingVINs(ctx, vins)
+	if err != nil {
+		return nil, err
+	}
+
+	kept := rows[:0]
+	for _, row := range rows {
+		var messages []string
+		if _, ok := existing[util.NormalizePlate(row.car.LicensePlate)]; ok {
+			messages = append(messages, "LicensePlate already exists")
+		}
+		if row.car.VIN != nil {
+			if _, ok := existingVINs[*row.car.VIN]; ok {
+				messages = append(messages, "VIN already exists")
+			}
+		}
+
+		if len(messages) > 0 {
+			result.Errors = append(result.Errors, dto.CarImportRowError{
+				Row:          row.line,
+				LicensePlate: row.car.LicensePlate,
+				Errors:       messages,
+			})
+			continue
+		}
+		kept = append(kept, row)
+	}
+
+	return kept, nil
+}
+
+// importRows inserts rows in chunks, each in its own transaction along with
+// the cars' ownership history. A plate or VIN
+// taken by a concurrent writer is reported on its row; a failing chunk stops
+// the import and its remaining rows are reported as not imported.
+func (s *carService) importRows(ctx context.Context, ownerID uuid.UUID, rows []carImportRow, result *dto.CarImportResult) {
+	for start := 0; start < len(rows); start += carImportChunkSize {
+		chunk := rows[start:min(start+carImportChunkSize, len(rows))]
+
+		cars := make([]*entity.Car, len(chunk))
+		for i, row := range chunk {
+			cars[i] = row.car
+		}
+
+		var inserted []*entity.Car
+		err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+			var err error
+			inserted, err = s.carRepository.ImportBulk(ctx, ownerID, cars)
+			if err != nil {
+				return err
+			}
+
+			records := make([]entity.CarOwnershipRecord, len(inserted))
+			for i, car := range inserted {
+				records[i] = entity.NewCarOwnershipRecord(car.ID, entity.CarOwnershipCreated, "", ownerID.String(), ownerID.String(), "")
+			}
+
+			return s.carRepository.AppendOwnershipHistory(ctx, records)
+		})
+		if err != nil {
+			zerolog.Ctx(ctx).Error().Err(err).Int("row", chunk[0].line).Msg("import_cars_chunk_err")
+			for _, row := range rows[start:] {
+				result.Errors = append(result.Errors, dto.CarImportRowError{
+					Row:          row.line,
+					LicensePlate: row.car.LicensePlate,
+					Errors:       []string{"not imported: batch failed"},
+				})
+			}
+			return
+		}
+
+		result.Imported += len(inserted)
+
+		for _, row := range chunk {
+			if row.car.ID == "" {
+				result.Errors = append(result.Errors, dto.CarImportRowError{
+					Row:          row.line,
+					LicensePlate: row.car.LicensePlate,
+					Errors:       []string{"LicensePlate or VIN already exists"},
+				})
+			}
+		}
+	}
+}
@@ -0,0 +1,241 @@
+package car
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"go-far/internal/infra/validator"
+	"go-far/internal/model/dto"
+	"go-far/internal/model/entity"
+	appErr "go-far/internal/model/errors"
+	"go-far/internal/repository/car"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+const importOwnerID = "0190a3b4-0000-7000-8000-000000000001"
+
+// fakeCarRepository embeds the interface so tests only implement what the
+// service under test calls.
+type fakeCarRepository struct {
+	car.CarRepositoryItf
+
+	existing  map[string]struct{}
+	raced     map[string]struct{}
+	importErr error
+	imported  [][]*entity.Car
+	history   []entity.CarOwnershipRecord
+}
+
+// fakeTransactor runs fn directly, without a transaction.
+type fakeTransactor struct{}
+
+func (fakeTransactor) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func (r *fakeCarRepository) FindExistingLicensePlates(_ context.Context, plates []string) (map[string]struct{}, error) {
+	found := make(map[string]struct{})
+	for _, plate := range plates {
+		if _, ok := r.existing[plate]; ok {
+			found[plate] = struct{}{}
+		}
+	}
+
+	return found, nil
+}
+
+func (r *fakeCarRepository) FindExistingVINs(_ context.Context, _ []string) (map[string]struct{}, error) {
+	return map[string]struct{}{}, nil
+}
+
+func (r *fakeCarRepository) AppendOwnershipHistory(_ context.Context, records []entity.CarOwnershipRecord) error {
+	r.history = append(r.history, records...)
+
+	return nil
+}
+
+func (r *fakeCarRepository) ImportBulk(_ context.Context, _ uuid.UUID, cars []*entity.Car) ([]*entity.Car, error) {
+	r.imported = append(r.imported, cars)
+	if r.importErr != nil {
+		return nil, r.importErr
+	}
+
+	var inserted []*entity.Car
+	for _, c := range cars {
+		if _, ok := r.raced[c.LicensePlate]; ok {
+			continue
+		}
+		c.ID = uuid.NewString()
+		inserted = append(inserted, c)
+	}
+
+	return inserted, nil
+}
+
+func initImportValidator() {
+	log := zerolog.Nop()
+	validator.InitValidator(&log)
+}
+
+func TestImportCarsCSVHeader(t *testing.T) {
+	initImportValidator()
+
+	tests := []struct {
+		name    string
+		csv     string
+		wantMsg string
+	}{
+		{name: "empty file", csv: "", wantMsg: "import file is empty"},
+		{name: "missing columns", csv: "brand,model\nVW,Golf\n", wantMsg: "missing CSV columns: year, license_plate"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := InitCarService(&fakeCarRepository{}, nil, fakeTransactor{}, nil)
+
+			_, err := svc.ImportCarsCSV(context.Background(), strings.NewReader(tt.csv), importOwnerID, false)
+			if appErr.ErrCode(err) != appErr.CodeHTTPBadRequest {
+				t.Fatalf("error = %v, want a bad request", err)
+			}
+
+			if !strings.Contains(err.Error(), tt.wantMsg) {
+				t.Errorf("error = %q, want %q", err.Error(), tt.wantMsg)
+			}
+		})
+	}
+}
+
+// TestImportCarsCSVRows reports each bad row by its CSV line and imports the
+// rest: invalid values, a malformed line, in-file and existing duplicates.
+func TestImportCarsCSVRows(t *testing.T) {
+	initImportValidator()
+
+	src := "\ufeffBrand, Model, Year, License_Plate, Color\n" + // line 1
+		"VW,Golf,2019,B-AB 123,blue\n" + // 2: ok
+		"Audi,A3,abc,B-CD 456,\n" + // 3: year not a number
+		"B,Polo,2021,B-EF 789,\n" + // 4: brand too short
+		"Opel,Co\"rsa,2020,B-GH 012\n" + // 5: bare quote
+		"\n" +
+		"VW,Passat,2018,b-ab-123,\n" + // 7: duplicates line 2 once normalized
+		"BMW,X1,2020,B-XX 999,\n" + // 8: already in the database
+		"Seat,Ibiza,2017\n" // 9: short record, no plate
+
+	repo := &fakeCarRepository{existing: map[string]struct{}{"BXX999": {}}}
+	svc := InitCarService(repo, nil, fakeTransactor{}, nil)
+
+	result, err := svc.ImportCarsCSV(context.Background(), strings.NewReader(src), importOwnerID, false)
+	if err != nil {
+		t.Fatalf("ImportCarsCSV: %v", err)
+	}
+
+	if result.Imported != 1 || result.Valid != 1 {
+		t.Errorf("imported = %d, valid = %d, want 1 and 1", result.Imported, result.Valid)
+	}
+
+	if result.Failed != len(result.Errors) {
+		t.Errorf("failed = %d, want %d", result.Failed, len(result.Errors))
+	}
+
+	got := make(map[int]string)
+	var rows []int
+	for _, rowErr := range result.Errors {
+		rows = append(rows, rowErr.Row)
+		got[rowErr.Row] = strings.Join(rowErr.Errors, "; ")
+	}
+
+	want := map[int]string{
+		3: "Year must be a number",
+		4: "Brand must be at least 2 characters",
+		7: "LicensePlate duplicates row 2",
+		8: "LicensePlate already exists",
+		9: "LicensePlate is required",
+	}
+	for row, msg := range want {
+		if !strings.Contains(got[row], msg) {
+			t.Errorf("row %d errors = %q, want %q", row, got[row], msg)
+		}
+	}
+
+	if _, ok := got[5]; !ok {
+		t.Errorf("malformed line 5 not reported: %v", got)
+	}
+
+	if !slices.IsSorted(rows) {
+		t.Errorf("errors not ordered by row: %v", rows)
+	}
+
+	if len(repo.imported) != 1 || len(repo.imported[0]) != 1 || repo.imported[0][0].LicensePlate != "B-AB 123" {
+		t.Errorf("imported batches = %v", repo.imported)
+	}
+}
+
+func TestImportCarsCSVDryRun(t *testing.T) {
+	initImportValidator()
+
+	src := "brand,model,year,license_plate\nVW,Golf,2019,B-AB 123\nAudi,A3,2020,B-CD 456\nBMW,X1,1800,B-EF 789\n"
+	repo := &fakeCarRepository{}
+	svc := InitCarService(repo, nil, fakeTransactor{}, nil)
+
+	result, err := svc.ImportCarsCSV(context.Background(), strings.NewReader(src), importOwnerID, true)
+	if err != nil {
+		t.Fatalf("ImportCarsCSV: %v", err)
+	}
+
+	want := &dto.CarImportResult{
+		DryRun:    true,
+		TotalRows: 3,
+		Valid:     2,
+		Failed:    1,
+		Errors: []dto.CarImportRowError{
+			{Row: 4, LicensePlate: "B-EF 789", Errors: []string{"Year must be >= 1900"}},
+		},
+	}
+	if !reflect.DeepEqual(result, want) {
+		t.Errorf("result = %+v, want %+v", result, want)
+	}
+
+	if len(repo.imported) != 0 {
+		t.Errorf("dry run imported %d batches", len(repo.imported))
+	}
+}
+
+// TestImportCarsCSVInsertConflicts covers plates or VINs taken between the
+// existence check and the insert, and a batch that fails outright.
+func TestImportCarsCSVInsertConflicts(t *testing.T) {
+	initImportValidator()
+
+	src := "brand,model,year,license_plate\nVW,Golf,2019,B-AB 123\nAudi,A3,2020,B-CD 456\n"
+
+	t.Run("raced plate", func(t *testing.T) {
+		repo := &fakeCarRepository{raced: map[string]struct{}{"B-CD 456": {}}}
+
+		result, err := InitCarService(repo, nil, fakeTransactor{}, nil).ImportCarsCSV(context.Background(), strings.NewReader(src), importOwnerID, false)
+		if err != nil {
+			t.Fatalf("ImportCarsCSV: %v", err)
+		}
+
+		want := []dto.CarImportRowError{{Row: 3, LicensePlate: "B-CD 456", Errors: []string{"LicensePlate or VIN already exists"}}}
+		if result.Imported != 1 || !reflect.DeepEqual(result.Errors, want) {
+			t.Errorf("imported = %d, errors = %+v", result.Imported, result.Errors)
+		}
+	})
+
+	t.Run("failed batch", func(t *testing.T) {
+		repo := &fakeCarRepository{importErr: errors.New("connection reset")}
+
+		result, err := InitCarService(repo, nil, fakeTransactor{}, nil).ImportCarsCSV(context.Background(), strings.NewReader(src), importOwnerID, false)
+		if err != nil {
+			t.Fatalf("ImportCarsCSV: %v", err)
+		}
+
+		if result.Imported != 0 || result.Failed != 2 {
+			t.Errorf("imported = %d, failed = %d, want 0 and 2", result.Imported, result.Failed)
+		}
+	})
+}
@@ -0,0 +1,36 @@
+package car
+
+import (
+	"context"
+
+	"go-far/internal/model/entity"
+	appErr "go-far/internal/model/errors"
+
+	"github.com/google/uuid"
+)
+
+// GetCarHistory lists every change of hands of a car, oldest first. Only the
+// current owner or an admin may read it.
+func (s *carService) GetCarHistory(ctx context.Context, carID uuid.UUID, userID string, isAdmin bool) ([]entity.CarOwnershipRecord, error) {
+	if !isAdmin {
+		isOwner, err := s.carRepository.IsCarOwnedByUser(ctx, carID, userID)
+		if err != nil {
+			return nil, err
+		}
+
+		if !isOwner {
+			return nil, appErr.NewWithCode(appErr.CodeHTTPForbidden, "you do not have permission to view this car's history")
+		}
+	}
+
+	records, err := s.carRepository.FindOwnershipHistory(ctx, carID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(records) == 0 {
+		return nil, appErr.NewWithCode(appErr.CodeHTTPNotFound, "car_not_found")
+	}
+
+	return records, nil
+}
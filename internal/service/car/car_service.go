@@ -2,39 +2,56 @@ package car
 
 import (
 	"context"
+	"strings"
+	"time"
 
+	"go-far/internal/infra/validator"
 	"go-far/internal/model/dto"
 	"go-far/internal/model/entity"
 	appErr "go-far/internal/model/errors"
+	"go-far/internal/util"
 
 	"github.com/google/uuid"
+	"github.com/rs/zerolog"
 )
 
 func (s *carService) CreateCar(ctx context.Context, req dto.CreateCarRequest, ownerUserID string) (*entity.Car, error) {
+	userUUID, err := uuid.Parse(ownerUserID)
+	if err != nil {
+		return nil, err
+	}
+
 	car := &entity.Car{
 		Brand:        req.Brand,
 		Model:        req.Model,
 		Year:         req.Year,
 		Color:        req.Color,
-		LicensePlate: req.LicensePlate,
-		IsAvailable:  true,
+		LicensePlate: strings.TrimSpace(req.LicensePlate),
+		PlateRegion:  storedPlateRegion(req.PlateRegion),
+		VIN:          storedVIN(req.VIN),
 	}
 
-	if err := s.carRepository.Create(ctx, car); err != nil {
-		return nil, err
-	}
+	// The car and its owner link are written together, so a failed
+	// assignment does not leave an orphan car behind.
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.carRepository.Create(ctx, car); err != nil {
+			return err
+		}
 
-	carUUID, err := uuid.Parse(car.ID)
-	if err != nil {
-		return nil, err
-	}
+		carUUID, err := uuid.Parse(car.ID)
+		if err != nil {
+			return err
+		}
 
-	userUUID, err := uuid.Parse(ownerUserID)
-	if err != nil {
-		return nil, err
-	}
+		if err := s.carRepository.AssignCarToUser(ctx, userUUID, carUUID); err != nil {
+			return err
+		}
 
-	if err := s.carRepository.AssignCarToUser(ctx, userUUID, carUUID); err != nil {
+		return s.carRepository.AppendOwnershipHistory(ctx, []entity.CarOwnershipRecord{
+			entity.NewCarOwnershipRecord(car.ID, entity.CarOwnershipCreated, "", ownerUserID, ownerUserID, ""),
+		})
+	})
+	if err != nil {
 		return nil, err
 	}
 
@@ -42,56 +59,109 @@ func (s *carService) CreateCar(ctx context.Context, req dto.CreateCarRequest, ow
 }
 
 func (s *carService) CreateBulkCars(ctx context.Context, req dto.BulkCreateCarsRequest, ownerUserID string) ([]*entity.Car, error) {
-	cars := make([]*entity.Car, 0, len(req.Cars))
-	carIDs := make([]uuid.UUID, 0, len(req.Cars))
+	userUUID, err := uuid.Parse(ownerUserID)
+	if err != nil {
+		return nil, err
+	}
 
+	cars := make([]*entity.Car, 0, len(req.Cars))
 	for _, carReq := range req.Cars {
 		car := &entity.Car{
 			Brand:        carReq.Brand,
 			Model:        carReq.Model,
 			Year:         carReq.Year,
 			Color:        carReq.Color,
-			LicensePlate: carReq.LicensePlate,
-			IsAvailable:  true,
+			LicensePlate: strings.TrimSpace(carReq.LicensePlate),
+			PlateRegion:  storedPlateRegion(carReq.PlateRegion),
+			VIN:          storedVIN(carReq.VIN),
 		}
 		cars = append(cars, car)
 	}
 
-	if err := s.carRepository.CreateBulk(ctx, cars); err != nil {
-		return nil, err
-	}
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.carRepository.CreateBulk(ctx, cars); err != nil {
+			return err
+		}
 
-	for _, car := range cars {
-		carID, err := uuid.Parse(car.ID)
-		if err != nil {
-			return nil, err
+		carIDs := make([]uuid.UUID, 0, len(cars))
+		records := make([]entity.CarOwnershipRecord, 0, len(cars))
+		for _, car := range cars {
+			carID, err := uuid.Parse(car.ID)
+			if err != nil {
+				return err
+			}
+			carIDs = append(carIDs, carID)
+			records = append(records, entity.NewCarOwnershipRecord(car.ID, entity.CarOwnershipCreated, "", ownerUserID, ownerUserID, ""))
 		}
-		carIDs = append(carIDs, carID)
-	}
 
-	// Assign all cars to user via junction table
-	userUUID, err := uuid.Parse(ownerUserID)
+		// Assign all cars to user via junction table
+		if err := s.carRepository.AssignCarsToUserBulk(ctx, userUUID, carIDs); err != nil {
+			return err
+		}
+
+		return s.carRepository.AppendOwnershipHistory(ctx, records)
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	if err := s.carRepository.AssignCarsToUserBulk(ctx, userUUID, carIDs); err != nil {
+	return cars, nil
+}
+
+func (s *carService) GetCar(ctx context.Context, cacheControl dto.CacheControl, id uuid.UUID) (*entity.Car, error) {
+	return s.carRepository.FindByID(ctx, cacheControl, id)
+}
+
+// GetCarByPlate finds a live car by its license plate in any spelling, e.g.
+// "b 1234-xy" for B1234XY.
+func (s *carService) GetCarByPlate(ctx context.Context, cacheControl dto.CacheControl, plate string) (*entity.Car, error) {
+	normalized := util.NormalizePlate(plate)
+	if normalized == "" {
+		return nil, appErr.NewWithCode(appErr.CodeHTTPBadRequest, "invalid_license_plate")
+	}
+
+	id, err := s.carRepository.FindIDByLicensePlate(ctx, normalized)
+	if err != nil {
 		return nil, err
 	}
 
-	return cars, nil
+	return s.carRepository.FindByID(ctx, cacheControl, id)
+}
+
+func (s *carService) GetCarWithDeleted(ctx context.Context, id uuid.UUID) (*entity.Car, error) {
+	return s.carRepository.FindByIDWithDeleted(ctx, id)
 }
 
-func (s *carService) GetCar(ctx context.Context, id uuid.UUID) (*entity.Car, error) {
-	return s.carRepository.FindByID(ctx, id)
+// GetCarColumns reads only the given columns, bypassing the cache; an empty
+// list reads the whole car through GetCar.
+func (s *carService) GetCarColumns(ctx context.Context, cacheControl dto.CacheControl, id uuid.UUID, columns []string) (*entity.Car, error) {
+	if len(columns) == 0 {
+		return s.GetCar(ctx, cacheControl, id)
+	}
+
+	cacheControl.Result.Record(dto.CacheBypass, 0)
+
+	return s.carRepository.FindByIDColumns(ctx, id, columns)
 }
 
-func (s *carService) GetCarWithOwner(ctx context.Context, id uuid.UUID) (*entity.CarWithOwner, error) {
-	return s.carRepository.FindByIDWithOwner(ctx, id)
+func (s *carService) GetCarWithOwner(ctx context.Context, cacheControl dto.CacheControl, id uuid.UUID, columns []string) (*entity.CarWithOwner, error) {
+	if len(columns) == 0 {
+		return s.carRepository.FindByIDWithOwner(ctx, cacheControl, id)
+	}
+
+	cacheControl.Result.Record(dto.CacheBypass, 0)
+
+	return s.carRepository.FindByIDWithOwnerColumns(ctx, id, columns)
 }
 
-func (s *carService) ListCarsByUser(ctx context.Context, userID uuid.UUID) ([]*entity.Car, error) {
-	return s.carRepository.FindByUserID(ctx, userID)
+func (s *carService) ListCarsByUser(ctx context.Context, cacheControl dto.CacheControl, userID uuid.UUID, columns []string) ([]*entity.Car, error) {
+	if len(columns) == 0 {
+		return s.carRepository.FindByUserID(ctx, cacheControl, userID)
+	}
+
+	cacheControl.Result.Record(dto.CacheBypass, 0)
+
+	return s.carRepository.FindByUserIDColumns(ctx, userID, columns)
 }
 
 func (s *carService) CountCarsByUser(ctx context.Context, userID uuid.UUID) (int, error) {
@@ -99,20 +169,16 @@ func (s *carService) CountCarsByUser(ctx context.Context, userID uuid.UUID) (int
 }
 
 func (s *carService) UpdateCar(ctx context.Context, id uuid.UUID, req *dto.UpdateCarRequest, userID string) (*entity.Car, error) {
-	existingCar, err := s.carRepository.FindByID(ctx, id)
+	// Read past the cache: the update is conditional on this version.
+	existingCar, err := s.carRepository.FindByID(ctx, dto.CacheControl{MustDbValidate: true}, id)
 	if err != nil {
 		return nil, err
 	}
 
-	isOwner, err := s.carRepository.IsCarOwnedByUser(ctx, id, userID)
-	if err != nil {
+	if err := RequireCarEditor(ctx, s.carRepository, id, userID); err != nil {
 		return nil, err
 	}
 
-	if !isOwner {
-		return nil, appErr.NewWithCode(appErr.CodeHTTPForbidden, "you do not have permission to update this car")
-	}
-
 	if req.Brand != "" {
 		existingCar.Brand = req.Brand
 	}
@@ -129,12 +195,25 @@ func (s *carService) UpdateCar(ctx context.Context, id uuid.UUID, req *dto.Updat
 		existingCar.Color = req.Color
 	}
 
-	if req.LicensePlate != "" {
-		existingCar.LicensePlate = req.LicensePlate
+	if req.LicensePlate != "" || req.PlateRegion != "" {
+		if req.LicensePlate != "" {
+			existingCar.LicensePlate = strings.TrimSpace(req.LicensePlate)
+		}
+		if req.PlateRegion != "" {
+			existingCar.PlateRegion = storedPlateRegion(req.PlateRegion)
+		}
+
+		if err := checkPlate(existingCar); err != nil {
+			return nil, err
+		}
+	}
+
+	if req.VIN != "" {
+		existingCar.VIN = storedVIN(req.VIN)
 	}
 
-	if req.IsAvailable != nil {
-		existingCar.IsAvailable = *req.IsAvailable
+	if req.Version != nil {
+		existingCar.Version = *req.Version
 	}
 
 	if err := s.carRepository.Update(ctx, id, existingCar); err != nil {
@@ -144,43 +223,187 @@ func (s *carService) UpdateCar(ctx context.Context, id uuid.UUID, req *dto.Updat
 	return existingCar, nil
 }
 
-func (s *carService) DeleteCar(ctx context.Context, id uuid.UUID, userID string) error {
-	isOwner, err := s.carRepository.IsCarOwnedByUser(ctx, id, userID)
+// PatchCar applies a JSON Merge Patch or JSON Patch to the stored car,
+// conditional on the patched version like PatchUser.
+func (s *carService) PatchCar(ctx context.Context, id uuid.UUID, req dto.PatchRequest, userID string) (*entity.Car, error) {
+	existingCar, err := s.carRepository.FindByID(ctx, dto.CacheControl{MustDbValidate: true}, id)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	if !isOwner {
-		return appErr.NewWithCode(appErr.CodeHTTPForbidden, "you do not have permission to delete this car")
+	if err := RequireCarEditor(ctx, s.carRepository, id, userID); err != nil {
+		return nil, err
 	}
 
-	return s.carRepository.Delete(ctx, id)
+	doc := dto.CarPatch{
+		VIN:          existingCar.VIN,
+		PlateRegion:  derefPlateRegion(existingCar.PlateRegion),
+		Brand:        existingCar.Brand,
+		Model:        existingCar.Model,
+		Color:        existingCar.Color,
+		LicensePlate: existingCar.LicensePlate,
+		Year:         existingCar.Year,
+		Version:      existingCar.Version,
+	}
+	if req.Version != nil {
+		doc.Version = *req.Version
+	}
+
+	var patched dto.CarPatch
+	if err := util.ApplyPatch(doc, req.MediaType, req.Document, &patched); err != nil {
+		if appErr.ErrCode(err) == appErr.CodeHTTPConflict {
+			return nil, appErr.WrapWithCode(&appErr.ConflictError{Current: existingCar}, appErr.CodeHTTPConflict, "car_patch_test_failed")
+		}
+
+		return nil, err
+	}
+
+	if err := validator.ValidateRequest(&patched); err != nil {
+		return nil, err
+	}
+
+	existingCar.Brand = patched.Brand
+	existingCar.Model = patched.Model
+	existingCar.Color = patched.Color
+	existingCar.LicensePlate = strings.TrimSpace(patched.LicensePlate)
+	existingCar.PlateRegion = storedPlateRegion(patched.PlateRegion)
+	existingCar.VIN = nil
+	if patched.VIN != nil {
+		existingCar.VIN = storedVIN(*patched.VIN)
+	}
+	existingCar.Year = patched.Year
+	existingCar.Version = patched.Version
+
+	if err := s.carRepository.Update(ctx, id, existingCar); err != nil {
+		return nil, err
+	}
+
+	return existingCar, nil
 }
 
-func (s *carService) TransferCarOwnership(ctx context.Context, carID, newUserID uuid.UUID, userID string) error {
-	isOwner, err := s.carRepository.IsCarOwnedByUser(ctx, carID, userID)
+func (s *carService) DeleteCar(ctx context.Context, id uuid.UUID, userID, reason string) error {
+	isOwner, err := s.carRepository.IsCarOwnedByUser(ctx, id, userID)
 	if err != nil {
 		return err
 	}
 
 	if !isOwner {
-		return appErr.NewWithCode(appErr.CodeHTTPForbidden, "you do not have permission to transfer this car")
+		return appErr.NewWithCode(appErr.CodeHTTPForbidden, "you do not have permission to delete this car")
 	}
 
-	return s.carRepository.TransferOwnership(ctx, carID, newUserID)
+	return s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		ownerID, err := s.carRepository.FindOwnerForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if err := s.carRepository.Delete(ctx, id); err != nil {
+			return err
+		}
+
+		return s.carRepository.AppendOwnershipHistory(ctx, []entity.CarOwnershipRecord{
+			entity.NewCarOwnershipRecord(id.String(), entity.CarOwnershipDeleted, ownerID.String(), "", userID, reason),
+		})
+	})
 }
 
-func (s *carService) BulkUpdateAvailability(ctx context.Context, req dto.BulkUpdateAvailabilityRequest, userID string) error {
-	ownershipMap, err := s.carRepository.AreCarsOwnedByUser(ctx, req.CarIDs, userID)
+func (s *carService) RestoreCar(ctx context.Context, id uuid.UUID, actorID string) (*entity.Car, error) {
+	var restored *entity.Car
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		restored, err = s.carRepository.Restore(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		ownerID, err := s.carRepository.FindOwnerForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		return s.carRepository.AppendOwnershipHistory(ctx, []entity.CarOwnershipRecord{
+			entity.NewCarOwnershipRecord(id.String(), entity.CarOwnershipRestored, "", ownerID.String(), actorID, ""),
+		})
+	})
 	if err != nil {
-		return err
+		if appErr.ErrCode(err) == appErr.CodeSQLEmptyRow {
+			return nil, appErr.WrapWithCode(err, appErr.CodeHTTPNotFound, "deleted_car_not_found")
+		}
+
+		return nil, err
 	}
 
-	for carID, isOwned := range ownershipMap {
-		if !isOwned {
-			return appErr.NewWithCode(appErr.CodeHTTPForbidden, "you do not have permission to update car: "+carID.String())
+	return restored, nil
+}
+
+// PurgeDeletedCars hard-deletes cars soft-deleted before deletedBefore, one
+// batch at a time so no single statement locks the whole backlog. The files
+// of their attachments are deleted once each batch's rows are gone, as
+// DeleteAttachment does, so a failure only leaves a file orphaned.
+func (s *carService) PurgeDeletedCars(ctx context.Context, deletedBefore time.Time, batchSize int) (int64, error) {
+	return util.DrainBatches(batchSize, func(limit int) (int64, error) {
+		rows, storageKeys, err := s.carRepository.Purge(ctx, deletedBefore, limit)
+		for _, key := range storageKeys {
+			if err := s.blobStore.Delete(ctx, key); err != nil {
+				zerolog.Ctx(ctx).Warn().Err(err).Str("key", key).Msg("delete_purged_attachment_blob_err")
+			}
 		}
+
+		return rows, err
+	})
+}
+
+func (s *carService) ExportCars(ctx context.Context, filter *dto.CarFilterV2, fn func(*entity.Car) error) error {
+	filter.VIN = util.NormalizeVIN(filter.VIN)
+	return s.carRepository.ExportV2(ctx, filter, fn)
+}
+
+// DecodeVIN looks vin up in the NHTSA vPIC API to pre-fill a new car.
+func (s *carService) DecodeVIN(ctx context.Context, cacheControl dto.CacheControl, vin string) (*entity.DecodedVIN, error) {
+	return s.vinRepository.Decode(ctx, cacheControl, util.NormalizeVIN(vin))
+}
+
+// storedVIN normalizes a requested VIN, mapping an empty one to NULL so the
+// unique constraint only applies to cars that have a VIN.
+func storedVIN(vin string) *string {
+	vin = util.NormalizeVIN(vin)
+	if vin == "" {
+		return nil
+	}
+
+	return &vin
+}
+
+// storedPlateRegion normalizes a requested plate region, mapping an empty one
+// to NULL.
+func storedPlateRegion(region string) *string {
+	region = util.NormalizePlateRegion(region)
+	if region == "" {
+		return nil
+	}
+
+	return &region
+}
+
+func derefPlateRegion(region *string) string {
+	if region == nil {
+		return ""
+	}
+
+	return *region
+}
+
+// checkPlate validates the plate of car against the format of its region, for
+// changes the request tags could not check on their own.
+func checkPlate(car *entity.Car) error {
+	region := derefPlateRegion(car.PlateRegion)
+	if util.ValidPlate(region, car.LicensePlate) {
+		return nil
+	}
+
+	if region == "" {
+		return appErr.NewWithCode(appErr.CodeHTTPValidatorError, "LicensePlate is not a valid license plate")
 	}
 
-	return s.carRepository.BulkUpdateAvailability(ctx, req.CarIDs, req.IsAvailable)
+	return appErr.NewWithCode(appErr.CodeHTTPValidatorError, "LicensePlate does not match the license plate format of %s", region)
 }
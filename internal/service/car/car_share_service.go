@@ -0,0 +1,108 @@
+package car
+
+import (
+	"context"
+
+	"go-far/internal/model/dto"
+	"go-far/internal/model/entity"
+	appErr "go-far/internal/model/errors"
+	"go-far/internal/repository/car"
+
+	"github.com/google/uuid"
+)
+
+// ListCarShares lists everyone with access to the car and their role, owner
+// first. Only the owner or an admin may read it.
+func (s *carService) ListCarShares(ctx context.Context, carID uuid.UUID, userID string, isAdmin bool) ([]entity.CarShare, error) {
+	if !isAdmin {
+		if err := s.checkOwner(ctx, carID, userID, "you do not have permission to view this car's shares"); err != nil {
+			return nil, err
+		}
+	}
+
+	shares, err := s.carRepository.FindShares(ctx, carID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(shares) == 0 {
+		return nil, appErr.NewWithCode(appErr.CodeHTTPNotFound, "car_not_found")
+	}
+
+	return shares, nil
+}
+
+// ShareCar gives targetUserID a co-owner, driver or viewer role on the car,
+// or changes the role they already have.
+func (s *carService) ShareCar(ctx context.Context, carID, targetUserID uuid.UUID, req dto.ShareCarRequest, userID string) (*entity.CarShare, error) {
+	if targetUserID.String() == userID {
+		return nil, appErr.NewWithCode(appErr.CodeHTTPBadRequest, "cannot_share_with_owner")
+	}
+
+	if err := s.checkOwner(ctx, carID, userID, "you do not have permission to share this car"); err != nil {
+		return nil, err
+	}
+
+	share, err := s.carRepository.Share(ctx, carID, targetUserID, entity.CarRole(req.Role))
+	if err != nil {
+		if appErr.ErrCode(err) == appErr.CodeSQLEmptyRow {
+			return nil, appErr.WrapWithCode(err, appErr.CodeHTTPNotFound, "car_or_user_not_found")
+		}
+
+		return nil, err
+	}
+
+	return share, nil
+}
+
+// UnshareCar revokes targetUserID's access. The owner may revoke anyone's
+// share, and a user may give up their own.
+func (s *carService) UnshareCar(ctx context.Context, carID, targetUserID uuid.UUID, userID string) error {
+	if targetUserID.String() != userID {
+		if err := s.checkOwner(ctx, carID, userID, "you do not have permission to unshare this car"); err != nil {
+			return err
+		}
+	}
+
+	if err := s.carRepository.Unshare(ctx, carID, targetUserID); err != nil {
+		if appErr.ErrCode(err) == appErr.CodeSQLEmptyRow {
+			return appErr.WrapWithCode(err, appErr.CodeHTTPNotFound, "car_share_not_found")
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+func (s *carService) checkOwner(ctx context.Context, carID uuid.UUID, userID, message string) error {
+	isOwner, err := s.carRepository.IsCarOwnedByUser(ctx, carID, userID)
+	if err != nil {
+		return err
+	}
+
+	if !isOwner {
+		return appErr.NewWithCode(appErr.CodeHTTPForbidden, message)
+	}
+
+	return nil
+}
+
+// errMsgCannotManage is the 403 for changing a car or the records kept with
+// it, such as its service log: only the owner and co-owners may.
+const errMsgCannotManage = "you do not have permission to manage this car"
+
+// RequireCarEditor returns 403 unless userID is the owner or a co-owner of
+// the car. Services that keep records for a car share it with UpdateCar.
+func RequireCarEditor(ctx context.Context, carRepository car.CarRepositoryItf, carID uuid.UUID, userID string) error {
+	role, err := carRepository.FindCarRole(ctx, carID, userID)
+	if err != nil {
+		return err
+	}
+
+	if !role.CanEdit() {
+		return appErr.NewWithCode(appErr.CodeHTTPForbidden, errMsgCannotManage)
+	}
+
+	return nil
+}
@@ -0,0 +1,289 @@
+package car
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"go-far/internal/infra/validator"
+	"go-far/internal/model/dto"
+	"go-far/internal/model/entity"
+	appErr "go-far/internal/model/errors"
+	"go-far/internal/repository/car"
+
+	"github.com/google/uuid"
+)
+
+var (
+	shareOwnerID   = uuid.MustParse("0190a3b4-0000-7000-8000-000000000001")
+	shareCoOwnerID = uuid.MustParse("0190a3b4-0000-7000-8000-000000000002")
+	shareDriverID  = uuid.MustParse("0190a3b4-0000-7000-8000-000000000003")
+	shareViewerID  = uuid.MustParse("0190a3b4-0000-7000-8000-000000000004")
+	shareOtherID   = uuid.MustParse("0190a3b4-0000-7000-8000-000000000005")
+	shareCarID     = uuid.MustParse("0190a3b4-0000-7000-8000-0000000000c1")
+)
+
+// fakeShareRepository keeps the roles on shareCarID in memory. Like the SQL
+// it never hands out or revokes the owner role through a share.
+type fakeShareRepository struct {
+	car.CarRepositoryItf
+
+	roles   map[uuid.UUID]entity.CarRole
+	updated bool
+}
+
+func newFakeShareRepository() *fakeShareRepository {
+	return &fakeShareRepository{roles: map[uuid.UUID]entity.CarRole{
+		shareOwnerID:   entity.CarRoleOwner,
+		shareCoOwnerID: entity.CarRoleCoOwner,
+		shareDriverID:  entity.CarRoleDriver,
+		shareViewerID:  entity.CarRoleViewer,
+	}}
+}
+
+func (r *fakeShareRepository) IsCarOwnedByUser(_ context.Context, carID uuid.UUID, userID string) (bool, error) {
+	return carID == shareCarID && r.roles[uuid.MustParse(userID)] == entity.CarRoleOwner, nil
+}
+
+func (r *fakeShareRepository) FindCarRole(_ context.Context, carID uuid.UUID, userID string) (entity.CarRole, error) {
+	if carID != shareCarID {
+		return "", nil
+	}
+
+	return r.roles[uuid.MustParse(userID)], nil
+}
+
+func (r *fakeShareRepository) FindShares(_ context.Context, carID uuid.UUID) ([]entity.CarShare, error) {
+	if carID != shareCarID {
+		return nil, nil
+	}
+
+	shares := make([]entity.CarShare, 0, len(r.roles))
+	for userID, role := range r.roles {
+		shares = append(shares, entity.CarShare{CarID: carID.String(), UserID: userID.String(), Role: role})
+	}
+
+	return shares, nil
+}
+
+func (r *fakeShareRepository) Share(_ context.Context, carID, userID uuid.UUID, role entity.CarRole) (*entity.CarShare, error) {
+	if carID != shareCarID || r.roles[userID] == entity.CarRoleOwner {
+		return nil, appErr.NewWithCode(appErr.CodeSQLEmptyRow, "car_or_user_not_found")
+	}
+
+	r.roles[userID] = role
+
+	return &entity.CarShare{CarID: carID.String(), UserID: userID.String(), Role: role}, nil
+}
+
+func (r *fakeShareRepository) Unshare(_ context.Context, carID, userID uuid.UUID) error {
+	role, ok := r.roles[userID]
+	if carID != shareCarID || !ok || role == entity.CarRoleOwner {
+		return appErr.NewWithCode(appErr.CodeSQLEmptyRow, "car_share_not_found")
+	}
+
+	delete(r.roles, userID)
+
+	return nil
+}
+
+func (r *fakeShareRepository) FindByID(_ context.Context, _ dto.CacheControl, id uuid.UUID) (*entity.Car, error) {
+	return &entity.Car{ID: id.String(), Brand: "VW"}, nil
+}
+
+func (r *fakeShareRepository) Update(_ context.Context, _ uuid.UUID, _ *entity.Car) error {
+	r.updated = true
+
+	return nil
+}
+
+// statusOf is the HTTP status err is reported with, or 0 for nil.
+func statusOf(err error) int {
+	if err == nil {
+		return 0
+	}
+
+	return appErr.ErrorMessages[appErr.ErrCode(err)].StatusCode
+}
+
+// TestShareCarRequestRole checks that a share may hand out every role except
+// owner, which only moves through a transfer.
+func TestShareCarRequestRole(t *testing.T) {
+	initImportValidator()
+
+	tests := []struct {
+		role    string
+		wantErr bool
+	}{
+		{role: string(entity.CarRoleCoOwner)},
+		{role: string(entity.CarRoleDriver)},
+		{role: string(entity.CarRoleViewer)},
+		{role: string(entity.CarRoleOwner), wantErr: true},
+		{role: "admin", wantErr: true},
+		{role: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.role, func(t *testing.T) {
+			err := validator.ValidateRequest(&dto.ShareCarRequest{Role: tt.role})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateRequest(%q) err = %v, want error = %v", tt.role, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCarRoleCanEdit(t *testing.T) {
+	want := map[entity.CarRole]bool{
+		entity.CarRoleOwner:   true,
+		entity.CarRoleCoOwner: true,
+		entity.CarRoleDriver:  false,
+		entity.CarRoleViewer:  false,
+		"":                    false,
+	}
+
+	for role, canEdit := range want {
+		if got := role.CanEdit(); got != canEdit {
+			t.Errorf("%q.CanEdit() = %v, want %v", role, got, canEdit)
+		}
+	}
+}
+
+func TestShareCar(t *testing.T) {
+	tests := []struct {
+		name       string
+		userID     uuid.UUID
+		target     uuid.UUID
+		wantStatus int
+	}{
+		{name: "owner shares", userID: shareOwnerID, target: shareOtherID},
+		{name: "owner changes a role", userID: shareOwnerID, target: shareViewerID},
+		{name: "owner cannot share with themselves", userID: shareOwnerID, target: shareOwnerID, wantStatus: http.StatusBadRequest},
+		{name: "co-owner cannot share", userID: shareCoOwnerID, target: shareOtherID, wantStatus: http.StatusForbidden},
+		{name: "driver cannot share", userID: shareDriverID, target: shareOtherID, wantStatus: http.StatusForbidden},
+		{name: "stranger cannot share", userID: shareOtherID, target: shareViewerID, wantStatus: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newFakeShareRepository()
+			before := repo.roles[tt.target]
+			svc := InitCarService(repo, nil, fakeTransactor{}, nil)
+
+			share, err := svc.ShareCar(context.Background(), shareCarID, tt.target, dto.ShareCarRequest{Role: string(entity.CarRoleDriver)}, tt.userID.String())
+			if got := statusOf(err); got != tt.wantStatus {
+				t.Fatalf("status = %d, want %d (err %v)", got, tt.wantStatus, err)
+			}
+
+			if tt.wantStatus != 0 {
+				if repo.roles[tt.target] != before {
+					t.Errorf("a rejected share changed the role to %q", repo.roles[tt.target])
+				}
+				return
+			}
+
+			if share.Role != entity.CarRoleDriver || repo.roles[tt.target] != entity.CarRoleDriver {
+				t.Errorf("share = %+v, stored role %q, want driver", share, repo.roles[tt.target])
+			}
+		})
+	}
+}
+
+func TestUnshareCar(t *testing.T) {
+	tests := []struct {
+		name       string
+		userID     uuid.UUID
+		target     uuid.UUID
+		wantStatus int
+	}{
+		{name: "owner revokes a share", userID: shareOwnerID, target: shareDriverID},
+		{name: "user gives up their own share", userID: shareViewerID, target: shareViewerID},
+		{name: "co-owner cannot revoke others", userID: shareCoOwnerID, target: shareDriverID, wantStatus: http.StatusForbidden},
+		{name: "stranger cannot revoke", userID: shareOtherID, target: shareDriverID, wantStatus: http.StatusForbidden},
+		{name: "owner cannot unshare themselves", userID: shareOwnerID, target: shareOwnerID, wantStatus: http.StatusNotFound},
+		{name: "no share to revoke", userID: shareOwnerID, target: shareOtherID, wantStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newFakeShareRepository()
+			_, hadShare := repo.roles[tt.target]
+			svc := InitCarService(repo, nil, fakeTransactor{}, nil)
+
+			err := svc.UnshareCar(context.Background(), shareCarID, tt.target, tt.userID.String())
+			if got := statusOf(err); got != tt.wantStatus {
+				t.Fatalf("status = %d, want %d (err %v)", got, tt.wantStatus, err)
+			}
+
+			if _, hasShare := repo.roles[tt.target]; hasShare != (hadShare && tt.wantStatus != 0) {
+				t.Errorf("share kept = %v after status %d", hasShare, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestListCarShares(t *testing.T) {
+	tests := []struct {
+		name       string
+		userID     uuid.UUID
+		isAdmin    bool
+		wantStatus int
+	}{
+		{name: "owner", userID: shareOwnerID},
+		{name: "admin", userID: shareOtherID, isAdmin: true},
+		{name: "co-owner", userID: shareCoOwnerID, wantStatus: http.StatusForbidden},
+		{name: "stranger", userID: shareOtherID, wantStatus: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := InitCarService(newFakeShareRepository(), nil, fakeTransactor{}, nil)
+
+			shares, err := svc.ListCarShares(context.Background(), shareCarID, tt.userID.String(), tt.isAdmin)
+			if got := statusOf(err); got != tt.wantStatus {
+				t.Fatalf("status = %d, want %d (err %v)", got, tt.wantStatus, err)
+			}
+
+			if tt.wantStatus == 0 && len(shares) != 4 {
+				t.Errorf("listed %d shares, want 4", len(shares))
+			}
+		})
+	}
+
+	svc := InitCarService(newFakeShareRepository(), nil, fakeTransactor{}, nil)
+	if _, err := svc.ListCarShares(context.Background(), uuid.New(), shareOwnerID.String(), true); statusOf(err) != http.StatusNotFound {
+		t.Errorf("unknown car err = %v, want 404", err)
+	}
+}
+
+// TestUpdateCarRole checks that only the owner and co-owners may change the
+// car's details.
+func TestUpdateCarRole(t *testing.T) {
+	tests := []struct {
+		name       string
+		userID     uuid.UUID
+		wantStatus int
+	}{
+		{name: "owner", userID: shareOwnerID},
+		{name: "co-owner", userID: shareCoOwnerID},
+		{name: "driver", userID: shareDriverID, wantStatus: http.StatusForbidden},
+		{name: "viewer", userID: shareViewerID, wantStatus: http.StatusForbidden},
+		{name: "stranger", userID: shareOtherID, wantStatus: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newFakeShareRepository()
+			svc := InitCarService(repo, nil, fakeTransactor{}, nil)
+
+			_, err := svc.UpdateCar(context.Background(), shareCarID, &dto.UpdateCarRequest{Color: "red"}, tt.userID.String())
+			if got := statusOf(err); got != tt.wantStatus {
+				t.Fatalf("status = %d, want %d (err %v)", got, tt.wantStatus, err)
+			}
+
+			if repo.updated != (tt.wantStatus == 0) {
+				t.Errorf("updated = %v, want %v", repo.updated, tt.wantStatus == 0)
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package reservation
+
+import (
+	"context"
+
+	"go-far/internal/model/dto"
+	"go-far/internal/model/entity"
+	"go-far/internal/repository/car"
+	"go-far/internal/repository/reservation"
+
+	"github.com/google/uuid"
+)
+
+type ReservationServiceItf interface {
+	CreateReservation(ctx context.Context, carID uuid.UUID, req dto.CreateReservationRequest, userID string, isAdmin bool) (*entity.Reservation, error)
+	GetReservation(ctx context.Context, id uuid.UUID, userID string, isAdmin bool) (*entity.Reservation, error)
+	CancelReservation(ctx context.Context, id uuid.UUID, userID string, isAdmin bool) (*entity.Reservation, error)
+	ListCarReservations(ctx context.Context, carID uuid.UUID, filter *dto.ReservationFilter, userID string, isAdmin bool) ([]entity.Reservation, *dto.Pagination, error)
+	ListUserReservations(ctx context.Context, filter *dto.ReservationFilter, userID string) ([]entity.Reservation, *dto.Pagination, error)
+	SearchAvailableCars(ctx context.Context, filter *dto.AvailabilityFilter) ([]*entity.Car, *dto.Pagination, error)
+}
+
+type reservationService struct {
+	reservationRepository reservation.ReservationRepositoryItf
+	carRepository         car.CarRepositoryItf
+}
+
+func InitReservationService(reservationRepository reservation.ReservationRepositoryItf, carRepository car.CarRepositoryItf) ReservationServiceItf {
+	return &reservationService{
+		reservationRepository: reservationRepository,
+		carRepository:         carRepository,
+	}
+}
@@ -0,0 +1,144 @@
+package reservation
+
+import (
+	"context"
+	"time"
+
+	"go-far/internal/model/dto"
+	"go-far/internal/model/entity"
+	appErr "go-far/internal/model/errors"
+
+	"github.com/google/uuid"
+)
+
+// CreateReservation books the car for userID. Only the car's owner and admins
+// may reserve it.
+func (s *reservationService) CreateReservation(ctx context.Context, carID uuid.UUID, req dto.CreateReservationRequest, userID string, isAdmin bool) (*entity.Reservation, error) {
+	if req.StartsAt.Before(time.Now()) {
+		return nil, appErr.NewWithCode(appErr.CodeHTTPBadRequest, "reservation_starts_in_past")
+	}
+
+	if !isAdmin {
+		isOwner, err := s.carRepository.IsCarOwnedByUser(ctx, carID, userID)
+		if err != nil {
+			return nil, err
+		}
+
+		if !isOwner {
+			return nil, appErr.NewWithCode(appErr.CodeHTTPForbidden, "you do not have permission to reserve this car")
+		}
+	}
+
+	reservation, err := s.reservationRepository.Create(ctx, carID, userID, req)
+	if err != nil {
+		if appErr.ErrCode(err) == appErr.CodeSQLEmptyRow {
+			return nil, appErr.WrapWithCode(err, appErr.CodeHTTPNotFound, "car_not_found")
+		}
+
+		return nil, err
+	}
+
+	return reservation, nil
+}
+
+func (s *reservationService) GetReservation(ctx context.Context, id uuid.UUID, userID string, isAdmin bool) (*entity.Reservation, error) {
+	reservation, err := s.findReservation(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkAccess(ctx, reservation, userID, isAdmin); err != nil {
+		return nil, err
+	}
+
+	return reservation, nil
+}
+
+// CancelReservation frees the reserved period. The reserving user, the car's
+// owner and admins may cancel; cancelling twice returns 409.
+func (s *reservationService) CancelReservation(ctx context.Context, id uuid.UUID, userID string, isAdmin bool) (*entity.Reservation, error) {
+	reservation, err := s.findReservation(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkAccess(ctx, reservation, userID, isAdmin); err != nil {
+		return nil, err
+	}
+
+	cancelled, err := s.reservationRepository.Cancel(ctx, id)
+	if err != nil {
+		if appErr.ErrCode(err) == appErr.CodeSQLEmptyRow {
+			return nil, appErr.WrapWithCode(err, appErr.CodeHTTPConflict, "reservation_not_active")
+		}
+
+		return nil, err
+	}
+
+	return cancelled, nil
+}
+
+// ListCarReservations lists every reservation of the car to its owner and to
+// admins. Other users only see their own reservations of it.
+func (s *reservationService) ListCarReservations(ctx context.Context, carID uuid.UUID, filter *dto.ReservationFilter, userID string, isAdmin bool) ([]entity.Reservation, *dto.Pagination, error) {
+	filter.CarID = carID.String()
+
+	if !isAdmin {
+		isOwner, err := s.carRepository.IsCarOwnedByUser(ctx, carID, userID)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if !isOwner {
+			filter.UserID = userID
+		}
+	}
+
+	return s.reservationRepository.FindAll(ctx, filter)
+}
+
+func (s *reservationService) ListUserReservations(ctx context.Context, filter *dto.ReservationFilter, userID string) ([]entity.Reservation, *dto.Pagination, error) {
+	filter.UserID = userID
+
+	return s.reservationRepository.FindAll(ctx, filter)
+}
+
+func (s *reservationService) SearchAvailableCars(ctx context.Context, filter *dto.AvailabilityFilter) ([]*entity.Car, *dto.Pagination, error) {
+	return s.reservationRepository.FindAvailableCars(ctx, filter)
+}
+
+func (s *reservationService) findReservation(ctx context.Context, id uuid.UUID) (*entity.Reservation, error) {
+	reservation, err := s.reservationRepository.FindByID(ctx, id)
+	if err != nil {
+		if appErr.ErrCode(err) == appErr.CodeSQLEmptyRow {
+			return nil, appErr.WrapWithCode(err, appErr.CodeHTTPNotFound, "reservation_not_found")
+		}
+
+		return nil, err
+	}
+
+	return reservation, nil
+}
+
+// checkAccess allows admins, the reserving user and the car's owner.
+func (s *reservationService) checkAccess(ctx context.Context, reservation *entity.Reservation, userID string, isAdmin bool) error {
+	if isAdmin || reservation.UserID == userID {
+		return nil
+	}
+
+	carID, err := uuid.Parse(reservation.CarID)
+	if err != nil {
+		return appErr.WrapWithCode(err, appErr.CodeHTTPInternalServerError, "invalid_car_id")
+	}
+
+	isOwner, err := s.carRepository.IsCarOwnedByUser(ctx, carID, userID)
+	if err != nil {
+		return err
+	}
+
+	if !isOwner {
+		return appErr.NewWithCode(appErr.CodeHTTPForbidden, "you do not have permission to access this reservation")
+	}
+
+	return nil
+}
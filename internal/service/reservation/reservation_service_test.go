@@ -0,0 +1,237 @@
+package reservation
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"go-far/internal/model/dto"
+	"go-far/internal/model/entity"
+	appErr "go-far/internal/model/errors"
+	"go-far/internal/repository/car"
+	"go-far/internal/repository/reservation"
+
+	"github.com/google/uuid"
+)
+
+const (
+	ownerID    = "0190a3b4-0000-7000-8000-000000000001"
+	strangerID = "0190a3b4-0000-7000-8000-000000000002"
+)
+
+var testCarID = uuid.MustParse("0190a3b4-0000-7000-8000-0000000000c1")
+
+// fakeReservationRepository keeps reservations in memory and rejects
+// overlapping active ones the way the reservations_no_overlap exclusion
+// constraint does.
+type fakeReservationRepository struct {
+	reservation.ReservationRepositoryItf
+
+	reservations map[string]*entity.Reservation
+}
+
+func (r *fakeReservationRepository) Create(_ context.Context, carID uuid.UUID, userID string, req dto.CreateReservationRequest) (*entity.Reservation, error) {
+	for _, other := range r.reservations {
+		if other.CarID == carID.String() && other.Status == entity.ReservationActive &&
+			req.StartsAt.Before(other.EndsAt) && other.StartsAt.Before(req.EndsAt) {
+			return nil, appErr.NewWithCode(appErr.CodeSQLConflict, "car_already_reserved")
+		}
+	}
+
+	created := &entity.Reservation{
+		ID:       uuid.NewString(),
+		CarID:    carID.String(),
+		UserID:   userID,
+		StartsAt: req.StartsAt,
+		EndsAt:   req.EndsAt,
+		Status:   entity.ReservationActive,
+	}
+	r.reservations[created.ID] = created
+
+	return created, nil
+}
+
+func (r *fakeReservationRepository) FindByID(_ context.Context, id uuid.UUID) (*entity.Reservation, error) {
+	found, ok := r.reservations[id.String()]
+	if !ok {
+		return nil, appErr.NewWithCode(appErr.CodeSQLEmptyRow, "reservation_not_found")
+	}
+
+	return found, nil
+}
+
+func (r *fakeReservationRepository) Cancel(_ context.Context, id uuid.UUID) (*entity.Reservation, error) {
+	found, ok := r.reservations[id.String()]
+	if !ok || found.Status != entity.ReservationActive {
+		return nil, appErr.NewWithCode(appErr.CodeSQLEmptyRow, "active_reservation_not_found")
+	}
+
+	now := time.Now()
+	found.Status = entity.ReservationCancelled
+	found.CancelledAt = &now
+
+	return found, nil
+}
+
+// fakeCarRepository owns testCarID by ownerID.
+type fakeCarRepository struct {
+	car.CarRepositoryItf
+}
+
+func (fakeCarRepository) IsCarOwnedByUser(_ context.Context, carID uuid.UUID, userID string) (bool, error) {
+	return carID == testCarID && userID == ownerID, nil
+}
+
+func newTestService() (*fakeReservationRepository, ReservationServiceItf) {
+	repo := &fakeReservationRepository{reservations: make(map[string]*entity.Reservation)}
+
+	return repo, InitReservationService(repo, fakeCarRepository{})
+}
+
+// period returns the request for [start, start+hours) hours from now.
+func period(start, hours int) dto.CreateReservationRequest {
+	base := time.Now().Add(24 * time.Hour).Truncate(time.Hour)
+
+	return dto.CreateReservationRequest{
+		StartsAt: base.Add(time.Duration(start) * time.Hour),
+		EndsAt:   base.Add(time.Duration(start+hours) * time.Hour),
+	}
+}
+
+// statusOf is the HTTP status err is reported with, or 0 for nil.
+func statusOf(err error) int {
+	if err == nil {
+		return 0
+	}
+
+	return appErr.ErrorMessages[appErr.ErrCode(err)].StatusCode
+}
+
+func TestCreateReservationAccess(t *testing.T) {
+	tests := []struct {
+		name       string
+		userID     string
+		isAdmin    bool
+		req        dto.CreateReservationRequest
+		wantStatus int
+	}{
+		{name: "owner", userID: ownerID, req: period(0, 2)},
+		{name: "admin", userID: strangerID, isAdmin: true, req: period(0, 2)},
+		{name: "other user", userID: strangerID, req: period(0, 2), wantStatus: http.StatusForbidden},
+		{
+			name:       "starts in the past",
+			userID:     ownerID,
+			req:        dto.CreateReservationRequest{StartsAt: time.Now().Add(-time.Hour), EndsAt: time.Now().Add(time.Hour)},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, svc := newTestService()
+
+			_, err := svc.CreateReservation(context.Background(), testCarID, tt.req, tt.userID, tt.isAdmin)
+			if got := statusOf(err); got != tt.wantStatus {
+				t.Fatalf("status = %d, want %d (err %v)", got, tt.wantStatus, err)
+			}
+
+			if wantStored := tt.wantStatus == 0; (len(repo.reservations) == 1) != wantStored {
+				t.Errorf("stored %d reservations, want stored = %v", len(repo.reservations), wantStored)
+			}
+		})
+	}
+}
+
+// TestCreateReservationOverlap books [0h, 4h) first: overlapping periods are
+// rejected with 409, while periods that only touch it are accepted because
+// reservations are half-open.
+func TestCreateReservationOverlap(t *testing.T) {
+	tests := []struct {
+		name       string
+		req        dto.CreateReservationRequest
+		wantStatus int
+	}{
+		{name: "same period", req: period(0, 4), wantStatus: http.StatusConflict},
+		{name: "overlaps the end", req: period(3, 2), wantStatus: http.StatusConflict},
+		{name: "overlaps the start", req: period(-1, 2), wantStatus: http.StatusConflict},
+		{name: "inside", req: period(1, 1), wantStatus: http.StatusConflict},
+		{name: "ends when it starts", req: period(-2, 2)},
+		{name: "starts when it ends", req: period(4, 2)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, svc := newTestService()
+			ctx := context.Background()
+
+			if _, err := svc.CreateReservation(ctx, testCarID, period(0, 4), ownerID, false); err != nil {
+				t.Fatalf("first reservation: %v", err)
+			}
+
+			_, err := svc.CreateReservation(ctx, testCarID, tt.req, ownerID, false)
+			if got := statusOf(err); got != tt.wantStatus {
+				t.Errorf("status = %d, want %d (err %v)", got, tt.wantStatus, err)
+			}
+		})
+	}
+}
+
+func TestCancelReservation(t *testing.T) {
+	tests := []struct {
+		name       string
+		userID     string
+		isAdmin    bool
+		wantStatus int
+	}{
+		{name: "reserver", userID: ownerID},
+		{name: "admin", userID: strangerID, isAdmin: true},
+		{name: "other user", userID: strangerID, wantStatus: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, svc := newTestService()
+			ctx := context.Background()
+
+			booked, err := svc.CreateReservation(ctx, testCarID, period(0, 4), ownerID, false)
+			if err != nil {
+				t.Fatalf("create reservation: %v", err)
+			}
+			id := uuid.MustParse(booked.ID)
+
+			cancelled, err := svc.CancelReservation(ctx, id, tt.userID, tt.isAdmin)
+			if got := statusOf(err); got != tt.wantStatus {
+				t.Fatalf("status = %d, want %d (err %v)", got, tt.wantStatus, err)
+			}
+
+			if tt.wantStatus != 0 {
+				if repo.reservations[booked.ID].Status != entity.ReservationActive {
+					t.Error("a rejected cancel changed the reservation")
+				}
+				return
+			}
+
+			if cancelled.Status != entity.ReservationCancelled || cancelled.CancelledAt == nil {
+				t.Errorf("cancelled reservation = %+v", cancelled)
+			}
+
+			if _, err := svc.CancelReservation(ctx, id, tt.userID, tt.isAdmin); statusOf(err) != http.StatusConflict {
+				t.Errorf("second cancel err = %v, want 409", err)
+			}
+
+			if _, err := svc.CreateReservation(ctx, testCarID, period(0, 4), ownerID, false); err != nil {
+				t.Errorf("rebooking the cancelled period: %v", err)
+			}
+		})
+	}
+}
+
+func TestCancelReservationNotFound(t *testing.T) {
+	_, svc := newTestService()
+
+	_, err := svc.CancelReservation(context.Background(), uuid.New(), ownerID, true)
+	if got := statusOf(err); got != http.StatusNotFound {
+		t.Errorf("status = %d, want %d (err %v)", got, http.StatusNotFound, err)
+	}
+}
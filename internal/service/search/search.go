@@ -0,0 +1,23 @@
+package search
+
+import (
+	"context"
+
+	"go-far/internal/model/dto"
+	"go-far/internal/model/entity"
+	"go-far/internal/repository/search"
+)
+
+type SearchServiceItf interface {
+	Search(ctx context.Context, filter *dto.SearchFilter) ([]entity.SearchResult, *dto.Pagination, error)
+}
+
+type searchService struct {
+	searchRepository search.SearchRepositoryItf
+}
+
+func InitSearchService(searchRepository search.SearchRepositoryItf) SearchServiceItf {
+	return &searchService{
+		searchRepository: searchRepository,
+	}
+}
@@ -0,0 +1,15 @@
+package search
+
+import (
+	"context"
+	"strings"
+
+	"go-far/internal/model/dto"
+	"go-far/internal/model/entity"
+)
+
+func (s *searchService) Search(ctx context.Context, filter *dto.SearchFilter) ([]entity.SearchResult, *dto.Pagination, error) {
+	filter.Q = strings.TrimSpace(filter.Q)
+
+	return s.searchRepository.Search(ctx, filter)
+}
@@ -1,23 +1,69 @@
 package service
 
 import (
+	"time"
+
+	"go-far/internal/infra/blob"
+	"go-far/internal/infra/database"
 	"go-far/internal/repository"
+	"go-far/internal/service/attachment"
 	"go-far/internal/service/car"
+	"go-far/internal/service/reservation"
+	"go-far/internal/service/search"
+	"go-far/internal/service/servicerecord"
+	"go-far/internal/service/stats"
+	"go-far/internal/service/transfer"
 	"go-far/internal/service/user"
 )
 
 type Service struct {
-	User user.UserServiceItf
-	Car  car.CarServiceItf
+	User          user.UserServiceItf
+	Car           car.CarServiceItf
+	Search        search.SearchServiceItf
+	Reservation   reservation.ReservationServiceItf
+	Transfer      transfer.TransferServiceItf
+	ServiceRecord servicerecord.ServiceRecordServiceItf
+	Attachment    attachment.AttachmentServiceItf
+	Stats         stats.StatsServiceItf
 }
 
-func InitService(repo *repository.Repository) *Service {
+func InitService(repo *repository.Repository, tx database.Transactor, transferRequestTTL time.Duration, blobStore blob.BlobStore, attachmentOpts attachment.AttachmentOptions) *Service {
 	return &Service{
 		User: user.InitUserService(
 			repo.User,
+			repo.Car,
 		),
 		Car: car.InitCarService(
 			repo.Car,
+			repo.VIN,
+			tx,
+			blobStore,
+		),
+		Search: search.InitSearchService(
+			repo.Search,
+		),
+		Reservation: reservation.InitReservationService(
+			repo.Reservation,
+			repo.Car,
+		),
+		Transfer: transfer.InitTransferService(
+			repo.Transfer,
+			repo.Car,
+			tx,
+			transferRequestTTL,
+		),
+		ServiceRecord: servicerecord.InitServiceRecordService(
+			repo.ServiceRecord,
+			repo.Car,
+		),
+		Attachment: attachment.InitAttachmentService(
+			repo.Attachment,
+			repo.Car,
+			blobStore,
+			attachmentOpts,
+		),
+		Stats: stats.InitStatsService(
+			repo.Stats,
 		),
 	}
 }
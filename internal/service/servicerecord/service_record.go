@@ -0,0 +1,34 @@
+package servicerecord
+
+import (
+	"context"
+	"time"
+
+	"go-far/internal/model/dto"
+	"go-far/internal/model/entity"
+	"go-far/internal/repository/car"
+	"go-far/internal/repository/servicerecord"
+
+	"github.com/google/uuid"
+)
+
+type ServiceRecordServiceItf interface {
+	CreateServiceRecord(ctx context.Context, carID uuid.UUID, req dto.ServiceRecordRequest, userID string) (*entity.ServiceRecord, error)
+	GetServiceRecord(ctx context.Context, carID, id uuid.UUID, userID string) (*entity.ServiceRecord, error)
+	ListServiceRecords(ctx context.Context, carID uuid.UUID, filter *dto.ServiceRecordFilter, userID string) ([]entity.ServiceRecord, *dto.Pagination, error)
+	UpdateServiceRecord(ctx context.Context, carID, id uuid.UUID, req dto.ServiceRecordRequest, userID string) (*entity.ServiceRecord, error)
+	DeleteServiceRecord(ctx context.Context, carID, id uuid.UUID, userID string) error
+	FlagOverdueCars(ctx context.Context, interval time.Duration, batchSize int) (flagged, cleared int64, err error)
+}
+
+type serviceRecordService struct {
+	serviceRecordRepository servicerecord.ServiceRecordRepositoryItf
+	carRepository           car.CarRepositoryItf
+}
+
+func InitServiceRecordService(serviceRecordRepository servicerecord.ServiceRecordRepositoryItf, carRepository car.CarRepositoryItf) ServiceRecordServiceItf {
+	return &serviceRecordService{
+		serviceRecordRepository: serviceRecordRepository,
+		carRepository:           carRepository,
+	}
+}
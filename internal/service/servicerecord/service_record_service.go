@@ -0,0 +1,125 @@
+package servicerecord
+
+import (
+	"context"
+	"time"
+
+	"go-far/internal/model/dto"
+	"go-far/internal/model/entity"
+	appErr "go-far/internal/model/errors"
+	carService "go-far/internal/service/car"
+
+	"github.com/google/uuid"
+)
+
+func (s *serviceRecordService) CreateServiceRecord(ctx context.Context, carID uuid.UUID, req dto.ServiceRecordRequest, userID string) (*entity.ServiceRecord, error) {
+	if err := carService.RequireCarEditor(ctx, s.carRepository, carID, userID); err != nil {
+		return nil, err
+	}
+
+	if err := normalizeServiceDate(&req); err != nil {
+		return nil, err
+	}
+
+	record, err := s.serviceRecordRepository.Create(ctx, carID, userID, req)
+	if err != nil {
+		if appErr.ErrCode(err) == appErr.CodeSQLEmptyRow {
+			return nil, appErr.WrapWithCode(err, appErr.CodeHTTPNotFound, "car_not_found")
+		}
+
+		return nil, err
+	}
+
+	return record, nil
+}
+
+func (s *serviceRecordService) GetServiceRecord(ctx context.Context, carID, id uuid.UUID, userID string) (*entity.ServiceRecord, error) {
+	if err := carService.RequireCarEditor(ctx, s.carRepository, carID, userID); err != nil {
+		return nil, err
+	}
+
+	record, err := s.serviceRecordRepository.FindByID(ctx, carID, id)
+	if err != nil {
+		return nil, notFound(err)
+	}
+
+	return record, nil
+}
+
+func (s *serviceRecordService) ListServiceRecords(ctx context.Context, carID uuid.UUID, filter *dto.ServiceRecordFilter, userID string) ([]entity.ServiceRecord, *dto.Pagination, error) {
+	if err := carService.RequireCarEditor(ctx, s.carRepository, carID, userID); err != nil {
+		return nil, nil, err
+	}
+
+	filter.CarID = carID.String()
+
+	return s.serviceRecordRepository.FindAll(ctx, filter)
+}
+
+func (s *serviceRecordService) UpdateServiceRecord(ctx context.Context, carID, id uuid.UUID, req dto.ServiceRecordRequest, userID string) (*entity.ServiceRecord, error) {
+	if err := carService.RequireCarEditor(ctx, s.carRepository, carID, userID); err != nil {
+		return nil, err
+	}
+
+	if err := normalizeServiceDate(&req); err != nil {
+		return nil, err
+	}
+
+	record, err := s.serviceRecordRepository.Update(ctx, carID, id, req)
+	if err != nil {
+		return nil, notFound(err)
+	}
+
+	return record, nil
+}
+
+func (s *serviceRecordService) DeleteServiceRecord(ctx context.Context, carID, id uuid.UUID, userID string) error {
+	if err := carService.RequireCarEditor(ctx, s.carRepository, carID, userID); err != nil {
+		return err
+	}
+
+	if err := s.serviceRecordRepository.Delete(ctx, carID, id); err != nil {
+		return notFound(err)
+	}
+
+	return nil
+}
+
+// FlagOverdueCars flags up to batchSize cars not serviced within interval,
+// and clears the flag of flagged cars that have been serviced since.
+func (s *serviceRecordService) FlagOverdueCars(ctx context.Context, interval time.Duration, batchSize int) (int64, int64, error) {
+	cutoff := time.Now().Add(-interval)
+
+	cleared, err := s.serviceRecordRepository.UnflagServicedCars(ctx, cutoff)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	flagged, err := s.serviceRecordRepository.FlagOverdueCars(ctx, cutoff, batchSize)
+	if err != nil {
+		return 0, cleared, err
+	}
+
+	return flagged, cleared, nil
+}
+
+// normalizeServiceDate rejects dates in the future and keeps only the
+// calendar date the client sent, in its own time zone.
+func normalizeServiceDate(req *dto.ServiceRecordRequest) error {
+	if req.ServiceDate.After(time.Now()) {
+		return appErr.NewWithCode(appErr.CodeHTTPBadRequest, "service_date_in_future")
+	}
+
+	year, month, day := req.ServiceDate.Date()
+	req.ServiceDate = time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+
+	return nil
+}
+
+func notFound(err error) error {
+	if appErr.ErrCode(err) == appErr.CodeSQLEmptyRow {
+		return appErr.WrapWithCode(err, appErr.CodeHTTPNotFound, "service_record_not_found")
+	}
+
+	return err
+}
@@ -0,0 +1,28 @@
+package stats
+
+import (
+	"context"
+
+	"go-far/internal/model/dto"
+	"go-far/internal/model/entity"
+	"go-far/internal/repository/stats"
+)
+
+type StatsServiceItf interface {
+	CarsByBrand(ctx context.Context, cacheControl dto.CacheControl, filter *dto.StatsFilter) ([]entity.BrandCount, error)
+	CarsByYear(ctx context.Context, cacheControl dto.CacheControl, filter *dto.StatsFilter) ([]entity.YearCount, error)
+	CarAvailability(ctx context.Context, cacheControl dto.CacheControl, filter *dto.StatsFilter) (*entity.FleetAvailability, error)
+	CarsPerUser(ctx context.Context, cacheControl dto.CacheControl, filter *dto.StatsFilter) ([]entity.CarsPerUser, error)
+	RegistrationTrend(ctx context.Context, cacheControl dto.CacheControl, filter *dto.StatsFilter) ([]entity.TrendPoint, error)
+	LoginTrend(ctx context.Context, cacheControl dto.CacheControl, filter *dto.StatsFilter) ([]entity.LoginTrendPoint, error)
+}
+
+type statsService struct {
+	statsRepository stats.StatsRepositoryItf
+}
+
+func InitStatsService(statsRepository stats.StatsRepositoryItf) StatsServiceItf {
+	return &statsService{
+		statsRepository: statsRepository,
+	}
+}
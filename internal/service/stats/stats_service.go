@@ -0,0 +1,171 @@
+package stats
+
+import (
+	"context"
+	"time"
+
+	"go-far/internal/model/dto"
+	"go-far/internal/model/entity"
+	appErr "go-far/internal/model/errors"
+)
+
+const (
+	intervalDay   = "day"
+	intervalWeek  = "week"
+	intervalMonth = "month"
+
+	// defaultTrendPeriods is how many periods, the current one included, a
+	// trend without From covers.
+	defaultTrendPeriods = 30
+	// maxTrendPeriods caps the rows of one trend, e.g. a year of days.
+	maxTrendPeriods = 366
+)
+
+func (s *statsService) CarsByBrand(ctx context.Context, cacheControl dto.CacheControl, filter *dto.StatsFilter) ([]entity.BrandCount, error) {
+	counts, err := s.statsRepository.CarsByBrand(ctx, cacheControl, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return nonNil(counts), nil
+}
+
+func (s *statsService) CarsByYear(ctx context.Context, cacheControl dto.CacheControl, filter *dto.StatsFilter) ([]entity.YearCount, error) {
+	counts, err := s.statsRepository.CarsByYear(ctx, cacheControl, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return nonNil(counts), nil
+}
+
+func (s *statsService) CarAvailability(ctx context.Context, cacheControl dto.CacheControl, filter *dto.StatsFilter) (*entity.FleetAvailability, error) {
+	availability, err := s.statsRepository.CarAvailability(ctx, cacheControl, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	result := *availability
+	result.Unavailable = result.Total - result.Available
+	if result.Total > 0 {
+		result.Ratio = float64(result.Available) / float64(result.Total)
+	}
+
+	return &result, nil
+}
+
+func (s *statsService) CarsPerUser(ctx context.Context, cacheControl dto.CacheControl, filter *dto.StatsFilter) ([]entity.CarsPerUser, error) {
+	buckets, err := s.statsRepository.CarsPerUser(ctx, cacheControl, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return nonNil(buckets), nil
+}
+
+func (s *statsService) RegistrationTrend(ctx context.Context, cacheControl dto.CacheControl, filter *dto.StatsFilter) ([]entity.TrendPoint, error) {
+	window, err := trendWindow(filter, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	points, err := s.statsRepository.RegistrationTrend(ctx, cacheControl, window)
+	if err != nil {
+		return nil, err
+	}
+
+	return nonNil(points), nil
+}
+
+func (s *statsService) LoginTrend(ctx context.Context, cacheControl dto.CacheControl, filter *dto.StatsFilter) ([]entity.LoginTrendPoint, error) {
+	window, err := trendWindow(filter, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	points, err := s.statsRepository.LoginTrend(ctx, cacheControl, window)
+	if err != nil {
+		return nil, err
+	}
+
+	return nonNil(points), nil
+}
+
+// trendWindow fills in the bounds a trend series needs. Interval defaults to
+// day and To to the current minute, so repeated requests share a cache entry.
+// Without From the series covers the last defaultTrendPeriods periods.
+func trendWindow(filter *dto.StatsFilter, now time.Time) (*dto.StatsFilter, error) {
+	window := *filter
+	if window.Interval == "" {
+		window.Interval = intervalDay
+	}
+
+	if !window.HasTo() {
+		window.To = now.UTC().Truncate(time.Minute)
+	}
+
+	if !window.HasFrom() {
+		window.From = addPeriods(periodStart(window.To, window.Interval), window.Interval, 1-defaultTrendPeriods)
+	}
+
+	if window.From.After(window.To) {
+		return nil, appErr.NewWithCode(appErr.CodeHTTPBadRequest, "stats_from_after_to")
+	}
+
+	if countPeriods(window.From, window.To, window.Interval) > maxTrendPeriods {
+		return nil, appErr.NewWithCode(appErr.CodeHTTPBadRequest, "trend exceeds %d periods, narrow the range or use a longer interval", maxTrendPeriods)
+	}
+
+	return &window, nil
+}
+
+// periodStart truncates t to the start of its UTC period, matching
+// Postgres' date_trunc: weeks start on Monday.
+func periodStart(t time.Time, interval string) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+
+	switch interval {
+	case intervalWeek:
+		return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+	case intervalMonth:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return day
+	}
+}
+
+func addPeriods(t time.Time, interval string, n int) time.Time {
+	switch interval {
+	case intervalWeek:
+		return t.AddDate(0, 0, 7*n)
+	case intervalMonth:
+		return t.AddDate(0, n, 0)
+	default:
+		return t.AddDate(0, 0, n)
+	}
+}
+
+// countPeriods is the number of periods from the one holding from to the one
+// holding to, both included.
+func countPeriods(from, to time.Time, interval string) int {
+	from, to = periodStart(from, interval), periodStart(to, interval)
+
+	switch interval {
+	case intervalMonth:
+		return (to.Year()-from.Year())*12 + int(to.Month()-from.Month()) + 1
+	case intervalWeek:
+		return int(to.Sub(from)/(7*24*time.Hour)) + 1
+	default:
+		return int(to.Sub(from)/(24*time.Hour)) + 1
+	}
+}
+
+// nonNil keeps empty results serialized as [] rather than null.
+func nonNil[T any](items []T) []T {
+	if items == nil {
+		return []T{}
+	}
+
+	return items
+}
@@ -0,0 +1,212 @@
+package stats
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"go-far/internal/model/dto"
+	"go-far/internal/model/entity"
+	appErr "go-far/internal/model/errors"
+	"go-far/internal/repository/stats"
+)
+
+// fakeStatsRepository returns canned aggregates and records the filter the
+// trends were asked for.
+type fakeStatsRepository struct {
+	stats.StatsRepositoryItf
+
+	availability *entity.FleetAvailability
+	window       *dto.StatsFilter
+}
+
+func (r *fakeStatsRepository) CarsByBrand(_ context.Context, _ dto.CacheControl, _ *dto.StatsFilter) ([]entity.BrandCount, error) {
+	return nil, nil
+}
+
+func (r *fakeStatsRepository) CarAvailability(_ context.Context, _ dto.CacheControl, _ *dto.StatsFilter) (*entity.FleetAvailability, error) {
+	return r.availability, nil
+}
+
+func (r *fakeStatsRepository) RegistrationTrend(_ context.Context, _ dto.CacheControl, filter *dto.StatsFilter) ([]entity.TrendPoint, error) {
+	r.window = filter
+
+	return nil, nil
+}
+
+// statusOf is the HTTP status err is reported with, or 0 for nil.
+func statusOf(err error) int {
+	if err == nil {
+		return 0
+	}
+
+	return appErr.ErrorMessages[appErr.ErrCode(err)].StatusCode
+}
+
+func TestCarAvailability(t *testing.T) {
+	tests := []struct {
+		name   string
+		counts entity.FleetAvailability
+		want   entity.FleetAvailability
+	}{
+		{name: "empty fleet", want: entity.FleetAvailability{}},
+		{name: "all available", counts: entity.FleetAvailability{Total: 4, Available: 4}, want: entity.FleetAvailability{Total: 4, Available: 4, Ratio: 1}},
+		{name: "some reserved", counts: entity.FleetAvailability{Total: 8, Available: 6}, want: entity.FleetAvailability{Total: 8, Available: 6, Unavailable: 2, Ratio: 0.75}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			counts := tt.counts
+			svc := InitStatsService(&fakeStatsRepository{availability: &counts})
+
+			got, err := svc.CarAvailability(context.Background(), dto.CacheControl{}, &dto.StatsFilter{})
+			if err != nil {
+				t.Fatalf("CarAvailability: %v", err)
+			}
+
+			if *got != tt.want {
+				t.Errorf("availability = %+v, want %+v", *got, tt.want)
+			}
+
+			if counts != tt.counts {
+				t.Errorf("the repository's result was changed to %+v, it may be cached", counts)
+			}
+		})
+	}
+}
+
+func TestEmptyStatsAreNotNull(t *testing.T) {
+	svc := InitStatsService(&fakeStatsRepository{})
+
+	brands, err := svc.CarsByBrand(context.Background(), dto.CacheControl{}, &dto.StatsFilter{})
+	if err != nil || brands == nil || len(brands) != 0 {
+		t.Errorf("CarsByBrand = %#v, %v, want an empty slice", brands, err)
+	}
+}
+
+// TestRegistrationTrendWindow checks the defaults and limits trendWindow
+// applies before the trend is queried.
+func TestRegistrationTrendWindow(t *testing.T) {
+	day := func(s string) time.Time {
+		d, err := time.Parse(time.DateOnly, s)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return d
+	}
+
+	tests := []struct {
+		name       string
+		filter     dto.StatsFilter
+		want       *dto.StatsFilter
+		wantStatus int
+	}{
+		{
+			name:   "bounds and interval kept",
+			filter: dto.StatsFilter{From: day("2026-01-01"), To: day("2026-03-31"), Interval: intervalMonth},
+			want:   &dto.StatsFilter{From: day("2026-01-01"), To: day("2026-03-31"), Interval: intervalMonth},
+		},
+		{
+			name:   "daily without from covers 30 days",
+			filter: dto.StatsFilter{To: day("2026-10-19").Add(15 * time.Hour)},
+			want:   &dto.StatsFilter{From: day("2026-09-20"), To: day("2026-10-19").Add(15 * time.Hour), Interval: intervalDay},
+		},
+		{
+			name:   "weekly without from starts on a Monday",
+			filter: dto.StatsFilter{To: day("2026-10-22"), Interval: intervalWeek},
+			want:   &dto.StatsFilter{From: day("2026-03-30"), To: day("2026-10-22"), Interval: intervalWeek},
+		},
+		{
+			name:   "monthly without from",
+			filter: dto.StatsFilter{To: day("2026-10-19"), Interval: intervalMonth},
+			want:   &dto.StatsFilter{From: day("2024-05-01"), To: day("2026-10-19"), Interval: intervalMonth},
+		},
+		{
+			name:   "a year of days",
+			filter: dto.StatsFilter{From: day("2024-01-01"), To: day("2024-12-31")},
+			want:   &dto.StatsFilter{From: day("2024-01-01"), To: day("2024-12-31"), Interval: intervalDay},
+		},
+		{
+			name:       "more than a year of days",
+			filter:     dto.StatsFilter{From: day("2024-01-01"), To: day("2025-01-01")},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "from after to",
+			filter:     dto.StatsFilter{From: day("2026-02-01"), To: day("2026-01-01")},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeStatsRepository{}
+			svc := InitStatsService(repo)
+
+			points, err := svc.RegistrationTrend(context.Background(), dto.CacheControl{}, &tt.filter)
+			if got := statusOf(err); got != tt.wantStatus {
+				t.Fatalf("status = %d, want %d (err %v)", got, tt.wantStatus, err)
+			}
+
+			if tt.wantStatus != 0 {
+				if repo.window != nil {
+					t.Errorf("a rejected window was queried: %+v", repo.window)
+				}
+				return
+			}
+
+			if !reflect.DeepEqual(repo.window, tt.want) {
+				t.Errorf("window = %+v, want %+v", repo.window, tt.want)
+			}
+
+			if points == nil {
+				t.Error("points = nil, want an empty slice")
+			}
+		})
+	}
+}
+
+// TestTrendWindowDefaultsToTheMinute keeps an open-ended trend on one cache
+// entry for the rest of the minute.
+func TestTrendWindowDefaultsToTheMinute(t *testing.T) {
+	now := time.Date(2026, 10, 19, 9, 41, 27, 500, time.FixedZone("WIB", 7*60*60))
+
+	window, err := trendWindow(&dto.StatsFilter{}, now)
+	if err != nil {
+		t.Fatalf("trendWindow: %v", err)
+	}
+
+	if want := time.Date(2026, 10, 19, 2, 41, 0, 0, time.UTC); !window.To.Equal(want) || window.To.Location() != time.UTC {
+		t.Errorf("To = %v, want %v", window.To, want)
+	}
+
+	if want := time.Date(2026, 9, 20, 0, 0, 0, 0, time.UTC); !window.From.Equal(want) {
+		t.Errorf("From = %v, want %v", window.From, want)
+	}
+}
+
+func TestCountPeriods(t *testing.T) {
+	from := time.Date(2026, 1, 31, 23, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		interval string
+		to       time.Time
+		want     int
+	}{
+		{interval: intervalDay, to: from, want: 1},
+		{interval: intervalDay, to: from.Add(2 * time.Hour), want: 2},
+		{interval: intervalWeek, to: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), want: 1},
+		{interval: intervalWeek, to: time.Date(2026, 2, 2, 0, 0, 0, 0, time.UTC), want: 2},
+		{interval: intervalMonth, to: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), want: 2},
+		{interval: intervalMonth, to: time.Date(2027, 1, 31, 0, 0, 0, 0, time.UTC), want: 13},
+	}
+
+	for _, tt := range tests {
+		if got := countPeriods(from, tt.to, tt.interval); got != tt.want {
+			t.Errorf("countPeriods(%v, %v, %s) = %d, want %d", from, tt.to, tt.interval, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package transfer
+
+import (
+	"context"
+	"time"
+
+	"go-far/internal/infra/database"
+	"go-far/internal/model/dto"
+	"go-far/internal/model/entity"
+	"go-far/internal/repository/car"
+	"go-far/internal/repository/transfer"
+
+	"github.com/google/uuid"
+)
+
+// defaultRequestTTL is used when transfer.request_ttl is not configured.
+const defaultRequestTTL = 72 * time.Hour
+
+type TransferServiceItf interface {
+	CreateTransferRequest(ctx context.Context, carID uuid.UUID, req dto.TransferCarRequest, userID string) (*entity.CarTransferRequest, error)
+	GetTransferRequest(ctx context.Context, id uuid.UUID, userID string, isAdmin bool) (*entity.CarTransferRequest, error)
+	AcceptTransferRequest(ctx context.Context, id uuid.UUID, userID string) (*entity.CarTransferRequest, error)
+	RejectTransferRequest(ctx context.Context, id uuid.UUID, userID string) (*entity.CarTransferRequest, error)
+	CancelTransferRequest(ctx context.Context, id uuid.UUID, userID string) (*entity.CarTransferRequest, error)
+	ListIncomingTransferRequests(ctx context.Context, filter *dto.TransferRequestFilter, userID string) ([]entity.CarTransferRequest, *dto.Pagination, error)
+	ListOutgoingTransferRequests(ctx context.Context, filter *dto.TransferRequestFilter, userID string) ([]entity.CarTransferRequest, *dto.Pagination, error)
+	ExpireTransferRequests(ctx context.Context, batchSize int) (int64, error)
+}
+
+type transferService struct {
+	transferRepository transfer.TransferRepositoryItf
+	carRepository      car.CarRepositoryItf
+	tx                 database.Transactor
+	requestTTL         time.Duration
+}
+
+func InitTransferService(transferRepository transfer.TransferRepositoryItf, carRepository car.CarRepositoryItf, tx database.Transactor, requestTTL time.Duration) TransferServiceItf {
+	if requestTTL <= 0 {
+		requestTTL = defaultRequestTTL
+	}
+
+	return &transferService{
+		transferRepository: transferRepository,
+		carRepository:      carRepository,
+		tx:                 tx,
+		requestTTL:         requestTTL,
+	}
+}
@@ -0,0 +1,225 @@
+package transfer
+
+import (
+	"context"
+	"time"
+
+	"go-far/internal/model/dto"
+	"go-far/internal/model/entity"
+	appErr "go-far/internal/model/errors"
+	"go-far/internal/util"
+
+	"github.com/google/uuid"
+)
+
+// CreateTransferRequest offers the caller's car to req.NewUserID. The car
+// changes hands only once the recipient accepts.
+func (s *transferService) CreateTransferRequest(ctx context.Context, carID uuid.UUID, req dto.TransferCarRequest, userID string) (*entity.CarTransferRequest, error) {
+	if req.NewUserID.String() == userID {
+		return nil, appErr.NewWithCode(appErr.CodeHTTPBadRequest, "cannot_transfer_to_self")
+	}
+
+	isOwner, err := s.carRepository.IsCarOwnedByUser(ctx, carID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isOwner {
+		return nil, appErr.NewWithCode(appErr.CodeHTTPForbidden, "you do not have permission to transfer this car")
+	}
+
+	var request *entity.CarTransferRequest
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.transferRepository.ExpireForCar(ctx, carID); err != nil {
+			return err
+		}
+
+		var err error
+		request, err = s.transferRepository.Create(ctx, carID, userID, req, time.Now().Add(s.requestTTL))
+		return err
+	})
+	if err != nil {
+		switch appErr.ErrCode(err) {
+		case appErr.CodeSQLEmptyRow:
+			return nil, appErr.WrapWithCode(err, appErr.CodeHTTPNotFound, "car_or_recipient_not_found")
+		case appErr.CodeSQLConflict:
+			return nil, appErr.WrapWithCode(err, appErr.CodeHTTPConflict, "car_transfer_already_pending")
+		}
+
+		return nil, err
+	}
+
+	return request, nil
+}
+
+// GetTransferRequest shows a request to its sender, its recipient and admins.
+func (s *transferService) GetTransferRequest(ctx context.Context, id uuid.UUID, userID string, isAdmin bool) (*entity.CarTransferRequest, error) {
+	request, err := s.findTransferRequest(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isAdmin && request.FromUserID != userID && request.ToUserID != userID {
+		return nil, appErr.NewWithCode(appErr.CodeHTTPForbidden, "you do not have permission to access this transfer request")
+	}
+
+	return request, nil
+}
+
+// AcceptTransferRequest hands the car to the recipient. The status change,
+// the ownership change and its history entry are written in one transaction,
+// and the sender must still own the car.
+func (s *transferService) AcceptTransferRequest(ctx context.Context, id uuid.UUID, userID string) (*entity.CarTransferRequest, error) {
+	request, err := s.findPendingRequest(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.ToUserID != userID {
+		return nil, appErr.NewWithCode(appErr.CodeHTTPForbidden, "only the recipient can accept this transfer request")
+	}
+
+	carID, err := uuid.Parse(request.CarID)
+	if err != nil {
+		return nil, appErr.WrapWithCode(err, appErr.CodeHTTPInternalServerError, "invalid_car_id")
+	}
+
+	newUserID, err := uuid.Parse(request.ToUserID)
+	if err != nil {
+		return nil, appErr.WrapWithCode(err, appErr.CodeHTTPInternalServerError, "invalid_user_id")
+	}
+
+	var accepted *entity.CarTransferRequest
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		accepted, err = s.respond(ctx, id, entity.TransferRequestAccepted)
+		if err != nil {
+			return err
+		}
+
+		ownerID, err := s.carRepository.FindOwnerForUpdate(ctx, carID)
+		if err != nil {
+			return err
+		}
+
+		if ownerID.String() != accepted.FromUserID {
+			return appErr.NewWithCode(appErr.CodeHTTPConflict, "car_owner_changed")
+		}
+
+		if err := s.carRepository.TransferOwnership(ctx, carID, newUserID); err != nil {
+			if appErr.ErrCode(err) == appErr.CodeSQLEmptyRow {
+				return appErr.WrapWithCode(err, appErr.CodeHTTPConflict, "car_not_transferable")
+			}
+
+			return err
+		}
+
+		var reason string
+		if accepted.Reason != nil {
+			reason = *accepted.Reason
+		}
+
+		// The sender initiated the hand-over, so they are recorded as the actor.
+		return s.carRepository.AppendOwnershipHistory(ctx, []entity.CarOwnershipRecord{
+			entity.NewCarOwnershipRecord(accepted.CarID, entity.CarOwnershipTransferred, accepted.FromUserID, accepted.ToUserID, accepted.FromUserID, reason),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return accepted, nil
+}
+
+func (s *transferService) RejectTransferRequest(ctx context.Context, id uuid.UUID, userID string) (*entity.CarTransferRequest, error) {
+	request, err := s.findPendingRequest(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.ToUserID != userID {
+		return nil, appErr.NewWithCode(appErr.CodeHTTPForbidden, "only the recipient can reject this transfer request")
+	}
+
+	return s.respond(ctx, id, entity.TransferRequestRejected)
+}
+
+func (s *transferService) CancelTransferRequest(ctx context.Context, id uuid.UUID, userID string) (*entity.CarTransferRequest, error) {
+	request, err := s.findPendingRequest(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.FromUserID != userID {
+		return nil, appErr.NewWithCode(appErr.CodeHTTPForbidden, "only the sender can cancel this transfer request")
+	}
+
+	return s.respond(ctx, id, entity.TransferRequestCancelled)
+}
+
+func (s *transferService) ListIncomingTransferRequests(ctx context.Context, filter *dto.TransferRequestFilter, userID string) ([]entity.CarTransferRequest, *dto.Pagination, error) {
+	filter.ToUserID = userID
+
+	return s.transferRepository.FindAll(ctx, filter)
+}
+
+func (s *transferService) ListOutgoingTransferRequests(ctx context.Context, filter *dto.TransferRequestFilter, userID string) ([]entity.CarTransferRequest, *dto.Pagination, error) {
+	filter.FromUserID = userID
+
+	return s.transferRepository.FindAll(ctx, filter)
+}
+
+// ExpireTransferRequests marks overdue pending requests expired, one batch at
+// a time.
+func (s *transferService) ExpireTransferRequests(ctx context.Context, batchSize int) (int64, error) {
+	return util.DrainBatches(batchSize, func(limit int) (int64, error) {
+		return s.transferRepository.Expire(ctx, limit)
+	})
+}
+
+func (s *transferService) findTransferRequest(ctx context.Context, id uuid.UUID) (*entity.CarTransferRequest, error) {
+	request, err := s.transferRepository.FindByID(ctx, id)
+	if err != nil {
+		if appErr.ErrCode(err) == appErr.CodeSQLEmptyRow {
+			return nil, appErr.WrapWithCode(err, appErr.CodeHTTPNotFound, "transfer_request_not_found")
+		}
+
+		return nil, err
+	}
+
+	return request, nil
+}
+
+// findPendingRequest returns the request if it can still be answered, and
+// 409 if it was already answered or has expired.
+func (s *transferService) findPendingRequest(ctx context.Context, id uuid.UUID) (*entity.CarTransferRequest, error) {
+	request, err := s.findTransferRequest(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.IsExpired(time.Now()) || request.Status == entity.TransferRequestExpired {
+		return nil, appErr.NewWithCode(appErr.CodeHTTPConflict, "transfer_request_expired")
+	}
+
+	if request.Status != entity.TransferRequestPending {
+		return nil, appErr.NewWithCode(appErr.CodeHTTPConflict, "transfer_request_not_pending")
+	}
+
+	return request, nil
+}
+
+// respond changes the status of a pending request; a concurrent answer or
+// expiry between the check and the update returns 409.
+func (s *transferService) respond(ctx context.Context, id uuid.UUID, status entity.TransferRequestStatus) (*entity.CarTransferRequest, error) {
+	request, err := s.transferRepository.Respond(ctx, id, status)
+	if err != nil {
+		if appErr.ErrCode(err) == appErr.CodeSQLEmptyRow {
+			return nil, appErr.WrapWithCode(err, appErr.CodeHTTPConflict, "transfer_request_not_pending")
+		}
+
+		return nil, err
+	}
+
+	return request, nil
+}
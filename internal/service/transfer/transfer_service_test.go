@@ -0,0 +1,293 @@
+package transfer
+
+import (
+	"context"
+	"maps"
+	"net/http"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+
+	"go-far/internal/model/entity"
+	appErr "go-far/internal/model/errors"
+	"go-far/internal/repository/car"
+	"go-far/internal/repository/transfer"
+
+	"github.com/google/uuid"
+)
+
+var (
+	senderID    = uuid.MustParse("0190a3b4-0000-7000-8000-000000000001")
+	recipientID = uuid.MustParse("0190a3b4-0000-7000-8000-000000000002")
+	testCarID   = uuid.MustParse("0190a3b4-0000-7000-8000-0000000000c1")
+)
+
+// fakeTransferRepository keeps requests in memory. Like the SQL it answers
+// only pending requests that have not expired.
+type fakeTransferRepository struct {
+	transfer.TransferRepositoryItf
+
+	requests map[string]*entity.CarTransferRequest
+}
+
+func (r *fakeTransferRepository) FindByID(_ context.Context, id uuid.UUID) (*entity.CarTransferRequest, error) {
+	request, ok := r.requests[id.String()]
+	if !ok {
+		return nil, appErr.NewWithCode(appErr.CodeSQLEmptyRow, "transfer_request_not_found")
+	}
+
+	found := *request
+	return &found, nil
+}
+
+func (r *fakeTransferRepository) Respond(_ context.Context, id uuid.UUID, status entity.TransferRequestStatus) (*entity.CarTransferRequest, error) {
+	now := time.Now()
+
+	request, ok := r.requests[id.String()]
+	if !ok || request.Status != entity.TransferRequestPending || request.IsExpired(now) {
+		return nil, appErr.NewWithCode(appErr.CodeSQLEmptyRow, "pending_transfer_request_not_found")
+	}
+
+	request.Status = status
+	request.RespondedAt = &now
+
+	answered := *request
+	return &answered, nil
+}
+
+func (r *fakeTransferRepository) Expire(_ context.Context, limit int) (int64, error) {
+	now := time.Now()
+
+	var expired int64
+	for _, id := range slices.Sorted(maps.Keys(r.requests)) {
+		if expired == int64(limit) {
+			break
+		}
+
+		if request := r.requests[id]; request.IsExpired(now) {
+			request.Status = entity.TransferRequestExpired
+			expired++
+		}
+	}
+
+	return expired, nil
+}
+
+// fakeCarRepository tracks the owner of each car and the history appended.
+type fakeCarRepository struct {
+	car.CarRepositoryItf
+
+	owners  map[uuid.UUID]uuid.UUID
+	history []entity.CarOwnershipRecord
+}
+
+func (r *fakeCarRepository) FindOwnerForUpdate(_ context.Context, carID uuid.UUID) (uuid.UUID, error) {
+	owner, ok := r.owners[carID]
+	if !ok {
+		return uuid.Nil, appErr.NewWithCode(appErr.CodeSQLEmptyRow, "car_not_found")
+	}
+
+	return owner, nil
+}
+
+func (r *fakeCarRepository) TransferOwnership(_ context.Context, carID, newUserID uuid.UUID) error {
+	r.owners[carID] = newUserID
+
+	return nil
+}
+
+func (r *fakeCarRepository) AppendOwnershipHistory(_ context.Context, records []entity.CarOwnershipRecord) error {
+	r.history = append(r.history, records...)
+
+	return nil
+}
+
+// fakeTransactor runs fn directly, without a transaction.
+type fakeTransactor struct{}
+
+func (fakeTransactor) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+type fixture struct {
+	transfers *fakeTransferRepository
+	cars      *fakeCarRepository
+	svc       TransferServiceItf
+}
+
+// newFixture stores requests and lets senderID own testCarID.
+func newFixture(requests ...*entity.CarTransferRequest) *fixture {
+	f := &fixture{
+		transfers: &fakeTransferRepository{requests: make(map[string]*entity.CarTransferRequest)},
+		cars:      &fakeCarRepository{owners: map[uuid.UUID]uuid.UUID{testCarID: senderID}},
+	}
+	for _, request := range requests {
+		f.transfers.requests[request.ID] = request
+	}
+	f.svc = InitTransferService(f.transfers, f.cars, fakeTransactor{}, 0)
+
+	return f
+}
+
+// newRequest is a request from senderID to recipientID that expires after
+// expiresIn.
+func newRequest(status entity.TransferRequestStatus, expiresIn time.Duration) *entity.CarTransferRequest {
+	return &entity.CarTransferRequest{
+		ID:         uuid.NewString(),
+		CarID:      testCarID.String(),
+		FromUserID: senderID.String(),
+		ToUserID:   recipientID.String(),
+		Status:     status,
+		ExpiresAt:  time.Now().Add(expiresIn),
+	}
+}
+
+// statusOf is the HTTP status err is reported with, or 0 for nil.
+func statusOf(err error) int {
+	if err == nil {
+		return 0
+	}
+
+	return appErr.ErrorMessages[appErr.ErrCode(err)].StatusCode
+}
+
+func TestAcceptTransferRequest(t *testing.T) {
+	tests := []struct {
+		name       string
+		request    *entity.CarTransferRequest
+		userID     uuid.UUID
+		owner      uuid.UUID
+		wantStatus int
+	}{
+		{name: "recipient accepts", request: newRequest(entity.TransferRequestPending, time.Hour), userID: recipientID, owner: senderID},
+		{name: "sender cannot accept", request: newRequest(entity.TransferRequestPending, time.Hour), userID: senderID, owner: senderID, wantStatus: http.StatusForbidden},
+		{name: "already rejected", request: newRequest(entity.TransferRequestRejected, time.Hour), userID: recipientID, owner: senderID, wantStatus: http.StatusConflict},
+		{name: "past its expiry", request: newRequest(entity.TransferRequestPending, -time.Minute), userID: recipientID, owner: senderID, wantStatus: http.StatusConflict},
+		{name: "marked expired", request: newRequest(entity.TransferRequestExpired, -time.Minute), userID: recipientID, owner: senderID, wantStatus: http.StatusConflict},
+		{name: "sender no longer owns the car", request: newRequest(entity.TransferRequestPending, time.Hour), userID: recipientID, owner: uuid.New(), wantStatus: http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(tt.request)
+			f.cars.owners[testCarID] = tt.owner
+
+			accepted, err := f.svc.AcceptTransferRequest(context.Background(), uuid.MustParse(tt.request.ID), tt.userID.String())
+			if got := statusOf(err); got != tt.wantStatus {
+				t.Fatalf("status = %d, want %d (err %v)", got, tt.wantStatus, err)
+			}
+
+			if tt.wantStatus != 0 {
+				if f.cars.owners[testCarID] != tt.owner || len(f.cars.history) != 0 {
+					t.Errorf("a rejected accept moved the car: owner %v, history %v", f.cars.owners[testCarID], f.cars.history)
+				}
+				return
+			}
+
+			if accepted.Status != entity.TransferRequestAccepted || accepted.RespondedAt == nil {
+				t.Errorf("accepted request = %+v", accepted)
+			}
+
+			if f.cars.owners[testCarID] != recipientID {
+				t.Errorf("owner = %v, want the recipient %v", f.cars.owners[testCarID], recipientID)
+			}
+
+			want := entity.NewCarOwnershipRecord(testCarID.String(), entity.CarOwnershipTransferred, senderID.String(), recipientID.String(), senderID.String(), "")
+			if len(f.cars.history) != 1 || !reflect.DeepEqual(f.cars.history[0], want) {
+				t.Errorf("history = %+v, want one transfer by the sender", f.cars.history)
+			}
+
+			if _, err := f.svc.AcceptTransferRequest(context.Background(), uuid.MustParse(tt.request.ID), tt.userID.String()); statusOf(err) != http.StatusConflict {
+				t.Errorf("second accept err = %v, want 409", err)
+			}
+		})
+	}
+}
+
+func TestRejectAndCancelTransferRequest(t *testing.T) {
+	tests := []struct {
+		name       string
+		cancel     bool
+		request    *entity.CarTransferRequest
+		userID     uuid.UUID
+		wantStatus int
+		wantState  entity.TransferRequestStatus
+	}{
+		{name: "recipient rejects", request: newRequest(entity.TransferRequestPending, time.Hour), userID: recipientID, wantState: entity.TransferRequestRejected},
+		{name: "sender cannot reject", request: newRequest(entity.TransferRequestPending, time.Hour), userID: senderID, wantStatus: http.StatusForbidden},
+		{name: "reject after expiry", request: newRequest(entity.TransferRequestPending, -time.Minute), userID: recipientID, wantStatus: http.StatusConflict},
+		{name: "reject after accept", request: newRequest(entity.TransferRequestAccepted, time.Hour), userID: recipientID, wantStatus: http.StatusConflict},
+		{name: "sender cancels", cancel: true, request: newRequest(entity.TransferRequestPending, time.Hour), userID: senderID, wantState: entity.TransferRequestCancelled},
+		{name: "recipient cannot cancel", cancel: true, request: newRequest(entity.TransferRequestPending, time.Hour), userID: recipientID, wantStatus: http.StatusForbidden},
+		{name: "cancel after expiry", cancel: true, request: newRequest(entity.TransferRequestPending, -time.Minute), userID: senderID, wantStatus: http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(tt.request)
+			before := tt.request.Status
+			answer := f.svc.RejectTransferRequest
+			if tt.cancel {
+				answer = f.svc.CancelTransferRequest
+			}
+
+			answered, err := answer(context.Background(), uuid.MustParse(tt.request.ID), tt.userID.String())
+			if got := statusOf(err); got != tt.wantStatus {
+				t.Fatalf("status = %d, want %d (err %v)", got, tt.wantStatus, err)
+			}
+
+			if tt.wantStatus != 0 {
+				if tt.request.Status != before {
+					t.Errorf("status changed to %q on a rejected answer", tt.request.Status)
+				}
+				return
+			}
+
+			if answered.Status != tt.wantState {
+				t.Errorf("status = %q, want %q", answered.Status, tt.wantState)
+			}
+
+			if f.cars.owners[testCarID] != senderID || len(f.cars.history) != 0 {
+				t.Error("answering without accepting moved the car")
+			}
+		})
+	}
+}
+
+// TestExpireTransferRequests drains overdue pending requests in batches and
+// leaves pending requests that are still open and answered ones alone.
+func TestExpireTransferRequests(t *testing.T) {
+	var overdue []*entity.CarTransferRequest
+	for range 5 {
+		overdue = append(overdue, newRequest(entity.TransferRequestPending, -time.Minute))
+	}
+	open := newRequest(entity.TransferRequestPending, time.Hour)
+	accepted := newRequest(entity.TransferRequestAccepted, -time.Minute)
+
+	f := newFixture(append(overdue, open, accepted)...)
+
+	expired, err := f.svc.ExpireTransferRequests(context.Background(), 2)
+	if err != nil {
+		t.Fatalf("ExpireTransferRequests: %v", err)
+	}
+	if expired != int64(len(overdue)) {
+		t.Errorf("expired = %d, want %d", expired, len(overdue))
+	}
+
+	for _, request := range overdue {
+		if request.Status != entity.TransferRequestExpired {
+			t.Errorf("overdue request status = %q, want expired", request.Status)
+		}
+	}
+	if open.Status != entity.TransferRequestPending {
+		t.Errorf("open request status = %q, want pending", open.Status)
+	}
+	if accepted.Status != entity.TransferRequestAccepted {
+		t.Errorf("accepted request status = %q, want accepted", accepted.Status)
+	}
+
+	if _, err := f.svc.AcceptTransferRequest(context.Background(), uuid.MustParse(overdue[0].ID), recipientID.String()); statusOf(err) != http.StatusConflict {
+		t.Errorf("accepting an expired request err = %v, want 409", err)
+	}
+}
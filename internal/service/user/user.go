@@ -2,9 +2,11 @@ package user
 
 import (
 	"context"
+	"time"
 
 	"go-far/internal/model/dto"
 	"go-far/internal/model/entity"
+	"go-far/internal/repository/car"
 	"go-far/internal/repository/user"
 )
 
@@ -12,19 +14,29 @@ type UserServiceItf interface {
 	CreateUser(ctx context.Context, req dto.CreateUserRequest) (*entity.User, error)
 	RegisterUser(ctx context.Context, req dto.RegisterRequest) (*entity.User, error)
 	Login(ctx context.Context, req dto.LoginRequest) (*entity.User, error)
-	GetUser(ctx context.Context, id string) (*entity.User, error)
+	GetUser(ctx context.Context, cacheControl dto.CacheControl, id string) (*entity.User, error)
+	GetUserWithDeleted(ctx context.Context, id string) (*entity.User, error)
+	GetUserColumns(ctx context.Context, cacheControl dto.CacheControl, id string, columns []string) (*entity.User, error)
+	GetUserWithCars(ctx context.Context, cacheControl dto.CacheControl, id string, columns []string) (*entity.UserWithCars, error)
 	ListUsers(ctx context.Context, cacheControl dto.CacheControl, filter *dto.UserFilter) (*[]entity.User, *dto.Pagination, error)
-	ListUsersV2(ctx context.Context, filter *dto.UserFilterV2) (*[]entity.User, *dto.Pagination, error)
+	ListUsersWithCars(ctx context.Context, cacheControl dto.CacheControl, filter *dto.UserFilter) ([]entity.UserWithCars, *dto.Pagination, error)
+	ListUsersV2(ctx context.Context, cacheControl dto.CacheControl, filter *dto.UserFilterV2) (*[]entity.User, *dto.Pagination, error)
+	ExportUsers(ctx context.Context, filter *dto.UserFilterV2, fn func(*entity.User) error) error
 	UpdateUser(ctx context.Context, id string, req dto.UpdateUserRequest) (*entity.User, error)
+	PatchUser(ctx context.Context, id string, req dto.PatchRequest) (*entity.User, error)
 	DeleteUser(ctx context.Context, id string) error
+	RestoreUser(ctx context.Context, id string) (*entity.User, error)
+	PurgeDeletedUsers(ctx context.Context, deletedBefore time.Time, batchSize int) (int64, error)
 }
 
 type userService struct {
 	userRepository user.UserRepositoryItf
+	carRepository  car.CarRepositoryItf
 }
 
-func InitUserService(userRepository user.UserRepositoryItf) UserServiceItf {
+func InitUserService(userRepository user.UserRepositoryItf, carRepository car.CarRepositoryItf) UserServiceItf {
 	return &userService{
 		userRepository: userRepository,
+		carRepository:  carRepository,
 	}
 }
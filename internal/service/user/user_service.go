@@ -2,11 +2,16 @@ package user
 
 import (
 	"context"
+	"slices"
+	"time"
 
+	"go-far/internal/infra/validator"
 	"go-far/internal/model/dto"
 	"go-far/internal/model/entity"
 	appErr "go-far/internal/model/errors"
+	"go-far/internal/util"
 
+	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
 )
 
@@ -28,7 +33,7 @@ func (s *userService) registerUser(ctx context.Context, name, email, password st
 		Name:     name,
 		Email:    email,
 		Password: string(hashedPassword),
-		Age:      age,
+		Age:      &age,
 		Role:     role,
 	}
 
@@ -50,11 +55,104 @@ func (s *userService) Login(ctx context.Context, req dto.LoginRequest) (*entity.
 		return nil, appErr.NewWithCode(appErr.CodeHTTPUnauthorized, "Invalid credentials")
 	}
 
+	// The login only feeds the statistics; failing to record it must not
+	// fail the login itself. The repository has already logged the error.
+	_ = s.userRepository.RecordLogin(ctx, user.ID)
+
 	return user, nil
 }
 
-func (s *userService) GetUser(ctx context.Context, id string) (*entity.User, error) {
-	return s.userRepository.FindByID(ctx, id)
+func (s *userService) GetUser(ctx context.Context, cacheControl dto.CacheControl, id string) (*entity.User, error) {
+	return s.userRepository.FindByID(ctx, cacheControl, id)
+}
+
+func (s *userService) GetUserWithDeleted(ctx context.Context, id string) (*entity.User, error) {
+	return s.userRepository.FindByIDWithDeleted(ctx, id)
+}
+
+// GetUserColumns reads only the given columns, bypassing the cache; an
+// empty list reads the whole user through GetUser.
+func (s *userService) GetUserColumns(ctx context.Context, cacheControl dto.CacheControl, id string, columns []string) (*entity.User, error) {
+	if len(columns) == 0 {
+		return s.GetUser(ctx, cacheControl, id)
+	}
+
+	cacheControl.Result.Record(dto.CacheBypass, 0)
+
+	return s.userRepository.FindByIDColumns(ctx, id, columns)
+}
+
+func (s *userService) GetUserWithCars(ctx context.Context, cacheControl dto.CacheControl, id string, columns []string) (*entity.UserWithCars, error) {
+	user, err := s.GetUserColumns(ctx, cacheControl, id, withColumn(columns, "id"))
+	if err != nil {
+		return nil, err
+	}
+
+	users, err := s.withCars(ctx, []entity.User{*user})
+	if err != nil {
+		return nil, err
+	}
+
+	return &users[0], nil
+}
+
+func (s *userService) ListUsersWithCars(ctx context.Context, cacheControl dto.CacheControl, filter *dto.UserFilter) ([]entity.UserWithCars, *dto.Pagination, error) {
+	filter.Columns = withColumn(filter.Columns, "id")
+
+	users, pagination, err := s.userRepository.FindAll(ctx, cacheControl, filter)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	result, err := s.withCars(ctx, *users)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return result, pagination, nil
+}
+
+// withCars attaches each user's cars using a single batch query for the page.
+func (s *userService) withCars(ctx context.Context, users []entity.User) ([]entity.UserWithCars, error) {
+	userIDs := make([]uuid.UUID, 0, len(users))
+	for _, u := range users {
+		id, err := uuid.Parse(u.ID)
+		if err != nil {
+			return nil, appErr.WrapWithCode(err, appErr.CodeHTTPInternalServerError, "invalid_user_id")
+		}
+		userIDs = append(userIDs, id)
+	}
+
+	carsByUser, err := s.carRepository.FindByUserIDs(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]entity.UserWithCars, len(users))
+	for i, u := range users {
+		cars := carsByUser[u.ID]
+		if cars == nil {
+			cars = []entity.Car{}
+		}
+
+		result[i] = entity.UserWithCars{
+			User:     u,
+			Cars:     cars,
+			CarCount: len(cars),
+		}
+	}
+
+	return result, nil
+}
+
+// withColumn adds column to a non-empty column selection, as the relation
+// loaders key on it even when the caller did not ask for it.
+func withColumn(columns []string, column string) []string {
+	if len(columns) == 0 || slices.Contains(columns, column) {
+		return columns
+	}
+
+	return append(slices.Clip(columns), column)
 }
 
 func (s *userService) ListUsers(ctx context.Context, cacheControl dto.CacheControl, filter *dto.UserFilter) (*[]entity.User, *dto.Pagination, error) {
@@ -62,11 +160,16 @@ func (s *userService) ListUsers(ctx context.Context, cacheControl dto.CacheContr
 }
 
 func (s *userService) UpdateUser(ctx context.Context, id string, req dto.UpdateUserRequest) (*entity.User, error) {
-	existingUser, err := s.userRepository.FindByID(ctx, id)
+	// Read past the cache: the update is conditional on this version.
+	existingUser, err := s.userRepository.FindByID(ctx, dto.CacheControl{MustDbValidate: true}, id)
 	if err != nil {
 		return nil, appErr.WrapWithCode(err, appErr.CodeHTTPNotFound, "user_not_found")
 	}
 
+	if req.Version != nil {
+		existingUser.Version = *req.Version
+	}
+
 	if req.Name != "" {
 		existingUser.Name = req.Name
 	}
@@ -76,7 +179,7 @@ func (s *userService) UpdateUser(ctx context.Context, id string, req dto.UpdateU
 	}
 
 	if req.Age > 0 {
-		existingUser.Age = req.Age
+		existingUser.Age = &req.Age
 	}
 
 	if req.Role != "" {
@@ -94,6 +197,75 @@ func (s *userService) UpdateUser(ctx context.Context, id string, req dto.UpdateU
 	return existingUser, nil
 }
 
+// PatchUser applies a JSON Merge Patch or JSON Patch to the stored user. The
+// write is conditional on the patched version, so a version in the patch, or
+// a "test" operation on it, catches concurrent edits like UpdateUser does.
+func (s *userService) PatchUser(ctx context.Context, id string, req dto.PatchRequest) (*entity.User, error) {
+	existingUser, err := s.userRepository.FindByID(ctx, dto.CacheControl{MustDbValidate: true}, id)
+	if err != nil {
+		return nil, appErr.WrapWithCode(err, appErr.CodeHTTPNotFound, "user_not_found")
+	}
+
+	doc := dto.UserPatch{
+		IsActive: &existingUser.IsActive,
+		Age:      existingUser.Age,
+		Name:     existingUser.Name,
+		Email:    existingUser.Email,
+		Role:     existingUser.Role,
+		Version:  existingUser.Version,
+	}
+	if req.Version != nil {
+		doc.Version = *req.Version
+	}
+
+	var patched dto.UserPatch
+	if err := util.ApplyPatch(doc, req.MediaType, req.Document, &patched); err != nil {
+		if appErr.ErrCode(err) == appErr.CodeHTTPConflict {
+			return nil, appErr.WrapWithCode(&appErr.ConflictError{Current: existingUser}, appErr.CodeHTTPConflict, "user_patch_test_failed")
+		}
+
+		return nil, err
+	}
+
+	if err := validator.ValidateRequest(&patched); err != nil {
+		return nil, err
+	}
+
+	existingUser.IsActive = *patched.IsActive
+	existingUser.Age = patched.Age
+	existingUser.Name = patched.Name
+	existingUser.Email = patched.Email
+	existingUser.Role = patched.Role
+	existingUser.Version = patched.Version
+
+	if err := s.userRepository.Update(ctx, existingUser); err != nil {
+		return nil, err
+	}
+
+	return existingUser, nil
+}
+
 func (s *userService) DeleteUser(ctx context.Context, id string) error {
 	return s.userRepository.Delete(ctx, id)
 }
+
+func (s *userService) RestoreUser(ctx context.Context, id string) (*entity.User, error) {
+	restored, err := s.userRepository.Restore(ctx, id)
+	if err != nil {
+		if appErr.ErrCode(err) == appErr.CodeSQLEmptyRow {
+			return nil, appErr.WrapWithCode(err, appErr.CodeHTTPNotFound, "deleted_user_not_found")
+		}
+
+		return nil, err
+	}
+
+	return restored, nil
+}
+
+// PurgeDeletedUsers hard-deletes users soft-deleted before deletedBefore, one
+// batch at a time so no single statement locks the whole backlog.
+func (s *userService) PurgeDeletedUsers(ctx context.Context, deletedBefore time.Time, batchSize int) (int64, error) {
+	return util.DrainBatches(batchSize, func(limit int) (int64, error) {
+		return s.userRepository.Purge(ctx, deletedBefore, limit)
+	})
+}
@@ -7,6 +7,10 @@ import (
 	"go-far/internal/model/entity"
 )
 
-func (s *userService) ListUsersV2(ctx context.Context, filter *dto.UserFilterV2) (*[]entity.User, *dto.Pagination, error) {
-	return s.userRepository.FindAllV2(ctx, filter)
+func (s *userService) ListUsersV2(ctx context.Context, cacheControl dto.CacheControl, filter *dto.UserFilterV2) (*[]entity.User, *dto.Pagination, error) {
+	return s.userRepository.FindAllV2(ctx, cacheControl, filter)
+}
+
+func (s *userService) ExportUsers(ctx context.Context, filter *dto.UserFilterV2, fn func(*entity.User) error) error {
+	return s.userRepository.ExportV2(ctx, filter, fn)
 }
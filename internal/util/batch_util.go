@@ -0,0 +1,24 @@
+package util
+
+import (
+	appErr "go-far/internal/model/errors"
+)
+
+// DrainBatches calls fn with batchSize until a batch affects fewer rows than
+// that, none at all, or fails, and returns the rows affected in total. It is
+// the loop behind the jobs that delete or update a backlog in bounded chunks
+// so no single statement locks all of it.
+func DrainBatches(batchSize int, fn func(limit int) (int64, error)) (int64, error) {
+	if batchSize <= 0 {
+		return 0, appErr.NewWithCode(appErr.CodeHTTPInternalServerError, "invalid_batch_size %d", batchSize)
+	}
+
+	var total int64
+	for {
+		rows, err := fn(batchSize)
+		total += rows
+		if err != nil || rows == 0 || rows < int64(batchSize) {
+			return total, err
+		}
+	}
+}
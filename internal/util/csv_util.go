@@ -0,0 +1,84 @@
+package util
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// CSVHeader returns the JSON names of T's visible fields in declaration
+// order, flattening embedded structs, for use as a CSV header row.
+func CSVHeader[T any]() []string {
+	var header []string
+	walkVisible(reflect.TypeFor[T](), func(field reflect.StructField, _ []int) {
+		header = append(header, jsonName(field))
+	}, nil)
+
+	return header
+}
+
+// CSVRecord formats v's visible fields in the same order as CSVHeader.
+// Times are written as RFC 3339 and cells that a spreadsheet would evaluate
+// as a formula are prefixed with a single quote.
+func CSVRecord(v any) []string {
+	val := reflect.Indirect(reflect.ValueOf(v))
+
+	var record []string
+	walkVisible(val.Type(), func(_ reflect.StructField, index []int) {
+		record = append(record, formatCell(val.FieldByIndex(index).Interface()))
+	}, nil)
+
+	return record
+}
+
+func walkVisible(typ reflect.Type, fn func(field reflect.StructField, index []int), prefix []int) {
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+
+	for i := range typ.NumField() {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		index := append(append([]int{}, prefix...), i)
+
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			walkVisible(field.Type, fn, index)
+			continue
+		}
+
+		if jsonName(field) == "" {
+			continue
+		}
+
+		fn(field, index)
+	}
+}
+
+func formatCell(v any) string {
+	if val := reflect.ValueOf(v); val.Kind() == reflect.Pointer {
+		if val.IsNil() {
+			return ""
+		}
+		v = val.Elem().Interface()
+	}
+
+	var s string
+	switch t := v.(type) {
+	case time.Time:
+		s = t.Format(time.RFC3339)
+	case fmt.Stringer:
+		s = t.String()
+	default:
+		s = fmt.Sprint(t)
+	}
+
+	if s != "" && strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		return "'" + s
+	}
+
+	return s
+}
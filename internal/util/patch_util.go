@@ -0,0 +1,58 @@
+package util
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+
+	appErr "go-far/internal/model/errors"
+	"go-far/internal/preference"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+)
+
+// ApplyPatch applies patch to the JSON form of doc and decodes the result
+// into out. mediaType selects JSON Merge Patch (RFC 7396) or JSON Patch
+// (RFC 6902). Members out does not declare are rejected, so a patch cannot
+// touch read-only fields such as id or created_at.
+//
+// A failed JSON Patch "test" operation is reported as CodeHTTPConflict, since
+// it means the document is not in the state the client expected.
+func ApplyPatch(doc any, mediaType string, patch []byte, out any) error {
+	original, err := json.Marshal(doc)
+	if err != nil {
+		return appErr.WrapWithCode(err, appErr.CodeHTTPMarshal, "marshal_patch_target")
+	}
+
+	var patched []byte
+	switch mediaType {
+	case preference.ContentTypeMergePatch:
+		patched, err = jsonpatch.MergePatch(original, patch)
+		if err != nil {
+			return appErr.WrapWithCode(err, appErr.CodeHTTPBadRequest, "invalid_merge_patch")
+		}
+	case preference.ContentTypeJSONPatch:
+		ops, decodeErr := jsonpatch.DecodePatch(patch)
+		if decodeErr != nil {
+			return appErr.WrapWithCode(decodeErr, appErr.CodeHTTPBadRequest, "invalid_json_patch")
+		}
+
+		patched, err = ops.Apply(original)
+		if errors.Is(err, jsonpatch.ErrTestFailed) {
+			return appErr.WrapWithCode(err, appErr.CodeHTTPConflict, "json_patch_test_failed")
+		}
+		if err != nil {
+			return appErr.WrapWithCode(err, appErr.CodeHTTPUnprocessableEntity, "apply_json_patch")
+		}
+	default:
+		return appErr.NewWithCode(appErr.CodeHTTPUnsupportedMediaType, "unsupported_patch_type %q", mediaType)
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(patched))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(out); err != nil {
+		return appErr.WrapWithCode(err, appErr.CodeHTTPUnprocessableEntity, "decode_patched_document")
+	}
+
+	return nil
+}
@@ -0,0 +1,132 @@
+package util
+
+import (
+	"net/http"
+	"testing"
+
+	appErr "go-far/internal/model/errors"
+	"go-far/internal/preference"
+)
+
+type patchTarget struct {
+	Name  string  `json:"name"`
+	Color *string `json:"color"`
+	Year  int     `json:"year"`
+}
+
+type patchView struct {
+	ID string `json:"id"`
+	patchTarget
+}
+
+func TestApplyPatch(t *testing.T) {
+	red := "red"
+	doc := patchView{ID: "car-1", patchTarget: patchTarget{Name: "Civic", Color: &red, Year: 2020}}
+
+	tests := []struct {
+		name      string
+		mediaType string
+		patch     string
+		doc       any
+		want      patchTarget
+		status    int
+	}{
+		{
+			name:      "merge patch sets a member",
+			mediaType: preference.ContentTypeMergePatch,
+			patch:     `{"year":2021}`,
+			doc:       doc.patchTarget,
+			want:      patchTarget{Name: "Civic", Color: &red, Year: 2021},
+		},
+		{
+			name:      "merge patch null clears a member",
+			mediaType: preference.ContentTypeMergePatch,
+			patch:     `{"color":null}`,
+			doc:       doc.patchTarget,
+			want:      patchTarget{Name: "Civic", Year: 2020},
+		},
+		{
+			name:      "merge patch rejects unknown members",
+			mediaType: preference.ContentTypeMergePatch,
+			patch:     `{"owner":"someone"}`,
+			doc:       doc.patchTarget,
+			status:    http.StatusUnprocessableEntity,
+		},
+		{
+			name:      "read-only members of the document are rejected",
+			mediaType: preference.ContentTypeMergePatch,
+			patch:     `{"year":2022}`,
+			doc:       doc,
+			status:    http.StatusUnprocessableEntity,
+		},
+		{
+			name:      "malformed merge patch",
+			mediaType: preference.ContentTypeMergePatch,
+			patch:     `{"year":`,
+			doc:       doc.patchTarget,
+			status:    http.StatusBadRequest,
+		},
+		{
+			name:      "json patch replace after passing test",
+			mediaType: preference.ContentTypeJSONPatch,
+			patch:     `[{"op":"test","path":"/year","value":2020},{"op":"replace","path":"/name","value":"Jazz"}]`,
+			doc:       doc.patchTarget,
+			want:      patchTarget{Name: "Jazz", Color: &red, Year: 2020},
+		},
+		{
+			name:      "json patch failed test conflicts",
+			mediaType: preference.ContentTypeJSONPatch,
+			patch:     `[{"op":"test","path":"/year","value":1999},{"op":"replace","path":"/name","value":"Jazz"}]`,
+			doc:       doc.patchTarget,
+			status:    http.StatusConflict,
+		},
+		{
+			name:      "json patch adding an unknown member",
+			mediaType: preference.ContentTypeJSONPatch,
+			patch:     `[{"op":"add","path":"/owner","value":"someone"}]`,
+			doc:       doc.patchTarget,
+			status:    http.StatusUnprocessableEntity,
+		},
+		{
+			name:      "json patch on a missing path",
+			mediaType: preference.ContentTypeJSONPatch,
+			patch:     `[{"op":"replace","path":"/missing/name","value":"x"}]`,
+			doc:       doc.patchTarget,
+			status:    http.StatusUnprocessableEntity,
+		},
+		{
+			name:      "unsupported media type",
+			mediaType: preference.ContentTypeJSON,
+			patch:     `{"year":2021}`,
+			doc:       doc.patchTarget,
+			status:    http.StatusUnsupportedMediaType,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got patchTarget
+			err := ApplyPatch(tt.doc, tt.mediaType, []byte(tt.patch), &got)
+
+			if tt.status != 0 {
+				if err == nil {
+					t.Fatalf("ApplyPatch succeeded with %+v, want status %d", got, tt.status)
+				}
+				if status, _ := appErr.Compile(appErr.COMMON, err, "en", false); status != tt.status {
+					t.Errorf("status = %d, want %d (err: %v)", status, tt.status, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("ApplyPatch: %v", err)
+			}
+			if got.Name != tt.want.Name || got.Year != tt.want.Year {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+			if (got.Color == nil) != (tt.want.Color == nil) || (got.Color != nil && *got.Color != *tt.want.Color) {
+				t.Errorf("Color = %v, want %v", got.Color, tt.want.Color)
+			}
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package util
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// PlateFormat tells whether a normalized license plate is well formed in the
+// region it is registered for.
+type PlateFormat interface {
+	Valid(plate string) bool
+}
+
+// platePattern is a PlateFormat matching the whole normalized plate.
+type platePattern struct {
+	re *regexp.Regexp
+}
+
+func (p platePattern) Valid(plate string) bool {
+	return p.re.MatchString(plate)
+}
+
+// NewPlatePattern compiles a regular expression into a PlateFormat. The
+// expression is matched against the normalized plate and anchored at both
+// ends.
+func NewPlatePattern(expr string) (PlateFormat, error) {
+	re, err := regexp.Compile(`^(?:` + expr + `)$`)
+	if err != nil {
+		return nil, err
+	}
+
+	return platePattern{re: re}, nil
+}
+
+func mustPlatePattern(expr string) PlateFormat {
+	format, err := NewPlatePattern(expr)
+	if err != nil {
+		panic(err)
+	}
+
+	return format
+}
+
+var (
+	plateFormatsMu sync.RWMutex
+	// plateFormats maps upper-case region codes to their plate format. The
+	// empty region is the generic format of cars registered without one.
+	plateFormats = map[string]PlateFormat{
+		"":   mustPlatePattern(`[A-Z0-9]{3,20}`),
+		"DE": mustPlatePattern(`[A-Z]{2,5}[0-9]{1,4}[EH]?`),
+		"FR": mustPlatePattern(`[A-Z]{2}[0-9]{3}[A-Z]{2}`),
+		"GB": mustPlatePattern(`[A-Z]{2}[0-9]{2}[A-Z]{3}`),
+		"ID": mustPlatePattern(`[A-Z]{1,2}[0-9]{1,4}[A-Z]{0,3}`),
+		"US": mustPlatePattern(`[A-Z0-9]{1,8}`),
+	}
+)
+
+// RegisterPlateFormat adds or replaces the plate format of a region.
+func RegisterPlateFormat(region string, format PlateFormat) {
+	plateFormatsMu.Lock()
+	defer plateFormatsMu.Unlock()
+
+	plateFormats[NormalizePlateRegion(region)] = format
+}
+
+// PlateFormatFor returns the plate format registered for region.
+func PlateFormatFor(region string) (PlateFormat, bool) {
+	plateFormatsMu.RLock()
+	defer plateFormatsMu.RUnlock()
+
+	format, ok := plateFormats[NormalizePlateRegion(region)]
+	return format, ok
+}
+
+// PlateRegions lists the registered region codes in order.
+func PlateRegions() []string {
+	plateFormatsMu.RLock()
+	defer plateFormatsMu.RUnlock()
+
+	regions := make([]string, 0, len(plateFormats))
+	for region := range plateFormats {
+		if region != "" {
+			regions = append(regions, region)
+		}
+	}
+	slices.Sort(regions)
+
+	return regions
+}
+
+// NormalizePlateRegion trims a region code and upper-cases it.
+func NormalizePlateRegion(region string) string {
+	return strings.ToUpper(strings.TrimSpace(region))
+}
+
+// NormalizePlate returns the canonical form of a license plate: upper case
+// with everything but ASCII letters and digits removed, so "B 1234 XY" and
+// "b-1234-xy" are the same plate. It must stay in line with the generated
+// cars.license_plate_normalized column.
+func NormalizePlate(plate string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		default:
+			return -1
+		}
+	}, plate)
+}
+
+// ValidPlate reports whether plate, once normalized, follows the format of
+// region. Unknown regions accept no plate.
+func ValidPlate(region, plate string) bool {
+	format, ok := PlateFormatFor(region)
+	if !ok {
+		return false
+	}
+
+	return format.Valid(NormalizePlate(plate))
+}
@@ -0,0 +1,103 @@
+package util
+
+import "testing"
+
+// TestNormalizePlate mirrors the cars.license_plate_normalized column, which
+// strips every character outside [A-Za-z0-9] and upper-cases the rest.
+func TestNormalizePlate(t *testing.T) {
+	tests := []struct {
+		plate string
+		want  string
+	}{
+		{plate: "", want: ""},
+		{plate: "B1234XY", want: "B1234XY"},
+		{plate: "b 1234 xy", want: "B1234XY"},
+		{plate: "b-1234-xy", want: "B1234XY"},
+		{plate: " AB.12_cd/3 ", want: "AB12CD3"},
+		{plate: "\tm ab\n123", want: "MAB123"},
+		{plate: "---", want: ""},
+		{plate: "MÜ AB 123", want: "MAB123"},
+		{plate: "ÄÖÜß", want: ""},
+		{plate: "ＡＢ１２", want: ""},
+		{plate: "B٣12", want: "B12"},
+	}
+
+	for _, tt := range tests {
+		if got := NormalizePlate(tt.plate); got != tt.want {
+			t.Errorf("NormalizePlate(%q) = %q, want %q", tt.plate, got, tt.want)
+		}
+	}
+}
+
+func TestValidPlate(t *testing.T) {
+	tests := []struct {
+		region string
+		plate  string
+		want   bool
+	}{
+		{region: "", plate: "ABC", want: true},
+		{region: "", plate: "AB", want: false},
+		{region: "", plate: "A1234567890123456789", want: true},
+		{region: "", plate: "A12345678901234567890", want: false},
+
+		{region: "DE", plate: "M-AB 123", want: true},
+		{region: "DE", plate: "B AB 1234 E", want: true},
+		{region: "DE", plate: "HH 1 H", want: true},
+		{region: "DE", plate: "M 12345", want: false},
+		{region: "DE", plate: "1 AB 123", want: false},
+
+		{region: "FR", plate: "AB-123-CD", want: true},
+		{region: "FR", plate: "AB-1234-CD", want: false},
+		{region: "FR", plate: "A-123-CD", want: false},
+
+		{region: "GB", plate: "AB12 CDE", want: true},
+		{region: "GB", plate: "AB12 CD", want: false},
+		{region: "GB", plate: "A12 CDE", want: false},
+
+		{region: "ID", plate: "B 1234 XY", want: true},
+		{region: "ID", plate: "DK 1 A", want: true},
+		{region: "ID", plate: "B 1234", want: true},
+		{region: "ID", plate: "B 12345 XY", want: false},
+		{region: "ID", plate: "B 1234 WXYZ", want: false},
+
+		{region: "US", plate: "7ABC123", want: true},
+		{region: "US", plate: "ABCD1234", want: true},
+		{region: "US", plate: "ABCDE12345", want: false},
+
+		{region: " de ", plate: "M AB 123", want: true},
+		{region: "id", plate: "b 1234 xy", want: true},
+		{region: "XX", plate: "ABC123", want: false},
+	}
+
+	for _, tt := range tests {
+		if got := ValidPlate(tt.region, tt.plate); got != tt.want {
+			t.Errorf("ValidPlate(%q, %q) = %v, want %v", tt.region, tt.plate, got, tt.want)
+		}
+	}
+}
+
+func TestRegisterPlateFormat(t *testing.T) {
+	format, err := NewPlatePattern(`[0-9]{3}[A-Z]{3}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	RegisterPlateFormat(" zz ", format)
+	t.Cleanup(func() {
+		plateFormatsMu.Lock()
+		delete(plateFormats, "ZZ")
+		plateFormatsMu.Unlock()
+	})
+
+	if !ValidPlate("ZZ", "123 abc") {
+		t.Error("registered format rejects a matching plate")
+	}
+
+	if ValidPlate("zz", "abc 123") {
+		t.Error("registered format accepts a plate it does not match")
+	}
+
+	if _, err := NewPlatePattern(`[A-Z`); err == nil {
+		t.Error("NewPlatePattern accepted an invalid expression")
+	}
+}
@@ -0,0 +1,158 @@
+package util
+
+import (
+	"reflect"
+	"strings"
+)
+
+// DBColumns returns the db tags of T that are visible in JSON responses,
+// including those promoted from embedded structs. Fields hidden with
+// `json:"-"` (e.g. password hashes) are never selectable.
+func DBColumns[T any]() map[string]struct{} {
+	columns := map[string]struct{}{}
+	collectColumns(reflect.TypeFor[T](), columns)
+
+	return columns
+}
+
+func collectColumns(typ reflect.Type, columns map[string]struct{}) {
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+
+	if typ.Kind() != reflect.Struct {
+		return
+	}
+
+	for i := range typ.NumField() {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		if field.Anonymous {
+			collectColumns(field.Type, columns)
+			continue
+		}
+
+		db := field.Tag.Get("db")
+		if db == "" || db == "-" || jsonName(field) == "" {
+			continue
+		}
+
+		columns[db] = struct{}{}
+	}
+}
+
+// SplitList splits a comma separated query value, trimming blanks and
+// dropping duplicates while keeping the original order.
+func SplitList(raw string) []string {
+	if strings.TrimSpace(raw) == "" {
+		return nil
+	}
+
+	seen := map[string]struct{}{}
+	items := make([]string, 0)
+
+	for item := range strings.SplitSeq(raw, ",") {
+		item = strings.ToLower(strings.TrimSpace(item))
+		if item == "" {
+			continue
+		}
+
+		if _, ok := seen[item]; ok {
+			continue
+		}
+
+		seen[item] = struct{}{}
+		items = append(items, item)
+	}
+
+	return items
+}
+
+// Project returns v (a struct, a pointer to one, or a slice of either) as
+// JSON-keyed maps holding only the fields whose db tag is listed in fields.
+// Fields without a db tag, such as embedded relations and computed counts,
+// are always kept. An empty fields list returns v unchanged.
+func Project(v any, fields []string) any {
+	if len(fields) == 0 {
+		return v
+	}
+
+	selected := make(map[string]struct{}, len(fields))
+	for _, field := range fields {
+		selected[field] = struct{}{}
+	}
+
+	return project(reflect.ValueOf(v), selected)
+}
+
+func project(val reflect.Value, selected map[string]struct{}) any {
+	for val.Kind() == reflect.Pointer || val.Kind() == reflect.Interface {
+		if val.IsNil() {
+			return nil
+		}
+		val = val.Elem()
+	}
+
+	switch val.Kind() {
+	case reflect.Slice, reflect.Array:
+		items := make([]any, val.Len())
+		for i := range val.Len() {
+			items[i] = project(val.Index(i), selected)
+		}
+		return items
+	case reflect.Struct:
+		out := map[string]any{}
+		projectStruct(val, selected, out)
+		return out
+	default:
+		return val.Interface()
+	}
+}
+
+func projectStruct(val reflect.Value, selected map[string]struct{}, out map[string]any) {
+	typ := val.Type()
+
+	for i := range typ.NumField() {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			projectStruct(val.Field(i), selected, out)
+			continue
+		}
+
+		name := jsonName(field)
+		if name == "" {
+			continue
+		}
+
+		if db := field.Tag.Get("db"); db != "" {
+			if _, ok := selected[db]; !ok {
+				continue
+			}
+		} else if strings.Contains(field.Tag.Get("json"), ",omitempty") && val.Field(i).IsZero() {
+			continue
+		}
+
+		out[name] = val.Field(i).Interface()
+	}
+}
+
+func jsonName(field reflect.StructField) string {
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return ""
+	}
+
+	name, _, _ := strings.Cut(tag, ",")
+	if name == "" {
+		return field.Name
+	}
+
+	return name
+}
@@ -66,6 +66,8 @@ func setField(field reflect.Value, rawVal string, typ reflect.Type) {
 		parseBool(field, rawVal)
 	case reflect.Slice:
 		parseStringSlice(field, typ, rawVal)
+	case reflect.Pointer:
+		parseOptional(field, typ, rawVal)
 	default:
 		// Handle other types as needed
 	}
@@ -102,6 +104,18 @@ func parseBool(field reflect.Value, val string) {
 	}
 }
 
+// parseOptional sets a *bool only when val parses, so a nil pointer still
+// means the parameter was absent.
+func parseOptional(field reflect.Value, typ reflect.Type, val string) {
+	if typ.Elem().Kind() != reflect.Bool {
+		return
+	}
+
+	if b, err := strconv.ParseBool(val); err == nil {
+		field.Set(reflect.ValueOf(&b))
+	}
+}
+
 func parseStringSlice(field reflect.Value, typ reflect.Type, val string) {
 	if typ.Elem().Kind() != reflect.String {
 		return
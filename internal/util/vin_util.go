@@ -0,0 +1,55 @@
+package util
+
+import "strings"
+
+// vinWeights are the ISO 3779 position weights used for the check digit.
+var vinWeights = [17]int{8, 7, 6, 5, 4, 3, 2, 10, 0, 9, 8, 7, 6, 5, 4, 3, 2}
+
+// NormalizeVIN trims a VIN and upper-cases it, the form it is stored in.
+func NormalizeVIN(vin string) string {
+	return strings.ToUpper(strings.TrimSpace(vin))
+}
+
+// ValidVIN reports whether vin is a normalized 17-character VIN whose ninth
+// character is the check digit of the others. I, O and Q are never used.
+func ValidVIN(vin string) bool {
+	if len(vin) != len(vinWeights) {
+		return false
+	}
+
+	sum := 0
+	for i := range len(vin) {
+		value, ok := vinValue(vin[i])
+		if !ok {
+			return false
+		}
+		sum += value * vinWeights[i]
+	}
+
+	check := byte('0' + sum%11)
+	if sum%11 == 10 {
+		check = 'X'
+	}
+
+	return vin[8] == check
+}
+
+// vinValue transliterates a VIN character to its check digit value.
+func vinValue(c byte) (int, bool) {
+	switch {
+	case c >= '0' && c <= '9':
+		return int(c - '0'), true
+	case c >= 'A' && c <= 'H':
+		return int(c-'A') + 1, true
+	case c >= 'J' && c <= 'N':
+		return int(c-'J') + 1, true
+	case c == 'P':
+		return 7, true
+	case c == 'R':
+		return 9, true
+	case c >= 'S' && c <= 'Z':
+		return int(c-'S') + 2, true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,53 @@
+package util
+
+import "testing"
+
+func TestValidVIN(t *testing.T) {
+	tests := []struct {
+		name string
+		vin  string
+		want bool
+	}{
+		{name: "valid", vin: "1HGCM82633A004352", want: true},
+		{name: "valid with letters in the serial", vin: "JH4KA7561PC008269", want: true},
+		{name: "check digit zero", vin: "2T1BURHE0JC014702", want: true},
+		{name: "check digit X", vin: "1M8GDM9AXKP042788", want: true},
+		{name: "all ones", vin: "11111111111111111", want: true},
+		{name: "wrong check digit", vin: "1HGCM82643A004352", want: false},
+		{name: "X where a digit is due", vin: "1HGCM826X3A004352", want: false},
+		// I, O and Q replace a character of the value they would have if they
+		// were transliterated (9, 6 and 8), so only the letter itself fails.
+		{name: "I is never used", vin: "1M8GDMIAXKP042788", want: false},
+		{name: "O is never used", vin: "1HGCM82O33A004352", want: false},
+		{name: "Q is never used", vin: "1HGCMQ2633A004352", want: false},
+		{name: "lower case is not normalized", vin: "1hgcm82633a004352", want: false},
+		{name: "too short", vin: "1HGCM82633A00435", want: false},
+		{name: "too long", vin: "1HGCM82633A0043521", want: false},
+		{name: "empty", vin: "", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ValidVIN(tt.vin); got != tt.want {
+				t.Errorf("ValidVIN(%q) = %v, want %v", tt.vin, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeVIN(t *testing.T) {
+	tests := []struct {
+		vin  string
+		want string
+	}{
+		{vin: " 1hgcm82633a004352\t", want: "1HGCM82633A004352"},
+		{vin: "1HGCM82633A004352", want: "1HGCM82633A004352"},
+		{vin: "   ", want: ""},
+	}
+
+	for _, tt := range tests {
+		if got := NormalizeVIN(tt.vin); got != tt.want {
+			t.Errorf("NormalizeVIN(%q) = %q, want %q", tt.vin, got, tt.want)
+		}
+	}
+}